@@ -0,0 +1,161 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audit records the decisions bulldozer makes about pull requests as
+// structured JSON, so that it is possible to explain why a pull request was
+// or was not merged long after it happened.
+package audit
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type Action string
+
+const (
+	ActionMerge  Action = "merge"
+	ActionUpdate Action = "update"
+)
+
+// Record describes the outcome of evaluating a pull request.
+type Record struct {
+	Time   time.Time `json:"time"`
+	Action Action    `json:"action"`
+
+	Owner  string `json:"owner"`
+	Repo   string `json:"repo"`
+	Number int    `json:"number"`
+	SHA    string `json:"sha"`
+
+	// Decision is a short, stable description of the outcome, like "merged"
+	// or "not_ready".
+	Decision string `json:"decision"`
+
+	// Reason explains the decision, including the signals that matched.
+	Reason string `json:"reason,omitempty"`
+
+	// Actor is the user who sent the event that caused the evaluation. It is
+	// empty for evaluations that bulldozer schedules itself.
+	Actor string `json:"actor,omitempty"`
+
+	// DeliveryID is the GUID of the webhook delivery that caused the
+	// evaluation, if any.
+	DeliveryID string `json:"delivery_id,omitempty"`
+}
+
+type SinkType string
+
+const (
+	SinkFile SinkType = "file"
+	SinkHTTP SinkType = "http"
+)
+
+// Config defines where audit records are written.
+type Config struct {
+	Type SinkType `yaml:"type"`
+
+	// Path is the file that records are appended to, one per line, for the
+	// file sink.
+	Path string `yaml:"path"`
+
+	// URL is the endpoint that records are posted to for the http sink.
+	URL string `yaml:"url"`
+
+	// Headers are added to requests for the http sink, for example to
+	// provide an authorization token.
+	Headers map[string]string `yaml:"headers"`
+}
+
+// Sink stores audit records.
+type Sink interface {
+	Write(ctx context.Context, r Record) error
+}
+
+// NewSink creates a sink from the configuration. If client is nil, the http
+// sink uses http.DefaultClient.
+func NewSink(config Config, client *http.Client) (Sink, error) {
+	switch config.Type {
+	case SinkFile:
+		return NewFileSink(config.Path)
+	case SinkHTTP:
+		if config.URL == "" {
+			return nil, errors.New("url is required")
+		}
+		if client == nil {
+			client = http.DefaultClient
+		}
+		return &HTTPSink{client: client, url: config.URL, headers: config.Headers}, nil
+	}
+	return nil, errors.Errorf("unknown sink type %q", config.Type)
+}
+
+// Logger writes audit records to a sink.
+type Logger struct {
+	sink Sink
+	now  func() time.Time
+}
+
+// NewLogger creates a logger that writes to the sink.
+func NewLogger(sink Sink) *Logger {
+	return &Logger{sink: sink, now: time.Now}
+}
+
+// Record writes the record, adding the time and the actor and delivery from
+// the context. It logs any errors. A nil Logger does nothing.
+func (l *Logger) Record(ctx context.Context, r Record) {
+	if l == nil {
+		return
+	}
+	if r.Time.IsZero() {
+		r.Time = l.now().UTC()
+	}
+	if r.Actor == "" {
+		r.Actor = ActorFromContext(ctx)
+	}
+	if r.DeliveryID == "" {
+		r.DeliveryID = DeliveryFromContext(ctx)
+	}
+
+	if err := l.sink.Write(ctx, r); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msgf("Failed to write %s audit record", r.Action)
+	}
+}
+
+type actorKey struct{}
+type deliveryKey struct{}
+
+// WithEvent returns a context that records the actor and webhook delivery
+// that caused an evaluation.
+func WithEvent(ctx context.Context, actor, deliveryID string) context.Context {
+	ctx = context.WithValue(ctx, actorKey{}, actor)
+	return context.WithValue(ctx, deliveryKey{}, deliveryID)
+}
+
+// ActorFromContext returns the actor in the context or an empty string.
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// DeliveryFromContext returns the webhook delivery in the context or an empty
+// string.
+func DeliveryFromContext(ctx context.Context) string {
+	delivery, _ := ctx.Value(deliveryKey{}).(string)
+	return delivery
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	sink, err := NewSink(Config{Type: SinkFile, Path: path}, nil)
+	require.NoError(t, err)
+
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	logger := NewLogger(sink)
+	logger.now = func() time.Time { return now }
+
+	ctx := WithEvent(context.Background(), "testuser", "72d3162e-cc78-11e3-81ab-4c9367dc0958")
+	logger.Record(ctx, Record{
+		Action:   ActionMerge,
+		Owner:    "testorg",
+		Repo:     "testrepo",
+		Number:   1,
+		SHA:      "a6b1b2c",
+		Decision: "merged",
+		Reason:   "mergeable because pull request has label \"merge when ready\" and all required status checks passed",
+	})
+	logger.Record(context.Background(), Record{
+		Action:   ActionUpdate,
+		Owner:    "testorg",
+		Repo:     "testrepo",
+		Number:   2,
+		SHA:      "d4e5f6a",
+		Decision: "not_ready",
+	})
+	require.NoError(t, sink.(*FileSink).Close())
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r Record
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &r))
+		records = append(records, r)
+	}
+	require.NoError(t, scanner.Err())
+
+	require.Len(t, records, 2)
+	assert.Equal(t, now, records[0].Time)
+	assert.Equal(t, "testuser", records[0].Actor)
+	assert.Equal(t, "72d3162e-cc78-11e3-81ab-4c9367dc0958", records[0].DeliveryID)
+	assert.Equal(t, "merged", records[0].Decision)
+
+	assert.Equal(t, ActionUpdate, records[1].Action)
+	assert.Empty(t, records[1].Actor)
+}
+
+func TestHTTPSink(t *testing.T) {
+	var received Record
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer srv.Close()
+
+	sink, err := NewSink(Config{Type: SinkHTTP, URL: srv.URL, Headers: map[string]string{"Authorization": "Bearer token"}}, srv.Client())
+	require.NoError(t, err)
+
+	err = sink.Write(context.Background(), Record{Action: ActionMerge, Owner: "testorg", Repo: "testrepo", Number: 1, Decision: "failed"})
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token", auth)
+	assert.Equal(t, "failed", received.Decision)
+}
+
+func TestNewSink(t *testing.T) {
+	_, err := NewSink(Config{Type: SinkFile}, nil)
+	assert.EqualError(t, err, "path is required")
+
+	_, err = NewSink(Config{Type: SinkHTTP}, nil)
+	assert.EqualError(t, err, "url is required")
+
+	_, err = NewSink(Config{Type: "s3"}, nil)
+	assert.EqualError(t, err, `unknown sink type "s3"`)
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/pull"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// AfterMergeAction is an action bulldozer takes on a pull request after
+// merging it. Each field that is set is applied, in the order they are
+// listed here.
+type AfterMergeAction struct {
+	// Comment is a text/template string posted as a comment. It has the same
+	// data and functions as squash commit templates.
+	Comment string `yaml:"comment"`
+
+	// AddLabels are added to the pull request.
+	AddLabels []string `yaml:"add_labels"`
+
+	// RemoveLabels are removed from the pull request, if present.
+	RemoveLabels []string `yaml:"remove_labels"`
+
+	// Milestone is the title of an open milestone to set on the pull request.
+	Milestone string `yaml:"milestone"`
+}
+
+// RunAfterMerge applies the actions to a merged pull request. It runs all
+// actions, even if some fail, and returns the first error.
+func RunAfterMerge(ctx context.Context, pullCtx pull.Context, client *github.Client, actions []AfterMergeAction) error {
+	var firstErr error
+	for _, action := range actions {
+		if err := runAfterMergeAction(ctx, pullCtx, client, action); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func runAfterMergeAction(ctx context.Context, pullCtx pull.Context, client *github.Client, action AfterMergeAction) error {
+	logger := zerolog.Ctx(ctx)
+	owner, repo, number := pullCtx.Owner(), pullCtx.Repo(), pullCtx.Number()
+
+	if action.Comment != "" {
+		body, err := executeCommitTemplate(ctx, pullCtx, "after_merge comment", action.Comment)
+		if err != nil {
+			return err
+		}
+		comment := &github.IssueComment{Body: github.String(body)}
+		if _, _, err := client.Issues.CreateComment(ctx, owner, repo, number, comment); err != nil {
+			return errors.Wrap(err, "failed to create after merge comment")
+		}
+	}
+
+	if len(action.AddLabels) > 0 {
+		if _, _, err := client.Issues.AddLabelsToIssue(ctx, owner, repo, number, action.AddLabels); err != nil {
+			return errors.Wrap(err, "failed to add after merge labels")
+		}
+		logger.Info().Msgf("Added labels %s after merge", strings.Join(action.AddLabels, ", "))
+	}
+
+	for _, label := range action.RemoveLabels {
+		res, err := client.Issues.RemoveLabelForIssue(ctx, owner, repo, number, label)
+		if err != nil {
+			if res != nil && res.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return errors.Wrapf(err, "failed to remove label %q after merge", label)
+		}
+		logger.Info().Msgf("Removed label %q after merge", label)
+	}
+
+	if action.Milestone != "" {
+		milestone, err := findMilestone(ctx, client, owner, repo, action.Milestone)
+		if err != nil {
+			return err
+		}
+		if milestone == nil {
+			return errors.Errorf("no open milestone named %q", action.Milestone)
+		}
+
+		req := &github.IssueRequest{Milestone: milestone.Number}
+		if _, _, err := client.Issues.Edit(ctx, owner, repo, number, req); err != nil {
+			return errors.Wrapf(err, "failed to set milestone %q after merge", action.Milestone)
+		}
+		logger.Info().Msgf("Set milestone %q after merge", action.Milestone)
+	}
+
+	return nil
+}
+
+// findMilestone returns the open milestone with the given title, or nil if
+// it does not exist.
+func findMilestone(ctx context.Context, client *github.Client, owner, repo, title string) (*github.Milestone, error) {
+	opts := &github.MilestoneListOptions{
+		State:       "open",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	for {
+		milestones, res, err := client.Issues.ListMilestones(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list milestones")
+		}
+		for _, m := range milestones {
+			if m.GetTitle() == title {
+				return m, nil
+			}
+		}
+		if res.NextPage == 0 {
+			return nil, nil
+		}
+		opts.Page = res.NextPage
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/pull/pulltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunAfterMerge(t *testing.T) {
+	var added, removed, comments []string
+	var milestone int
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/testorg/testrepo/issues/1/comments", func(w http.ResponseWriter, r *http.Request) {
+		var comment github.IssueComment
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&comment))
+		comments = append(comments, comment.GetBody())
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/issues/1/labels", func(w http.ResponseWriter, r *http.Request) {
+		var labels []string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&labels))
+		added = append(added, labels...)
+		_, _ = io.WriteString(w, `[]`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/issues/1/labels/merge when ready", func(w http.ResponseWriter, r *http.Request) {
+		removed = append(removed, "merge when ready")
+		_, _ = io.WriteString(w, `[]`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/issues/1/labels/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"message": "Label does not exist"}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/milestones", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "open", r.URL.Query().Get("state"))
+		_, _ = io.WriteString(w, `[{"number": 3, "title": "1.0.0"}, {"number": 4, "title": "1.1.0"}]`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/issues/1", func(w http.ResponseWriter, r *http.Request) {
+		var req github.IssueRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		milestone = req.GetMilestone()
+		_, _ = io.WriteString(w, `{}`)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	ctx := context.Background()
+	pullCtx := &pulltest.MockPullContext{
+		OwnerValue:  "testorg",
+		RepoValue:   "testrepo",
+		NumberValue: 1,
+		AuthorValue: "mhaypenny",
+	}
+
+	err := RunAfterMerge(ctx, pullCtx, client, []AfterMergeAction{
+		{
+			Comment:      "Thanks @{{.Author}}! Please add release notes for #{{.Number}}.",
+			AddLabels:    []string{"merged-by-bulldozer"},
+			RemoveLabels: []string{"merge when ready", "missing"},
+		},
+		{
+			Milestone: "1.1.0",
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Thanks @mhaypenny! Please add release notes for #1."}, comments)
+	assert.Equal(t, []string{"merged-by-bulldozer"}, added)
+	assert.Equal(t, []string{"merge when ready"}, removed)
+	assert.Equal(t, 4, milestone)
+
+	err = RunAfterMerge(ctx, pullCtx, client, []AfterMergeAction{{Milestone: "2.0.0"}})
+	assert.EqualError(t, err, `no open milestone named "2.0.0"`)
+}
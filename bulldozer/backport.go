@@ -0,0 +1,216 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/pull"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// BackportBranch returns the name of the branch bulldozer creates to backport
+// a pull request to a target branch.
+func BackportBranch(number int, target string) string {
+	return fmt.Sprintf("bulldozer/backport-%d-%s", number, target)
+}
+
+// BackportPR cherry-picks the changes from a merged pull request onto each
+// branch mapped from the labels on the pull request and opens a pull request
+// for each backport. If a backport fails, bulldozer comments on the merged
+// pull request with the reason. The method is used to find the commits added
+// by the merge, starting from mergeCommitSHA. If signer is not nil, the
+// backport commits are signed.
+func BackportPR(ctx context.Context, pullCtx pull.Context, client *github.Client, backport map[string]string, mergeCommitSHA string, method MergeMethod, signer *CommitSigner) error {
+	logger := zerolog.Ctx(ctx)
+
+	labels, err := pullCtx.Labels(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to list labels")
+	}
+
+	targets := make(map[string]bool)
+	for label, target := range backport {
+		for _, l := range labels {
+			if strings.EqualFold(l, label) {
+				targets[target] = true
+			}
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	sorted := make([]string, 0, len(targets))
+	for target := range targets {
+		sorted = append(sorted, target)
+	}
+	sort.Strings(sorted)
+
+	parentSHA, err := backportParent(ctx, pullCtx, client, mergeCommitSHA, method)
+	if err != nil {
+		return err
+	}
+
+	for _, target := range sorted {
+		pr, err := backportTo(ctx, pullCtx, client, mergeCommitSHA, parentSHA, target, signer)
+		if err != nil {
+			logger.Error().Err(err).Msgf("Failed to backport pull request to %s", target)
+
+			body := fmt.Sprintf("Unable to backport this pull request to `%s`: %s", target, errors.Cause(err))
+			comment := &github.IssueComment{Body: github.String(body)}
+			if _, _, err := client.Issues.CreateComment(ctx, pullCtx.Owner(), pullCtx.Repo(), pullCtx.Number(), comment); err != nil {
+				return errors.Wrap(err, "failed to create backport failure comment")
+			}
+			continue
+		}
+		if pr != nil {
+			logger.Info().Msgf("Opened backport %s to %s", pr.GetHTMLURL(), target)
+		}
+	}
+	return nil
+}
+
+// backportParent returns the commit before the changes added by the merge.
+// Merge commits and squash commits contain all changes, while rebase and
+// fast-forward merges add each commit in the pull request.
+func backportParent(ctx context.Context, pullCtx pull.Context, client *github.Client, mergeCommitSHA string, method MergeMethod) (string, error) {
+	count := 1
+	if method == RebaseAndMerge || method == FastForwardOnly {
+		commits, err := pullCtx.Commits(ctx)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to list commits")
+		}
+		count = len(commits)
+	}
+
+	sha := mergeCommitSHA
+	for i := 0; i < count; i++ {
+		commit, _, err := client.Git.GetCommit(ctx, pullCtx.Owner(), pullCtx.Repo(), sha)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to get commit %s", sha)
+		}
+		if len(commit.Parents) == 0 {
+			return "", errors.Errorf("commit %s has no parents", sha)
+		}
+		sha = commit.Parents[0].GetSHA()
+	}
+	return sha, nil
+}
+
+// backportTo cherry-picks the changes between parentSHA and mergeCommitSHA
+// onto the target branch. The GitHub API cannot cherry-pick directly, so it
+// merges the changes into a temporary commit with the tree of the target
+// branch and parentSHA as its parent, then creates a commit with the result
+// on top of the target branch. It returns nil if the backport already exists.
+func backportTo(ctx context.Context, pullCtx pull.Context, client *github.Client, mergeCommitSHA, parentSHA, target string, signer *CommitSigner) (*github.PullRequest, error) {
+	logger := zerolog.Ctx(ctx)
+	owner, repo, number := pullCtx.Owner(), pullCtx.Repo(), pullCtx.Number()
+	branch := BackportBranch(number, target)
+
+	targetRef, _, err := client.Git.GetRef(ctx, owner, repo, "heads/"+target)
+	if err != nil {
+		if isMissingRef(err) {
+			return nil, errors.Errorf("branch %s does not exist", target)
+		}
+		return nil, errors.Wrapf(err, "failed to get ref for %s", target)
+	}
+	targetSHA := targetRef.GetObject().GetSHA()
+
+	targetCommit, _, err := client.Git.GetCommit(ctx, owner, repo, targetSHA)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get commit %s", targetSHA)
+	}
+
+	temp, _, err := client.Git.CreateCommit(ctx, owner, repo, &github.Commit{
+		Message: github.String(fmt.Sprintf("Backport #%d to %s", number, target)),
+		Tree:    &github.Tree{SHA: targetCommit.GetTree().SHA},
+		Parents: []*github.Commit{{SHA: github.String(parentSHA)}},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create temporary commit")
+	}
+
+	ref := &github.Reference{
+		Ref:    github.String("refs/heads/" + branch),
+		Object: &github.GitObject{SHA: temp.SHA},
+	}
+	if _, _, err := client.Git.CreateRef(ctx, owner, repo, ref); err != nil {
+		if rerr, ok := errors.Cause(err).(*github.ErrorResponse); ok && rerr.Response.StatusCode == http.StatusUnprocessableEntity {
+			logger.Debug().Msgf("Not backporting to %s because %s already exists", target, branch)
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to create %s", branch)
+	}
+
+	deleteBranch := func() {
+		if _, err := client.Git.DeleteRef(ctx, owner, repo, "heads/"+branch); err != nil {
+			logger.Error().Err(errors.WithStack(err)).Msgf("Failed to delete %s", branch)
+		}
+	}
+
+	merged, _, err := client.Repositories.Merge(ctx, owner, repo, &github.RepositoryMergeRequest{
+		Base:          github.String(branch),
+		Head:          github.String(mergeCommitSHA),
+		CommitMessage: github.String(fmt.Sprintf("Backport #%d to %s", number, target)),
+	})
+	if err != nil {
+		deleteBranch()
+		if rerr, ok := errors.Cause(err).(*github.ErrorResponse); ok && rerr.Response.StatusCode == http.StatusConflict {
+			return nil, errors.Errorf("the changes conflict with %s", target)
+		}
+		return nil, errors.Wrapf(err, "failed to apply changes to %s", branch)
+	}
+	if merged == nil {
+		deleteBranch()
+		return nil, errors.Errorf("the changes are already in %s", target)
+	}
+
+	backport := &github.Commit{
+		Message: github.String(fmt.Sprintf("%s (#%d)\n\nBackport of #%d to %s.", pullCtx.Title(), number, number, target)),
+		Tree:    &github.Tree{SHA: merged.GetCommit().GetTree().SHA},
+		Parents: []*github.Commit{{SHA: github.String(targetSHA)}},
+	}
+	signer.sign(backport)
+
+	commit, _, err := client.Git.CreateCommit(ctx, owner, repo, backport)
+	if err != nil {
+		deleteBranch()
+		return nil, errors.Wrap(err, "failed to create backport commit")
+	}
+
+	ref.Object = &github.GitObject{SHA: commit.SHA}
+	if _, _, err := client.Git.UpdateRef(ctx, owner, repo, ref, true); err != nil {
+		deleteBranch()
+		return nil, errors.Wrapf(err, "failed to update %s", branch)
+	}
+
+	pr, _, err := client.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+		Title: github.String(fmt.Sprintf("[Backport %s] %s", target, pullCtx.Title())),
+		Head:  github.String(branch),
+		Base:  github.String(target),
+		Body:  github.String(fmt.Sprintf("Backport of #%d to `%s`, created by bulldozer.", number, target)),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create backport pull request")
+	}
+	return pr, nil
+}
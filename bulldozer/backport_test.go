@@ -0,0 +1,217 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/pull"
+	"github.com/palantir/bulldozer/pull/pulltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackportPR(t *testing.T) {
+	type createCommit struct {
+		Message string   `json:"message"`
+		Tree    string   `json:"tree"`
+		Parents []string `json:"parents"`
+	}
+
+	var conflict bool
+	var commits []createCommit
+	var refs, deleted, comments []string
+	var created []github.NewPullRequest
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/testorg/testrepo/git/commits/", func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimPrefix(r.URL.Path, "/repos/testorg/testrepo/git/commits/") {
+		case "merged":
+			_, _ = io.WriteString(w, `{"sha": "merged", "parents": [{"sha": "rebased"}]}`)
+		case "rebased":
+			_, _ = io.WriteString(w, `{"sha": "rebased", "parents": [{"sha": "before"}]}`)
+		case "release":
+			_, _ = io.WriteString(w, `{"sha": "release", "tree": {"sha": "release-tree"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/git/commits", func(w http.ResponseWriter, r *http.Request) {
+		var commit createCommit
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&commit))
+		commits = append(commits, commit)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{"sha": "created"}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/git/ref/heads/release-1.x", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"ref": "refs/heads/release-1.x", "object": {"sha": "release"}}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/git/ref/heads/release-0.x", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"message": "Not Found"}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/git/refs", func(w http.ResponseWriter, r *http.Request) {
+		var ref map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&ref))
+		refs = append(refs, ref["ref"])
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/git/refs/heads/bulldozer/backport-1-release-1.x", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_, _ = io.WriteString(w, `{}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/merges", func(w http.ResponseWriter, r *http.Request) {
+		if conflict {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = io.WriteString(w, `{"message": "Merge conflict"}`)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{"sha": "merge", "commit": {"tree": {"sha": "backport-tree"}}}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		var pr github.NewPullRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&pr))
+		created = append(created, pr)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{"number": 2}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/issues/1/comments", func(w http.ResponseWriter, r *http.Request) {
+		var comment github.IssueComment
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&comment))
+		comments = append(comments, comment.GetBody())
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{}`)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	ctx := context.Background()
+	backport := map[string]string{
+		"backport/1.x": "release-1.x",
+		"backport/0.x": "release-0.x",
+	}
+	reset := func() {
+		commits, refs, deleted, comments, created = nil, nil, nil, nil, nil
+	}
+
+	t.Run("squash", func(t *testing.T) {
+		reset()
+		pullCtx := &pulltest.MockPullContext{
+			OwnerValue:  "testorg",
+			RepoValue:   "testrepo",
+			NumberValue: 1,
+			TitleValue:  "Fix the bug",
+			LabelValue:  []string{"Backport/1.x"},
+		}
+
+		err := BackportPR(ctx, pullCtx, client, backport, "merged", SquashAndMerge, nil)
+		require.NoError(t, err)
+
+		require.Len(t, commits, 2)
+		assert.Equal(t, createCommit{Message: "Backport #1 to release-1.x", Tree: "release-tree", Parents: []string{"rebased"}}, commits[0])
+		assert.Equal(t, createCommit{Message: "Fix the bug (#1)\n\nBackport of #1 to release-1.x.", Tree: "backport-tree", Parents: []string{"release"}}, commits[1])
+
+		assert.Equal(t, []string{"refs/heads/bulldozer/backport-1-release-1.x"}, refs)
+		require.Len(t, created, 1)
+		assert.Equal(t, "[Backport release-1.x] Fix the bug", created[0].GetTitle())
+		assert.Equal(t, "release-1.x", created[0].GetBase())
+		assert.Equal(t, "bulldozer/backport-1-release-1.x", created[0].GetHead())
+		assert.Empty(t, comments)
+	})
+
+	t.Run("rebase", func(t *testing.T) {
+		reset()
+		pullCtx := &pulltest.MockPullContext{
+			OwnerValue:   "testorg",
+			RepoValue:    "testrepo",
+			NumberValue:  1,
+			LabelValue:   []string{"backport/1.x"},
+			CommitsValue: []*pull.Commit{{SHA: "a"}, {SHA: "b"}},
+		}
+
+		err := BackportPR(ctx, pullCtx, client, backport, "merged", RebaseAndMerge, nil)
+		require.NoError(t, err)
+
+		require.Len(t, commits, 2)
+		assert.Equal(t, []string{"before"}, commits[0].Parents)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		reset()
+		conflict = true
+		defer func() { conflict = false }()
+
+		pullCtx := &pulltest.MockPullContext{
+			OwnerValue:  "testorg",
+			RepoValue:   "testrepo",
+			NumberValue: 1,
+			LabelValue:  []string{"backport/1.x"},
+		}
+
+		err := BackportPR(ctx, pullCtx, client, backport, "merged", SquashAndMerge, nil)
+		require.NoError(t, err)
+
+		assert.Empty(t, created)
+		assert.Len(t, deleted, 1, "backport branch was not deleted")
+		assert.Equal(t, []string{"Unable to backport this pull request to `release-1.x`: the changes conflict with release-1.x"}, comments)
+	})
+
+	t.Run("missingBranch", func(t *testing.T) {
+		reset()
+		pullCtx := &pulltest.MockPullContext{
+			OwnerValue:  "testorg",
+			RepoValue:   "testrepo",
+			NumberValue: 1,
+			LabelValue:  []string{"backport/0.x"},
+		}
+
+		err := BackportPR(ctx, pullCtx, client, backport, "merged", SquashAndMerge, nil)
+		require.NoError(t, err)
+
+		assert.Empty(t, created)
+		assert.Equal(t, []string{"Unable to backport this pull request to `release-0.x`: branch release-0.x does not exist"}, comments)
+	})
+
+	t.Run("noLabels", func(t *testing.T) {
+		reset()
+		pullCtx := &pulltest.MockPullContext{
+			OwnerValue:  "testorg",
+			RepoValue:   "testrepo",
+			NumberValue: 1,
+		}
+
+		err := BackportPR(ctx, pullCtx, client, backport, "merged", SquashAndMerge, nil)
+		require.NoError(t, err)
+		assert.Empty(t, commits)
+	})
+}
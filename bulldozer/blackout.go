@@ -0,0 +1,158 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// BlackoutWindow is a recurring period of time when bulldozer does not merge
+// pull requests. Start and End are formatted as "HH:MM" for windows that
+// repeat every day or as "Day HH:MM", like "Fri 16:00", for windows that
+// repeat every week. Both must use the same format. Times are in Timezone, an
+// IANA time zone name, or in UTC if Timezone is empty.
+type BlackoutWindow struct {
+	Start    string `yaml:"start"`
+	End      string `yaml:"end"`
+	Timezone string `yaml:"timezone"`
+}
+
+type blackoutTime struct {
+	weekly  bool
+	weekday time.Weekday
+	minute  int
+}
+
+// offset returns the number of minutes between the start of the period and t
+func (bt blackoutTime) offset() int {
+	if bt.weekly {
+		return int(bt.weekday)*24*60 + bt.minute
+	}
+	return bt.minute
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+func parseBlackoutTime(s string) (blackoutTime, error) {
+	var bt blackoutTime
+
+	clock := strings.TrimSpace(s)
+	if day, rest, ok := strings.Cut(clock, " "); ok {
+		weekday, ok := weekdays[strings.ToLower(day)]
+		if !ok {
+			return bt, errors.Errorf("invalid day %q in %q", day, s)
+		}
+		bt.weekly = true
+		bt.weekday = weekday
+		clock = strings.TrimSpace(rest)
+	}
+
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return bt, errors.Errorf("invalid time %q, expected HH:MM", s)
+	}
+	bt.minute = t.Hour()*60 + t.Minute()
+	return bt, nil
+}
+
+func (w BlackoutWindow) parse() (start, end blackoutTime, loc *time.Location, err error) {
+	if start, err = parseBlackoutTime(w.Start); err != nil {
+		return
+	}
+	if end, err = parseBlackoutTime(w.End); err != nil {
+		return
+	}
+	if start.weekly != end.weekly {
+		err = errors.Errorf("blackout window %q to %q must use days in both or neither of start and end", w.Start, w.End)
+		return
+	}
+	if start == end {
+		err = errors.Errorf("blackout window %q to %q is empty", w.Start, w.End)
+		return
+	}
+
+	loc = time.UTC
+	if w.Timezone != "" {
+		if loc, err = time.LoadLocation(w.Timezone); err != nil {
+			err = errors.Wrapf(err, "invalid timezone %q", w.Timezone)
+		}
+	}
+	return
+}
+
+// BlackoutEnd returns the time when all blackout windows that contain now end.
+// The boolean is false if no blackout window contains now.
+func BlackoutEnd(windows []BlackoutWindow, now time.Time) (time.Time, bool, error) {
+	var latest time.Time
+	var held bool
+
+	for _, w := range windows {
+		start, end, loc, err := w.parse()
+		if err != nil {
+			return time.Time{}, false, err
+		}
+
+		local := now.In(loc)
+		current := blackoutTime{
+			weekly:  start.weekly,
+			weekday: local.Weekday(),
+			minute:  local.Hour()*60 + local.Minute(),
+		}
+
+		s, e, c := start.offset(), end.offset(), current.offset()
+		var active bool
+		if s < e {
+			active = s <= c && c < e
+		} else {
+			active = c >= s || c < e
+		}
+		if !active {
+			continue
+		}
+
+		// find the next day at or after now that has the end time, using
+		// calendar days so that the end is correct across DST transitions
+		days := 0
+		if end.weekly {
+			days = (int(end.weekday) - int(local.Weekday()) + 7) % 7
+		}
+		if days == 0 && end.minute <= current.minute {
+			if end.weekly {
+				days = 7
+			} else {
+				days = 1
+			}
+		}
+		endTime := time.Date(local.Year(), local.Month(), local.Day()+days, end.minute/60, end.minute%60, 0, 0, loc)
+
+		if !held || endTime.After(latest) {
+			latest = endTime
+		}
+		held = true
+	}
+
+	return latest, held, nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlackoutEnd(t *testing.T) {
+	weekend := BlackoutWindow{Start: "Fri 16:00", End: "Mon 08:00"}
+	nightly := BlackoutWindow{Start: "22:00", End: "06:00", Timezone: "America/New_York"}
+
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		Windows []BlackoutWindow
+		Now     time.Time
+		Held    bool
+		End     time.Time
+	}{
+		"beforeWeekend": {
+			Windows: []BlackoutWindow{weekend},
+			Now:     time.Date(2026, 3, 13, 15, 59, 0, 0, time.UTC), // Friday
+			Held:    false,
+		},
+		"startOfWeekend": {
+			Windows: []BlackoutWindow{weekend},
+			Now:     time.Date(2026, 3, 13, 16, 0, 0, 0, time.UTC),
+			Held:    true,
+			End:     time.Date(2026, 3, 16, 8, 0, 0, 0, time.UTC),
+		},
+		"sundayOfWeekend": {
+			Windows: []BlackoutWindow{weekend},
+			Now:     time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC),
+			Held:    true,
+			End:     time.Date(2026, 3, 16, 8, 0, 0, 0, time.UTC),
+		},
+		"endOfWeekend": {
+			Windows: []BlackoutWindow{weekend},
+			Now:     time.Date(2026, 3, 16, 8, 0, 0, 0, time.UTC),
+			Held:    false,
+		},
+		"nightlyBeforeMidnight": {
+			Windows: []BlackoutWindow{nightly},
+			Now:     time.Date(2026, 3, 10, 23, 0, 0, 0, newYork),
+			Held:    true,
+			End:     time.Date(2026, 3, 11, 6, 0, 0, 0, newYork),
+		},
+		"nightlyAfterMidnight": {
+			Windows: []BlackoutWindow{nightly},
+			Now:     time.Date(2026, 3, 11, 5, 0, 0, 0, newYork),
+			Held:    true,
+			End:     time.Date(2026, 3, 11, 6, 0, 0, 0, newYork),
+		},
+		"nightlyInUTC": {
+			Windows: []BlackoutWindow{nightly},
+			Now:     time.Date(2026, 3, 11, 1, 0, 0, 0, time.UTC), // 21:00 in New York
+			Held:    false,
+		},
+		"nightlyAcrossDST": {
+			Windows: []BlackoutWindow{nightly},
+			Now:     time.Date(2026, 3, 7, 23, 0, 0, 0, newYork),
+			Held:    true,
+			End:     time.Date(2026, 3, 8, 6, 0, 0, 0, newYork),
+		},
+		"latestEnd": {
+			Windows: []BlackoutWindow{weekend, {Start: "Sun 00:00", End: "Mon 12:00"}},
+			Now:     time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC),
+			Held:    true,
+			End:     time.Date(2026, 3, 16, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			end, held, err := BlackoutEnd(test.Windows, test.Now)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Held, held)
+			if test.Held {
+				assert.True(t, test.End.Equal(end), "expected end %s, but got %s", test.End, end)
+			}
+		})
+	}
+}
+
+func TestBlackoutWindowInvalid(t *testing.T) {
+	tests := map[string]BlackoutWindow{
+		"badDay":      {Start: "Someday 16:00", End: "Mon 08:00"},
+		"badTime":     {Start: "Fri 4pm", End: "Mon 08:00"},
+		"mixedFormat": {Start: "Fri 16:00", End: "08:00"},
+		"empty":       {Start: "16:00", End: "16:00"},
+		"badTimezone": {Start: "16:00", End: "08:00", Timezone: "Mars/Olympus_Mons"},
+	}
+
+	for name, window := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := BlackoutEnd([]BlackoutWindow{window}, time.Now())
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// BranchConfig overrides parts of the configuration for pull requests that
+// target branches matching a pattern.
+type BranchConfig struct {
+	// Pattern is a regular expression that must match the whole name of the
+	// target branch
+	Pattern string
+
+	// Override contains configuration keys that replace the same keys in the
+	// rest of the file. Keys in the "merge" and "update" sections replace
+	// individual keys in those sections.
+	Override yaml.MapSlice
+}
+
+func (b *BranchConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var m yaml.MapSlice
+	if err := unmarshal(&m); err != nil {
+		return err
+	}
+
+	*b = BranchConfig{}
+	for _, item := range m {
+		if item.Key != "pattern" {
+			b.Override = append(b.Override, item)
+			continue
+		}
+		pattern, ok := item.Value.(string)
+		if !ok {
+			return errors.Errorf("branch pattern must be a string, not %v", item.Value)
+		}
+		b.Pattern = pattern
+	}
+	return nil
+}
+
+func (b BranchConfig) MarshalYAML() (interface{}, error) {
+	return append(yaml.MapSlice{{Key: "pattern", Value: b.Pattern}}, b.Override...), nil
+}
+
+// branchConfig is the complete configuration for branches matching a
+// pattern.
+type branchConfig struct {
+	pattern *regexp.Regexp
+	config  *Config
+}
+
+// ForBranch returns the configuration for pull requests that target the
+// branch, using the first override in Branches that matches it. It returns
+// the configuration itself if no override matches.
+func (c *Config) ForBranch(branch string) *Config {
+	if c == nil {
+		return nil
+	}
+	for _, b := range c.branches {
+		if b.pattern.MatchString(branch) {
+			return b.config
+		}
+	}
+	return c
+}
+
+// parseBranches creates the configuration for each branch override by
+// applying the override to the rest of the configuration file.
+func (c *Config) parseBranches(bytes []byte) error {
+	var base yaml.MapSlice
+	if err := yaml.Unmarshal(bytes, &base); err != nil {
+		return errors.Wrap(err, "failed to unmarshal configuration")
+	}
+	base = removeKey(base, "branches")
+
+	for _, b := range c.Branches {
+		if b.Pattern == "" {
+			return errors.New("branches must have a pattern")
+		}
+		pattern, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", b.Pattern))
+		if err != nil {
+			return errors.Wrapf(err, "invalid branch pattern %q", b.Pattern)
+		}
+		for _, item := range b.Override {
+			if item.Key == "version" || item.Key == "branches" {
+				return errors.Errorf("branches matching %q cannot override %q", b.Pattern, item.Key)
+			}
+		}
+
+		merged, err := yaml.Marshal(mergeMapSlices(base, b.Override, 1))
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal configuration for branches matching %q", b.Pattern)
+		}
+		config, err := parseConfigV1(merged)
+		if err != nil {
+			return errors.Wrapf(err, "invalid configuration for branches matching %q", b.Pattern)
+		}
+		c.branches = append(c.branches, branchConfig{pattern: pattern, config: config})
+	}
+	return nil
+}
+
+// mergeMapSlices returns a copy of base with the values of keys in override
+// replaced. If depth is not zero, values that are maps in both are merged
+// instead, with depth reduced by one. A negative depth merges all maps.
+func mergeMapSlices(base, override yaml.MapSlice, depth int) yaml.MapSlice {
+	merged := append(yaml.MapSlice{}, base...)
+	for _, item := range override {
+		i := indexOfKey(merged, item.Key)
+		if i < 0 {
+			merged = append(merged, item)
+			continue
+		}
+
+		baseValue, baseIsMap := merged[i].Value.(yaml.MapSlice)
+		overrideValue, overrideIsMap := item.Value.(yaml.MapSlice)
+		if depth != 0 && baseIsMap && overrideIsMap {
+			merged[i].Value = mergeMapSlices(baseValue, overrideValue, depth-1)
+		} else {
+			merged[i].Value = item.Value
+		}
+	}
+	return merged
+}
+
+func indexOfKey(m yaml.MapSlice, key interface{}) int {
+	for i, item := range m {
+		if item.Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func removeKey(m yaml.MapSlice, key interface{}) yaml.MapSlice {
+	var removed yaml.MapSlice
+	for _, item := range m {
+		if item.Key != key {
+			removed = append(removed, item)
+		}
+	}
+	return removed
+}
@@ -0,0 +1,215 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/palantir/bulldozer/pull/pulltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigForBranch(t *testing.T) {
+	config := `
+version: 1
+
+merge:
+  trigger:
+    labels: ["merge when ready"]
+  method: squash
+  delete_after_merge: true
+
+update:
+  trigger:
+    labels: ["update me"]
+  ignore_drafts: true
+
+branches:
+  - pattern: release/.*
+    merge:
+      trigger:
+        labels: ["release ready"]
+      method: merge
+    update:
+      ignore_drafts: false
+  - pattern: release/1\..*
+    merge:
+      method: rebase
+`
+
+	actual, err := ParseConfig([]byte(config))
+	require.NoError(t, err)
+
+	t.Run("noMatch", func(t *testing.T) {
+		assert.Same(t, actual, actual.ForBranch("main"))
+		assert.Same(t, actual, actual.ForBranch("feature/release/1"))
+	})
+
+	t.Run("match", func(t *testing.T) {
+		release := actual.ForBranch("release/2.0")
+		assert.Equal(t, Signals{Labels: []string{"release ready"}}, release.Merge.Trigger)
+		assert.Equal(t, MergeCommit, release.Merge.Method)
+		assert.True(t, release.Merge.DeleteAfterMerge)
+
+		assert.Equal(t, Signals{Labels: []string{"update me"}}, release.Update.Trigger)
+		require.NotNil(t, release.Update.IgnoreDrafts)
+		assert.False(t, *release.Update.IgnoreDrafts)
+	})
+
+	t.Run("firstMatchApplies", func(t *testing.T) {
+		release := actual.ForBranch("release/1.0")
+		assert.Equal(t, MergeCommit, release.Merge.Method)
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		var c *Config
+		assert.Nil(t, c.ForBranch("main"))
+	})
+}
+
+func TestConfigForBranchInvalid(t *testing.T) {
+	tests := map[string]struct {
+		Config string
+		Error  string
+	}{
+		"missingPattern": {
+			Config: `
+version: 1
+branches:
+  - merge:
+      method: rebase
+`,
+			Error: "branches must have a pattern",
+		},
+		"invalidPattern": {
+			Config: `
+version: 1
+branches:
+  - pattern: release/(
+    merge:
+      method: rebase
+`,
+			Error: "invalid branch pattern \"release/(\": error parsing regexp: missing closing ): `^(?:release/()$`",
+		},
+		"overrideVersion": {
+			Config: `
+version: 1
+branches:
+  - pattern: main
+    version: 0
+`,
+			Error: "branches matching \"main\" cannot override \"version\"",
+		},
+		"invalidOverride": {
+			Config: `
+version: 1
+branches:
+  - pattern: main
+    update:
+      method: squash
+`,
+			Error: "invalid configuration for branches matching \"main\": invalid update method \"squash\"",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseConfig([]byte(test.Config))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.Error)
+		})
+	}
+}
+
+func TestConfigModeForBranch(t *testing.T) {
+	config := `
+version: 1
+
+mode: update_only
+
+merge:
+  trigger:
+    labels: ["merge when ready"]
+
+update:
+  trigger:
+    labels: ["update me"]
+
+branches:
+  - pattern: release/.*
+    mode: merge_only
+  - pattern: main
+    mode: merge_and_update
+`
+
+	actual, err := ParseConfig([]byte(config))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	pullCtx := &pulltest.MockPullContext{
+		LabelValue:            []string{"merge when ready", "update me"},
+		RequiredStatusesValue: []string{"ci"},
+		SuccessStatusesValue:  []string{"ci"},
+	}
+
+	tests := map[string]struct {
+		Branch       string
+		Mergeable    bool
+		MergeReason  string
+		Updateable   bool
+		UpdateReason string
+	}{
+		"updateOnly": {
+			Branch:       "develop",
+			MergeReason:  "not mergeable because the configuration is update only",
+			Updateable:   true,
+			UpdateReason: `updateable because triggering is enabled and pull request has a triggered label: "update me"`,
+		},
+		"mergeOnly": {
+			Branch:       "release/1.0",
+			Mergeable:    true,
+			MergeReason:  `mergeable because pull request has a triggered label: "merge when ready" and all required status checks passed`,
+			UpdateReason: "not updateable because the configuration is merge only",
+		},
+		"mergeAndUpdate": {
+			Branch:       "main",
+			Mergeable:    true,
+			MergeReason:  `mergeable because pull request has a triggered label: "merge when ready" and all required status checks passed`,
+			Updateable:   true,
+			UpdateReason: `updateable because triggering is enabled and pull request has a triggered label: "update me"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := actual.ForBranch(test.Branch)
+
+			mergeable, reason, err := ExplainMergePR(ctx, pullCtx, config.Merge)
+			require.NoError(t, err)
+			assert.Equal(t, test.Mergeable, mergeable)
+			assert.Equal(t, test.MergeReason, reason)
+
+			updateable, reason, err := ExplainUpdatePR(ctx, pullCtx, config.Update)
+			require.NoError(t, err)
+			assert.Equal(t, test.Updateable, updateable)
+			assert.Equal(t, test.UpdateReason, reason)
+		})
+	}
+
+	_, err = ParseConfig([]byte("version: 1\nmode: read_only\n"))
+	assert.EqualError(t, err, `invalid mode "read_only"`)
+}
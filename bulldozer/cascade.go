@@ -0,0 +1,59 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/pull"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// RetargetStacked changes the base branch of open pull requests that target
+// the head branch of a merged pull request to the base branch of the merged
+// pull request, so that stacked pull requests remain open after the head
+// branch is deleted. It returns the pull requests that were retargeted.
+func RetargetStacked(ctx context.Context, pullCtx pull.Context, client *github.Client) ([]*github.PullRequest, error) {
+	logger := zerolog.Ctx(ctx)
+
+	base, head := pullCtx.Branches()
+	if strings.ContainsRune(head, ':') {
+		// branches in forks cannot be the base of pull requests
+		return nil, nil
+	}
+
+	owner, repo := pullCtx.Owner(), pullCtx.Repo()
+	prs, err := pull.ListOpenPullRequestsForRefAndSHA(ctx, client, owner, repo, head, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list stacked pull requests")
+	}
+
+	var retargeted []*github.PullRequest
+	for _, pr := range prs {
+		edit := &github.PullRequest{
+			Base: &github.PullRequestBranch{Ref: github.String(base)},
+		}
+		updated, _, err := client.PullRequests.Edit(ctx, owner, repo, pr.GetNumber(), edit)
+		if err != nil {
+			return retargeted, errors.Wrapf(err, "failed to change the base branch of %s/%s#%d", owner, repo, pr.GetNumber())
+		}
+		logger.Info().Msgf("Changed the base branch of stacked pull request #%d from %s to %s", pr.GetNumber(), head, base)
+		retargeted = append(retargeted, updated)
+	}
+	return retargeted, nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/pull/pulltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetargetStacked(t *testing.T) {
+	edits := make(map[string]string)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/testorg/testrepo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "feature", r.URL.Query().Get("base"))
+		_, _ = io.WriteString(w, `[
+			{"number": 2, "state": "open", "base": {"ref": "feature"}},
+			{"number": 3, "state": "open", "base": {"ref": "feature"}}
+		]`)
+	})
+	for _, number := range []string{"2", "3"} {
+		number := number
+		mux.HandleFunc("/repos/testorg/testrepo/pulls/"+number, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPatch, r.Method)
+
+			var edit map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&edit))
+			edits[number], _ = edit["base"].(string)
+			_, _ = io.WriteString(w, `{"number": `+number+`, "state": "open", "base": {"ref": "develop"}}`)
+		})
+	}
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	ctx := context.Background()
+
+	t.Run("retargeted", func(t *testing.T) {
+		pullCtx := &pulltest.MockPullContext{
+			OwnerValue:  "testorg",
+			RepoValue:   "testrepo",
+			NumberValue: 1,
+			BranchBase:  "develop",
+			BranchName:  "feature",
+		}
+
+		retargeted, err := RetargetStacked(ctx, pullCtx, client)
+		require.NoError(t, err)
+
+		require.Len(t, retargeted, 2)
+		assert.Equal(t, 2, retargeted[0].GetNumber())
+		assert.Equal(t, 3, retargeted[1].GetNumber())
+		assert.Equal(t, map[string]string{"2": "develop", "3": "develop"}, edits)
+	})
+
+	t.Run("fork", func(t *testing.T) {
+		pullCtx := &pulltest.MockPullContext{
+			OwnerValue:  "testorg",
+			RepoValue:   "testrepo",
+			NumberValue: 1,
+			BranchBase:  "develop",
+			BranchName:  "fork:feature",
+		}
+
+		retargeted, err := RetargetStacked(ctx, pullCtx, client)
+		require.NoError(t, err)
+		assert.Empty(t, retargeted)
+	})
+}
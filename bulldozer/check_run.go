@@ -0,0 +1,95 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/pull"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// CheckRunName is the name of the check run that shows the result of
+// evaluating a pull request.
+const CheckRunName = "bulldozer"
+
+// PublishCheckRun creates or updates the bulldozer check run on the head
+// commit of the pull request to show the evaluation. The check run always
+// has a neutral conclusion, so it never blocks merges. The check run is not
+// updated if its output is unchanged.
+func PublishCheckRun(ctx context.Context, pullCtx pull.Context, client *github.Client, e *Eligibility, title, summary string) error {
+	logger := zerolog.Ctx(ctx)
+	owner, repo, sha := pullCtx.Owner(), pullCtx.Repo(), pullCtx.HeadSHA()
+
+	output := &github.CheckRunOutput{
+		Title:   github.String(title),
+		Summary: github.String(summary),
+		Text:    github.String(e.Markdown()),
+	}
+
+	runs, _, err := client.Checks.ListCheckRunsForRef(ctx, owner, repo, sha, &github.ListCheckRunsOptions{
+		CheckName: github.String(CheckRunName),
+		Filter:    github.String("latest"),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list check runs")
+	}
+
+	now := github.Timestamp{Time: time.Now()}
+	for _, run := range runs.CheckRuns {
+		if run.GetName() != CheckRunName {
+			continue
+		}
+		existing := run.GetOutput()
+		if existing.GetTitle() == output.GetTitle() && existing.GetSummary() == output.GetSummary() && existing.GetText() == output.GetText() {
+			logger.Debug().Msg("Skipping check run update because the output is unchanged")
+			return nil
+		}
+		_, _, err := client.Checks.UpdateCheckRun(ctx, owner, repo, run.GetID(), github.UpdateCheckRunOptions{
+			Name:        CheckRunName,
+			Status:      github.String("completed"),
+			Conclusion:  github.String("neutral"),
+			CompletedAt: &now,
+			Output:      output,
+		})
+		return errors.Wrap(err, "failed to update check run")
+	}
+
+	_, _, err = client.Checks.CreateCheckRun(ctx, owner, repo, github.CreateCheckRunOptions{
+		Name:        CheckRunName,
+		HeadSHA:     sha,
+		Status:      github.String("completed"),
+		Conclusion:  github.String("neutral"),
+		CompletedAt: &now,
+		Output:      output,
+	})
+	return errors.Wrap(err, "failed to create check run")
+}
+
+// IsBulldozerCheckRun returns true if a check run with the name, created by
+// the GitHub App with ID appID, is one that bulldozer creates, like the merge
+// evaluation and configuration validation check runs. ownAppID is the ID of
+// the GitHub App that bulldozer runs as; if it is zero, only the name is
+// compared.
+func IsBulldozerCheckRun(name string, appID, ownAppID int64) bool {
+	if ownAppID != 0 && appID != ownAppID {
+		return false
+	}
+	return name == CheckRunName || name == ValidationCheckRunName || strings.HasPrefix(name, CheckRunName+"/")
+}
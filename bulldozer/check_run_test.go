@@ -0,0 +1,149 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/pull/pulltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCheckRunRecorder returns a client for a repository that has the check
+// runs and records created and updated check runs.
+func newCheckRunRecorder(t *testing.T, existing []*github.CheckRun) (*github.Client, *[]github.CreateCheckRunOptions, *[]github.UpdateCheckRunOptions) {
+	var created []github.CreateCheckRunOptions
+	var updated []github.UpdateCheckRunOptions
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/testorg/testrepo/commits/abc/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, CheckRunName, r.URL.Query().Get("check_name"))
+		require.NoError(t, json.NewEncoder(w).Encode(github.ListCheckRunsResults{
+			Total:     github.Int(len(existing)),
+			CheckRuns: existing,
+		}))
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		var opts github.CreateCheckRunOptions
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&opts))
+		created = append(created, opts)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/check-runs/7", func(w http.ResponseWriter, r *http.Request) {
+		var opts github.UpdateCheckRunOptions
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&opts))
+		updated = append(updated, opts)
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+	return client, &created, &updated
+}
+
+func TestPublishCheckRun(t *testing.T) {
+	ctx := context.Background()
+	pullCtx := &pulltest.MockPullContext{
+		OwnerValue:   "testorg",
+		RepoValue:    "testrepo",
+		NumberValue:  1,
+		HeadSHAValue: "abc",
+	}
+	e := &Eligibility{
+		Reason:              "not mergeable because of unfulfilled status checks: [lint]",
+		UpdateReason:        "not updateable because updates are not configured",
+		Trigger:             []SignalResult{{Name: "labels", Matched: true, Description: `pull request has a triggered label: "merge when ready"`}},
+		RequiredStatuses:    []string{"build", "lint"},
+		SuccessStatuses:     []string{"build"},
+		UnsatisfiedStatuses: []string{"lint"},
+	}
+	summary := "The pull request is " + e.Reason + "."
+
+	t.Run("creates", func(t *testing.T) {
+		client, created, updated := newCheckRunRecorder(t, nil)
+
+		require.NoError(t, PublishCheckRun(ctx, pullCtx, client, e, "Not ready to merge", summary))
+		require.Len(t, *created, 1)
+		assert.Empty(t, *updated)
+
+		opts := (*created)[0]
+		assert.Equal(t, CheckRunName, opts.Name)
+		assert.Equal(t, "abc", opts.HeadSHA)
+		assert.Equal(t, "neutral", opts.GetConclusion())
+		assert.Equal(t, "Not ready to merge", opts.Output.GetTitle())
+		assert.Equal(t, summary, opts.Output.GetSummary())
+		assert.Equal(t, "### Trigger signals\n\n"+
+			"| Signal | Matched | Description |\n| --- | --- | --- |\n"+
+			"| `labels` | yes | pull request has a triggered label: \"merge when ready\" |\n\n"+
+			"### Ignore signals\n\nNone configured.\n\n"+
+			"### Required statuses\n\n"+
+			"| Status | Passed |\n| --- | --- |\n"+
+			"| `build` | yes |\n"+
+			"| `lint` | no |\n\n"+
+			"### Update\n\nThe pull request is not updateable because updates are not configured.\n", opts.Output.GetText())
+	})
+
+	t.Run("updates", func(t *testing.T) {
+		client, created, updated := newCheckRunRecorder(t, []*github.CheckRun{{
+			ID:   github.Int64(7),
+			Name: github.String(CheckRunName),
+			Output: &github.CheckRunOutput{
+				Title:   github.String("Waiting to merge"),
+				Summary: github.String("The pull request is mergeable."),
+			},
+		}})
+
+		require.NoError(t, PublishCheckRun(ctx, pullCtx, client, e, "Not ready to merge", summary))
+		assert.Empty(t, *created)
+		require.Len(t, *updated, 1)
+		assert.Equal(t, "Not ready to merge", (*updated)[0].Output.GetTitle())
+	})
+
+	t.Run("skipsUnchanged", func(t *testing.T) {
+		client, created, updated := newCheckRunRecorder(t, []*github.CheckRun{{
+			ID:   github.Int64(7),
+			Name: github.String(CheckRunName),
+			Output: &github.CheckRunOutput{
+				Title:   github.String("Not ready to merge"),
+				Summary: github.String(summary),
+				Text:    github.String(e.Markdown()),
+			},
+		}})
+
+		require.NoError(t, PublishCheckRun(ctx, pullCtx, client, e, "Not ready to merge", summary))
+		assert.Empty(t, *created)
+		assert.Empty(t, *updated)
+	})
+}
+
+func TestIsBulldozerCheckRun(t *testing.T) {
+	assert.True(t, IsBulldozerCheckRun(CheckRunName, 42, 42))
+	assert.True(t, IsBulldozerCheckRun(ValidationCheckRunName, 42, 42))
+	assert.True(t, IsBulldozerCheckRun("bulldozer/rollup", 7, 0), "without an app ID, the name is enough")
+	assert.False(t, IsBulldozerCheckRun("bulldozer/lint", 7, 42), "check runs from other apps are not bulldozer's")
+	assert.False(t, IsBulldozerCheckRun("build", 42, 42))
+	assert.False(t, IsBulldozerCheckRun("bulldozer-tests", 42, 42))
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+
+	"github.com/palantir/bulldozer/pull"
+)
+
+// upsertMarkedComment creates a comment on the pull request that starts with
+// the marker, or updates the existing comment with the marker if its body is
+// different, so that bulldozer keeps a single comment up to date instead of
+// posting a new comment each time. The marker is an HTML comment that GitHub
+// does not display. It returns the current comment.
+func upsertMarkedComment(ctx context.Context, pullCtx pull.Context, client *github.Client, marker, body string) (*github.IssueComment, error) {
+	owner, repo, number := pullCtx.Owner(), pullCtx.Repo(), pullCtx.Number()
+
+	comment, err := findMarkedComment(ctx, pullCtx, client, marker)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case comment == nil:
+		comment, _, err = client.Issues.CreateComment(ctx, owner, repo, number, &github.IssueComment{Body: &body})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create comment")
+		}
+		zerolog.Ctx(ctx).Info().Msgf("Posted comment %s", marker)
+
+	case comment.GetBody() != body:
+		comment, _, err = client.Issues.EditComment(ctx, owner, repo, comment.GetID(), &github.IssueComment{Body: &body})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to update comment")
+		}
+		zerolog.Ctx(ctx).Info().Msgf("Updated comment %s", marker)
+	}
+	return comment, nil
+}
+
+func findMarkedComment(ctx context.Context, pullCtx pull.Context, client *github.Client, marker string) (*github.IssueComment, error) {
+	opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		comments, res, err := client.Issues.ListComments(ctx, pullCtx.Owner(), pullCtx.Repo(), pullCtx.Number(), opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list comments")
+		}
+		for _, c := range comments {
+			if strings.HasPrefix(c.GetBody(), marker) {
+				return c, nil
+			}
+		}
+		if res.NextPage == 0 {
+			return nil, nil
+		}
+		opts.Page = res.NextPage
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/pkg/errors"
+
+	"github.com/palantir/bulldozer/pull"
+)
+
+const commitLintMarker = "<!-- bulldozer:commit-lint -->"
+
+var conventionalSubjectPattern = regexp.MustCompile(`^([a-zA-Z]+)(\([^()]+\))?!?: \S`)
+
+// CommitLintConfig defines the rules that squash commit messages must follow
+// before bulldozer merges a pull request.
+type CommitLintConfig struct {
+	// SubjectPattern is a regular expression that the first line of the
+	// message must match
+	SubjectPattern string `yaml:"subject_pattern"`
+
+	// BodyPattern is a regular expression that the rest of the message must
+	// match, like "(?m)^Signed-off-by: " to require a sign-off
+	BodyPattern string `yaml:"body_pattern"`
+
+	// MaxSubjectLength is the maximum number of characters in the first line
+	// of the message. If zero, the length is not limited
+	MaxSubjectLength int `yaml:"max_subject_length"`
+
+	// ConventionalTypes requires the subject to be a conventional commit,
+	// like "fix(parser): handle empty files", with one of the types
+	ConventionalTypes []string `yaml:"conventional_types"`
+
+	// Comment is posted on the pull request when the message breaks the
+	// rules, explaining which rules failed
+	Comment bool `yaml:"comment"`
+}
+
+func (c CommitLintConfig) enabled() bool {
+	return c.SubjectPattern != "" || c.BodyPattern != "" || c.MaxSubjectLength > 0 || len(c.ConventionalTypes) > 0
+}
+
+func (c CommitLintConfig) validate() error {
+	if _, err := regexp.Compile(c.SubjectPattern); err != nil {
+		return errors.Wrap(err, "invalid commit_lint subject_pattern")
+	}
+	if _, err := regexp.Compile(c.BodyPattern); err != nil {
+		return errors.Wrap(err, "invalid commit_lint body_pattern")
+	}
+	if c.MaxSubjectLength < 0 {
+		return errors.Errorf("invalid commit_lint max_subject_length %d, must not be negative", c.MaxSubjectLength)
+	}
+	return nil
+}
+
+// LintCommitMessage checks the squash commit message of the pull request
+// against the rules in the configuration and returns a description of each
+// rule that fails. Only squash merges are checked, because bulldozer does not
+// create the messages of other merges. When GitHub chooses the title or body,
+// the rules are checked against the pull request title and a summary of the
+// commits, like the messages GitHub creates.
+func LintCommitMessage(ctx context.Context, pullCtx pull.Context, mergeConfig MergeConfig) ([]string, error) {
+	config := mergeConfig.CommitLint
+	if !config.enabled() {
+		return nil, nil
+	}
+
+	method, err := DetermineMergeMethod(ctx, pullCtx, mergeConfig)
+	if err != nil {
+		return nil, err
+	}
+	if method != SquashAndMerge {
+		return nil, nil
+	}
+
+	msg, err := squashCommitMessage(ctx, pullCtx, mergeConfig.Options.Squash)
+	if err != nil {
+		return nil, err
+	}
+
+	subject := msg.Title
+	if subject == "" {
+		subject = fmt.Sprintf("%s (#%d)", pullCtx.Title(), pullCtx.Number())
+	}
+	body := msg.Message
+	switch body {
+	case "":
+		commits, err := pullCtx.Commits(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get commits")
+		}
+		body = summarizeCommits(commits, nil)
+	case " ":
+		body = ""
+	}
+
+	return lintCommitMessage(config, subject, body), nil
+}
+
+func lintCommitMessage(config CommitLintConfig, subject, body string) []string {
+	var problems []string
+
+	if config.MaxSubjectLength > 0 {
+		if n := utf8.RuneCountInString(subject); n > config.MaxSubjectLength {
+			problems = append(problems, fmt.Sprintf("the subject has %d characters, but the maximum is %d", n, config.MaxSubjectLength))
+		}
+	}
+
+	if len(config.ConventionalTypes) > 0 {
+		m := conventionalSubjectPattern.FindStringSubmatch(subject)
+		switch {
+		case m == nil:
+			problems = append(problems, "the subject is not a conventional commit, like \"type(scope): description\"")
+		case !containsFold(config.ConventionalTypes, m[1]):
+			problems = append(problems, fmt.Sprintf("the subject has type %q, but the allowed types are %s", m[1], strings.Join(config.ConventionalTypes, ", ")))
+		}
+	}
+
+	// patterns are checked when parsing the configuration
+	if config.SubjectPattern != "" && !regexp.MustCompile(config.SubjectPattern).MatchString(subject) {
+		problems = append(problems, fmt.Sprintf("the subject does not match %q", config.SubjectPattern))
+	}
+	if config.BodyPattern != "" && !regexp.MustCompile(config.BodyPattern).MatchString(body) {
+		problems = append(problems, fmt.Sprintf("the body does not match %q", config.BodyPattern))
+	}
+
+	return problems
+}
+
+// ReportCommitLint posts or updates a comment on the pull request that
+// explains why its commit message breaks the rules, if the configuration
+// enables comments.
+func ReportCommitLint(ctx context.Context, pullCtx pull.Context, client *github.Client, config CommitLintConfig, problems []string) error {
+	if !config.Comment || len(problems) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+	b.WriteString(commitLintMarker + "\n")
+	b.WriteString("This pull request was not merged because its squash commit message does not follow the rules for this repository:\n\n")
+	for _, p := range problems {
+		fmt.Fprintf(&b, "* %s\n", p)
+	}
+	b.WriteString("\nTo change the message, edit the title or description of the pull request.\n")
+
+	if _, err := upsertMarkedComment(ctx, pullCtx, client, commitLintMarker, b.String()); err != nil {
+		return errors.Wrap(err, "failed to post commit lint comment")
+	}
+	return nil
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
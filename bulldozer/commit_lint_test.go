@@ -0,0 +1,107 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/bulldozer/pull"
+	"github.com/palantir/bulldozer/pull/pulltest"
+)
+
+func TestLintCommitMessage(t *testing.T) {
+	tests := map[string]struct {
+		Config   CommitLintConfig
+		Method   MergeMethod
+		Title    string
+		Body     string
+		Problems []string
+	}{
+		"disabled": {
+			Method: SquashAndMerge,
+			Title:  "this title is fine",
+		},
+		"notSquash": {
+			Config: CommitLintConfig{MaxSubjectLength: 10},
+			Method: MergeCommit,
+			Title:  "this title is too long",
+		},
+		"valid": {
+			Config: CommitLintConfig{
+				MaxSubjectLength:  72,
+				ConventionalTypes: []string{"feat", "fix"},
+				SubjectPattern:    `\(#\d+\)$`,
+				BodyPattern:       `(?m)^Signed-off-by: `,
+			},
+			Method: SquashAndMerge,
+			Title:  "fix(parser)!: handle empty files",
+			Body:   "==COMMIT_MSG==\nHandle empty files.\n\nSigned-off-by: Alice <alice@example.com>\n==COMMIT_MSG==",
+		},
+		"subjectTooLong": {
+			Config:   CommitLintConfig{MaxSubjectLength: 20},
+			Method:   SquashAndMerge,
+			Title:    "Handle empty files in the parser",
+			Problems: []string{"the subject has 37 characters, but the maximum is 20"},
+		},
+		"notConventional": {
+			Config:   CommitLintConfig{ConventionalTypes: []string{"feat", "fix"}},
+			Method:   SquashAndMerge,
+			Title:    "Handle empty files",
+			Problems: []string{`the subject is not a conventional commit, like "type(scope): description"`},
+		},
+		"wrongConventionalType": {
+			Config:   CommitLintConfig{ConventionalTypes: []string{"feat", "fix"}},
+			Method:   SquashAndMerge,
+			Title:    "chore: update dependencies",
+			Problems: []string{`the subject has type "chore", but the allowed types are feat, fix`},
+		},
+		"patterns": {
+			Config:   CommitLintConfig{SubjectPattern: `^[A-Z]+-\d+: `, BodyPattern: `(?m)^Signed-off-by: `},
+			Method:   SquashAndMerge,
+			Title:    "Handle empty files",
+			Problems: []string{`the subject does not match "^[A-Z]+-\\d+: "`, `the body does not match "(?m)^Signed-off-by: "`},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pullCtx := &pulltest.MockPullContext{
+				NumberValue:  1,
+				TitleValue:   test.Title,
+				BodyValue:    test.Body,
+				CommitsValue: []*pull.Commit{{SHA: "a6b1b2c", Message: "Handle empty files"}},
+			}
+			mergeConfig := MergeConfig{
+				Method:     test.Method,
+				Options:    MergeOptions{Squash: &SquashOptions{Body: PullRequestBody, MessageDelimiter: "==COMMIT_MSG=="}},
+				CommitLint: test.Config,
+			}
+
+			problems, err := LintCommitMessage(context.Background(), pullCtx, mergeConfig)
+			require.NoError(t, err)
+			assert.Equal(t, test.Problems, problems)
+		})
+	}
+}
+
+func TestCommitLintConfigValidate(t *testing.T) {
+	assert.NoError(t, CommitLintConfig{}.validate())
+	assert.EqualError(t, CommitLintConfig{SubjectPattern: "("}.validate(), "invalid commit_lint subject_pattern: error parsing regexp: missing closing ): `(`")
+	assert.EqualError(t, CommitLintConfig{MaxSubjectLength: -1}.validate(), "invalid commit_lint max_subject_length -1, must not be negative")
+}
@@ -15,6 +15,7 @@
 package bulldozer
 
 import (
+	"github.com/palantir/bulldozer/notify"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v2"
 )
@@ -58,6 +59,88 @@ func parseConfigV1(bytes []byte) (*Config, error) {
 		return nil, errors.Errorf("unexpected version %d, expected 1", config.Version)
 	}
 
+	switch config.Drafts {
+	case "", DraftsIgnore, DraftsUpdate, DraftsReady:
+		config.Merge.Drafts = config.Drafts
+		config.Update.Drafts = config.Drafts
+	default:
+		return nil, errors.Errorf("invalid drafts mode %q", config.Drafts)
+	}
+
+	switch config.Mode {
+	case "", ModeMergeAndUpdate:
+	case ModeUpdateOnly:
+		config.Merge.Disabled = true
+	case ModeMergeOnly:
+		config.Update.Disabled = true
+	default:
+		return nil, errors.Errorf("invalid mode %q", config.Mode)
+	}
+
+	if err := validateTrigger("merge.trigger", config.Merge.Trigger); err != nil {
+		return nil, err
+	}
+	if err := validateTrigger("update.trigger", config.Update.Trigger); err != nil {
+		return nil, err
+	}
+	if rollup := config.Merge.Rollup; rollup != nil {
+		if err := validateTrigger("merge.rollup.trigger", rollup.Trigger); err != nil {
+			return nil, err
+		}
+	}
+
+	switch config.Update.Method {
+	case "", UpdateMerge, UpdateRebase:
+	default:
+		return nil, errors.Errorf("invalid update method %q", config.Update.Method)
+	}
+
+	for _, w := range config.Merge.BlackoutWindows {
+		if _, _, _, err := w.parse(); err != nil {
+			return nil, err
+		}
+	}
+
+	if squash := config.Merge.Options.Squash; squash != nil {
+		if _, err := parseCommitTemplate("title_template", squash.TitleTemplate); err != nil {
+			return nil, err
+		}
+		if _, err := parseCommitTemplate("body_template", squash.BodyTemplate); err != nil {
+			return nil, err
+		}
+		for _, trailer := range squash.Trailers.Custom {
+			if _, err := parseCommitTemplate(customTrailerTemplate, trailer); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := config.Merge.CommitLint.validate(); err != nil {
+		return nil, err
+	}
+
+	for _, route := range config.Notifications {
+		for _, event := range route.Events {
+			switch event {
+			case notify.MergeSucceeded, notify.MergeFailed, notify.ConflictDetected, notify.ConfigError:
+			default:
+				return nil, errors.Errorf("invalid notification event %q", event)
+			}
+		}
+	}
+
+	for _, action := range config.Merge.AfterMerge {
+		if _, err := parseCommitTemplate("after_merge comment", action.Comment); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(config.Branches) > 0 {
+		if err := config.parseBranches(bytes); err != nil {
+			return nil, err
+		}
+	}
+
 	return &config, nil
 }
 
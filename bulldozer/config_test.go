@@ -182,4 +182,42 @@ update:
 			Labels: []string{"new dnu"},
 		}, actual.Update.Ignore)
 	})
+
+	t.Run("parseDrafts", func(t *testing.T) {
+		config := `
+version: 1
+drafts: ready
+`
+
+		actual, err := ParseConfig([]byte(config))
+		require.Nil(t, err)
+
+		assert.Equal(t, DraftsReady, actual.Merge.Drafts)
+		assert.Equal(t, DraftsReady, actual.Update.Drafts)
+
+		_, err = ParseConfig([]byte("version: 1\ndrafts: sometimes\n"))
+		assert.EqualError(t, err, `invalid drafts mode "sometimes"`)
+	})
+
+	t.Run("parseAfterMerge", func(t *testing.T) {
+		config := `
+version: 1
+merge:
+  after_merge:
+    - comment: "Merged #{{.Number}}"
+      add_labels: ["merged-by-bulldozer"]
+    - milestone: "1.1.0"
+`
+
+		actual, err := ParseConfig([]byte(config))
+		require.Nil(t, err)
+
+		assert.Equal(t, []AfterMergeAction{
+			{Comment: "Merged #{{.Number}}", AddLabels: []string{"merged-by-bulldozer"}},
+			{Milestone: "1.1.0"},
+		}, actual.Merge.AfterMerge)
+
+		_, err = ParseConfig([]byte("version: 1\nmerge:\n  after_merge:\n    - comment: \"{{.Number\"\n"))
+		assert.Error(t, err)
+	})
 }
@@ -14,9 +14,16 @@
 
 package bulldozer
 
+import (
+	"github.com/palantir/bulldozer/notify"
+)
+
 type MessageStrategy string
 type TitleStrategy string
 type MergeMethod string
+type DraftMode string
+type UpdateMethod string
+type ConfigMode string
 
 const (
 	PullRequestBody  MessageStrategy = "pull_request_body"
@@ -31,6 +38,18 @@ const (
 	SquashAndMerge  MergeMethod = "squash"
 	RebaseAndMerge  MergeMethod = "rebase"
 	FastForwardOnly MergeMethod = "ff-only"
+	MergeQueue      MergeMethod = "merge_queue"
+
+	DraftsIgnore DraftMode = "ignore"
+	DraftsUpdate DraftMode = "update"
+	DraftsReady  DraftMode = "ready"
+
+	UpdateMerge  UpdateMethod = "merge"
+	UpdateRebase UpdateMethod = "rebase"
+
+	ModeMergeAndUpdate ConfigMode = "merge_and_update"
+	ModeUpdateOnly     ConfigMode = "update_only"
+	ModeMergeOnly      ConfigMode = "merge_only"
 )
 
 type MergeConfig struct {
@@ -44,6 +63,69 @@ type MergeConfig struct {
 	DeleteAfterMerge       bool `yaml:"delete_after_merge"`
 	AllowMergeWithNoChecks bool `yaml:"allow_merge_with_no_checks"`
 
+	// GitDataFallback creates merge and squash commits with the git data API
+	// when GitHub rejects a merge because the merge method is disabled or the
+	// commits are not signed
+	GitDataFallback bool `yaml:"git_data_fallback"`
+
+	// IgnoreBranchRequirements skips checking the reviews, signatures, and
+	// history required by branch protection and rulesets before merging, for
+	// apps that are allowed to bypass these requirements
+	IgnoreBranchRequirements bool `yaml:"ignore_branch_requirements"`
+
+	// DeleteGracePeriod delays deleting the head branch after a merge
+	DeleteGracePeriod Duration `yaml:"delete_grace_period"`
+
+	// MergeTrain merges pull requests targeting the same branch one at a
+	// time, re-evaluating each pull request after the previous merge
+	MergeTrain bool `yaml:"merge_train"`
+
+	// Priority maps labels to the priority of pull requests with that label.
+	// Pull requests waiting to merge into the same branch merge in order of
+	// priority, starting with the highest
+	Priority map[string]int `yaml:"priority"`
+
+	// Drafts is set from Config.Drafts when parsing the configuration
+	Drafts DraftMode `yaml:"-"`
+
+	// Disabled is set from Config.Mode when parsing the configuration
+	Disabled bool `yaml:"-"`
+
+	// Signer signs the commits bulldozer creates for backports. It is set by
+	// the server, not the configuration file
+	Signer *CommitSigner `yaml:"-"`
+
+	// BlackoutWindows are periods of time when pull requests are not merged
+	BlackoutWindows []BlackoutWindow `yaml:"blackout_windows"`
+
+	// Delay is how long a pull request must stay ready to merge at the same
+	// head commit before it is merged
+	Delay Duration `yaml:"delay"`
+
+	// Retry controls how merges that fail with temporary errors are attempted
+	// again
+	Retry RetryConfig `yaml:"retry"`
+
+	// Rerun controls how failed check runs that are known to fail
+	// intermittently are requested again before giving up on the merge
+	Rerun RerunConfig `yaml:"rerun"`
+
+	// Rollup merges pull requests together in rollup pull requests
+	Rollup *RollupConfig `yaml:"rollup"`
+
+	// Cascade changes the base branch of stacked pull requests that target
+	// the head branch of a merged pull request and evaluates them again
+	Cascade bool `yaml:"cascade"`
+
+	// Backport maps labels to branches. After merging a pull request with one
+	// of the labels, bulldozer opens a pull request with the same changes
+	// targeting the branch
+	Backport map[string]string `yaml:"backport"`
+
+	// AfterMerge are actions applied to pull requests after bulldozer merges
+	// them
+	AfterMerge []AfterMergeAction `yaml:"after_merge"`
+
 	Method       MergeMethod              `yaml:"method"`
 	MergeMethods []ConditionalMergeMethod `yaml:"merge_method"`
 	Options      MergeOptions             `yaml:"options"`
@@ -53,6 +135,14 @@ type MergeConfig struct {
 	// Additional status checks that bulldozer should require
 	// (even if the branch protection settings doesn't require it)
 	RequiredStatuses []string `yaml:"required_statuses"`
+
+	// PolicyBot controls whether the policy-bot status is required when it
+	// is on the pull request
+	PolicyBot PolicyBotConfig `yaml:"policy_bot"`
+
+	// CommitLint defines rules for squash commit messages. Pull requests with
+	// messages that break the rules are not merged
+	CommitLint CommitLintConfig `yaml:"commit_lint"`
 }
 
 type MergeOptions struct {
@@ -68,6 +158,18 @@ type SquashOptions struct {
 	Title            TitleStrategy   `yaml:"title"`
 	Body             MessageStrategy `yaml:"body"`
 	MessageDelimiter string          `yaml:"message_delimiter"`
+
+	// TitleTemplate and BodyTemplate are text/template strings that replace
+	// the Title and Body strategies when set
+	TitleTemplate string `yaml:"title_template"`
+	BodyTemplate  string `yaml:"body_template"`
+
+	// Trailers are added to the end of the commit message
+	Trailers TrailerConfig `yaml:"trailers"`
+
+	// Preview comments on pull requests with the squash commit message before
+	// merging them
+	Preview PreviewConfig `yaml:"preview"`
 }
 
 type UpdateConfig struct {
@@ -76,6 +178,41 @@ type UpdateConfig struct {
 
 	IgnoreDrafts *bool `yaml:"ignore_drafts"`
 
+	// Method is how pull requests are updated with changes from their base
+	// branch. If empty, UpdateMerge is used.
+	Method UpdateMethod `yaml:"method"`
+
+	// OnlyWhenRequired only updates pull requests if the branch protection of
+	// the base branch requires branches to be up to date before merging
+	OnlyWhenRequired bool `yaml:"only_when_required"`
+
+	// Conflicts defines how authors are notified when updates fail because
+	// of conflicts
+	Conflicts ConflictConfig `yaml:"conflicts"`
+
+	// Reapproval defines how bulldozer asks for approvals again when an
+	// update dismisses them
+	Reapproval ReapprovalConfig `yaml:"reapproval"`
+
+	// MinBehindBy is the number of commits a pull request must be behind its
+	// base branch before it is updated
+	MinBehindBy int `yaml:"min_behind_by"`
+
+	// MinInterval is the time that must pass after the latest commit on a
+	// pull request before it is updated
+	MinInterval Duration `yaml:"min_interval"`
+
+	// Drafts is set from Config.Drafts when parsing the configuration
+	Drafts DraftMode `yaml:"-"`
+
+	// Disabled is set from Config.Mode when parsing the configuration
+	Disabled bool `yaml:"-"`
+
+	// Signer signs the merge commits bulldozer creates when updating pull
+	// requests with the merge method. It is set by the server, not the
+	// configuration file
+	Signer *CommitSigner `yaml:"-"`
+
 	// Additional status checks that bulldozer should require
 	// (even if the branch protection settings doesn't require it)
 	RequiredStatuses []string `yaml:"required_statuses"`
@@ -88,6 +225,46 @@ type UpdateConfig struct {
 type Config struct {
 	Version int `yaml:"version"`
 
+	// Extends is a reference to a configuration file in another repository
+	// that this configuration is merged into. The server resolves it before
+	// parsing the configuration.
+	Extends string `yaml:"extends"`
+
+	// Include lists configuration fragments in the same repository that are
+	// added to this configuration. The server resolves them before parsing
+	// the configuration.
+	Include []string `yaml:"include"`
+
 	Merge  MergeConfig  `yaml:"merge"`
 	Update UpdateConfig `yaml:"update"`
+
+	// DryRun evaluates pull requests and comments with the result instead of
+	// merging or updating them
+	DryRun bool `yaml:"dry_run"`
+
+	// CheckRun publishes the result of each evaluation as a check run on the
+	// head commit of the pull request
+	CheckRun bool `yaml:"check_run"`
+
+	// Drafts controls how bulldozer handles draft pull requests. If empty,
+	// drafts are not merged and updates follow UpdateConfig.IgnoreDrafts.
+	Drafts DraftMode `yaml:"drafts"`
+
+	// Mode limits bulldozer to updating pull requests without merging them
+	// or to merging pull requests without updating them. If empty, the
+	// default is ModeMergeAndUpdate. Branch overrides can set a different
+	// mode for some branches.
+	Mode ConfigMode `yaml:"mode"`
+
+	// Notifications route events from this repository to notification sinks
+	// defined by the server
+	Notifications []notify.Route `yaml:"notifications"`
+
+	// Branches override parts of the configuration for pull requests that
+	// target matching branches. The first matching override applies.
+	Branches []BranchConfig `yaml:"branches"`
+
+	// branches are the complete configurations for each of Branches, created
+	// when parsing the configuration
+	branches []branchConfig
 }
@@ -0,0 +1,133 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/pull"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// ConflictConfig defines how bulldozer notifies authors when it cannot update
+// a pull request because of conflicts with the base branch.
+type ConflictConfig struct {
+	// Comment is posted on the pull request when an update fails because of
+	// conflicts. If empty, bulldozer does not comment.
+	Comment string `yaml:"comment"`
+
+	// Label is added to the pull request when an update fails because of
+	// conflicts. If empty, bulldozer does not add a label.
+	Label string `yaml:"label"`
+
+	// RemoveLabel removes Label when the pull request is updated or is no
+	// longer behind the base branch.
+	RemoveLabel bool `yaml:"remove_label"`
+}
+
+// isConflict returns true if the error is from updating a branch that has
+// conflicts with the base branch.
+func isConflict(err error) bool {
+	if rerr, ok := errors.Cause(err).(*github.ErrorResponse); ok {
+		return rerr.Response.StatusCode == http.StatusConflict
+	}
+	// GraphQL errors do not have status codes
+	return strings.Contains(strings.ToLower(err.Error()), "conflict")
+}
+
+func hasLabel(ctx context.Context, pullCtx pull.Context, label string) (bool, error) {
+	labels, err := pullCtx.Labels(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to list labels")
+	}
+	for _, l := range labels {
+		if strings.EqualFold(l, label) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// reportConflict comments on and labels the pull request after an update
+// fails because of conflicts. It does not comment if the pull request already
+// has the label or an identical comment, so authors are notified once.
+func reportConflict(ctx context.Context, pullCtx pull.Context, client *github.Client, config ConflictConfig) error {
+	logger := zerolog.Ctx(ctx)
+	owner, repo, number := pullCtx.Owner(), pullCtx.Repo(), pullCtx.Number()
+
+	if config.Label != "" {
+		labeled, err := hasLabel(ctx, pullCtx, config.Label)
+		if err != nil {
+			return err
+		}
+		if labeled {
+			logger.Debug().Msg("Pull request already has the conflict label")
+			return nil
+		}
+
+		if _, _, err := client.Issues.AddLabelsToIssue(ctx, owner, repo, number, []string{config.Label}); err != nil {
+			return errors.Wrap(err, "failed to add conflict label")
+		}
+		logger.Info().Msgf("Added conflict label %q", config.Label)
+	}
+
+	if config.Comment == "" {
+		return nil
+	}
+
+	// without a label, an identical comment is the only record of a
+	// previous notification
+	if config.Label == "" {
+		comments, err := pullCtx.Comments(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to list comments")
+		}
+		for _, comment := range comments {
+			if comment == config.Comment {
+				logger.Debug().Msg("Pull request already has the conflict comment")
+				return nil
+			}
+		}
+	}
+
+	comment := &github.IssueComment{Body: github.String(config.Comment)}
+	if _, _, err := client.Issues.CreateComment(ctx, owner, repo, number, comment); err != nil {
+		return errors.Wrap(err, "failed to create conflict comment")
+	}
+	return nil
+}
+
+// clearConflict removes the conflict label from the pull request, if the
+// configuration allows it and the pull request has the label.
+func clearConflict(ctx context.Context, pullCtx pull.Context, client *github.Client, config ConflictConfig) error {
+	if config.Label == "" || !config.RemoveLabel {
+		return nil
+	}
+
+	labeled, err := hasLabel(ctx, pullCtx, config.Label)
+	if err != nil || !labeled {
+		return err
+	}
+
+	if _, err := client.Issues.RemoveLabelForIssue(ctx, pullCtx.Owner(), pullCtx.Repo(), pullCtx.Number(), config.Label); err != nil {
+		return errors.Wrap(err, "failed to remove conflict label")
+	}
+	zerolog.Ctx(ctx).Info().Msgf("Removed conflict label %q", config.Label)
+	return nil
+}
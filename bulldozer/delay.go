@@ -0,0 +1,107 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/palantir/bulldozer/pull"
+	"github.com/pkg/errors"
+)
+
+// Duration is a time.Duration that is formatted like "2h" or "30m" in
+// configuration files.
+type Duration time.Duration
+
+func (d *Duration) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return errors.Wrapf(err, "invalid duration %q", s)
+	}
+	if parsed < 0 {
+		return errors.Errorf("invalid duration %q, must not be negative", s)
+	}
+
+	*d = Duration(parsed)
+	return nil
+}
+
+type pullKey struct {
+	owner  string
+	repo   string
+	number int
+}
+
+type delayEntry struct {
+	sha   string
+	since time.Time
+}
+
+// DelayTracker records when pull requests became eligible to merge, so that
+// pull requests can be required to stay eligible for some time before they
+// merge. Eligibility starts again when new commits are pushed. It is safe for
+// concurrent use.
+type DelayTracker struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[pullKey]delayEntry
+}
+
+func NewDelayTracker() *DelayTracker {
+	return &DelayTracker{
+		now:     time.Now,
+		entries: make(map[pullKey]delayEntry),
+	}
+}
+
+func newPullKey(pullCtx pull.Context) pullKey {
+	return pullKey{
+		owner:  strings.ToLower(pullCtx.Owner()),
+		repo:   strings.ToLower(pullCtx.Repo()),
+		number: pullCtx.Number(),
+	}
+}
+
+// Eligible records that the pull request is eligible to merge and returns the
+// time when it will have been eligible at its current head for the delay.
+func (t *DelayTracker) Eligible(pullCtx pull.Context, delay time.Duration) time.Time {
+	key := newPullKey(pullCtx)
+	sha := pullCtx.HeadSHA()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entry, ok := t.entries[key]
+	if !ok || entry.sha != sha {
+		entry = delayEntry{sha: sha, since: t.now()}
+		t.entries[key] = entry
+	}
+	return entry.since.Add(delay)
+}
+
+// Reset records that the pull request is not eligible to merge.
+func (t *DelayTracker) Reset(pullCtx pull.Context) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.entries, newPullKey(pullCtx))
+}
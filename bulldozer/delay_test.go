@@ -0,0 +1,59 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/palantir/bulldozer/pull/pulltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestDelayTracker(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+
+	tracker := NewDelayTracker()
+	tracker.now = func() time.Time { return now }
+
+	pullCtx := &pulltest.MockPullContext{OwnerValue: "testorg", RepoValue: "testrepo", NumberValue: 1, HeadSHAValue: "a"}
+
+	assert.Equal(t, start.Add(time.Hour), tracker.Eligible(pullCtx, time.Hour))
+
+	now = start.Add(30 * time.Minute)
+	assert.Equal(t, start.Add(time.Hour), tracker.Eligible(pullCtx, time.Hour), "eligibility did not continue")
+
+	pullCtx.HeadSHAValue = "b"
+	assert.Equal(t, now.Add(time.Hour), tracker.Eligible(pullCtx, time.Hour), "new commits did not reset eligibility")
+
+	now = start.Add(45 * time.Minute)
+	tracker.Reset(pullCtx)
+	assert.Equal(t, now.Add(time.Hour), tracker.Eligible(pullCtx, time.Hour), "reset did not reset eligibility")
+}
+
+func TestDurationUnmarshal(t *testing.T) {
+	var config struct {
+		Delay Duration `yaml:"delay"`
+	}
+
+	require.NoError(t, yaml.UnmarshalStrict([]byte("delay: 2h30m"), &config))
+	assert.Equal(t, Duration(150*time.Minute), config.Delay)
+
+	assert.Error(t, yaml.UnmarshalStrict([]byte("delay: soon"), &config))
+	assert.Error(t, yaml.UnmarshalStrict([]byte("delay: -1h"), &config))
+}
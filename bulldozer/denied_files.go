@@ -0,0 +1,95 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/pull"
+	"github.com/pkg/errors"
+)
+
+// ByteSize is a number of bytes that is formatted like "512KB" or "5MB" in
+// configuration files. Units are powers of 1024.
+type ByteSize int64
+
+var byteSizeUnits = []struct {
+	suffix string
+	size   ByteSize
+}{
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+func (s *ByteSize) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+
+	value, unit := strings.ToUpper(strings.TrimSpace(str)), ByteSize(1)
+	for _, u := range byteSizeUnits {
+		if strings.HasSuffix(value, u.suffix) {
+			value, unit = strings.TrimSpace(strings.TrimSuffix(value, u.suffix)), u.size
+			break
+		}
+	}
+
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n < 0 {
+		return errors.Errorf("invalid size %q", str)
+	}
+
+	*s = ByteSize(n * float64(unit))
+	return nil
+}
+
+func (s ByteSize) String() string {
+	for _, u := range byteSizeUnits {
+		if s >= u.size && u.size > 1 {
+			return strconv.FormatFloat(float64(s)/float64(u.size), 'f', 1, 64) + u.suffix
+		}
+	}
+	return fmt.Sprintf("%dB", int64(s))
+}
+
+const deniedFilesMarker = "<!-- bulldozer:denied-files -->"
+
+// ReportDeniedFiles comments on the pull request if the denied files signal
+// in the ignore section matches and is configured to comment. The comment is
+// updated if the reason changes.
+func ReportDeniedFiles(ctx context.Context, pullCtx pull.Context, client *github.Client, mergeConfig MergeConfig) error {
+	signal := mergeConfig.Ignore.DeniedFiles
+	if !signal.Comment {
+		return nil
+	}
+
+	matches, reason, err := signal.Matches(ctx, pullCtx, "ignored")
+	if err != nil || !matches {
+		return err
+	}
+
+	body := fmt.Sprintf("%s\nThis pull request will not be merged automatically because %s.\n", deniedFilesMarker, reason)
+	if _, err := upsertMarkedComment(ctx, pullCtx, client, deniedFilesMarker, body); err != nil {
+		return errors.Wrap(err, "failed to post denied files comment")
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Dependency is an issue or pull request that a pull request depends on.
+type Dependency struct {
+	Owner  string
+	Repo   string
+	Number int
+}
+
+func (d Dependency) String() string {
+	return fmt.Sprintf("%s/%s#%d", d.Owner, d.Repo, d.Number)
+}
+
+var (
+	dependsOnPattern  = regexp.MustCompile(`(?im)^[ \t]*depends[ \t-]on:(.*)$`)
+	dependencyPattern = regexp.MustCompile(`^(?:https?://[^/]+/([\w.-]+)/([\w.-]+)/(?:pull|issues)/|(?:([\w.-]+)/([\w.-]+))?#)(\d+)$`)
+)
+
+// ParseDependencies returns the dependencies declared in the body of a pull
+// request, in lines like "Depends-on: #123" or "Depends-on: org/repo#456".
+// A line may list more than one reference, separated by commas or spaces,
+// and references may also be URLs of issues or pull requests. References
+// without a repository are in the repository of the pull request.
+func ParseDependencies(body, owner, repo string) []Dependency {
+	var deps []Dependency
+	seen := make(map[string]bool)
+
+	for _, line := range dependsOnPattern.FindAllStringSubmatch(body, -1) {
+		for _, field := range strings.FieldsFunc(line[1], func(r rune) bool { return r == ',' || r == ' ' || r == '\t' || r == '\r' }) {
+			m := dependencyPattern.FindStringSubmatch(field)
+			if m == nil {
+				continue
+			}
+
+			number, err := strconv.Atoi(m[5])
+			if err != nil {
+				continue
+			}
+			d := Dependency{Owner: owner, Repo: repo, Number: number}
+			switch {
+			case m[1] != "":
+				d.Owner, d.Repo = m[1], m[2]
+			case m[3] != "":
+				d.Owner, d.Repo = m[3], m[4]
+			}
+
+			if key := strings.ToLower(d.String()); !seen[key] {
+				seen[key] = true
+				deps = append(deps, d)
+			}
+		}
+	}
+	return deps
+}
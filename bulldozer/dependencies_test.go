@@ -0,0 +1,40 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDependencies(t *testing.T) {
+	body := `Adds the new client.
+
+Depends-on: #12, testorg/otherrepo#34
+depends on: https://github.com/otherorg/lib/pull/56
+Depends-on: #12 and not #abc
+This PR does not depend on: #99 because the prefix is not at the start
+`
+
+	deps := ParseDependencies(body, "testorg", "testrepo")
+	assert.Equal(t, []Dependency{
+		{Owner: "testorg", Repo: "testrepo", Number: 12},
+		{Owner: "testorg", Repo: "otherrepo", Number: 34},
+		{Owner: "otherorg", Repo: "lib", Number: 56},
+	}, deps)
+
+	assert.Empty(t, ParseDependencies("Fixes #12", "testorg", "testrepo"))
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+
+	"github.com/palantir/bulldozer/pull"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/shurcooL/githubv4"
+)
+
+// MarkPullRequestReadyForReviewInput is the input to the
+// markPullRequestReadyForReview mutation. The name of this type must match
+// the name of the type in the GraphQL schema.
+type MarkPullRequestReadyForReviewInput struct {
+	PullRequestID githubv4.ID `json:"pullRequestId"`
+}
+
+// MarkReadyForReview marks a draft pull request as ready for review. The REST
+// API does not support this, so it uses the GraphQL API.
+func MarkReadyForReview(ctx context.Context, pullCtx pull.Context, client GraphQLClient) error {
+	var q struct {
+		Repository struct {
+			PullRequest struct {
+				ID      githubv4.ID
+				IsDraft bool
+			} `graphql:"pullRequest(number: $number)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+
+	qvars := map[string]interface{}{
+		"owner":  githubv4.String(pullCtx.Owner()),
+		"name":   githubv4.String(pullCtx.Repo()),
+		"number": githubv4.Int(pullCtx.Number()),
+	}
+	if err := client.Query(ctx, &q, qvars); err != nil {
+		return errors.Wrap(err, "failed to get draft state")
+	}
+
+	pr := q.Repository.PullRequest
+	if !pr.IsDraft {
+		return nil
+	}
+
+	var mutation struct {
+		MarkPullRequestReadyForReview struct {
+			PullRequest struct {
+				IsDraft bool
+			}
+		} `graphql:"markPullRequestReadyForReview(input: $input)"`
+	}
+
+	input := MarkPullRequestReadyForReviewInput{PullRequestID: pr.ID}
+	if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+		return errors.Wrap(err, "failed to mark pull request ready for review")
+	}
+
+	zerolog.Ctx(ctx).Info().Msg("Marked pull request ready for review")
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/palantir/bulldozer/pull/pulltest"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkReadyForReview(t *testing.T) {
+	draft := true
+	var markCount int
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/graphql", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasPrefix(body.Query, "mutation") {
+			assert.Equal(t, map[string]interface{}{"pullRequestId": "PR_1"}, body.Variables["input"])
+			markCount++
+			draft = false
+			_, _ = io.WriteString(w, `{"data": {"markPullRequestReadyForReview": {"pullRequest": {"isDraft": false}}}}`)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"data": {"repository": {"pullRequest": {"id": "PR_1", "isDraft": %t}}}}`, draft)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := githubv4.NewEnterpriseClient(srv.URL+"/api/graphql", srv.Client())
+
+	ctx := context.Background()
+	pullCtx := &pulltest.MockPullContext{OwnerValue: "testorg", RepoValue: "testrepo", NumberValue: 1}
+
+	require.NoError(t, MarkReadyForReview(ctx, pullCtx, client))
+	assert.Equal(t, 1, markCount, "pull request was not marked ready for review")
+
+	require.NoError(t, MarkReadyForReview(ctx, pullCtx, client))
+	assert.Equal(t, 1, markCount, "ready pull request was marked ready for review again")
+}
+
+func TestDraftModes(t *testing.T) {
+	ctx := context.Background()
+	pullCtx := &pulltest.MockPullContext{
+		IsDraftValue: true,
+		LabelValue:   []string{"trigger"},
+	}
+	trigger := Signals{Labels: []string{"trigger"}}
+
+	tests := map[string]struct {
+		Drafts DraftMode
+		Merge  bool
+		Update bool
+		Reason string
+	}{
+		"default": {
+			Drafts: "",
+			Merge:  false,
+			Update: true,
+			Reason: "not mergeable because PR is in a draft state",
+		},
+		"ignore": {
+			Drafts: DraftsIgnore,
+			Merge:  false,
+			Update: false,
+			Reason: "not mergeable because PR is in a draft state",
+		},
+		"update": {
+			Drafts: DraftsUpdate,
+			Merge:  false,
+			Update: true,
+			Reason: "not mergeable because PR is in a draft state",
+		},
+		"ready": {
+			Drafts: DraftsReady,
+			Merge:  true,
+			Update: true,
+			Reason: `mergeable because pull request has a triggered label: "trigger" and all required status checks passed`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mergeConfig := MergeConfig{Trigger: trigger, AllowMergeWithNoChecks: true, Drafts: test.Drafts}
+			merge, reason, err := ExplainMergePR(ctx, pullCtx, mergeConfig)
+			require.NoError(t, err)
+			assert.Equal(t, test.Merge, merge)
+			assert.Equal(t, test.Reason, reason)
+
+			updateConfig := UpdateConfig{Trigger: trigger, Drafts: test.Drafts}
+			update, _, err := ExplainUpdatePR(ctx, pullCtx, updateConfig)
+			require.NoError(t, err)
+			assert.Equal(t, test.Update, update)
+		})
+	}
+
+	t.Run("updateOverridesIgnoreDrafts", func(t *testing.T) {
+		ignoreDrafts := true
+		updateConfig := UpdateConfig{
+			Ignore:       Signals{Labels: []string{"ignore"}},
+			IgnoreDrafts: &ignoreDrafts,
+		}
+
+		update, _, err := ExplainUpdatePR(ctx, pullCtx, updateConfig)
+		require.NoError(t, err)
+		assert.False(t, update)
+
+		updateConfig.Drafts = DraftsUpdate
+		update, _, err = ExplainUpdatePR(ctx, pullCtx, updateConfig)
+		require.NoError(t, err)
+		assert.True(t, update)
+	})
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/pull"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// DryRunMergePR evaluates a pull request for merging and comments on the pull
+// request with the result instead of merging it.
+func DryRunMergePR(ctx context.Context, pullCtx pull.Context, client *github.Client, mergeConfig MergeConfig) error {
+	shouldMerge, reason, err := ExplainMergePR(ctx, pullCtx, mergeConfig)
+	if err != nil {
+		return err
+	}
+
+	action := "would not merge this pull request"
+	if shouldMerge {
+		method, err := DetermineMergeMethod(ctx, pullCtx, mergeConfig)
+		if err != nil {
+			return errors.Wrap(err, "failed to determine merge method")
+		}
+		action = fmt.Sprintf("would merge this pull request with method `%s`", method)
+	}
+	return postDryRunComment(ctx, pullCtx, client, action, reason)
+}
+
+// DryRunUpdatePR evaluates a pull request for updating and comments on the pull
+// request with the result instead of updating it. It does not comment if
+// updates are not configured.
+func DryRunUpdatePR(ctx context.Context, pullCtx pull.Context, client *github.Client, updateConfig UpdateConfig, baseRef string) error {
+	if !updateConfig.configured() {
+		return nil
+	}
+
+	shouldUpdate, reason, err := ExplainUpdatePR(ctx, pullCtx, updateConfig)
+	if err != nil {
+		return err
+	}
+
+	action := "would not update this pull request"
+	if shouldUpdate {
+		action = fmt.Sprintf("would update this pull request from `%s` if it is out of date", baseRef)
+	}
+	return postDryRunComment(ctx, pullCtx, client, action, reason)
+}
+
+// postDryRunComment comments on the pull request, unless the pull request
+// already has an identical comment. Comments include the head SHA, so there is
+// at most one comment for each result on each commit.
+func postDryRunComment(ctx context.Context, pullCtx pull.Context, client *github.Client, action, reason string) error {
+	logger := zerolog.Ctx(ctx)
+
+	body := fmt.Sprintf("**bulldozer dry run** at %s: %s.\n\nThe pull request is %s.", pullCtx.HeadSHA(), action, reason)
+
+	comments, err := pullCtx.Comments(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to list comments")
+	}
+	for _, comment := range comments {
+		if comment == body {
+			logger.Debug().Msg("Skipping dry run comment because the pull request already has it")
+			return nil
+		}
+	}
+
+	logger.Info().Msgf("Dry run: %s: %s", action, reason)
+	comment := &github.IssueComment{Body: github.String(body)}
+	if _, _, err := client.Issues.CreateComment(ctx, pullCtx.Owner(), pullCtx.Repo(), pullCtx.Number(), comment); err != nil {
+		return errors.Wrap(err, "failed to create dry run comment")
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/pull/pulltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCommentRecorder returns a client that records the bodies of comments
+// created on any pull request.
+func newCommentRecorder(t *testing.T) (*github.Client, *[]string) {
+	var comments []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/testorg/testrepo/issues/1/comments", func(w http.ResponseWriter, r *http.Request) {
+		var comment github.IssueComment
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&comment))
+		comments = append(comments, comment.GetBody())
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+	return client, &comments
+}
+
+func TestDryRunMergePR(t *testing.T) {
+	ctx := context.Background()
+	mergeConfig := MergeConfig{
+		Trigger: Signals{
+			Labels: []string{"merge when ready"},
+		},
+		Method:                 SquashAndMerge,
+		AllowMergeWithNoChecks: true,
+	}
+
+	t.Run("wouldMerge", func(t *testing.T) {
+		client, comments := newCommentRecorder(t)
+		pullCtx := &pulltest.MockPullContext{
+			OwnerValue:   "testorg",
+			RepoValue:    "testrepo",
+			NumberValue:  1,
+			HeadSHAValue: "abc",
+			LabelValue:   []string{"merge when ready"},
+		}
+
+		require.NoError(t, DryRunMergePR(ctx, pullCtx, client, mergeConfig))
+		require.Len(t, *comments, 1)
+		assert.Equal(t, "**bulldozer dry run** at abc: would merge this pull request with method `squash`.\n\nThe pull request is mergeable because pull request has a triggered label: \"merge when ready\" and all required status checks passed.", (*comments)[0])
+	})
+
+	t.Run("wouldNotMerge", func(t *testing.T) {
+		client, comments := newCommentRecorder(t)
+		pullCtx := &pulltest.MockPullContext{
+			OwnerValue:   "testorg",
+			RepoValue:    "testrepo",
+			NumberValue:  1,
+			HeadSHAValue: "abc",
+		}
+
+		require.NoError(t, DryRunMergePR(ctx, pullCtx, client, mergeConfig))
+		require.Len(t, *comments, 1)
+		assert.Contains(t, (*comments)[0], "would not merge this pull request.\n\nThe pull request is not mergeable because triggering is enabled")
+	})
+
+	t.Run("skipsDuplicateComment", func(t *testing.T) {
+		client, comments := newCommentRecorder(t)
+		pullCtx := &pulltest.MockPullContext{
+			OwnerValue:   "testorg",
+			RepoValue:    "testrepo",
+			NumberValue:  1,
+			HeadSHAValue: "abc",
+		}
+
+		require.NoError(t, DryRunMergePR(ctx, pullCtx, client, mergeConfig))
+		pullCtx.CommentValue = *comments
+
+		require.NoError(t, DryRunMergePR(ctx, pullCtx, client, mergeConfig))
+		assert.Len(t, *comments, 1, "duplicate comment was created")
+	})
+}
+
+func TestDryRunUpdatePR(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("notConfigured", func(t *testing.T) {
+		client, comments := newCommentRecorder(t)
+		pullCtx := &pulltest.MockPullContext{OwnerValue: "testorg", RepoValue: "testrepo", NumberValue: 1}
+
+		require.NoError(t, DryRunUpdatePR(ctx, pullCtx, client, UpdateConfig{}, "develop"))
+		assert.Empty(t, *comments)
+	})
+
+	t.Run("wouldUpdate", func(t *testing.T) {
+		client, comments := newCommentRecorder(t)
+		pullCtx := &pulltest.MockPullContext{
+			OwnerValue:   "testorg",
+			RepoValue:    "testrepo",
+			NumberValue:  1,
+			HeadSHAValue: "abc",
+			LabelValue:   []string{"update me"},
+		}
+		updateConfig := UpdateConfig{
+			Trigger: Signals{
+				Labels: []string{"update me"},
+			},
+		}
+
+		require.NoError(t, DryRunUpdatePR(ctx, pullCtx, client, updateConfig, "develop"))
+		require.Len(t, *comments, 1)
+		assert.Contains(t, (*comments)[0], "would update this pull request from `develop` if it is out of date")
+	})
+}
@@ -0,0 +1,147 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/palantir/bulldozer/pull"
+	"github.com/pkg/errors"
+)
+
+// Eligibility describes each input to the decision to merge a pull request,
+// so that users can see why a pull request is or is not merged.
+type Eligibility struct {
+	Mergeable bool   `json:"mergeable"`
+	Reason    string `json:"reason"`
+
+	Updateable   bool   `json:"updateable"`
+	UpdateReason string `json:"update_reason"`
+
+	Draft bool `json:"draft"`
+
+	// Ignore and Trigger are the results of the enabled merge ignore and
+	// trigger signals
+	Ignore  []SignalResult `json:"ignore"`
+	Trigger []SignalResult `json:"trigger"`
+
+	// RequiredStatuses includes statuses required by branch protection and
+	// by the configuration
+	RequiredStatuses    []string `json:"required_statuses"`
+	SuccessStatuses     []string `json:"success_statuses"`
+	UnsatisfiedStatuses []string `json:"unsatisfied_statuses"`
+
+	// PolicyBot lists the approval rules that policy-bot has not approved,
+	// if the server can read them
+	PolicyBot []PolicyBotRule `json:"policy_bot,omitempty"`
+}
+
+// ExplainEligibility evaluates the pull request for merging and updating and
+// returns the state of every signal and status that contributes to the
+// decisions.
+func ExplainEligibility(ctx context.Context, pullCtx pull.Context, config Config) (*Eligibility, error) {
+	var e Eligibility
+	var err error
+
+	e.Mergeable, e.Reason, err = ExplainMergePR(ctx, pullCtx, config.Merge)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to evaluate pull request for merge")
+	}
+	e.Updateable, e.UpdateReason, err = ExplainUpdatePR(ctx, pullCtx, config.Update)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to evaluate pull request for update")
+	}
+
+	e.Draft = pullCtx.IsDraft(ctx)
+
+	if e.Ignore, err = config.Merge.Ignore.Explain(ctx, pullCtx, "ignored"); err != nil {
+		return nil, err
+	}
+	if e.Trigger, err = config.Merge.Trigger.Explain(ctx, pullCtx, "triggered"); err != nil {
+		return nil, err
+	}
+
+	required, err := pullCtx.RequiredStatuses(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to determine required Github status checks")
+	}
+	e.RequiredStatuses = append(append([]string{}, required...), config.Merge.RequiredStatuses...)
+	if e.RequiredStatuses, err = requirePolicyBot(ctx, pullCtx, config.Merge.PolicyBot, e.RequiredStatuses); err != nil {
+		return nil, errors.Wrap(err, "failed to determine policy-bot status checks")
+	}
+
+	if e.SuccessStatuses, err = pullCtx.CurrentSuccessStatuses(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to determine currently successful status checks")
+	}
+	e.UnsatisfiedStatuses = statusSetDifference(e.RequiredStatuses, e.SuccessStatuses)
+
+	return &e, nil
+}
+
+// Markdown describes each signal and status in the evaluation as Markdown
+// tables.
+func (e *Eligibility) Markdown() string {
+	var b strings.Builder
+
+	writeSignals := func(heading string, results []SignalResult) {
+		fmt.Fprintf(&b, "### %s\n\n", heading)
+		if len(results) == 0 {
+			b.WriteString("None configured.\n\n")
+			return
+		}
+		b.WriteString("| Signal | Matched | Description |\n| --- | --- | --- |\n")
+		for _, r := range results {
+			fmt.Fprintf(&b, "| `%s` | %s | %s |\n", r.Name, checkMark(r.Matched), r.Description)
+		}
+		b.WriteString("\n")
+	}
+	writeSignals("Trigger signals", e.Trigger)
+	writeSignals("Ignore signals", e.Ignore)
+
+	b.WriteString("### Required statuses\n\n")
+	if len(e.RequiredStatuses) == 0 {
+		b.WriteString("None required.\n\n")
+	} else {
+		unsatisfied := make(map[string]bool)
+		for _, s := range e.UnsatisfiedStatuses {
+			unsatisfied[s] = true
+		}
+		b.WriteString("| Status | Passed |\n| --- | --- |\n")
+		for _, s := range e.RequiredStatuses {
+			fmt.Fprintf(&b, "| `%s` | %s |\n", s, checkMark(!unsatisfied[s]))
+		}
+		b.WriteString("\n")
+	}
+
+	if len(e.PolicyBot) > 0 {
+		b.WriteString("### Unapproved policy-bot rules\n\n| Rule | Status | Description |\n| --- | --- | --- |\n")
+		for _, r := range e.PolicyBot {
+			fmt.Fprintf(&b, "| `%s` | %s | %s |\n", r.Name, r.Status, r.Description)
+		}
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "### Update\n\nThe pull request is %s.\n", e.UpdateReason)
+	return b.String()
+}
+
+func checkMark(ok bool) string {
+	if ok {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/palantir/bulldozer/pull/pulltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainEligibility(t *testing.T) {
+	config := Config{
+		Merge: MergeConfig{
+			Trigger: Signals{
+				Labels:   []string{"merge when ready"},
+				Branches: []string{"develop"},
+			},
+			Ignore: Signals{
+				Labels: []string{"do not merge"},
+			},
+			RequiredStatuses: []string{"lint"},
+		},
+	}
+
+	pullCtx := &pulltest.MockPullContext{
+		LabelValue:            []string{"merge when ready"},
+		BranchBase:            "main",
+		RequiredStatusesValue: []string{"build"},
+		SuccessStatusesValue:  []string{"build"},
+	}
+
+	e, err := ExplainEligibility(context.Background(), pullCtx, config)
+	require.NoError(t, err)
+
+	assert.False(t, e.Mergeable)
+	assert.Equal(t, "not mergeable because of unfulfilled status checks: [lint]", e.Reason)
+	assert.False(t, e.Updateable)
+
+	assert.Equal(t, []SignalResult{
+		{Name: "labels", Matched: false},
+	}, e.Ignore)
+	assert.Equal(t, []SignalResult{
+		{Name: "labels", Matched: true, Description: `pull request has a triggered label: "merge when ready"`},
+		{Name: "branches", Matched: false},
+	}, e.Trigger)
+
+	assert.Equal(t, []string{"build", "lint"}, e.RequiredStatuses)
+	assert.Equal(t, []string{"lint"}, e.UnsatisfiedStatuses)
+}
@@ -16,9 +16,12 @@ package bulldozer
 
 import (
 	"context"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/palantir/bulldozer/pull"
+	"github.com/palantir/bulldozer/tracing"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 )
@@ -95,18 +98,48 @@ func statusSetDifference(required, actual []string) []string {
 	return result
 }
 
-// ShouldMergePR TODO: may want to return a richer type than bool
+// ShouldMergePR returns true if the pull request should be merged.
 func ShouldMergePR(ctx context.Context, pullCtx pull.Context, mergeConfig MergeConfig) (bool, error) {
+	shouldMerge, reason, err := ExplainMergePR(ctx, pullCtx, mergeConfig)
+	if err != nil {
+		return false, err
+	}
+	zerolog.Ctx(ctx).Debug().Msgf("%s is deemed %s", pullCtx.Locator(), reason)
+	return shouldMerge, nil
+}
+
+// ExplainMergePR returns true if the pull request should be merged.
+// Additionally, a description of the reason will be returned.
+func ExplainMergePR(ctx context.Context, pullCtx pull.Context, mergeConfig MergeConfig) (bool, string, error) {
+	ctx, span := tracing.Start(ctx, "evaluate merge", tracing.SpanKindInternal, tracing.Int("github.pull_request", pullCtx.Number()))
+	defer span.End()
+
+	shouldMerge, reason, err := explainMergePR(ctx, pullCtx, mergeConfig)
+	span.SetAttributes(tracing.Bool("bulldozer.should_merge", shouldMerge), tracing.String("bulldozer.reason", reason))
+	span.RecordError(err)
+	return shouldMerge, reason, err
+}
+
+func explainMergePR(ctx context.Context, pullCtx pull.Context, mergeConfig MergeConfig) (bool, string, error) {
 	logger := zerolog.Ctx(ctx)
 
+	var triggerReason string
+
+	if mergeConfig.Disabled {
+		return false, "not mergeable because the configuration is update only", nil
+	}
+
+	if pullCtx.IsDraft(ctx) && mergeConfig.Drafts != DraftsReady {
+		return false, "not mergeable because PR is in a draft state", nil
+	}
+
 	if mergeConfig.Ignore.Enabled() {
 		ignored, reason, err := IsPRIgnored(ctx, pullCtx, mergeConfig.Ignore)
 		if err != nil {
-			return false, errors.Wrap(err, "failed to determine if pull request is ignored for merge")
+			return false, "", errors.Wrap(err, "failed to determine if pull request is ignored for merge")
 		}
 		if ignored {
-			logger.Debug().Msgf("%s is deemed not mergeable because ignoring is enabled and %s", pullCtx.Locator(), reason)
-			return false, nil
+			return false, fmt.Sprintf("not mergeable because ignoring is enabled and %s", reason), nil
 		}
 	} else {
 		logger.Debug().Msg("ignoring for merge is not enabled")
@@ -115,79 +148,143 @@ func ShouldMergePR(ctx context.Context, pullCtx pull.Context, mergeConfig MergeC
 	if mergeConfig.Trigger.Enabled() {
 		triggered, reason, err := IsPRTriggered(ctx, pullCtx, mergeConfig.Trigger)
 		if err != nil {
-			return false, errors.Wrap(err, "failed to determine if pull request is triggered for merge")
+			return false, "", errors.Wrap(err, "failed to determine if pull request is triggered for merge")
 		}
 		if !triggered {
-			logger.Debug().Msgf("%s is deemed not mergeable because triggering is enabled and no trigger signal detected", pullCtx.Locator())
-			return false, nil
+			return false, "not mergeable because triggering is enabled and no trigger signal detected", nil
 		}
 
 		logger.Debug().Msgf("%s is triggered for merge because triggering is enabled and %s", pullCtx.Locator(), reason)
+		triggerReason = reason
 	} else {
 		logger.Debug().Msg("triggering for merge is not enabled")
 	}
 
 	requiredStatuses, err := pullCtx.RequiredStatuses(ctx)
 	if err != nil {
-		return false, errors.Wrap(err, "failed to determine required Github status checks for merge")
+		return false, "", errors.Wrap(err, "failed to determine required Github status checks for merge")
 	}
 	requiredStatuses = append(requiredStatuses, mergeConfig.RequiredStatuses...)
 
+	// the policy-bot status alone is not enough to merge, so it does not
+	// count as a required status check here
 	if len(requiredStatuses) == 0 && !mergeConfig.AllowMergeWithNoChecks {
-		logger.Debug().Msgf("%s has 0 required status checks, but is deemed not mergeable because AllowMergeWithNoChecks is false", pullCtx.Locator())
-		return false, nil
+		return false, "not mergeable because there are 0 required status checks and AllowMergeWithNoChecks is false", nil
+	}
+
+	if requiredStatuses, err = requirePolicyBot(ctx, pullCtx, mergeConfig.PolicyBot, requiredStatuses); err != nil {
+		return false, "", errors.Wrap(err, "failed to determine policy-bot status checks for merge")
 	}
 
 	successStatuses, err := pullCtx.CurrentSuccessStatuses(ctx)
 	if err != nil {
-		return false, errors.Wrap(err, "failed to determine currently successful status checks for merge")
+		return false, "", errors.Wrap(err, "failed to determine currently successful status checks for merge")
 	}
 
 	unsatisfiedStatuses := statusSetDifference(requiredStatuses, successStatuses)
 	if len(unsatisfiedStatuses) > 0 {
-		logger.Debug().Msgf("%s is deemed not mergeable because of unfulfilled status checks: [%s]", pullCtx.Locator(), strings.Join(unsatisfiedStatuses, ","))
-		return false, nil
+		return false, fmt.Sprintf("not mergeable because of unfulfilled status checks: [%s]", strings.Join(unsatisfiedStatuses, ",")), nil
 	}
 
-	// Ignore required reviews and try a merge (which may fail with a 4XX).
-	return true, nil
+	if !mergeConfig.IgnoreBranchRequirements {
+		unmet, err := UnmetBranchRequirement(ctx, pullCtx, mergeConfig)
+		if err != nil {
+			return false, "", errors.Wrap(err, "failed to determine if branch requirements are met for merge")
+		}
+		if unmet != "" {
+			return false, fmt.Sprintf("not mergeable because %s", unmet), nil
+		}
+	}
+
+	blackoutEnd, held, err := BlackoutEnd(mergeConfig.BlackoutWindows, time.Now())
+	if err != nil {
+		return false, "", errors.Wrap(err, "failed to determine if merges are held by a blackout window")
+	}
+	if held {
+		return false, fmt.Sprintf("not mergeable because merges are held by a blackout window until %s", blackoutEnd.Format(time.RFC3339)), nil
+	}
+
+	// Merges may still fail with a 4XX for requirements that are not checked,
+	// like code owner reviews.
+	if triggerReason != "" {
+		return true, fmt.Sprintf("mergeable because %s and all required status checks passed", triggerReason), nil
+	}
+	return true, "mergeable because all required status checks passed", nil
 }
 
+// ShouldUpdatePR returns true if the pull request should be updated.
 func ShouldUpdatePR(ctx context.Context, pullCtx pull.Context, updateConfig UpdateConfig) (bool, error) {
-	logger := zerolog.Ctx(ctx)
+	shouldUpdate, reason, err := ExplainUpdatePR(ctx, pullCtx, updateConfig)
+	if err != nil {
+		return false, err
+	}
+	zerolog.Ctx(ctx).Debug().Msgf("%s is deemed %s", pullCtx.Locator(), reason)
+	return shouldUpdate, nil
+}
+
+// configured returns true if any option that enables updates is set.
+func (updateConfig UpdateConfig) configured() bool {
+	return updateConfig.Ignore.Enabled() || updateConfig.Trigger.Enabled() || updateConfig.IgnoreDrafts != nil || len(updateConfig.RequiredStatuses) > 0
+}
+
+// ExplainUpdatePR returns true if the pull request should be updated.
+// Additionally, a description of the reason will be returned.
+func ExplainUpdatePR(ctx context.Context, pullCtx pull.Context, updateConfig UpdateConfig) (bool, string, error) {
+	ctx, span := tracing.Start(ctx, "evaluate update", tracing.SpanKindInternal, tracing.Int("github.pull_request", pullCtx.Number()))
+	defer span.End()
+
+	shouldUpdate, reason, err := explainUpdatePR(ctx, pullCtx, updateConfig)
+	span.SetAttributes(tracing.Bool("bulldozer.should_update", shouldUpdate), tracing.String("bulldozer.reason", reason))
+	span.RecordError(err)
+	return shouldUpdate, reason, err
+}
 
-	if !updateConfig.Ignore.Enabled() && !updateConfig.Trigger.Enabled() && updateConfig.IgnoreDrafts == nil && len(updateConfig.RequiredStatuses) == 0 {
-		return false, nil
+func explainUpdatePR(ctx context.Context, pullCtx pull.Context, updateConfig UpdateConfig) (bool, string, error) {
+	if updateConfig.Disabled {
+		return false, "not updateable because the configuration is merge only", nil
+	}
+
+	if !updateConfig.configured() {
+		return false, "not updateable because updates are not configured", nil
+	}
+
+	if updateConfig.Drafts == DraftsIgnore && pullCtx.IsDraft(ctx) {
+		return false, "not updateable because PR is in a draft state and drafts are ignored", nil
 	}
 
 	if updateConfig.Ignore.Enabled() {
 		ignored, reason, err := IsPRIgnored(ctx, pullCtx, updateConfig.Ignore)
 		if err != nil {
-			return false, errors.Wrap(err, "failed to determine if pull request is ignored for update")
+			return false, "", errors.Wrap(err, "failed to determine if pull request is ignored for update")
 		}
 		if ignored {
-			logger.Debug().Msgf("%s is deemed not updateable because ignoring is enabled and %s", pullCtx.Locator(), reason)
-			return false, nil
+			return false, fmt.Sprintf("not updateable because ignoring is enabled and %s", reason), nil
+		}
+	}
+
+	if updateConfig.OnlyWhenRequired {
+		required, err := pullCtx.RequiresUpToDate(ctx)
+		if err != nil {
+			return false, "", errors.Wrap(err, "failed to determine if branch protection requires updates")
+		}
+		if !required {
+			return false, "not updateable because the target branch does not require branches to be up to date", nil
 		}
 	}
 
 	if updateConfig.Trigger.Enabled() {
 		triggered, reason, err := IsPRTriggered(ctx, pullCtx, updateConfig.Trigger)
 		if err != nil {
-			return false, errors.Wrap(err, "failed to determine if pull request is triggered for update")
+			return false, "", errors.Wrap(err, "failed to determine if pull request is triggered for update")
 		}
 		if !triggered {
-			logger.Debug().Msgf("%s is deemed not updateable because triggering is enabled and no trigger signal detected", pullCtx.Locator())
-			return false, nil
+			return false, "not updateable because triggering is enabled and no trigger signal detected", nil
 		}
-
-		logger.Debug().Msgf("%s is triggered for update because triggering is enabled and %s", pullCtx.Locator(), reason)
-		return true, nil
+		return true, fmt.Sprintf("updateable because triggering is enabled and %s", reason), nil
 	}
 
-	if updateConfig.IgnoreDrafts != nil && *updateConfig.IgnoreDrafts && pullCtx.IsDraft(ctx) {
-		logger.Debug().Msgf("%s is deemed not updateable because PR is in a draft state", pullCtx.Locator())
-		return false, nil
+	if updateConfig.IgnoreDrafts != nil && *updateConfig.IgnoreDrafts && updateConfig.Drafts != DraftsUpdate && pullCtx.IsDraft(ctx) {
+		return false, "not updateable because PR is in a draft state", nil
 	}
 
 	requiredStatuses := updateConfig.RequiredStatuses
@@ -195,15 +292,14 @@ func ShouldUpdatePR(ctx context.Context, pullCtx pull.Context, updateConfig Upda
 	if len(requiredStatuses) > 0 {
 		successStatuses, err := pullCtx.CurrentSuccessStatuses(ctx)
 		if err != nil {
-			return false, errors.Wrap(err, "failed to determine currently successful status checks for update")
+			return false, "", errors.Wrap(err, "failed to determine currently successful status checks for update")
 		}
 
 		unsatisfiedStatuses := statusSetDifference(requiredStatuses, successStatuses)
 		if len(unsatisfiedStatuses) > 0 {
-			logger.Debug().Msgf("%s is deemed not updateable because of unfulfilled status checks: [%s]", pullCtx.Locator(), strings.Join(unsatisfiedStatuses, ","))
-			return false, nil
+			return false, fmt.Sprintf("not updateable because of unfulfilled status checks: [%s]", strings.Join(unsatisfiedStatuses, ",")), nil
 		}
 	}
 
-	return true, nil
+	return true, "updateable because no ignore signal matched", nil
 }
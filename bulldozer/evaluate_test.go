@@ -678,6 +678,32 @@ func TestShouldUpdatePR(t *testing.T) {
 			},
 			expectingUpdate: false,
 		},
+		// Test branch protection handling
+		"onlyWhenRequiredNotStrict": {
+			pullCtx: pulltest.MockPullContext{
+				LabelValue: []string{"trigger"},
+			},
+			updateConfig: UpdateConfig{
+				OnlyWhenRequired: true,
+				Trigger: Signals{
+					Labels: []string{"trigger"},
+				},
+			},
+			expectingUpdate: false,
+		},
+		"onlyWhenRequiredStrict": {
+			pullCtx: pulltest.MockPullContext{
+				LabelValue:            []string{"trigger"},
+				RequiresUpToDateValue: true,
+			},
+			updateConfig: UpdateConfig{
+				OnlyWhenRequired: true,
+				Trigger: Signals{
+					Labels: []string{"trigger"},
+				},
+			},
+			expectingUpdate: true,
+		},
 		// Test required statuses handling
 		"statusesOnly": {
 			pullCtx: pulltest.MockPullContext{
@@ -0,0 +1,92 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// ExtendsRef identifies a configuration file in another repository. Its
+// string form is "owner/repo@ref:path", where the ref is optional.
+type ExtendsRef struct {
+	Owner string
+	Repo  string
+
+	// Ref is the branch, tag, or SHA to read. If empty, the default branch of
+	// the repository is used.
+	Ref  string
+	Path string
+}
+
+// ParseExtendsRef parses a reference in "owner/repo@ref:path" form.
+func ParseExtendsRef(s string) (ExtendsRef, error) {
+	var r ExtendsRef
+
+	repo, path, ok := strings.Cut(s, ":")
+	if !ok || path == "" {
+		return r, errors.Errorf("invalid extends reference %q: a path is required", s)
+	}
+	r.Path = path
+
+	repo, r.Ref, _ = strings.Cut(repo, "@")
+	r.Owner, r.Repo, ok = strings.Cut(repo, "/")
+	if !ok || r.Owner == "" || r.Repo == "" || strings.Contains(r.Repo, "/") {
+		return r, errors.Errorf("invalid extends reference %q: the repository must be in owner/repo form", s)
+	}
+	return r, nil
+}
+
+func (r ExtendsRef) String() string {
+	if r.Ref == "" {
+		return fmt.Sprintf("%s/%s:%s", r.Owner, r.Repo, r.Path)
+	}
+	return fmt.Sprintf("%s/%s@%s:%s", r.Owner, r.Repo, r.Ref, r.Path)
+}
+
+// ReadExtends returns the value of the extends key in a configuration file,
+// or an empty string if the key is not set.
+func ReadExtends(bytes []byte) (string, error) {
+	var config struct {
+		Extends string `yaml:"extends"`
+	}
+	if err := yaml.Unmarshal(bytes, &config); err != nil {
+		return "", errors.Wrap(err, "failed to unmarshal configuration")
+	}
+	return config.Extends, nil
+}
+
+// MergeConfigs merges the override configuration file into the base file.
+// Maps are merged key by key and all other values, including lists, are
+// replaced. The extends key is removed from the result.
+func MergeConfigs(base, override []byte) ([]byte, error) {
+	var baseMap, overrideMap yaml.MapSlice
+	if err := yaml.Unmarshal(base, &baseMap); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal base configuration")
+	}
+	if err := yaml.Unmarshal(override, &overrideMap); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal configuration")
+	}
+
+	merged := mergeMapSlices(removeKey(baseMap, "extends"), removeKey(overrideMap, "extends"), -1)
+	b, err := yaml.Marshal(merged)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal merged configuration")
+	}
+	return b, nil
+}
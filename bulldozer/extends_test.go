@@ -0,0 +1,74 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExtendsRef(t *testing.T) {
+	ref, err := ParseExtendsRef("testorg/policy@v1:bulldozer/base.yml")
+	require.NoError(t, err)
+	assert.Equal(t, ExtendsRef{Owner: "testorg", Repo: "policy", Ref: "v1", Path: "bulldozer/base.yml"}, ref)
+	assert.Equal(t, "testorg/policy@v1:bulldozer/base.yml", ref.String())
+
+	ref, err = ParseExtendsRef("testorg/policy:base.yml")
+	require.NoError(t, err)
+	assert.Equal(t, ExtendsRef{Owner: "testorg", Repo: "policy", Path: "base.yml"}, ref)
+
+	_, err = ParseExtendsRef("testorg/policy@v1")
+	assert.EqualError(t, err, `invalid extends reference "testorg/policy@v1": a path is required`)
+
+	_, err = ParseExtendsRef("policy:base.yml")
+	assert.EqualError(t, err, `invalid extends reference "policy:base.yml": the repository must be in owner/repo form`)
+}
+
+func TestMergeConfigs(t *testing.T) {
+	base := `
+version: 1
+extends: testorg/shared:base.yml
+merge:
+  trigger:
+    labels: ["merge when ready"]
+  options:
+    squash:
+      body: summarize_commits
+      message_delimiter: ==COMMIT_MSG==
+  required_statuses: ["ci", "lint"]
+`
+	override := `
+version: 1
+extends: testorg/policy:base.yml
+merge:
+  options:
+    squash:
+      body: pull_request_body
+  required_statuses: ["ci"]
+`
+
+	merged, err := MergeConfigs([]byte(base), []byte(override))
+	require.NoError(t, err)
+
+	config, err := ParseConfig(merged)
+	require.NoError(t, err)
+	assert.Empty(t, config.Extends)
+	assert.Equal(t, LabelsSignal{"merge when ready"}, config.Merge.Trigger.Labels)
+	assert.Equal(t, PullRequestBody, config.Merge.Options.Squash.Body)
+	assert.Equal(t, "==COMMIT_MSG==", config.Merge.Options.Squash.MessageDelimiter)
+	assert.Equal(t, []string{"ci"}, config.Merge.RequiredStatuses)
+}
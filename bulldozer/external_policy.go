@@ -0,0 +1,212 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/palantir/bulldozer/pull"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultExternalPolicyTimeout is how long bulldozer waits for an
+	// external policy to respond if the policy does not set a timeout.
+	DefaultExternalPolicyTimeout = 5 * time.Second
+
+	// DefaultExternalPolicyRetry is how long bulldozer waits to evaluate a
+	// pull request again after an external policy defers the merge without
+	// saying when to retry or after a closed policy fails.
+	DefaultExternalPolicyRetry = 5 * time.Minute
+)
+
+type ExternalDecision string
+
+const (
+	ExternalAllow ExternalDecision = "allow"
+	ExternalDeny  ExternalDecision = "deny"
+	ExternalDefer ExternalDecision = "defer"
+)
+
+type ExternalFailureMode string
+
+const (
+	// ExternalFailClosed does not merge pull requests when the external
+	// policy cannot be reached or returns an invalid response.
+	ExternalFailClosed ExternalFailureMode = "closed"
+
+	// ExternalFailOpen merges pull requests as if the external policy
+	// allowed them when it cannot be reached or returns an invalid response.
+	ExternalFailOpen ExternalFailureMode = "open"
+)
+
+// ExternalPolicy is an HTTP endpoint that decides whether pull requests that
+// bulldozer would merge may merge, so that organizations can add rules that
+// bulldozer does not support. The endpoint receives an ExternalPolicyRequest
+// and returns an ExternalPolicyResponse, both as JSON.
+type ExternalPolicy struct {
+	URL string `yaml:"url"`
+
+	// Headers are added to every request, for example to provide an
+	// authorization token.
+	Headers map[string]string `yaml:"headers"`
+
+	// Timeout is how long to wait for a response. If zero, the default is
+	// DefaultExternalPolicyTimeout.
+	Timeout Duration `yaml:"timeout"`
+
+	// FailureMode is "closed" (the default) to keep pull requests from
+	// merging when the policy fails or "open" to merge them anyway.
+	FailureMode ExternalFailureMode `yaml:"failure_mode"`
+}
+
+func (p ExternalPolicy) Validate() error {
+	if p.URL == "" {
+		return errors.New("url is required")
+	}
+	switch p.FailureMode {
+	case "", ExternalFailClosed, ExternalFailOpen:
+	default:
+		return errors.Errorf("invalid failure mode %q", p.FailureMode)
+	}
+	return nil
+}
+
+// FailOpen returns true if pull requests may merge when the policy fails.
+func (p ExternalPolicy) FailOpen() bool {
+	return p.FailureMode == ExternalFailOpen
+}
+
+// ExternalPolicyRequest describes a pull request that bulldozer would merge.
+type ExternalPolicyRequest struct {
+	Owner  string   `json:"owner"`
+	Repo   string   `json:"repo"`
+	Number int      `json:"number"`
+	Title  string   `json:"title"`
+	Author string   `json:"author"`
+	Base   string   `json:"base"`
+	Head   string   `json:"head"`
+	SHA    string   `json:"sha"`
+	Labels []string `json:"labels"`
+
+	// Reason explains why bulldozer would merge the pull request.
+	Reason string `json:"reason"`
+
+	// Eligibility is the state of the signals and statuses that bulldozer
+	// used to decide to merge the pull request.
+	Eligibility *Eligibility `json:"eligibility"`
+}
+
+// NewExternalPolicyRequest describes the pull request for an external
+// policy.
+func NewExternalPolicyRequest(ctx context.Context, pullCtx pull.Context, reason string, eligibility *Eligibility) (*ExternalPolicyRequest, error) {
+	labels, err := pullCtx.Labels(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list labels")
+	}
+
+	base, head := pullCtx.Branches()
+	return &ExternalPolicyRequest{
+		Owner:       pullCtx.Owner(),
+		Repo:        pullCtx.Repo(),
+		Number:      pullCtx.Number(),
+		Title:       pullCtx.Title(),
+		Author:      pullCtx.Author(),
+		Base:        base,
+		Head:        head,
+		SHA:         pullCtx.HeadSHA(),
+		Labels:      labels,
+		Reason:      reason,
+		Eligibility: eligibility,
+	}, nil
+}
+
+// ExternalPolicyResponse is the decision of an external policy.
+type ExternalPolicyResponse struct {
+	Decision ExternalDecision `json:"decision"`
+
+	// Reason explains the decision to users.
+	Reason string `json:"reason,omitempty"`
+
+	// RetryAfter is the number of seconds to wait before evaluating a
+	// deferred pull request again. If zero, the default is
+	// DefaultExternalPolicyRetry.
+	RetryAfter int `json:"retry_after,omitempty"`
+}
+
+// Retry returns how long to wait before evaluating a deferred pull request
+// again.
+func (r ExternalPolicyResponse) Retry() time.Duration {
+	if r.RetryAfter > 0 {
+		return time.Duration(r.RetryAfter) * time.Second
+	}
+	return DefaultExternalPolicyRetry
+}
+
+// Decide sends the request to the policy and returns its decision. If client
+// is nil, it uses http.DefaultClient.
+func (p ExternalPolicy) Decide(ctx context.Context, client *http.Client, r *ExternalPolicyRequest) (*ExternalPolicyResponse, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	timeout := time.Duration(p.Timeout)
+	if timeout <= 0 {
+		timeout = DefaultExternalPolicyTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal external policy request")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.URL, bytes.NewReader(b))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create external policy request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range p.Headers {
+		req.Header.Set(k, v)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to send external policy request")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		_, _ = io.Copy(io.Discard, res.Body)
+		return nil, errors.Errorf("external policy request failed with status %d", res.StatusCode)
+	}
+
+	var decision ExternalPolicyResponse
+	if err := json.NewDecoder(res.Body).Decode(&decision); err != nil {
+		return nil, errors.Wrap(err, "failed to parse external policy response")
+	}
+	switch decision.Decision {
+	case ExternalAllow, ExternalDeny, ExternalDefer:
+	default:
+		return nil, errors.Errorf("invalid external policy decision %q", decision.Decision)
+	}
+	return &decision, nil
+}
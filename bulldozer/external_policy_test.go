@@ -0,0 +1,63 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalPolicyDecide(t *testing.T) {
+	var response string
+	var status int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if status != 0 {
+			w.WriteHeader(status)
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	defer srv.Close()
+
+	policy := ExternalPolicy{URL: srv.URL}
+	ctx := context.Background()
+	req := &ExternalPolicyRequest{Owner: "testorg", Repo: "testrepo", Number: 1}
+
+	response, status = `{"decision": "defer", "reason": "waiting for the deploy", "retry_after": 30}`, 0
+	res, err := policy.Decide(ctx, srv.Client(), req)
+	require.NoError(t, err)
+	assert.Equal(t, ExternalDefer, res.Decision)
+	assert.Equal(t, "waiting for the deploy", res.Reason)
+	assert.Equal(t, 30*time.Second, res.Retry())
+
+	response, status = `{"decision": "deny"}`, 0
+	res, err = policy.Decide(ctx, srv.Client(), req)
+	require.NoError(t, err)
+	assert.Equal(t, ExternalDeny, res.Decision)
+	assert.Equal(t, DefaultExternalPolicyRetry, res.Retry())
+
+	response, status = `{}`, http.StatusBadGateway
+	_, err = policy.Decide(ctx, srv.Client(), req)
+	assert.EqualError(t, err, "external policy request failed with status 502")
+
+	response, status = `{"decision": "ALLOW"}`, 0
+	_, err = policy.Decide(ctx, srv.Client(), req)
+	assert.EqualError(t, err, `invalid external policy decision "ALLOW"`)
+}
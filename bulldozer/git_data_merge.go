@@ -0,0 +1,185 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+
+	"github.com/palantir/bulldozer/pull"
+)
+
+// GitDataMerger is implemented by mergers that can merge pull requests by
+// creating commits with the git data API and moving the base branch, instead
+// of using the merge endpoint.
+type GitDataMerger interface {
+	// MergeWithGitData merges the pull request with the git data API,
+	// signing the commit if signer is not nil. It returns the SHA of the new
+	// commit on the base branch.
+	MergeWithGitData(ctx context.Context, pullCtx pull.Context, method MergeMethod, msg CommitMessage, signer *CommitSigner) (string, error)
+}
+
+// resolvableMergeErrors are parts of the messages GitHub returns when it
+// rejects merges for reasons that creating the commit with the git data API
+// resolves.
+var resolvableMergeErrors = []string{
+	"merge commits are not allowed",
+	"squash merges are not allowed",
+	"verified signature",
+	"signed commits",
+}
+
+// canFallBackToGitData returns true if the merge endpoint rejected a merge
+// for a reason that merging with the git data API resolves.
+func canFallBackToGitData(err error) bool {
+	gerr, ok := errors.Cause(err).(*github.ErrorResponse)
+	if !ok || gerr.Response == nil || gerr.Response.StatusCode != http.StatusMethodNotAllowed {
+		return false
+	}
+	message := strings.ToLower(gerr.Message)
+	for _, m := range resolvableMergeErrors {
+		if strings.Contains(message, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// fallBackToGitData merges the pull request with the git data API after the
+// merge endpoint rejected it with mergeErr. It returns mergeErr if the merger
+// does not support the git data API.
+func fallBackToGitData(ctx context.Context, pullCtx pull.Context, merger Merger, method MergeMethod, msg CommitMessage, signer *CommitSigner, mergeErr error) (bool, error) {
+	logger := zerolog.Ctx(ctx)
+
+	gm, ok := merger.(GitDataMerger)
+	if !ok {
+		return false, mergeErr
+	}
+
+	logger.Info().Msgf("Merge was rejected, attempting to merge with the git data API: %v", mergeErr)
+	sha, err := gm.MergeWithGitData(ctx, pullCtx, method, msg, signer)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to merge pull request with the git data API")
+		return false, errors.Wrapf(err, "merge with the git data API failed after %v", mergeErr)
+	}
+	logger.Info().Msgf("Successfully merged pull request with the git data API as %s", sha)
+	return true, nil
+}
+
+// MergeWithGitData creates the merge or squash commit from the tree of the
+// merge commit that GitHub computes for the pull request, then fast-forwards
+// the base branch to the new commit. GitHub marks pull requests merged this
+// way with a merge commit as merged. Squashed pull requests are closed with a
+// comment that links the commit. Rebasing is not supported.
+func (m *GitHubMerger) MergeWithGitData(ctx context.Context, pullCtx pull.Context, method MergeMethod, msg CommitMessage, signer *CommitSigner) (string, error) {
+	owner, repo, number := pullCtx.Owner(), pullCtx.Repo(), pullCtx.Number()
+	if method != MergeCommit && method != SquashAndMerge {
+		return "", errors.Errorf("cannot merge with method %s using the git data API", method)
+	}
+
+	pr, _, err := m.client.PullRequests.Get(ctx, owner, repo, number)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get pull request %s", pullCtx.Locator())
+	}
+	if pr.GetMergeCommitSHA() == "" {
+		return "", errors.New("GitHub has not computed a merge commit for the pull request")
+	}
+
+	baseRef, _, err := m.client.Git.GetRef(ctx, owner, repo, "heads/"+pr.GetBase().GetRef())
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get ref for the base branch")
+	}
+	baseSHA, headSHA := baseRef.GetObject().GetSHA(), pr.GetHead().GetSHA()
+
+	// the merge commit is computed asynchronously, so make sure it merges
+	// the current commits before using its tree
+	test, _, err := m.client.Git.GetCommit(ctx, owner, repo, pr.GetMergeCommitSHA())
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get merge commit %s", pr.GetMergeCommitSHA())
+	}
+	if len(test.Parents) != 2 || test.Parents[0].GetSHA() != baseSHA || test.Parents[1].GetSHA() != headSHA {
+		return "", errors.New("the merge commit computed by GitHub is out of date")
+	}
+
+	commit := &github.Commit{Tree: test.Tree}
+	switch method {
+	case MergeCommit:
+		commit.Message = github.String(fmt.Sprintf("Merge pull request #%d from %s\n\n%s", number, pr.GetHead().GetLabel(), pr.GetTitle()))
+		commit.Parents = []*github.Commit{{SHA: &baseSHA}, {SHA: &headSHA}}
+
+	case SquashAndMerge:
+		message, err := gitDataSquashMessage(ctx, pullCtx, msg)
+		if err != nil {
+			return "", err
+		}
+		commit.Message = &message
+		commit.Parents = []*github.Commit{{SHA: &baseSHA}}
+	}
+	signer.sign(commit)
+
+	created, _, err := m.client.Git.CreateCommit(ctx, owner, repo, commit)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create commit")
+	}
+
+	baseRef.Object.SHA = created.SHA
+	if _, _, err := m.client.Git.UpdateRef(ctx, owner, repo, baseRef, false); err != nil {
+		return "", errors.Wrapf(err, "failed to update %s", pr.GetBase().GetRef())
+	}
+
+	if method == SquashAndMerge {
+		comment := fmt.Sprintf("Squashed and merged as %s.", created.GetSHA())
+		if _, _, err := m.client.Issues.CreateComment(ctx, owner, repo, number, &github.IssueComment{Body: &comment}); err != nil {
+			return "", errors.Wrap(err, "failed to comment on squashed pull request")
+		}
+		if _, _, err := m.client.PullRequests.Edit(ctx, owner, repo, number, &github.PullRequest{State: github.String("closed")}); err != nil {
+			return "", errors.Wrap(err, "failed to close squashed pull request")
+		}
+	}
+	return created.GetSHA(), nil
+}
+
+// gitDataSquashMessage returns the full message of a squash commit. The merge
+// endpoint fills in empty titles and messages, but the git data API does not,
+// so they are replaced with the values GitHub would use.
+func gitDataSquashMessage(ctx context.Context, pullCtx pull.Context, msg CommitMessage) (string, error) {
+	title := msg.Title
+	if title == "" {
+		title = fmt.Sprintf("%s (#%d)", pullCtx.Title(), pullCtx.Number())
+	}
+
+	body := msg.Message
+	switch body {
+	case "":
+		commits, err := pullCtx.Commits(ctx)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to get commits")
+		}
+		body = summarizeCommits(commits, nil)
+	case " ":
+		body = ""
+	}
+
+	if body == "" {
+		return title, nil
+	}
+	return title + "\n\n" + body, nil
+}
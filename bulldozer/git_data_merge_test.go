@@ -0,0 +1,154 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/bulldozer/pull"
+	"github.com/palantir/bulldozer/pull/pulltest"
+)
+
+type mockGitDataMerger struct {
+	MockMerger
+	GitDataCount int
+}
+
+func (m *mockGitDataMerger) MergeWithGitData(ctx context.Context, pullCtx pull.Context, method MergeMethod, msg CommitMessage, signer *CommitSigner) (string, error) {
+	m.GitDataCount++
+	return "cafebabe", nil
+}
+
+func newMergeError(status int, message string) error {
+	return github.CheckResponse(&http.Response{
+		StatusCode: status,
+		Body:       io.NopCloser(bytes.NewReader([]byte(`{"message": "` + message + `"}`))),
+	})
+}
+
+func TestCanFallBackToGitData(t *testing.T) {
+	assert.True(t, canFallBackToGitData(newMergeError(http.StatusMethodNotAllowed, "Squash merges are not allowed on this repository.")))
+	assert.True(t, canFallBackToGitData(newMergeError(http.StatusMethodNotAllowed, "Commits must have verified signatures.")))
+	assert.False(t, canFallBackToGitData(newMergeError(http.StatusMethodNotAllowed, "Required status check \"ci\" is expected.")))
+	assert.False(t, canFallBackToGitData(newMergeError(http.StatusConflict, "Merge commits are not allowed")))
+	assert.False(t, canFallBackToGitData(errClosed))
+}
+
+func TestMergePRGitDataFallback(t *testing.T) {
+	ctx := context.Background()
+	pullCtx := &pulltest.MockPullContext{MergeStateValue: &pull.MergeState{Mergeable: boolVal(true)}}
+	rejected := newMergeError(http.StatusMethodNotAllowed, "Merge commits are not allowed on this repository.")
+
+	merger := &mockGitDataMerger{MockMerger: MockMerger{MergeError: rejected}}
+	result := MergePR(ctx, pullCtx, merger, MergeConfig{Method: MergeCommit})
+	assert.False(t, result.Merged, "merged without enabling the fallback")
+	assert.Equal(t, 0, merger.GitDataCount)
+
+	result = MergePR(ctx, pullCtx, merger, MergeConfig{Method: MergeCommit, GitDataFallback: true})
+	assert.True(t, result.Merged, "fallback did not merge")
+	assert.Equal(t, 1, merger.GitDataCount)
+
+	merger = &mockGitDataMerger{MockMerger: MockMerger{MergeError: newMergeError(http.StatusMethodNotAllowed, "Pull Request is not mergeable")}}
+	result = MergePR(ctx, pullCtx, merger, MergeConfig{Method: MergeCommit, GitDataFallback: true})
+	assert.False(t, result.Merged)
+	assert.Equal(t, 0, merger.GitDataCount, "fallback used for an error it cannot resolve")
+}
+
+func TestMergeWithGitData(t *testing.T) {
+	var created map[string]interface{}
+	var updated map[string]interface{}
+	var comments []string
+	var closed bool
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/testorg/testrepo/pulls/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			closed = true
+		}
+		_, _ = io.WriteString(w, `{"number": 1, "title": "Fix the bug", "merge_commit_sha": "test", "base": {"ref": "develop"}, "head": {"sha": "head", "label": "testorg:feature"}}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/git/ref/heads/develop", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"ref": "refs/heads/develop", "object": {"sha": "base"}}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/git/commits/test", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"sha": "test", "tree": {"sha": "tree"}, "parents": [{"sha": "base"}, {"sha": "head"}]}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/git/commits", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{"sha": "squashed"}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/git/refs/heads/develop", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&updated))
+		_, _ = io.WriteString(w, `{}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/issues/1/comments", func(w http.ResponseWriter, r *http.Request) {
+		var comment github.IssueComment
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&comment))
+		comments = append(comments, comment.GetBody())
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{}`)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	ctx := context.Background()
+	pullCtx := &pulltest.MockPullContext{OwnerValue: "testorg", RepoValue: "testrepo", NumberValue: 1, HeadSHAValue: "head"}
+	merger := &GitHubMerger{client: client}
+
+	t.Run("squash", func(t *testing.T) {
+		sha, err := merger.MergeWithGitData(ctx, pullCtx, SquashAndMerge, CommitMessage{Title: "Fix the bug (#1)", Message: "Fixes the parser."}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "squashed", sha)
+
+		assert.Equal(t, "tree", created["tree"])
+		assert.Equal(t, []interface{}{"base"}, created["parents"])
+		assert.Equal(t, "Fix the bug (#1)\n\nFixes the parser.", created["message"])
+		assert.Equal(t, "squashed", updated["sha"])
+		assert.Equal(t, false, updated["force"])
+		assert.Equal(t, []string{"Squashed and merged as squashed."}, comments)
+		assert.True(t, closed, "squashed pull request was not closed")
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		closed = false
+		_, err := merger.MergeWithGitData(ctx, pullCtx, MergeCommit, CommitMessage{}, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, []interface{}{"base", "head"}, created["parents"])
+		assert.Equal(t, "Merge pull request #1 from testorg:feature\n\nFix the bug", created["message"])
+		assert.False(t, closed, "merged pull request was closed")
+	})
+
+	t.Run("rebase", func(t *testing.T) {
+		_, err := merger.MergeWithGitData(ctx, pullCtx, RebaseAndMerge, CommitMessage{}, nil)
+		assert.EqualError(t, err, "cannot merge with method rebase using the git data API")
+	})
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// globToRegexp converts a path glob to an anchored regular expression. In the
+// glob, "*" matches any characters except "/", "?" matches a single character
+// except "/", and "**" matches any characters including "/". A "**/" prefix
+// or infix also matches zero directories, so "docs/**/*.md" matches
+// "docs/README.md".
+func globToRegexp(glob string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; {
+		case c == '*' && strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case c == '*' && strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+func matchesAnyGlob(globs []string, path string) (string, bool) {
+	for _, glob := range globs {
+		if globToRegexp(glob).MatchString(path) {
+			return glob, true
+		}
+	}
+	return "", false
+}
+
+// nameMatches returns true if the name of a status context or check run
+// matches the pattern. Patterns wrapped in slashes, like "/^ci-.*$/", are
+// regular expressions. Other patterns are case-insensitive globs, so names
+// without wildcards must match exactly, ignoring case. Invalid regular
+// expressions match nothing.
+func nameMatches(pattern, name string) bool {
+	if len(pattern) > 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		matched, _ := regexp.MatchString(pattern[1:len(pattern)-1], name)
+		return matched
+	}
+	return globToRegexp(strings.ToLower(pattern)).MatchString(strings.ToLower(name))
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlobToRegexp(t *testing.T) {
+	tests := map[string]struct {
+		Glob    string
+		Matches []string
+		Misses  []string
+	}{
+		"literal": {
+			Glob:    "README.md",
+			Matches: []string{"README.md"},
+			Misses:  []string{"docs/README.md", "README_md"},
+		},
+		"star": {
+			Glob:    "*.md",
+			Matches: []string{"README.md", ".md"},
+			Misses:  []string{"docs/README.md"},
+		},
+		"question": {
+			Glob:    "v?.txt",
+			Matches: []string{"v1.txt"},
+			Misses:  []string{"v10.txt", "v/.txt"},
+		},
+		"doubleStarSuffix": {
+			Glob:    "migrations/**",
+			Matches: []string{"migrations/001.sql", "migrations/a/b/c.sql"},
+			Misses:  []string{"migrations", "src/migrations/001.sql"},
+		},
+		"doubleStarInfix": {
+			Glob:    "docs/**/*.md",
+			Matches: []string{"docs/README.md", "docs/a/b/guide.md"},
+			Misses:  []string{"docs/image.png", "README.md"},
+		},
+		"doubleStarPrefix": {
+			Glob:    "**/*.go",
+			Matches: []string{"main.go", "server/handler/base.go"},
+			Misses:  []string{"main.go.orig"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := globToRegexp(test.Glob)
+			for _, path := range test.Matches {
+				assert.True(t, r.MatchString(path), "expected %q to match %q", test.Glob, path)
+			}
+			for _, path := range test.Misses {
+				assert.False(t, r.MatchString(path), "expected %q to not match %q", test.Glob, path)
+			}
+		})
+	}
+}
+
+func TestNameMatches(t *testing.T) {
+	tests := map[string]struct {
+		Pattern string
+		Matches []string
+		Misses  []string
+	}{
+		"exact": {
+			Pattern: "ci/build",
+			Matches: []string{"ci/build", "CI/Build"},
+			Misses:  []string{"ci/build-linux"},
+		},
+		"glob": {
+			Pattern: "ci/*",
+			Matches: []string{"ci/test (ubuntu, 1.20)", "CI/lint"},
+			Misses:  []string{"ci/test/unit", "deploy/ci"},
+		},
+		"regexp": {
+			Pattern: `/^deploy\/preview-\d+$/`,
+			Matches: []string{"deploy/preview-42"},
+			Misses:  []string{"deploy/preview-abc", "Deploy/preview-42"},
+		},
+		"invalidRegexp": {
+			Pattern: "/(/",
+			Misses:  []string{"(", "/(/"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, n := range test.Matches {
+				assert.True(t, nameMatches(test.Pattern, n), "expected %q to match %q", test.Pattern, n)
+			}
+			for _, n := range test.Misses {
+				assert.False(t, nameMatches(test.Pattern, n), "expected %q to not match %q", test.Pattern, n)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// ReadIncludes returns the value of the include key in a configuration file,
+// or nil if the key is not set.
+func ReadIncludes(bytes []byte) ([]string, error) {
+	var config struct {
+		Include []string `yaml:"include"`
+	}
+	if err := yaml.Unmarshal(bytes, &config); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal configuration")
+	}
+	return config.Include, nil
+}
+
+// AppendConfig merges a configuration fragment into the configuration file.
+// Unlike MergeConfigs, lists in both are combined, so that fragments can add
+// signals and required statuses without repeating the values in other
+// files. Maps are merged key by key and other values are replaced. Fragments
+// cannot set the version, extends, include, or branches keys. The include
+// key is removed from the result.
+func AppendConfig(config, fragment []byte) ([]byte, error) {
+	var configMap, fragmentMap yaml.MapSlice
+	if err := yaml.Unmarshal(config, &configMap); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal configuration")
+	}
+	if err := yaml.Unmarshal(fragment, &fragmentMap); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal configuration fragment")
+	}
+
+	for _, key := range []string{"version", "extends", "include", "branches"} {
+		if indexOfKey(fragmentMap, key) >= 0 {
+			return nil, errors.Errorf("configuration fragments cannot set %q", key)
+		}
+	}
+
+	merged := appendMapSlices(removeKey(configMap, "include"), fragmentMap)
+	b, err := yaml.Marshal(merged)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal merged configuration")
+	}
+	return b, nil
+}
+
+// appendMapSlices returns a copy of base with the values of keys in fragment
+// added. Maps in both are merged, lists in both are combined, and all other
+// values are replaced.
+func appendMapSlices(base, fragment yaml.MapSlice) yaml.MapSlice {
+	merged := append(yaml.MapSlice{}, base...)
+	for _, item := range fragment {
+		i := indexOfKey(merged, item.Key)
+		if i < 0 {
+			merged = append(merged, item)
+			continue
+		}
+
+		switch baseValue := merged[i].Value.(type) {
+		case yaml.MapSlice:
+			if fragmentValue, ok := item.Value.(yaml.MapSlice); ok {
+				merged[i].Value = appendMapSlices(baseValue, fragmentValue)
+				continue
+			}
+		case []interface{}:
+			if fragmentValue, ok := item.Value.([]interface{}); ok {
+				merged[i].Value = append(append([]interface{}{}, baseValue...), fragmentValue...)
+				continue
+			}
+		}
+		merged[i].Value = item.Value
+	}
+	return merged
+}
+
+// ExtendConfig merges the extension configuration file into the base file,
+// but only allows the extension to add to the base. Maps are merged key by
+// key and lists are combined, but other values cannot be changed if the base
+// sets them. The extends key is removed from the result.
+func ExtendConfig(base, extension []byte) ([]byte, error) {
+	var baseMap, extensionMap yaml.MapSlice
+	if err := yaml.Unmarshal(base, &baseMap); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal base configuration")
+	}
+	if err := yaml.Unmarshal(extension, &extensionMap); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal configuration")
+	}
+
+	merged, err := extendMapSlices(removeKey(baseMap, "extends"), removeKey(extensionMap, "extends"), "")
+	if err != nil {
+		return nil, err
+	}
+	b, err := yaml.Marshal(merged)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal merged configuration")
+	}
+	return b, nil
+}
+
+// extendMapSlices returns a copy of base with the values of keys in extension
+// added. Maps in both are merged and lists in both are combined. It returns
+// an error if the extension changes any other value in base. The prefix is
+// the path of the maps, for errors.
+func extendMapSlices(base, extension yaml.MapSlice, prefix string) (yaml.MapSlice, error) {
+	merged := append(yaml.MapSlice{}, base...)
+	for _, item := range extension {
+		key := fmt.Sprint(item.Key)
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		i := indexOfKey(merged, item.Key)
+		if i < 0 {
+			merged = append(merged, item)
+			continue
+		}
+
+		switch baseValue := merged[i].Value.(type) {
+		case yaml.MapSlice:
+			if extensionValue, ok := item.Value.(yaml.MapSlice); ok {
+				value, err := extendMapSlices(baseValue, extensionValue, key)
+				if err != nil {
+					return nil, err
+				}
+				merged[i].Value = value
+				continue
+			}
+		case []interface{}:
+			if extensionValue, ok := item.Value.([]interface{}); ok {
+				merged[i].Value = append(append([]interface{}{}, baseValue...), extensionValue...)
+				continue
+			}
+		}
+		if !reflect.DeepEqual(merged[i].Value, item.Value) {
+			return nil, errors.Errorf("cannot change %q, which is set by the base configuration", key)
+		}
+	}
+	return merged, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendConfig(t *testing.T) {
+	config := `
+version: 1
+include: ["services/payments/bulldozer.yml"]
+merge:
+  trigger:
+    labels: ["merge when ready"]
+  ignore:
+    labels: ["do not merge"]
+  method: squash
+  required_statuses: ["ci"]
+`
+	fragment := `
+merge:
+  trigger:
+    only_paths: ["services/payments/**"]
+  ignore:
+    labels: ["payments: hold"]
+  method: merge
+  required_statuses: ["payments/integration"]
+`
+
+	merged, err := AppendConfig([]byte(config), []byte(fragment))
+	require.NoError(t, err)
+
+	parsed, err := ParseConfig(merged)
+	require.NoError(t, err)
+	assert.Empty(t, parsed.Include)
+	assert.Equal(t, LabelsSignal{"merge when ready"}, parsed.Merge.Trigger.Labels)
+	assert.Equal(t, OnlyPathsSignal{"services/payments/**"}, parsed.Merge.Trigger.OnlyPaths)
+	assert.Equal(t, LabelsSignal{"do not merge", "payments: hold"}, parsed.Merge.Ignore.Labels)
+	assert.Equal(t, MergeCommit, parsed.Merge.Method)
+	assert.Equal(t, []string{"ci", "payments/integration"}, parsed.Merge.RequiredStatuses)
+
+	_, err = AppendConfig([]byte(config), []byte("version: 1\n"))
+	assert.EqualError(t, err, `configuration fragments cannot set "version"`)
+}
+
+func TestReadIncludes(t *testing.T) {
+	includes, err := ReadIncludes([]byte("version: 1\ninclude: [a.yml, b/bulldozer.yml]\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.yml", "b/bulldozer.yml"}, includes)
+
+	includes, err = ReadIncludes([]byte("version: 1\n"))
+	require.NoError(t, err)
+	assert.Empty(t, includes)
+}
+
+func TestExtendConfig(t *testing.T) {
+	base := `
+version: 1
+merge:
+  trigger:
+    labels: ["merge when ready"]
+  method: squash
+`
+
+	merged, err := ExtendConfig([]byte(base), []byte(`
+version: 1
+merge:
+  trigger:
+    labels: ["automerge"]
+  method: squash
+  delete_after_merge: true
+`))
+	require.NoError(t, err)
+
+	config, err := ParseConfig(merged)
+	require.NoError(t, err)
+	assert.Equal(t, LabelsSignal{"merge when ready", "automerge"}, config.Merge.Trigger.Labels)
+	assert.Equal(t, SquashAndMerge, config.Merge.Method)
+	assert.True(t, config.Merge.DeleteAfterMerge)
+
+	_, err = ExtendConfig([]byte(base), []byte("version: 1\nmerge:\n  method: merge\n"))
+	assert.EqualError(t, err, `cannot change "merge.method", which is set by the base configuration`)
+}
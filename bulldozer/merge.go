@@ -30,6 +30,22 @@ import (
 
 const MaxPullRequestPollCount = 5
 
+// retryMode describes if and when a failed merge should be attempted again.
+type retryMode int
+
+const (
+	noRetry retryMode = iota
+	retryNow
+	retryLater
+)
+
+var (
+	errClosed              = errors.New("pull request is closed")
+	errMergeabilityUnknown = errors.New("pull request mergeability is not known")
+	errNotMergeable        = errors.New("pull request is not mergeable")
+	errNotFastForward      = errors.New("pull request head does not contain the base branch")
+)
+
 type Merger interface {
 	// Merge merges the pull request in the context using the commit message
 	// and options. It returns the SHA of the merge commit on success.
@@ -64,7 +80,9 @@ func (m *GitHubMerger) Merge(ctx context.Context, pullCtx pull.Context, method M
 
 // ff-only merge is accomplished by calling Git.UpdateRef with the force
 // parameter set to false, and the new commit hash for the base branch's
-// pointer
+// pointer. The base branch must be an ancestor of the head commit, which is
+// checked first so that a pull request that needs an update fails with a
+// clear error instead of a rejected ref update.
 func (m *GitHubMerger) ffOnlyMerge(ctx context.Context, pullCtx pull.Context) (string, error) {
 	base, _ := pullCtx.Branches()
 
@@ -74,6 +92,15 @@ func (m *GitHubMerger) ffOnlyMerge(ctx context.Context, pullCtx pull.Context) (s
 	}
 
 	headCommitSHA := pullCtx.HeadSHA()
+
+	comparison, _, err := m.client.Repositories.CompareCommits(ctx, pullCtx.Owner(), pullCtx.Repo(), ref.GetObject().GetSHA(), headCommitSHA, nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not compare %s to PR head %s", base, headCommitSHA)
+	}
+	if behindBy := comparison.GetBehindBy(); behindBy > 0 {
+		return "", errors.Wrapf(errNotFastForward, "cannot fast-forward %s to %s, the head is %d commits behind", base, headCommitSHA, behindBy)
+	}
+
 	ref.Object.SHA = &headCommitSHA
 
 	newRef, _, err := m.client.Git.UpdateRef(ctx, pullCtx.Owner(), pullCtx.Repo(), ref, false)
@@ -138,6 +165,22 @@ func (m *PushRestrictionMerger) Merge(ctx context.Context, pullCtx pull.Context,
 	return m.Normal.Merge(ctx, pullCtx, method, msg)
 }
 
+func (m *PushRestrictionMerger) MergeWithGitData(ctx context.Context, pullCtx pull.Context, method MergeMethod, msg CommitMessage, signer *CommitSigner) (string, error) {
+	restricted, err := pullCtx.PushRestrictions(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	merger := m.Normal
+	if restricted {
+		merger = m.Restricted
+	}
+	if gm, ok := merger.(GitDataMerger); ok {
+		return gm.MergeWithGitData(ctx, pullCtx, method, msg, signer)
+	}
+	return "", errors.New("merger does not support the git data API")
+}
+
 func (m *PushRestrictionMerger) DeleteHead(ctx context.Context, pullCtx pull.Context) error {
 	restricted, err := pullCtx.PushRestrictions(ctx)
 	if err != nil {
@@ -185,131 +228,234 @@ func DetermineMergeMethod(ctx context.Context, pullCtx pull.Context, mergeConfig
 	return mergeMethod, nil
 }
 
+// DeterminePriority returns the highest priority of the labels on the pull
+// request, or 0 if no label has a priority.
+func DeterminePriority(ctx context.Context, pullCtx pull.Context, mergeConfig MergeConfig) (int, error) {
+	if len(mergeConfig.Priority) == 0 {
+		return 0, nil
+	}
+
+	labels, err := pullCtx.Labels(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get labels")
+	}
+
+	var priority int
+	var found bool
+	for _, label := range labels {
+		for priorityLabel, p := range mergeConfig.Priority {
+			if strings.EqualFold(priorityLabel, label) && (!found || p > priority) {
+				priority = p
+				found = true
+			}
+		}
+	}
+	return priority, nil
+}
+
+// MergeResult describes the outcome of MergePR.
+type MergeResult struct {
+	// Merged is true if the pull request merged or was added to a merge queue.
+	Merged bool
+
+	// Queued is true if the pull request was added to a merge queue.
+	Queued bool
+
+	// Retry is true if the merge failed with an error that may not happen if
+	// the merge is attempted again later.
+	Retry bool
+
+	// Err is the reason the merge failed, if it did not succeed.
+	Err error
+}
+
+// Reason returns a short description of why the merge failed that is suitable
+// for grouping failures in metrics. It returns an empty string if the merge
+// succeeded.
+func (r MergeResult) Reason() string {
+	if r.Merged {
+		return ""
+	}
+
+	switch cause := errors.Cause(r.Err).(type) {
+	case nil:
+		return "unknown"
+	case *github.RateLimitError, *github.AbuseRateLimitError:
+		return "rate_limited"
+	case *github.ErrorResponse:
+		switch cause.Response.StatusCode {
+		case http.StatusMethodNotAllowed:
+			return "rejected"
+		case http.StatusConflict:
+			return "conflict"
+		}
+		return "github_error"
+	}
+
+	switch errors.Cause(r.Err) {
+	case errClosed:
+		return "closed"
+	case errMergeabilityUnknown:
+		return "mergeability_unknown"
+	case errNotMergeable:
+		return "not_mergeable"
+	case errNotFastForward:
+		return "not_fast_forward"
+	}
+	return "error"
+}
+
 // MergePR merges a pull request if all conditions are met. It logs any errors
-// that it encounters.
-func MergePR(ctx context.Context, pullCtx pull.Context, merger Merger, mergeConfig MergeConfig) {
+// that it encounters and returns the outcome of the merge.
+func MergePR(ctx context.Context, pullCtx pull.Context, merger Merger, mergeConfig MergeConfig) MergeResult {
 	logger := zerolog.Ctx(ctx)
 
 	mergeMethod, err := DetermineMergeMethod(ctx, pullCtx, mergeConfig)
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to determine merge method")
-		return
+		return MergeResult{Err: err}
 	}
 
 	commitMsg := CommitMessage{}
 	if mergeMethod == SquashAndMerge {
-		opt := mergeConfig.Options.Squash
-		if opt == nil {
+		if mergeConfig.Options.Squash == nil {
 			logger.Info().Msgf("No squash options defined; using defaults")
-			opt = &SquashOptions{}
 		}
-
-		if opt.Title == "" {
-			opt.Title = PullRequestTitle
-		}
-		if opt.Body == "" {
-			opt.Body = EmptyBody
-		}
-
-		message, err := calculateCommitMessage(ctx, pullCtx, *opt)
+		commitMsg, err = squashCommitMessage(ctx, pullCtx, mergeConfig.Options.Squash)
 		if err != nil {
 			logger.Error().Err(err).Msg("Failed to calculate commit message")
-			return
-		}
-		commitMsg.Message = message
-
-		title, err := calculateCommitTitle(ctx, pullCtx, *opt)
-		if err != nil {
-			logger.Error().Err(err).Msg("Failed to calculate commit title")
-			return
+			return MergeResult{Err: err}
 		}
-		commitMsg.Title = title
 	}
 
 	var attempts int
-	var merged, retry bool
+	var merged bool
+	var retry retryMode
 	for {
-		merged, retry = attemptMerge(ctx, pullCtx, merger, mergeMethod, commitMsg)
-		if merged || !retry {
+		merged, retry, err = attemptMerge(ctx, pullCtx, merger, mergeMethod, commitMsg)
+		if merged || retry == noRetry {
 			break
 		}
+		if retry == retryLater {
+			return MergeResult{Retry: true, Err: err}
+		}
 
 		attempts++
 		if attempts >= MaxPullRequestPollCount {
 			logger.Error().Msgf("Failed to merge pull request after %d attempts", attempts)
-			return
+			return MergeResult{Retry: true, Err: err}
 		}
 		time.Sleep(4 * time.Second)
 	}
+	if !merged && mergeConfig.GitDataFallback && canFallBackToGitData(err) {
+		merged, err = fallBackToGitData(ctx, pullCtx, merger, mergeMethod, commitMsg, mergeConfig.Signer, err)
+	}
+	if !merged {
+		return MergeResult{Err: err}
+	}
 
 	_, head := pullCtx.Branches()
-	if merged {
-		if mergeConfig.DeleteAfterMerge {
-			attemptDelete(ctx, pullCtx, head, merger)
-		} else {
-			logger.Debug().Msgf("Not deleting refs/heads/%s, delete after merge is not enabled", head)
-		}
-	}
+	if mergeMethod == MergeQueue {
+		// the pull request is merged later, when it leaves the queue
+		logger.Debug().Msgf("Not deleting refs/heads/%s, pull request was added to the merge queue", head)
+		return MergeResult{Merged: true, Queued: true}
+	}
+	if mergeConfig.DeleteAfterMerge && mergeConfig.DeleteGracePeriod > 0 {
+		logger.Debug().Msgf("Not deleting refs/heads/%s until the grace period of %s ends", head, time.Duration(mergeConfig.DeleteGracePeriod))
+	} else if mergeConfig.DeleteAfterMerge {
+		attemptDelete(ctx, pullCtx, head, merger)
+	} else {
+		logger.Debug().Msgf("Not deleting refs/heads/%s, delete after merge is not enabled", head)
+	}
+	return MergeResult{Merged: true}
 }
 
 // attemptMerge attempts to merge a pull request, logging any errors and
-// returing flags to show if the merge suceeded and if a retry is needed.
-func attemptMerge(ctx context.Context, pullCtx pull.Context, merger Merger, method MergeMethod, msg CommitMessage) (merged, retry bool) {
+// returing a flag to show if the merge suceeded, when a retry is needed, and
+// the reason the merge failed.
+func attemptMerge(ctx context.Context, pullCtx pull.Context, merger Merger, method MergeMethod, msg CommitMessage) (merged bool, retry retryMode, reason error) {
 	logger := zerolog.Ctx(ctx)
 
 	mergeState, err := pullCtx.MergeState(ctx)
 	if err != nil {
 		logger.Error().Err(err).Msgf("Failed to get merge state for %q", pullCtx.Locator())
-		return false, false
+		return false, noRetry, err
 	}
 
 	if mergeState.Closed {
 		logger.Debug().Msg("Pull request already closed")
-		return false, false
+		return false, noRetry, errClosed
 	}
 
 	if mergeState.Mergeable == nil {
 		logger.Debug().Msg("Pull request mergeability not yet known")
-		return false, true
+		return false, retryNow, errMergeabilityUnknown
 	}
 
 	if !*mergeState.Mergeable {
 		logger.Debug().Msg("Pull request is not mergeable")
-		return false, false
+		return false, noRetry, errNotMergeable
 	}
 
 	logger.Info().Msgf("Attempting to merge pull request with method %s", method)
 	sha, err := merger.Merge(ctx, pullCtx, method, msg)
 	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *github.RateLimitError, *github.AbuseRateLimitError:
+			logger.Info().Msgf("Merge rejected due to rate limiting: %s", err)
+			return false, retryLater, err
+		}
+
+		if errors.Cause(err) == errNotFastForward {
+			logger.Info().Msgf("Merge rejected: %s", err)
+			return false, noRetry, err
+		}
+
 		gerr, ok := errors.Cause(err).(*github.ErrorResponse)
 		if !ok {
 			logger.Error().Err(err).Msg("Failed to merge pull request")
-			return false, true
+			return false, retryNow, err
 		}
 
 		switch gerr.Response.StatusCode {
 		case http.StatusMethodNotAllowed:
 			if gerr.Message == "Base branch was modified. Review and try the merge again." {
 				logger.Info().Msg("Base branch was modified, retrying")
-				return false, true
+				return false, retryNow, err
 			}
 			logger.Info().Msgf("Merge rejected due to unsatisfied condition: %q", gerr.Message)
-			return false, false
+			return false, noRetry, err
 		case http.StatusConflict:
-			logger.Info().Msgf("Merge rejected due to being invalid: %q", gerr.Message)
-			return false, false
+			// GitHub returns a conflict if the head branch changes during the
+			// merge, which may succeed when the pull request is evaluated again
+			logger.Info().Msgf("Merge rejected due to a conflict: %q", gerr.Message)
+			return false, retryLater, err
 		default:
 			logger.Error().Msgf("Merge failed with unexpected status: %d: %q", gerr.Response.StatusCode, gerr.Message)
-			return false, true
+			return false, retryNow, err
 		}
 	}
 
+	if method == MergeQueue {
+		logger.Info().Msg("Successfully added pull request to the merge queue")
+		return true, noRetry, nil
+	}
+
 	logger.Info().Msgf("Successfully merged pull request as SHA %s", sha)
-	return true, false
+	return true, noRetry, nil
+}
+
+// DeleteHead deletes the head branch of a merged pull request, unless it is
+// not safe to delete. It logs any errors and returns true if successful.
+func DeleteHead(ctx context.Context, pullCtx pull.Context, merger Merger) bool {
+	_, head := pullCtx.Branches()
+	return attemptDelete(ctx, pullCtx, head, merger)
 }
 
 // attemptDelete attempts to delete a pull request branch, logging any errors
-// and returning true if successful.
+// and returning true if successful. It does not delete the default branch,
+// protected branches, branches that are the target of other pull requests,
+// or branches with commits that were not part of the pull request.
 func attemptDelete(ctx context.Context, pullCtx pull.Context, head string, merger Merger) bool {
 	logger := zerolog.Ctx(ctx)
 
@@ -321,6 +467,11 @@ func attemptDelete(ctx context.Context, pullCtx pull.Context, head string, merge
 
 	ref := fmt.Sprintf("refs/heads/%s", head)
 
+	if head == pullCtx.DefaultBranch() {
+		logger.Info().Msgf("Not deleting %s because it is the default branch", ref)
+		return false
+	}
+
 	// check other open PRs to make sure that nothing is trying to merge into the ref we're about to delete
 	isTargeted, err := pullCtx.IsTargeted(ctx)
 	if err != nil {
@@ -332,6 +483,24 @@ func attemptDelete(ctx context.Context, pullCtx pull.Context, head string, merge
 		return false
 	}
 
+	branch, err := pullCtx.HeadBranch(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msgf("Unable to get the current state of %s", ref)
+		return false
+	}
+	if branch == nil {
+		logger.Debug().Msgf("Not deleting %s because it does not exist", ref)
+		return false
+	}
+	if branch.Protected {
+		logger.Info().Msgf("Not deleting %s because it is protected", ref)
+		return false
+	}
+	if branch.SHA != pullCtx.HeadSHA() {
+		logger.Info().Msgf("Not deleting %s because it has commits that were not merged", ref)
+		return false
+	}
+
 	logger.Info().Msgf("Attempting to delete ref %s", ref)
 	if err := merger.DeleteHead(ctx, pullCtx); err != nil {
 		logger.Error().Err(err).Msgf("Failed to delete %s", ref)
@@ -343,7 +512,36 @@ func attemptDelete(ctx context.Context, pullCtx pull.Context, head string, merge
 }
 
 func isValidMergeMethod(input MergeMethod) bool {
-	return input == SquashAndMerge || input == RebaseAndMerge || input == MergeCommit || input == FastForwardOnly
+	return input == SquashAndMerge || input == RebaseAndMerge || input == MergeCommit || input == FastForwardOnly || input == MergeQueue
+}
+
+// squashCommitMessage returns the title and message of the commit created by
+// squashing the pull request. If options is nil, it uses the defaults.
+func squashCommitMessage(ctx context.Context, pullCtx pull.Context, options *SquashOptions) (CommitMessage, error) {
+	var opt SquashOptions
+	if options != nil {
+		opt = *options
+	}
+	if opt.Title == "" {
+		opt.Title = PullRequestTitle
+	}
+	if opt.Body == "" {
+		opt.Body = EmptyBody
+	}
+
+	message, err := calculateCommitMessage(ctx, pullCtx, opt)
+	if err != nil {
+		return CommitMessage{}, err
+	}
+	message, err = addTrailers(ctx, pullCtx, message, opt.Trailers)
+	if err != nil {
+		return CommitMessage{}, err
+	}
+	title, err := calculateCommitTitle(ctx, pullCtx, opt)
+	if err != nil {
+		return CommitMessage{}, errors.Wrap(err, "failed to calculate commit title")
+	}
+	return CommitMessage{Title: title, Message: message}, nil
 }
 
 func calculateCommitMessage(ctx context.Context, pullCtx pull.Context, option SquashOptions) (string, error) {
@@ -356,6 +554,17 @@ func calculateCommitMessage(ctx context.Context, pullCtx pull.Context, option Sq
 		emptyMessage   = " "
 	)
 
+	if option.BodyTemplate != "" {
+		message, err := executeCommitTemplate(ctx, pullCtx, "body_template", option.BodyTemplate)
+		if err != nil {
+			return "", err
+		}
+		if message == "" {
+			return emptyMessage, nil
+		}
+		return message, nil
+	}
+
 	commitMessage := defaultMessage
 	switch option.Body {
 	case PullRequestBody:
@@ -386,6 +595,10 @@ func calculateCommitMessage(ctx context.Context, pullCtx pull.Context, option Sq
 }
 
 func calculateCommitTitle(ctx context.Context, pullCtx pull.Context, option SquashOptions) (string, error) {
+	if option.TitleTemplate != "" {
+		return executeCommitTemplate(ctx, pullCtx, "title_template", option.TitleTemplate)
+	}
+
 	var title string
 	switch option.Title {
 	case PullRequestTitle:
@@ -0,0 +1,117 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+
+	"github.com/palantir/bulldozer/pull"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/shurcooL/githubv4"
+)
+
+// GraphQLClient is the subset of the GitHub v4 (GraphQL) client used by
+// bulldozer. It is satisfied by *githubv4.Client.
+type GraphQLClient interface {
+	Query(ctx context.Context, q interface{}, variables map[string]interface{}) error
+	Mutate(ctx context.Context, m interface{}, input githubv4.Input, variables map[string]interface{}) error
+}
+
+// EnqueuePullRequestInput is the input to the enqueuePullRequest mutation. The
+// name of this type must match the name of the type in the GraphQL schema.
+type EnqueuePullRequestInput struct {
+	PullRequestID githubv4.ID `json:"pullRequestId"`
+}
+
+// MergeQueueMerger adds pull requests to the merge queue of their target
+// branch when using the MergeQueue method. GitHub merges the pull request
+// once it reaches the front of the queue. All other methods are delegated to
+// another Merger.
+type MergeQueueMerger struct {
+	Direct Merger
+
+	client GraphQLClient
+}
+
+func NewMergeQueueMerger(direct Merger, client GraphQLClient) Merger {
+	return &MergeQueueMerger{
+		Direct: direct,
+		client: client,
+	}
+}
+
+// Merge adds the pull request to the merge queue if the method is MergeQueue.
+// Pull requests are only added to the queue once, so calling Merge on a
+// queued pull request does nothing. Because the merge happens later, Merge
+// always returns an empty SHA for queued pull requests.
+func (m *MergeQueueMerger) Merge(ctx context.Context, pullCtx pull.Context, method MergeMethod, msg CommitMessage) (string, error) {
+	if method != MergeQueue {
+		return m.Direct.Merge(ctx, pullCtx, method, msg)
+	}
+
+	var q struct {
+		Repository struct {
+			PullRequest struct {
+				ID             githubv4.ID
+				IsInMergeQueue bool
+			} `graphql:"pullRequest(number: $number)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+
+	qvars := map[string]interface{}{
+		"owner":  githubv4.String(pullCtx.Owner()),
+		"name":   githubv4.String(pullCtx.Repo()),
+		"number": githubv4.Int(pullCtx.Number()),
+	}
+	if err := m.client.Query(ctx, &q, qvars); err != nil {
+		return "", errors.Wrap(err, "failed to get merge queue state")
+	}
+
+	pr := q.Repository.PullRequest
+	if pr.IsInMergeQueue {
+		zerolog.Ctx(ctx).Debug().Msg("Pull request is already in the merge queue")
+		return "", nil
+	}
+
+	var mutation struct {
+		EnqueuePullRequest struct {
+			MergeQueueEntry struct {
+				Position int
+			}
+		} `graphql:"enqueuePullRequest(input: $input)"`
+	}
+
+	input := EnqueuePullRequestInput{PullRequestID: pr.ID}
+	if err := m.client.Mutate(ctx, &mutation, input, nil); err != nil {
+		return "", errors.Wrap(err, "failed to add pull request to the merge queue")
+	}
+
+	zerolog.Ctx(ctx).Info().Msgf("Added pull request to the merge queue at position %d", mutation.EnqueuePullRequest.MergeQueueEntry.Position)
+	return "", nil
+}
+
+// MergeWithGitData delegates to the other Merger, since pull requests in the
+// merge queue are never merged with the git data API.
+func (m *MergeQueueMerger) MergeWithGitData(ctx context.Context, pullCtx pull.Context, method MergeMethod, msg CommitMessage, signer *CommitSigner) (string, error) {
+	if gm, ok := m.Direct.(GitDataMerger); ok {
+		return gm.MergeWithGitData(ctx, pullCtx, method, msg, signer)
+	}
+	return "", errors.New("merger does not support the git data API")
+}
+
+func (m *MergeQueueMerger) DeleteHead(ctx context.Context, pullCtx pull.Context) error {
+	return m.Direct.DeleteHead(ctx, pullCtx)
+}
@@ -17,14 +17,21 @@ package bulldozer
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-github/v50/github"
 	"github.com/palantir/bulldozer/pull"
 	"github.com/palantir/bulldozer/pull/pulltest"
+	"github.com/pkg/errors"
+	"github.com/shurcooL/githubv4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -201,6 +208,389 @@ func TestBaseBranchChangedRetry(t *testing.T) {
 	ctx := context.Background()
 	pullCtx := &pulltest.MockPullContext{MergeStateValue: &pull.MergeState{Closed: false, Mergeable: boolVal(true)}}
 
-	_, retry := attemptMerge(ctx, pullCtx, merger, SquashAndMerge, CommitMessage{})
-	assert.True(t, retry, "should retry on base branch changed error")
+	_, retry, _ := attemptMerge(ctx, pullCtx, merger, SquashAndMerge, CommitMessage{})
+	assert.Equal(t, retryNow, retry, "should retry on base branch changed error")
+}
+
+func TestRetryLater(t *testing.T) {
+	ctx := context.Background()
+	pullCtx := &pulltest.MockPullContext{MergeStateValue: &pull.MergeState{Closed: false, Mergeable: boolVal(true)}}
+
+	tests := map[string]error{
+		"conflict": github.CheckResponse(
+			&http.Response{
+				StatusCode: http.StatusConflict,
+				Body:       io.NopCloser(bytes.NewReader([]byte(`{"message": "Head branch was modified. Review and try the merge again."}`))),
+			},
+		),
+		"rateLimit":      &github.RateLimitError{Message: "API rate limit exceeded"},
+		"abuseRateLimit": &github.AbuseRateLimitError{Message: "You have triggered an abuse detection mechanism."},
+	}
+
+	for name, mergeErr := range tests {
+		t.Run(name, func(t *testing.T) {
+			merger := &MockMerger{MergeError: mergeErr}
+			_, retry, _ := attemptMerge(ctx, pullCtx, merger, SquashAndMerge, CommitMessage{})
+			assert.Equal(t, retryLater, retry)
+
+			assert.True(t, MergePR(ctx, pullCtx, merger, MergeConfig{Method: SquashAndMerge}).Retry, "MergePR did not request a retry")
+		})
+	}
+}
+
+func TestMergeResultReason(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		MergeState *pull.MergeState
+		MergeError error
+		Reason     string
+	}{
+		"merged": {
+			MergeState: &pull.MergeState{Mergeable: boolVal(true)},
+			Reason:     "",
+		},
+		"closed": {
+			MergeState: &pull.MergeState{Closed: true},
+			Reason:     "closed",
+		},
+		"notMergeable": {
+			MergeState: &pull.MergeState{Mergeable: boolVal(false)},
+			Reason:     "not_mergeable",
+		},
+		"rejected": {
+			MergeState: &pull.MergeState{Mergeable: boolVal(true)},
+			MergeError: github.CheckResponse(
+				&http.Response{
+					StatusCode: http.StatusMethodNotAllowed,
+					Body:       io.NopCloser(bytes.NewReader([]byte(`{"message": "Required status check \"ci\" is expected."}`))),
+				},
+			),
+			Reason: "rejected",
+		},
+		"rateLimit": {
+			MergeState: &pull.MergeState{Mergeable: boolVal(true)},
+			MergeError: &github.RateLimitError{Message: "API rate limit exceeded"},
+			Reason:     "rate_limited",
+		},
+		"notFastForward": {
+			MergeState: &pull.MergeState{Mergeable: boolVal(true)},
+			MergeError: errors.Wrap(errNotFastForward, "cannot fast-forward develop"),
+			Reason:     "not_fast_forward",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pullCtx := &pulltest.MockPullContext{MergeStateValue: test.MergeState}
+			merger := &MockMerger{MergeError: test.MergeError}
+
+			result := MergePR(ctx, pullCtx, merger, MergeConfig{Method: MergeCommit})
+			assert.Equal(t, test.Reason, result.Reason())
+		})
+	}
+}
+
+func TestDeleteHead(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		PullContext *pulltest.MockPullContext
+		Deleted     bool
+	}{
+		"deleted": {
+			PullContext: &pulltest.MockPullContext{
+				BranchName:      "feature",
+				HeadSHAValue:    "deadbeef",
+				HeadBranchValue: &pull.Branch{Name: "feature", SHA: "deadbeef"},
+			},
+			Deleted: true,
+		},
+		"fork": {
+			PullContext: &pulltest.MockPullContext{
+				BranchName:   "fork:feature",
+				HeadSHAValue: "deadbeef",
+			},
+		},
+		"defaultBranch": {
+			PullContext: &pulltest.MockPullContext{
+				BranchName:         "develop",
+				DefaultBranchValue: "develop",
+				HeadSHAValue:       "deadbeef",
+				HeadBranchValue:    &pull.Branch{Name: "develop", SHA: "deadbeef"},
+			},
+		},
+		"targeted": {
+			PullContext: &pulltest.MockPullContext{
+				BranchName:      "feature",
+				HeadSHAValue:    "deadbeef",
+				HeadBranchValue: &pull.Branch{Name: "feature", SHA: "deadbeef"},
+				IsTargetedValue: true,
+			},
+		},
+		"missing": {
+			PullContext: &pulltest.MockPullContext{
+				BranchName:   "feature",
+				HeadSHAValue: "deadbeef",
+			},
+		},
+		"protected": {
+			PullContext: &pulltest.MockPullContext{
+				BranchName:      "feature",
+				HeadSHAValue:    "deadbeef",
+				HeadBranchValue: &pull.Branch{Name: "feature", SHA: "deadbeef", Protected: true},
+			},
+		},
+		"newCommits": {
+			PullContext: &pulltest.MockPullContext{
+				BranchName:      "feature",
+				HeadSHAValue:    "deadbeef",
+				HeadBranchValue: &pull.Branch{Name: "feature", SHA: "cafebabe"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			merger := &MockMerger{}
+			deleted := DeleteHead(ctx, test.PullContext, merger)
+
+			assert.Equal(t, test.Deleted, deleted)
+			if test.Deleted {
+				assert.Equal(t, 1, merger.DeleteCount, "delete was not called")
+			} else {
+				assert.Equal(t, 0, merger.DeleteCount, "delete was incorrectly called")
+			}
+		})
+	}
+
+	t.Run("gracePeriod", func(t *testing.T) {
+		pullCtx := &pulltest.MockPullContext{
+			MergeStateValue: &pull.MergeState{Closed: false, Mergeable: boolVal(true)},
+			BranchName:      "feature",
+			HeadSHAValue:    "deadbeef",
+			HeadBranchValue: &pull.Branch{Name: "feature", SHA: "deadbeef"},
+		}
+		merger := &MockMerger{}
+		MergePR(ctx, pullCtx, merger, MergeConfig{
+			Method:            SquashAndMerge,
+			DeleteAfterMerge:  true,
+			DeleteGracePeriod: Duration(time.Hour),
+		})
+
+		assert.Equal(t, 1, merger.MergeCount, "merge was not called")
+		assert.Equal(t, 0, merger.DeleteCount, "delete was called before the grace period ended")
+	})
+}
+
+func TestMergeQueueMerger(t *testing.T) {
+	var queued bool
+	var enqueueCount int
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/graphql", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasPrefix(body.Query, "mutation") {
+			assert.Equal(t, map[string]interface{}{"pullRequestId": "PR_1"}, body.Variables["input"])
+			enqueueCount++
+			queued = true
+			_, _ = io.WriteString(w, `{"data": {"enqueuePullRequest": {"mergeQueueEntry": {"position": 1}}}}`)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"data": {"repository": {"pullRequest": {"id": "PR_1", "isInMergeQueue": %t}}}}`, queued)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	direct := &MockMerger{}
+	merger := NewMergeQueueMerger(direct, githubv4.NewEnterpriseClient(srv.URL+"/api/graphql", srv.Client()))
+
+	ctx := context.Background()
+	pullCtx := &pulltest.MockPullContext{OwnerValue: "testorg", RepoValue: "testrepo", NumberValue: 1}
+
+	sha, err := merger.Merge(ctx, pullCtx, SquashAndMerge, CommitMessage{})
+	require.NoError(t, err)
+	assert.Equal(t, "deadbeef", sha)
+	assert.Equal(t, 1, direct.MergeCount, "direct merge was not called")
+	assert.Equal(t, 0, enqueueCount, "pull request was incorrectly enqueued")
+
+	sha, err = merger.Merge(ctx, pullCtx, MergeQueue, CommitMessage{})
+	require.NoError(t, err)
+	assert.Equal(t, "", sha)
+	assert.Equal(t, 1, direct.MergeCount, "direct merge was incorrectly called")
+	assert.Equal(t, 1, enqueueCount, "pull request was not enqueued")
+
+	_, err = merger.Merge(ctx, pullCtx, MergeQueue, CommitMessage{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, enqueueCount, "queued pull request was enqueued again")
+
+	_ = merger.DeleteHead(ctx, pullCtx)
+	assert.Equal(t, 1, direct.DeleteCount, "direct delete was not called")
+}
+
+func TestDeterminePriority(t *testing.T) {
+	ctx := context.Background()
+	mergeConfig := MergeConfig{
+		Priority: map[string]int{
+			"urgent":       10,
+			"hotfix":       5,
+			"low-priority": -10,
+		},
+	}
+
+	tests := map[string]struct {
+		Labels   []string
+		Config   MergeConfig
+		Expected int
+	}{
+		"noLabels": {
+			Config:   mergeConfig,
+			Expected: 0,
+		},
+		"unknownLabel": {
+			Labels:   []string{"bug"},
+			Config:   mergeConfig,
+			Expected: 0,
+		},
+		"caseInsensitive": {
+			Labels:   []string{"URGENT"},
+			Config:   mergeConfig,
+			Expected: 10,
+		},
+		"highestLabelWins": {
+			Labels:   []string{"hotfix", "urgent", "low-priority"},
+			Config:   mergeConfig,
+			Expected: 10,
+		},
+		"negativePriority": {
+			Labels:   []string{"low-priority"},
+			Config:   mergeConfig,
+			Expected: -10,
+		},
+		"noPriorities": {
+			Labels:   []string{"urgent"},
+			Expected: 0,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pullCtx := &pulltest.MockPullContext{LabelValue: test.Labels}
+
+			priority, err := DeterminePriority(ctx, pullCtx, test.Config)
+			require.NoError(t, err)
+			assert.Equal(t, test.Expected, priority)
+		})
+	}
+}
+
+func TestDetermineMergeMethod(t *testing.T) {
+	ctx := context.Background()
+	mergeConfig := MergeConfig{
+		Method: MergeCommit,
+		MergeMethods: []ConditionalMergeMethod{
+			{
+				Method:  SquashAndMerge,
+				Trigger: Signals{MaxCommits: 1},
+			},
+			{
+				Method:  RebaseAndMerge,
+				Trigger: Signals{Labels: []string{"stacked"}},
+			},
+		},
+		BranchMethod: map[string]MergeMethod{
+			"release": FastForwardOnly,
+		},
+	}
+
+	commits := func(n int) []*pull.Commit {
+		var c []*pull.Commit
+		for i := 0; i < n; i++ {
+			c = append(c, &pull.Commit{SHA: fmt.Sprintf("%d", i)})
+		}
+		return c
+	}
+
+	tests := map[string]struct {
+		PullContext *pulltest.MockPullContext
+		Expected    MergeMethod
+	}{
+		"singleCommitIsSquashed": {
+			PullContext: &pulltest.MockPullContext{CommitsValue: commits(1)},
+			Expected:    SquashAndMerge,
+		},
+		"firstMatchingMethodWins": {
+			PullContext: &pulltest.MockPullContext{CommitsValue: commits(1), LabelValue: []string{"stacked"}},
+			Expected:    SquashAndMerge,
+		},
+		"labelIsRebased": {
+			PullContext: &pulltest.MockPullContext{CommitsValue: commits(3), LabelValue: []string{"Stacked"}},
+			Expected:    RebaseAndMerge,
+		},
+		"defaultMethod": {
+			PullContext: &pulltest.MockPullContext{CommitsValue: commits(3)},
+			Expected:    MergeCommit,
+		},
+		"branchMethod": {
+			PullContext: &pulltest.MockPullContext{CommitsValue: commits(3), BranchBase: "release"},
+			Expected:    FastForwardOnly,
+		},
+		"conditionalMethodOverridesBranchMethod": {
+			PullContext: &pulltest.MockPullContext{CommitsValue: commits(1), BranchBase: "release"},
+			Expected:    SquashAndMerge,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			method, err := DetermineMergeMethod(ctx, test.PullContext, mergeConfig)
+			require.NoError(t, err)
+			assert.Equal(t, test.Expected, method)
+		})
+	}
+}
+
+func TestFFOnlyMerge(t *testing.T) {
+	var behindBy int
+	var updated map[string]interface{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/testorg/testrepo/git/ref/heads/develop", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"ref": "refs/heads/develop", "object": {"sha": "base"}}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/compare/base...head", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"ahead_by": 2, "behind_by": %d}`, behindBy)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/git/refs/heads/develop", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&updated))
+		_, _ = fmt.Fprintf(w, `{"ref": "refs/heads/develop", "object": {"sha": %q}}`, updated["sha"])
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	ctx := context.Background()
+	pullCtx := &pulltest.MockPullContext{OwnerValue: "testorg", RepoValue: "testrepo", HeadSHAValue: "head", BranchBase: "develop"}
+	merger := &GitHubMerger{client: client}
+
+	sha, err := merger.Merge(ctx, pullCtx, FastForwardOnly, CommitMessage{})
+	require.NoError(t, err)
+	assert.Equal(t, "head", sha)
+	assert.Equal(t, "head", updated["sha"])
+	assert.Equal(t, false, updated["force"])
+
+	updated = nil
+	behindBy = 1
+	_, err = merger.Merge(ctx, pullCtx, FastForwardOnly, CommitMessage{})
+	assert.Equal(t, errNotFastForward, errors.Cause(err))
+	assert.Nil(t, updated, "base branch was updated when the head was behind")
 }
@@ -0,0 +1,146 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Policy constrains the configuration that repositories may use. Servers
+// define policies for organizations so that repository configuration cannot
+// weaken rules that apply to every repository.
+type Policy struct {
+	// MergeMethods are the merge methods that repositories may use. If
+	// empty, all methods are allowed.
+	MergeMethods []MergeMethod `yaml:"merge_methods"`
+
+	// RequiredIgnoreLabels are labels that repositories must include in the
+	// labels of the merge ignore section.
+	RequiredIgnoreLabels []string `yaml:"required_ignore_labels"`
+
+	// MinUpdateInterval is the smallest update min_interval that repositories
+	// that update pull requests may use.
+	MinUpdateInterval Duration `yaml:"min_update_interval"`
+
+	// External is an endpoint that decides whether pull requests that
+	// bulldozer would merge may merge. If nil, there is no external policy.
+	External *ExternalPolicy `yaml:"external"`
+}
+
+func (p Policy) Validate() error {
+	for _, method := range p.MergeMethods {
+		if !isValidMergeMethod(method) {
+			return errors.Errorf("invalid merge method %q", method)
+		}
+	}
+	if p.External != nil {
+		if err := p.External.Validate(); err != nil {
+			return errors.Wrap(err, "invalid external policy")
+		}
+	}
+	return nil
+}
+
+// Violations returns descriptions of the ways the configuration breaks the
+// policy, including the configuration for each branch override. It returns
+// nil if the configuration follows the policy.
+func (p Policy) Violations(c *Config) []string {
+	if c == nil {
+		return nil
+	}
+
+	var violations []string
+	add := func(violation string) {
+		for _, v := range violations {
+			if v == violation {
+				return
+			}
+		}
+		violations = append(violations, violation)
+	}
+
+	configs := []*Config{c}
+	for _, b := range c.branches {
+		configs = append(configs, b.config)
+	}
+	for _, config := range configs {
+		for _, v := range p.violations(config) {
+			add(v)
+		}
+	}
+	return violations
+}
+
+func (p Policy) violations(c *Config) []string {
+	var violations []string
+
+	if len(p.MergeMethods) > 0 {
+		methods := []MergeMethod{c.Merge.Method}
+		if !isValidMergeMethod(c.Merge.Method) {
+			methods[0] = MergeCommit
+		}
+		branches := make([]string, 0, len(c.Merge.BranchMethod))
+		for branch := range c.Merge.BranchMethod {
+			branches = append(branches, branch)
+		}
+		sort.Strings(branches)
+		for _, branch := range branches {
+			methods = append(methods, c.Merge.BranchMethod[branch])
+		}
+		for _, conditional := range c.Merge.MergeMethods {
+			methods = append(methods, conditional.Method)
+		}
+
+		for _, method := range methods {
+			if !p.allowsMergeMethod(method) {
+				violations = append(violations, fmt.Sprintf("merge method %q is not allowed, use one of %s", method, formatMergeMethods(p.MergeMethods)))
+			}
+		}
+	}
+
+	for _, label := range p.RequiredIgnoreLabels {
+		if !containsFold(c.Merge.Ignore.Labels, label) {
+			violations = append(violations, fmt.Sprintf("merge.ignore.labels must include %q", label))
+		}
+	}
+
+	if min := time.Duration(p.MinUpdateInterval); min > 0 && c.Update.configured() && time.Duration(c.Update.MinInterval) < min {
+		violations = append(violations, fmt.Sprintf("update.min_interval must be at least %s", min))
+	}
+
+	return violations
+}
+
+func (p Policy) allowsMergeMethod(method MergeMethod) bool {
+	for _, m := range p.MergeMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+func formatMergeMethods(methods []MergeMethod) string {
+	quoted := make([]string, len(methods))
+	for i, m := range methods {
+		quoted[i] = fmt.Sprintf("%q", m)
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,191 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/palantir/bulldozer/pull"
+	"github.com/pkg/errors"
+)
+
+// DefaultPolicyBotContext is the status context that policy-bot uses unless
+// its server changes it.
+const DefaultPolicyBotContext = "policy-bot"
+
+// PolicyBotConfig controls how bulldozer waits for the approval status of
+// palantir/policy-bot. When the status is on the head commit of a pull
+// request, bulldozer requires it as if it were listed in required_statuses.
+type PolicyBotConfig struct {
+	// Disabled stops bulldozer from requiring the policy-bot status unless
+	// branch protection or required_statuses require it.
+	Disabled bool `yaml:"disabled"`
+
+	// StatusContext is the status context that policy-bot uses. Statuses
+	// with this context or with this context followed by ": " and a branch
+	// name are policy-bot statuses. If empty, the default is "policy-bot".
+	StatusContext string `yaml:"status_context"`
+}
+
+func (c PolicyBotConfig) isStatus(context string) bool {
+	prefix := c.StatusContext
+	if prefix == "" {
+		prefix = DefaultPolicyBotContext
+	}
+	return context == prefix || strings.HasPrefix(context, prefix+": ")
+}
+
+// PolicyBotStatuses returns the policy-bot statuses on the head commit of the
+// pull request, or nil if the policy-bot status is disabled.
+func PolicyBotStatuses(ctx context.Context, pullCtx pull.Context, config PolicyBotConfig) ([]*pull.Status, error) {
+	if config.Disabled {
+		return nil, nil
+	}
+
+	statuses, err := pullCtx.Statuses(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list statuses")
+	}
+
+	var policyBot []*pull.Status
+	for _, s := range statuses {
+		if config.isStatus(s.Context) {
+			policyBot = append(policyBot, s)
+		}
+	}
+	return policyBot, nil
+}
+
+// requirePolicyBot adds the contexts of the policy-bot statuses on the pull
+// request to the required statuses if they are not already required.
+func requirePolicyBot(ctx context.Context, pullCtx pull.Context, config PolicyBotConfig, required []string) ([]string, error) {
+	statuses, err := PolicyBotStatuses(ctx, pullCtx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range statuses {
+		if !containsString(required, s.Context) {
+			required = append(required, s.Context)
+		}
+	}
+	return required, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// PolicyBotRule is an approval rule evaluated by policy-bot.
+type PolicyBotRule struct {
+	Name        string `json:"name"`
+	Status      string `json:"status"`
+	Description string `json:"description"`
+}
+
+// PolicyBotServer is a policy-bot server that bulldozer reads the result of
+// approval policies from, so that explanations can list the rules that are
+// not approved.
+type PolicyBotServer struct {
+	// URL is the base URL of the policy-bot server.
+	URL string `yaml:"url"`
+
+	// Token is a GitHub token that policy-bot accepts for its simulation API.
+	Token string `yaml:"token"`
+}
+
+func (s PolicyBotServer) Enabled() bool {
+	return s.URL != ""
+}
+
+// policyBotResult is a node in the evaluation result returned by the
+// policy-bot simulation API.
+type policyBotResult struct {
+	Name              string             `json:"name"`
+	Description       string             `json:"description"`
+	StatusDescription string             `json:"status_description"`
+	Status            string             `json:"status"`
+	Error             string             `json:"error"`
+	Children          []*policyBotResult `json:"children"`
+}
+
+// UnmetRules returns the approval rules of the pull request that policy-bot
+// has not approved. If client is nil, it uses http.DefaultClient.
+func (s PolicyBotServer) UnmetRules(ctx context.Context, client *http.Client, owner, repo string, number int) ([]PolicyBotRule, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	u := fmt.Sprintf("%s/api/simulate/%s/%s/%d", strings.TrimSuffix(s.URL, "/"), owner, repo, number)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create policy-bot request")
+	}
+	req.Header.Set("Accept", "application/json")
+	if s.Token != "" {
+		req.Header.Set("Authorization", "token "+s.Token)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get policy-bot result")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		_, _ = io.Copy(io.Discard, res.Body)
+		return nil, errors.Errorf("getting policy-bot result failed with status %d", res.StatusCode)
+	}
+
+	var result policyBotResult
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, errors.Wrap(err, "failed to parse policy-bot result")
+	}
+
+	var rules []PolicyBotRule
+	var collect func(r *policyBotResult)
+	collect = func(r *policyBotResult) {
+		if len(r.Children) > 0 {
+			for _, child := range r.Children {
+				collect(child)
+			}
+			return
+		}
+		switch strings.ToLower(r.Status) {
+		case "approved", "skipped":
+			return
+		}
+		description := r.StatusDescription
+		if r.Error != "" {
+			description = r.Error
+		}
+		if description == "" {
+			description = r.Description
+		}
+		rules = append(rules, PolicyBotRule{Name: r.Name, Status: strings.ToLower(r.Status), Description: description})
+	}
+	collect(&result)
+	return rules, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/palantir/bulldozer/pull"
+	"github.com/palantir/bulldozer/pull/pulltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyBotRequired(t *testing.T) {
+	ctx := context.Background()
+	pullCtx := &pulltest.MockPullContext{
+		RequiredStatusesValue: []string{"ci"},
+		SuccessStatusesValue:  []string{"ci"},
+		StatusesValue: []*pull.Status{
+			{Context: "ci", State: "success"},
+			{Context: "policy-bot: develop", State: "pending"},
+			{Context: "policy-bot-legacy", State: "pending"},
+		},
+	}
+
+	mergeable, reason, err := ExplainMergePR(ctx, pullCtx, MergeConfig{})
+	require.NoError(t, err)
+	assert.False(t, mergeable)
+	assert.Equal(t, "not mergeable because of unfulfilled status checks: [policy-bot: develop]", reason)
+
+	mergeable, _, err = ExplainMergePR(ctx, pullCtx, MergeConfig{PolicyBot: PolicyBotConfig{Disabled: true}})
+	require.NoError(t, err)
+	assert.True(t, mergeable)
+
+	mergeable, reason, err = ExplainMergePR(ctx, pullCtx, MergeConfig{PolicyBot: PolicyBotConfig{StatusContext: "policy-bot-legacy"}})
+	require.NoError(t, err)
+	assert.False(t, mergeable)
+	assert.Equal(t, "not mergeable because of unfulfilled status checks: [policy-bot-legacy]", reason)
+
+	// a successful policy-bot status does not count as a required check
+	noChecks := &pulltest.MockPullContext{
+		SuccessStatusesValue: []string{"policy-bot: develop"},
+		StatusesValue:        []*pull.Status{{Context: "policy-bot: develop", State: "success"}},
+	}
+	mergeable, reason, err = ExplainMergePR(ctx, noChecks, MergeConfig{})
+	require.NoError(t, err)
+	assert.False(t, mergeable)
+	assert.Equal(t, "not mergeable because there are 0 required status checks and AllowMergeWithNoChecks is false", reason)
+
+	mergeable, _, err = ExplainMergePR(ctx, noChecks, MergeConfig{AllowMergeWithNoChecks: true})
+	require.NoError(t, err)
+	assert.True(t, mergeable)
+
+	pullCtx.SuccessStatusesValue = []string{"ci", "policy-bot: develop"}
+	pullCtx.RequiredStatusesValue = []string{"ci", "policy-bot: develop"}
+	e, err := ExplainEligibility(ctx, pullCtx, Config{})
+	require.NoError(t, err)
+	assert.True(t, e.Mergeable)
+	assert.Equal(t, []string{"ci", "policy-bot: develop"}, e.RequiredStatuses)
+}
+
+func TestPolicyBotUnmetRules(t *testing.T) {
+	var path, auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, auth = r.URL.Path, r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{
+  "name": "policy",
+  "status": "pending",
+  "children": [
+    {"name": "approval", "status": "pending", "children": [
+      {"name": "two reviewers", "status": "approved", "status_description": "Approved by alice, bob"},
+      {"name": "security review", "status": "pending", "status_description": "0/1 required approvals"}
+    ]},
+    {"name": "docs only", "status": "skipped"},
+    {"name": "no migrations", "status": "disapproved", "status_description": "Disapproved by carol"}
+  ]
+}`))
+	}))
+	defer srv.Close()
+
+	server := PolicyBotServer{URL: srv.URL + "/", Token: "secret"}
+	require.True(t, server.Enabled())
+
+	rules, err := server.UnmetRules(context.Background(), srv.Client(), "testorg", "testrepo", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "/api/simulate/testorg/testrepo/1", path)
+	assert.Equal(t, "token secret", auth)
+	assert.Equal(t, []PolicyBotRule{
+		{Name: "security review", Status: "pending", Description: "0/1 required approvals"},
+		{Name: "no migrations", Status: "disapproved", Description: "Disapproved by carol"},
+	}, rules)
+
+	e := Eligibility{PolicyBot: rules}
+	assert.Contains(t, e.Markdown(), "| `security review` | pending | 0/1 required approvals |")
+}
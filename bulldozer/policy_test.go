@@ -0,0 +1,123 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyViolations(t *testing.T) {
+	policy := Policy{
+		MergeMethods:         []MergeMethod{SquashAndMerge},
+		RequiredIgnoreLabels: []string{"do not merge"},
+		MinUpdateInterval:    Duration(time.Hour),
+	}
+
+	tests := map[string]struct {
+		Config     string
+		Violations []string
+	}{
+		"follows": {
+			Config: `
+version: 1
+merge:
+  method: squash
+  ignore:
+    labels: ["Do Not Merge"]
+update:
+  trigger:
+    labels: ["update me"]
+  min_interval: 2h
+`,
+		},
+		"defaultMethod": {
+			Config: `
+version: 1
+merge:
+  ignore:
+    labels: ["do not merge"]
+`,
+			Violations: []string{`merge method "merge" is not allowed, use one of "squash"`},
+		},
+		"conditionalMethod": {
+			Config: `
+version: 1
+merge:
+  method: squash
+  merge_method:
+    - method: rebase
+      trigger:
+        labels: ["rebase"]
+  ignore:
+    labels: ["do not merge"]
+`,
+			Violations: []string{`merge method "rebase" is not allowed, use one of "squash"`},
+		},
+		"branchOverride": {
+			Config: `
+version: 1
+merge:
+  method: squash
+  ignore:
+    labels: ["do not merge"]
+branches:
+  - pattern: "release/*"
+    merge:
+      method: merge
+`,
+			Violations: []string{`merge method "merge" is not allowed, use one of "squash"`},
+		},
+		"missingLabel": {
+			Config: `
+version: 1
+merge:
+  method: squash
+  ignore:
+    labels: ["wip"]
+`,
+			Violations: []string{`merge.ignore.labels must include "do not merge"`},
+		},
+		"updateInterval": {
+			Config: `
+version: 1
+merge:
+  method: squash
+  ignore:
+    labels: ["do not merge"]
+update:
+  trigger:
+    labels: ["update me"]
+`,
+			Violations: []string{"update.min_interval must be at least 1h0m0s"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config, err := ParseConfig([]byte(test.Config))
+			require.NoError(t, err)
+			assert.Equal(t, test.Violations, policy.Violations(config))
+		})
+	}
+
+	assert.NoError(t, policy.Validate())
+	assert.EqualError(t, Policy{MergeMethods: []MergeMethod{"octopus"}}.Validate(), `invalid merge method "octopus"`)
+	assert.EqualError(t, Policy{External: &ExternalPolicy{}}.Validate(), "invalid external policy: url is required")
+	assert.EqualError(t, Policy{External: &ExternalPolicy{URL: "https://example.com", FailureMode: "ajar"}}.Validate(), `invalid external policy: invalid failure mode "ajar"`)
+}
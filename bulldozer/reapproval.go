@@ -0,0 +1,95 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/pull"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// ReapprovalConfig defines how bulldozer asks for approvals again after it
+// updates a pull request that targets a branch that dismisses stale
+// approvals when new commits are pushed.
+type ReapprovalConfig struct {
+	// RequestReviews requests reviews from the users whose approvals were
+	// dismissed by the update.
+	RequestReviews bool `yaml:"request_reviews"`
+
+	// Comment is posted on the pull request when an update dismisses
+	// approvals. If empty, bulldozer does not comment.
+	Comment string `yaml:"comment"`
+}
+
+func (c ReapprovalConfig) enabled() bool {
+	return c.RequestReviews || c.Comment != ""
+}
+
+// dismissedApprovers returns the users whose approvals will be dismissed when
+// the pull request is updated, sorted by login. It returns nil if the target
+// branch does not dismiss stale approvals.
+func dismissedApprovers(ctx context.Context, pullCtx pull.Context) ([]string, error) {
+	requirements, err := pullCtx.MergeRequirements(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get merge requirements")
+	}
+	if !requirements.DismissesStaleReviews {
+		return nil, nil
+	}
+
+	reviews, err := pullCtx.Reviews(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list reviews")
+	}
+
+	var approvers []string
+	for login, state := range latestReviewStates(reviews) {
+		if state == pull.ReviewApproved && !strings.EqualFold(login, pullCtx.Author()) {
+			approvers = append(approvers, login)
+		}
+	}
+	sort.Strings(approvers)
+	return approvers, nil
+}
+
+// requestReapproval requests reviews again from the approvers and comments on
+// the pull request, as allowed by the configuration.
+func requestReapproval(ctx context.Context, pullCtx pull.Context, client *github.Client, config ReapprovalConfig, approvers []string) error {
+	if len(approvers) == 0 {
+		return nil
+	}
+	owner, repo, number := pullCtx.Owner(), pullCtx.Repo(), pullCtx.Number()
+
+	if config.RequestReviews {
+		reviewers := github.ReviewersRequest{Reviewers: approvers}
+		if _, _, err := client.PullRequests.RequestReviewers(ctx, owner, repo, number, reviewers); err != nil {
+			return errors.Wrap(err, "failed to request reviews")
+		}
+		zerolog.Ctx(ctx).Info().Msgf("Requested reviews again from %s", strings.Join(approvers, ", "))
+	}
+
+	if config.Comment != "" {
+		comment := &github.IssueComment{Body: github.String(config.Comment)}
+		if _, _, err := client.Issues.CreateComment(ctx, owner, repo, number, comment); err != nil {
+			return errors.Wrap(err, "failed to create reapproval comment")
+		}
+	}
+	return nil
+}
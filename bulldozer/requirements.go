@@ -0,0 +1,88 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/palantir/bulldozer/pull"
+)
+
+// UnmetBranchRequirement returns a description of the first requirement from
+// branch protection or rulesets on the target branch that the pull request
+// does not meet, or an empty string if it meets all of them. Required status
+// checks are evaluated separately, and required code owner reviews are not
+// evaluated because they depend on the CODEOWNERS file.
+func UnmetBranchRequirement(ctx context.Context, pullCtx pull.Context, mergeConfig MergeConfig) (string, error) {
+	requirements, err := pullCtx.MergeRequirements(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get merge requirements")
+	}
+
+	if requirements.RequiredApprovingReviews > 0 {
+		reviews, err := pullCtx.Reviews(ctx)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to list reviews")
+		}
+
+		states := latestReviewStates(reviews)
+		authors := make([]string, 0, len(states))
+		for author := range states {
+			authors = append(authors, author)
+		}
+		sort.Strings(authors)
+
+		approvals := 0
+		for _, author := range authors {
+			switch states[author] {
+			case pull.ReviewChangesRequested:
+				return fmt.Sprintf("branch protection requires approving reviews, but %s requested changes", author), nil
+			case pull.ReviewApproved:
+				approvals++
+			}
+		}
+		if approvals < requirements.RequiredApprovingReviews {
+			return fmt.Sprintf("branch protection requires %d approving reviews, but the pull request has %d", requirements.RequiredApprovingReviews, approvals), nil
+		}
+	}
+
+	if requirements.RequiresSignedCommits {
+		commits, err := pullCtx.Commits(ctx)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to list commits")
+		}
+		for _, c := range commits {
+			if !c.Verified {
+				return fmt.Sprintf("branch protection requires signed commits, but commit %s is not verified", c.SHA), nil
+			}
+		}
+	}
+
+	if requirements.RequiresLinearHistory {
+		method, err := DetermineMergeMethod(ctx, pullCtx, mergeConfig)
+		if err != nil {
+			return "", err
+		}
+		if method == MergeCommit {
+			return fmt.Sprintf("branch protection requires linear history, but the merge method is %s", method), nil
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/bulldozer/pull"
+	"github.com/palantir/bulldozer/pull/pulltest"
+)
+
+func TestUnmetBranchRequirement(t *testing.T) {
+	tests := map[string]struct {
+		PullContext *pulltest.MockPullContext
+		MergeConfig MergeConfig
+		Unmet       string
+	}{
+		"noRequirements": {
+			PullContext: &pulltest.MockPullContext{},
+		},
+		"enoughApprovals": {
+			PullContext: &pulltest.MockPullContext{
+				MergeRequirementsValue: &pull.MergeRequirements{RequiredApprovingReviews: 1},
+				ReviewsValue: []*pull.Review{
+					{Author: "alice", State: pull.ReviewApproved},
+				},
+			},
+		},
+		"tooFewApprovals": {
+			PullContext: &pulltest.MockPullContext{
+				MergeRequirementsValue: &pull.MergeRequirements{RequiredApprovingReviews: 2},
+				ReviewsValue: []*pull.Review{
+					{Author: "alice", State: pull.ReviewApproved},
+					{Author: "bob", State: pull.ReviewCommented},
+				},
+			},
+			Unmet: "branch protection requires 2 approving reviews, but the pull request has 1",
+		},
+		"changesRequested": {
+			PullContext: &pulltest.MockPullContext{
+				MergeRequirementsValue: &pull.MergeRequirements{RequiredApprovingReviews: 1},
+				ReviewsValue: []*pull.Review{
+					{Author: "alice", State: pull.ReviewApproved},
+					{Author: "Bob", State: pull.ReviewChangesRequested},
+				},
+			},
+			Unmet: "branch protection requires approving reviews, but bob requested changes",
+		},
+		"unsignedCommit": {
+			PullContext: &pulltest.MockPullContext{
+				MergeRequirementsValue: &pull.MergeRequirements{RequiresSignedCommits: true},
+				CommitsValue: []*pull.Commit{
+					{SHA: "a6b1b2c", Verified: true},
+					{SHA: "d4e5f6a"},
+				},
+			},
+			Unmet: "branch protection requires signed commits, but commit d4e5f6a is not verified",
+		},
+		"linearHistoryWithMergeCommit": {
+			PullContext: &pulltest.MockPullContext{
+				MergeRequirementsValue: &pull.MergeRequirements{RequiresLinearHistory: true},
+			},
+			MergeConfig: MergeConfig{Method: MergeCommit},
+			Unmet:       "branch protection requires linear history, but the merge method is merge",
+		},
+		"linearHistoryWithSquash": {
+			PullContext: &pulltest.MockPullContext{
+				MergeRequirementsValue: &pull.MergeRequirements{RequiresLinearHistory: true},
+			},
+			MergeConfig: MergeConfig{Method: SquashAndMerge},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			unmet, err := UnmetBranchRequirement(context.Background(), test.PullContext, test.MergeConfig)
+			require.NoError(t, err)
+			assert.Equal(t, test.Unmet, unmet)
+		})
+	}
+}
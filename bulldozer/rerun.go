@@ -0,0 +1,157 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/pull"
+	"github.com/pkg/errors"
+)
+
+type RerunConfig struct {
+	// Checks are the names of check runs that fail intermittently. Names may
+	// be globs or regular expressions, as described by nameMatches.
+	Checks []string `yaml:"checks"`
+
+	// MaxAttempts is the number of times each check run is requested again
+	// for the same head commit. If zero, check runs are not requested again.
+	MaxAttempts int `yaml:"max_attempts"`
+}
+
+// RerunnableChecks returns the failed check runs that match the rerun
+// configuration if they are the only reason the pull request is not
+// mergeable. It returns nil if the pull request is blocked by anything else.
+func RerunnableChecks(ctx context.Context, pullCtx pull.Context, mergeConfig MergeConfig) ([]*pull.CheckRun, error) {
+	config := mergeConfig.Rerun
+	if len(config.Checks) == 0 || config.MaxAttempts <= 0 {
+		return nil, nil
+	}
+
+	checkRuns, err := pullCtx.CheckRuns(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list check runs")
+	}
+
+	conclusions := pullCtx.CheckConclusions()
+	var failed []*pull.CheckRun
+	var names []string
+	for _, run := range checkRuns {
+		if conclusions.Result(run) != pull.CheckFailure {
+			continue
+		}
+		for _, name := range config.Checks {
+			if nameMatches(name, run.Name) {
+				failed = append(failed, run)
+				names = append(names, run.Name)
+				break
+			}
+		}
+	}
+	if len(failed) == 0 {
+		return nil, nil
+	}
+
+	// evaluate the pull request as if the check runs succeeded to find out
+	// if anything else prevents the merge
+	mergeable, _, err := ExplainMergePR(ctx, &rerunContext{Context: pullCtx, succeeded: names}, mergeConfig)
+	if err != nil {
+		return nil, err
+	}
+	if !mergeable {
+		return nil, nil
+	}
+	return failed, nil
+}
+
+// rerunContext is a pull request context where the named check runs are
+// successful.
+type rerunContext struct {
+	pull.Context
+	succeeded []string
+}
+
+func (c *rerunContext) CurrentSuccessStatuses(ctx context.Context) ([]string, error) {
+	statuses, err := c.Context.CurrentSuccessStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]string{}, statuses...), c.succeeded...), nil
+}
+
+// RerunChecks requests the check runs again, which restarts them if the
+// provider of the check supports it.
+func RerunChecks(ctx context.Context, pullCtx pull.Context, client *github.Client, checkRuns []*pull.CheckRun) error {
+	for _, run := range checkRuns {
+		if _, err := client.Checks.ReRequestCheckRun(ctx, pullCtx.Owner(), pullCtx.Repo(), run.ID); err != nil {
+			return errors.Wrapf(err, "failed to request check run %q again for %s", run.Name, pullCtx.Locator())
+		}
+	}
+	return nil
+}
+
+type rerunEntry struct {
+	sha      string
+	attempts map[string]int
+}
+
+// RerunTracker counts the times check runs were requested again for the
+// head commits of pull requests. It is safe for concurrent use.
+type RerunTracker struct {
+	mu      sync.Mutex
+	entries map[pullKey]*rerunEntry
+}
+
+func NewRerunTracker() *RerunTracker {
+	return &RerunTracker{
+		entries: make(map[pullKey]*rerunEntry),
+	}
+}
+
+// Next records that the named check runs are requested again for the head
+// commit of the pull request. It returns false and records nothing if any
+// check run was already requested again the maximum number of times.
+func (t *RerunTracker) Next(pullCtx pull.Context, names []string, maxAttempts int) bool {
+	key := newPullKey(pullCtx)
+	sha := pullCtx.HeadSHA()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entry, ok := t.entries[key]
+	if !ok || entry.sha != sha {
+		entry = &rerunEntry{sha: sha, attempts: make(map[string]int)}
+		t.entries[key] = entry
+	}
+
+	for _, name := range names {
+		if entry.attempts[name] >= maxAttempts {
+			return false
+		}
+	}
+	for _, name := range names {
+		entry.attempts[name]++
+	}
+	return true
+}
+
+// Reset clears the check runs recorded for the pull request.
+func (t *RerunTracker) Reset(pullCtx pull.Context) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.entries, newPullKey(pullCtx))
+}
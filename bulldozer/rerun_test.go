@@ -0,0 +1,156 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/pull"
+	"github.com/palantir/bulldozer/pull/pulltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRerunnableChecks(t *testing.T) {
+	ctx := context.Background()
+
+	mergeConfig := MergeConfig{
+		Trigger: Signals{
+			Labels: []string{"merge when ready"},
+		},
+		RequiredStatuses: []string{"build", "e2e (ubuntu)"},
+		Rerun: RerunConfig{
+			Checks:      []string{"e2e (*)"},
+			MaxAttempts: 2,
+		},
+	}
+
+	tests := map[string]struct {
+		Config    *RerunConfig
+		Labels    []string
+		Success   []string
+		CheckRuns []*pull.CheckRun
+		Rerun     []string
+	}{
+		"flakyCheck": {
+			Labels:  []string{"merge when ready"},
+			Success: []string{"build"},
+			CheckRuns: []*pull.CheckRun{
+				{ID: 1, Name: "build", Status: "completed", Conclusion: "success"},
+				{ID: 2, Name: "e2e (ubuntu)", Status: "completed", Conclusion: "failure"},
+			},
+			Rerun: []string{"e2e (ubuntu)"},
+		},
+		"otherFailedCheck": {
+			Labels: []string{"merge when ready"},
+			CheckRuns: []*pull.CheckRun{
+				{ID: 1, Name: "build", Status: "completed", Conclusion: "failure"},
+				{ID: 2, Name: "e2e (ubuntu)", Status: "completed", Conclusion: "failure"},
+			},
+		},
+		"notTriggered": {
+			Success: []string{"build"},
+			CheckRuns: []*pull.CheckRun{
+				{ID: 1, Name: "build", Status: "completed", Conclusion: "success"},
+				{ID: 2, Name: "e2e (ubuntu)", Status: "completed", Conclusion: "failure"},
+			},
+		},
+		"pendingCheck": {
+			Labels:  []string{"merge when ready"},
+			Success: []string{"build"},
+			CheckRuns: []*pull.CheckRun{
+				{ID: 1, Name: "build", Status: "completed", Conclusion: "success"},
+				{ID: 2, Name: "e2e (ubuntu)", Status: "in_progress"},
+			},
+		},
+		"disabled": {
+			Config:  &RerunConfig{Checks: []string{"e2e (*)"}},
+			Labels:  []string{"merge when ready"},
+			Success: []string{"build"},
+			CheckRuns: []*pull.CheckRun{
+				{ID: 2, Name: "e2e (ubuntu)", Status: "completed", Conclusion: "failure"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := mergeConfig
+			if test.Config != nil {
+				config.Rerun = *test.Config
+			}
+			pullCtx := &pulltest.MockPullContext{
+				LabelValue:           test.Labels,
+				SuccessStatusesValue: test.Success,
+				CheckRunsValue:       test.CheckRuns,
+			}
+
+			checkRuns, err := RerunnableChecks(ctx, pullCtx, config)
+			require.NoError(t, err)
+
+			var names []string
+			for _, run := range checkRuns {
+				names = append(names, run.Name)
+			}
+			assert.Equal(t, test.Rerun, names)
+		})
+	}
+}
+
+func TestRerunChecks(t *testing.T) {
+	var requested []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/testorg/testrepo/check-runs/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		requested = append(requested, r.URL.Path)
+		w.WriteHeader(http.StatusCreated)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	pullCtx := &pulltest.MockPullContext{OwnerValue: "testorg", RepoValue: "testrepo", NumberValue: 1}
+	err := RerunChecks(context.Background(), pullCtx, client, []*pull.CheckRun{{ID: 2, Name: "e2e (ubuntu)"}, {ID: 3, Name: "e2e (macos)"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"/repos/testorg/testrepo/check-runs/2/rerequest",
+		"/repos/testorg/testrepo/check-runs/3/rerequest",
+	}, requested)
+}
+
+func TestRerunTracker(t *testing.T) {
+	tracker := NewRerunTracker()
+	pullCtx := &pulltest.MockPullContext{OwnerValue: "testorg", RepoValue: "testrepo", NumberValue: 1, HeadSHAValue: "abc"}
+
+	assert.True(t, tracker.Next(pullCtx, []string{"e2e (ubuntu)"}, 2))
+	assert.True(t, tracker.Next(pullCtx, []string{"e2e (ubuntu)", "e2e (macos)"}, 2))
+	assert.False(t, tracker.Next(pullCtx, []string{"e2e (macos)", "e2e (ubuntu)"}, 2), "requested again after the maximum attempts")
+	assert.True(t, tracker.Next(pullCtx, []string{"e2e (macos)"}, 2), "attempts recorded after reaching the maximum")
+	assert.False(t, tracker.Next(pullCtx, []string{"e2e (macos)"}, 2))
+
+	pullCtx.HeadSHAValue = "def"
+	assert.True(t, tracker.Next(pullCtx, []string{"e2e (ubuntu)"}, 2), "attempts not reset for a new head commit")
+
+	tracker.Next(pullCtx, []string{"e2e (ubuntu)"}, 2)
+	tracker.Reset(pullCtx)
+	assert.True(t, tracker.Next(pullCtx, []string{"e2e (ubuntu)"}, 2))
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/palantir/bulldozer/pull"
+)
+
+const (
+	DefaultRetryBackoff = time.Minute
+	MaxRetryBackoff     = time.Hour
+)
+
+type RetryConfig struct {
+	// MaxAttempts is the number of times to attempt a failed merge again. If
+	// zero, failed merges are attempted again on the next event.
+	MaxAttempts int `yaml:"max_attempts"`
+
+	// Backoff is the time to wait before the first retry. The time doubles
+	// after each retry. If zero, DefaultRetryBackoff is used.
+	Backoff Duration `yaml:"backoff"`
+}
+
+// RetryTracker counts failed merge attempts for pull requests. It is safe for
+// concurrent use.
+type RetryTracker struct {
+	mu       sync.Mutex
+	attempts map[pullKey]int
+}
+
+func NewRetryTracker() *RetryTracker {
+	return &RetryTracker{
+		attempts: make(map[pullKey]int),
+	}
+}
+
+// Next records a failed merge and returns how long to wait before attempting
+// the merge again. It returns false if the merge failed too many times, after
+// which the count starts again.
+func (t *RetryTracker) Next(pullCtx pull.Context, config RetryConfig) (time.Duration, bool) {
+	key := newPullKey(pullCtx)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	attempt := t.attempts[key]
+	if attempt >= config.MaxAttempts {
+		delete(t.attempts, key)
+		return 0, false
+	}
+	t.attempts[key] = attempt + 1
+
+	backoff := time.Duration(config.Backoff)
+	if backoff <= 0 {
+		backoff = DefaultRetryBackoff
+	}
+	for i := 0; i < attempt && backoff < MaxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > MaxRetryBackoff {
+		backoff = MaxRetryBackoff
+	}
+	return backoff, true
+}
+
+// Attempts returns the number of failed merges recorded for the pull request.
+func (t *RetryTracker) Attempts(pullCtx pull.Context) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.attempts[newPullKey(pullCtx)]
+}
+
+// Reset clears the failed merges recorded for the pull request.
+func (t *RetryTracker) Reset(pullCtx pull.Context) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.attempts, newPullKey(pullCtx))
+}
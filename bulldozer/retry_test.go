@@ -0,0 +1,60 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/palantir/bulldozer/pull/pulltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryTracker(t *testing.T) {
+	tracker := NewRetryTracker()
+	pullCtx := &pulltest.MockPullContext{OwnerValue: "testorg", RepoValue: "testrepo", NumberValue: 1}
+
+	t.Run("exponentialBackoff", func(t *testing.T) {
+		config := RetryConfig{MaxAttempts: 3, Backoff: Duration(10 * time.Second)}
+
+		for _, expected := range []time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second} {
+			backoff, ok := tracker.Next(pullCtx, config)
+			assert.True(t, ok)
+			assert.Equal(t, expected, backoff)
+		}
+		assert.Equal(t, 3, tracker.Attempts(pullCtx))
+
+		_, ok := tracker.Next(pullCtx, config)
+		assert.False(t, ok, "retried after the maximum attempts")
+		assert.Equal(t, 0, tracker.Attempts(pullCtx))
+	})
+
+	t.Run("maximumBackoff", func(t *testing.T) {
+		config := RetryConfig{MaxAttempts: 20}
+
+		var backoff time.Duration
+		for i := 0; i < 20; i++ {
+			backoff, _ = tracker.Next(pullCtx, config)
+		}
+		assert.Equal(t, MaxRetryBackoff, backoff)
+		tracker.Reset(pullCtx)
+		assert.Equal(t, 0, tracker.Attempts(pullCtx))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		_, ok := tracker.Next(pullCtx, RetryConfig{})
+		assert.False(t, ok)
+	})
+}
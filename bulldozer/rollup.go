@@ -0,0 +1,251 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/pull"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+const (
+	DefaultRollupBranch  = "bulldozer/rollup"
+	DefaultRollupMaxSize = 10
+)
+
+// RollupConfig enables merging several pull requests together. Instead of
+// merging pull requests that match the trigger one at a time, bulldozer merges
+// them into a rollup branch and opens a rollup pull request. When the rollup
+// pull request is ready to merge, bulldozer merges it with a merge commit,
+// which also marks the included pull requests as merged.
+type RollupConfig struct {
+	// Trigger selects the pull requests that are merged in rollups. Pull
+	// requests must also be ready to merge.
+	Trigger Signals `yaml:"trigger"`
+
+	// Branch is the prefix of rollup branches. The name of the base branch is
+	// appended to it. If empty, DefaultRollupBranch is used.
+	Branch string `yaml:"branch"`
+
+	// MaxSize is the maximum number of pull requests in a rollup. If zero,
+	// DefaultRollupMaxSize is used.
+	MaxSize int `yaml:"max_size"`
+}
+
+// BranchFor returns the name of the rollup branch for the base branch.
+func (r RollupConfig) BranchFor(base string) string {
+	branch := r.Branch
+	if branch == "" {
+		branch = DefaultRollupBranch
+	}
+	return fmt.Sprintf("%s/%s", strings.TrimSuffix(branch, "/"), base)
+}
+
+func (r RollupConfig) maxSize() int {
+	if r.MaxSize <= 0 {
+		return DefaultRollupMaxSize
+	}
+	return r.MaxSize
+}
+
+// IsRollupPR returns true if the pull request is a rollup pull request.
+func IsRollupPR(pullCtx pull.Context, rollup RollupConfig) bool {
+	base, head := pullCtx.Branches()
+	return head == rollup.BranchFor(base)
+}
+
+// RollupMergeConfig returns the configuration used to merge rollup pull
+// requests. Rollups are merged with merge commits so that GitHub marks the
+// included pull requests as merged, and they ignore the triggers for other
+// pull requests.
+func RollupMergeConfig(mergeConfig MergeConfig) MergeConfig {
+	return MergeConfig{
+		Method:                 MergeCommit,
+		DeleteAfterMerge:       true,
+		AllowMergeWithNoChecks: mergeConfig.AllowMergeWithNoChecks,
+		RequiredStatuses:       mergeConfig.RequiredStatuses,
+		PolicyBot:              mergeConfig.PolicyBot,
+		Disabled:               mergeConfig.Disabled,
+		BlackoutWindows:        mergeConfig.BlackoutWindows,
+	}
+}
+
+const rollupConflictsHeading = "These pull requests were left out because they have conflicts:"
+
+// rollupBody describes the pull requests included in a rollup and those left
+// out because of conflicts, so that later evaluations can tell whether the
+// rollup is up to date.
+func rollupBody(included, conflicting []*github.PullRequest) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "This pull request was created by bulldozer to merge the following pull requests together:\n\n")
+	for _, pr := range included {
+		fmt.Fprintf(&b, "- #%d at %s\n", pr.GetNumber(), pr.GetHead().GetSHA())
+	}
+	if len(conflicting) > 0 {
+		fmt.Fprintf(&b, "\n%s\n\n", rollupConflictsHeading)
+		for _, pr := range conflicting {
+			fmt.Fprintf(&b, "- #%d at %s\n", pr.GetNumber(), pr.GetHead().GetSHA())
+		}
+	}
+	return b.String()
+}
+
+// splitRollup splits the pull requests into those expected in the rollup and
+// those that the rollup body records as left out because of conflicts at
+// their current head.
+func splitRollup(body string, prs []*github.PullRequest) (included, conflicting []*github.PullRequest) {
+	var left string
+	if i := strings.Index(body, rollupConflictsHeading); i >= 0 {
+		left = body[i:]
+	}
+	for _, pr := range prs {
+		if strings.Contains(left, fmt.Sprintf("- #%d at %s\n", pr.GetNumber(), pr.GetHead().GetSHA())) {
+			conflicting = append(conflicting, pr)
+		} else {
+			included = append(included, pr)
+		}
+	}
+	return included, conflicting
+}
+
+// UpdateRollup merges the pull requests into the rollup branch for the base
+// branch and opens or updates the rollup pull request. The branch is created
+// again from the base branch whenever the pull requests or their heads change.
+// Pull requests that conflict with the base branch or with earlier pull
+// requests are left out of the rollup. If all pull requests are left out, it
+// returns nil.
+func UpdateRollup(ctx context.Context, client *github.Client, owner, repo, base string, prs []*github.PullRequest, rollup RollupConfig) (*github.PullRequest, error) {
+	logger := zerolog.Ctx(ctx)
+	branch := rollup.BranchFor(base)
+
+	prs = append([]*github.PullRequest(nil), prs...)
+	sort.Slice(prs, func(i, j int) bool { return prs[i].GetNumber() < prs[j].GetNumber() })
+	if len(prs) > rollup.maxSize() {
+		prs = prs[:rollup.maxSize()]
+	}
+
+	existing, _, err := client.PullRequests.List(ctx, owner, repo, &github.PullRequestListOptions{
+		State: "open",
+		Head:  fmt.Sprintf("%s:%s", owner, branch),
+		Base:  base,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list rollup pull requests")
+	}
+
+	var rollupPR *github.PullRequest
+	if len(existing) > 0 {
+		rollupPR = existing[0]
+
+		// pull requests that had conflicts at the same head are left out
+		// again, so they do not make an unchanged rollup look stale
+		if rollupPR.GetBody() == rollupBody(splitRollup(rollupPR.GetBody(), prs)) {
+			logger.Debug().Msgf("Rollup %s is up to date", branch)
+			return rollupPR, nil
+		}
+	}
+
+	baseRef, _, err := client.Git.GetRef(ctx, owner, repo, "heads/"+base)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get ref for %s", base)
+	}
+
+	ref := &github.Reference{
+		Ref:    github.String("refs/heads/" + branch),
+		Object: &github.GitObject{SHA: baseRef.GetObject().SHA},
+	}
+	if _, _, err := client.Git.UpdateRef(ctx, owner, repo, ref, true); err != nil {
+		if !isMissingRef(err) {
+			return nil, errors.Wrapf(err, "failed to reset %s", branch)
+		}
+		if _, _, err := client.Git.CreateRef(ctx, owner, repo, ref); err != nil {
+			return nil, errors.Wrapf(err, "failed to create %s", branch)
+		}
+	}
+
+	var included, conflicting []*github.PullRequest
+	for _, pr := range prs {
+		_, _, err := client.Repositories.Merge(ctx, owner, repo, &github.RepositoryMergeRequest{
+			Base:          github.String(branch),
+			Head:          github.String(pr.GetHead().GetSHA()),
+			CommitMessage: github.String(fmt.Sprintf("Merge pull request #%d from %s\n\n%s", pr.GetNumber(), pr.GetHead().GetLabel(), pr.GetTitle())),
+		})
+		if err != nil {
+			if rerr, ok := errors.Cause(err).(*github.ErrorResponse); ok && rerr.Response.StatusCode == http.StatusConflict {
+				logger.Info().Msgf("Leaving #%d out of rollup %s because it has conflicts", pr.GetNumber(), branch)
+				conflicting = append(conflicting, pr)
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to merge #%d into %s", pr.GetNumber(), branch)
+		}
+		included = append(included, pr)
+	}
+
+	if len(included) == 0 {
+		logger.Info().Msgf("Not updating rollup %s because no pull requests could be merged into it", branch)
+		return nil, nil
+	}
+
+	body := rollupBody(included, conflicting)
+	title := fmt.Sprintf("Rollup of %d pull requests", len(included))
+
+	if rollupPR != nil {
+		rollupPR, _, err = client.PullRequests.Edit(ctx, owner, repo, rollupPR.GetNumber(), &github.PullRequest{
+			Title: github.String(title),
+			Body:  github.String(body),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to update rollup pull request")
+		}
+	} else {
+		rollupPR, _, err = client.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+			Title: github.String(title),
+			Head:  github.String(branch),
+			Base:  github.String(base),
+			Body:  github.String(body),
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create rollup pull request")
+		}
+	}
+
+	logger.Info().Msgf("Updated rollup %s with %d pull requests", rollupPR.GetHTMLURL(), len(included))
+	return rollupPR, nil
+}
+
+// isMissingRef returns true if the error is from updating a ref that does not
+// exist, which GitHub reports as an unprocessable entity.
+func isMissingRef(err error) bool {
+	if rerr, ok := errors.Cause(err).(*github.ErrorResponse); ok {
+		return rerr.Response.StatusCode == http.StatusNotFound || rerr.Response.StatusCode == http.StatusUnprocessableEntity
+	}
+	return false
+}
+
+// isExistingRef returns true if the error is from creating a ref that already
+// exists, which GitHub reports as an unprocessable entity.
+func isExistingRef(err error) bool {
+	if rerr, ok := errors.Cause(err).(*github.ErrorResponse); ok {
+		return rerr.Response.StatusCode == http.StatusUnprocessableEntity && strings.Contains(rerr.Message, "already exists")
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/pull/pulltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateRollup(t *testing.T) {
+	var created *github.NewPullRequest
+	var merged []string
+	var refCreated bool
+	existing := `[]`
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/testorg/testrepo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			assert.Equal(t, "testorg:bulldozer/rollup/develop", r.URL.Query().Get("head"))
+			_, _ = w.Write([]byte(existing))
+			return
+		}
+		created = &github.NewPullRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(created))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"number": 10}`))
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/pulls/10", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		_, _ = w.Write([]byte(`{"number": 10}`))
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/git/ref/heads/develop", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ref": "refs/heads/develop", "object": {"sha": "base"}}`))
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/git/refs/heads/bulldozer/rollup/develop", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message": "Reference does not exist"}`))
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/git/refs", func(w http.ResponseWriter, r *http.Request) {
+		var ref struct {
+			Ref string `json:"ref"`
+			SHA string `json:"sha"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&ref))
+		assert.Equal(t, "refs/heads/bulldozer/rollup/develop", ref.Ref)
+		assert.Equal(t, "base", ref.SHA)
+		refCreated = true
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/merges", func(w http.ResponseWriter, r *http.Request) {
+		var req github.RepositoryMergeRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "bulldozer/rollup/develop", req.GetBase())
+		if req.GetHead() == "conflict" {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"message": "Merge conflict"}`))
+			return
+		}
+		merged = append(merged, req.GetHead())
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	prs := []*github.PullRequest{
+		{Number: github.Int(3), Head: &github.PullRequestBranch{SHA: github.String("c")}},
+		{Number: github.Int(1), Head: &github.PullRequestBranch{SHA: github.String("a")}},
+		{Number: github.Int(2), Head: &github.PullRequestBranch{SHA: github.String("conflict")}},
+	}
+
+	ctx := context.Background()
+	rollupPR, err := UpdateRollup(ctx, client, "testorg", "testrepo", "develop", prs, RollupConfig{})
+	require.NoError(t, err)
+	require.NotNil(t, rollupPR)
+
+	assert.True(t, refCreated, "rollup branch was not created")
+	assert.Equal(t, []string{"a", "c"}, merged)
+	require.NotNil(t, created)
+	assert.Equal(t, "Rollup of 2 pull requests", created.GetTitle())
+	assert.Equal(t, "bulldozer/rollup/develop", created.GetHead())
+	body := "This pull request was created by bulldozer to merge the following pull requests together:\n\n- #1 at a\n- #3 at c\n\n" +
+		"These pull requests were left out because they have conflicts:\n\n- #2 at conflict\n"
+	assert.Equal(t, body, created.GetBody())
+
+	// the rollup is up to date if only the pull requests that had conflicts
+	// are left out
+	encoded, err := json.Marshal([]*github.PullRequest{{Number: github.Int(10), Body: github.String(body)}})
+	require.NoError(t, err)
+	existing = string(encoded)
+	merged, refCreated = nil, false
+
+	rollupPR, err = UpdateRollup(ctx, client, "testorg", "testrepo", "develop", prs, RollupConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, 10, rollupPR.GetNumber())
+	assert.False(t, refCreated, "rollup branch was reset")
+	assert.Empty(t, merged)
+
+	// a new head of a pull request that had conflicts rebuilds the rollup
+	prs[2] = &github.PullRequest{Number: github.Int(2), Head: &github.PullRequestBranch{SHA: github.String("b")}}
+	_, err = UpdateRollup(ctx, client, "testorg", "testrepo", "develop", prs, RollupConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, merged)
+}
+
+func TestIsRollupPR(t *testing.T) {
+	rollup := RollupConfig{Branch: "rollups/"}
+
+	assert.True(t, IsRollupPR(&pulltest.MockPullContext{BranchBase: "develop", BranchName: "rollups/develop"}, rollup))
+	assert.False(t, IsRollupPR(&pulltest.MockPullContext{BranchBase: "main", BranchName: "rollups/develop"}, rollup))
+	assert.False(t, IsRollupPR(&pulltest.MockPullContext{BranchBase: "develop", BranchName: "feature"}, rollup))
+}
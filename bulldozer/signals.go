@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/palantir/bulldozer/pull"
 	"github.com/pkg/errors"
@@ -37,20 +38,187 @@ type LabelsSignal []string
 type CommentSubstringsSignal []string
 type CommentsSignal []string
 type PRBodySubstringsSignal []string
+
+// TitlePatternsSignal and BodyPatternsSignal list regular expressions that
+// match anywhere in the title or body of the pull request.
+type TitlePatternsSignal []string
+type BodyPatternsSignal []string
 type BranchesSignal []string
 type BranchPatternsSignal []string
+
+// HeadBranchesSignal lists patterns for the head branch of the pull request,
+// which may be globs or regular expressions, as described by nameMatches.
+type HeadBranchesSignal []string
 type MaxCommitsSignal int
 type AutoMergeSignal bool
 
+// CheckRunsSignal maps check run names to conclusions. The special conclusion
+// "pending" matches check runs that are not completed. Names may be globs or
+// regular expressions, as described by nameMatches.
+type CheckRunsSignal map[string][]string
+
+// StatusesSignal maps commit status contexts to states, like "success" or
+// "pending". Contexts may be globs or regular expressions, as described by
+// nameMatches.
+type StatusesSignal map[string][]string
+
+// ChecksSignal matches the combined results of the commit statuses and check
+// runs for the head commit, for repositories where the set of checks varies
+// and cannot be listed by name.
+type ChecksSignal struct {
+	// MinSuccessful is the minimum number of successful statuses and check
+	// runs
+	MinSuccessful int `yaml:"min_successful"`
+
+	// AllComplete requires at least one status or check run and that all of
+	// them are complete and none failed
+	AllComplete bool `yaml:"all_complete"`
+}
+
+// DeploymentsSignal maps environment names to deployment states. It matches
+// the state of the latest deployment of the head commit to each environment.
+type DeploymentsSignal map[string][]string
+
+// MilestonesSignal lists milestone titles, which may be globs or regular
+// expressions, as described by nameMatches.
+type MilestonesSignal []string
+
+// ProjectFieldsSignal maps the names of project fields to values. It matches
+// the field values of any project item for the pull request. Names and
+// values are case-insensitive.
+type ProjectFieldsSignal map[string][]string
+
+// PathsSignal matches if any file changed by the pull request matches one of
+// the globs.
+type PathsSignal []string
+
+// OnlyPathsSignal matches if every file changed by the pull request matches
+// one of the globs.
+type OnlyPathsSignal []string
+
+// DeniedFilesSignal matches if the pull request changes a file that matches
+// one of the globs or that is larger than the maximum size, so that changes
+// to sensitive or binary files are never merged automatically.
+type DeniedFilesSignal struct {
+	// Paths are globs for files that must not be changed
+	Paths []string `yaml:"paths"`
+
+	// MaxSize is the maximum size of each changed file at the head commit
+	MaxSize ByteSize `yaml:"max_size"`
+
+	// Comment posts the reason on pull requests that are ignored because of
+	// the signal
+	Comment bool `yaml:"comment"`
+}
+
+// MaxChangedLinesSignal matches if the pull request adds and deletes more than
+// this number of lines in total.
+type MaxChangedLinesSignal int
+
+// MaxChangedFilesSignal matches if the pull request changes more than this
+// number of files.
+type MaxChangedFilesSignal int
+
+// MinAgeSignal matches if the pull request was opened less than this long ago.
+// It is meant for ignore, to give reviewers a cooling-off period.
+type MinAgeSignal Duration
+
+// StaleApprovalsSignal matches if the most recent approval was submitted for
+// an older head commit, meaning commits were pushed after it.
+type StaleApprovalsSignal bool
+
+// OpenDependenciesSignal matches if the pull request declares dependencies
+// with "Depends-on" lines in its body and any of them is an open issue or a
+// pull request that is not merged.
+type OpenDependenciesSignal bool
+
+// TicketsSignal matches if the title or head branch of the pull request
+// refers to a ticket in the issue tracker defined by the server that is not
+// in one of Statuses. Tickets are found with Pattern, which defaults to keys
+// like "ABC-123". If Required is true, it also matches pull requests that do
+// not refer to any tickets.
+type TicketsSignal struct {
+	Statuses []string `yaml:"statuses"`
+	Pattern  string   `yaml:"pattern"`
+	Required bool     `yaml:"required"`
+}
+
+// ChangesRequestedSignal matches if the latest review from any user requests
+// changes and was not dismissed, even if branch protection does not block
+// merging because of it.
+type ChangesRequestedSignal bool
+
+// PendingReviewersSignal matches if any user or team whose review is
+// requested has not submitted a review.
+type PendingReviewersSignal bool
+
+// CodeOwnersSignal matches if, for each entry of the CODEOWNERS file that
+// applies to a file changed by the pull request, an owner approved the pull
+// request. Teams approve if any member approves.
+type CodeOwnersSignal bool
+
+// AuthorsSignal matches pull requests opened by any of the users or members of
+// any of the teams. Teams are formatted as "org/team-slug".
+type AuthorsSignal []string
+
+// AllOfSignal matches if the pull request matches every group of signals. Like
+// a trigger, a group matches if any of its signals match.
+type AllOfSignal []Signals
+
+// AnyOfSignal matches if the pull request matches any group of signals.
+type AnyOfSignal []Signals
+
+// NotSignal matches if the pull request does not match the group of signals.
+type NotSignal Signals
+
+type ApprovalsSignal struct {
+	// Count is the minimum number of approvals
+	Count int `yaml:"count"`
+
+	// Teams limits the approvals that count to members of these teams,
+	// formatted as "org/team-slug" or "team-slug" for teams in the same
+	// organization as the repository
+	Teams []string `yaml:"teams"`
+}
+
 type Signals struct {
 	Labels            LabelsSignal            `yaml:"labels"`
 	CommentSubstrings CommentSubstringsSignal `yaml:"comment_substrings"`
 	Comments          CommentsSignal          `yaml:"comments"`
 	PRBodySubstrings  PRBodySubstringsSignal  `yaml:"pr_body_substrings"`
+	TitlePatterns     TitlePatternsSignal     `yaml:"title_patterns"`
+	BodyPatterns      BodyPatternsSignal      `yaml:"body_patterns"`
 	Branches          BranchesSignal          `yaml:"branches"`
 	BranchPatterns    BranchPatternsSignal    `yaml:"branch_patterns"`
+	HeadBranches      HeadBranchesSignal      `yaml:"head_branches"`
 	MaxCommits        MaxCommitsSignal        `yaml:"max_commits"`
 	AutoMerge         AutoMergeSignal         `yaml:"auto_merge"`
+	Approvals         ApprovalsSignal         `yaml:"approvals"`
+	CheckRuns         CheckRunsSignal         `yaml:"check_runs"`
+	Statuses          StatusesSignal          `yaml:"statuses"`
+	Checks            ChecksSignal            `yaml:"checks"`
+	Deployments       DeploymentsSignal       `yaml:"deployments"`
+	Milestones        MilestonesSignal        `yaml:"milestones"`
+	ProjectFields     ProjectFieldsSignal     `yaml:"project_fields"`
+	Paths             PathsSignal             `yaml:"paths"`
+	OnlyPaths         OnlyPathsSignal         `yaml:"only_paths"`
+	DeniedFiles       DeniedFilesSignal       `yaml:"denied_files"`
+	MaxChangedLines   MaxChangedLinesSignal   `yaml:"max_changed_lines"`
+	MaxChangedFiles   MaxChangedFilesSignal   `yaml:"max_changed_files"`
+	Authors           AuthorsSignal           `yaml:"authors"`
+	MinAge            MinAgeSignal            `yaml:"min_age"`
+	StaleApprovals    StaleApprovalsSignal    `yaml:"stale_approvals"`
+	OpenDependencies  OpenDependenciesSignal  `yaml:"open_dependencies"`
+	Tickets           TicketsSignal           `yaml:"tickets"`
+	ChangesRequested  ChangesRequestedSignal  `yaml:"changes_requested"`
+	PendingReviewers  PendingReviewersSignal  `yaml:"pending_reviewers"`
+	CodeOwners        CodeOwnersSignal        `yaml:"code_owners"`
+
+	// AllOf, AnyOf, and Not combine groups of signals, so that a trigger can
+	// require more than one signal to match
+	AllOf AllOfSignal `yaml:"all_of"`
+	AnyOf AnyOfSignal `yaml:"any_of"`
+	Not   *NotSignal  `yaml:"not"`
 }
 
 func (signal LabelsSignal) Enabled() bool {
@@ -69,6 +237,14 @@ func (signal PRBodySubstringsSignal) Enabled() bool {
 	return len(signal) > 0
 }
 
+func (signal TitlePatternsSignal) Enabled() bool {
+	return len(signal) > 0
+}
+
+func (signal BodyPatternsSignal) Enabled() bool {
+	return len(signal) > 0
+}
+
 func (signal BranchesSignal) Enabled() bool {
 	return len(signal) > 0
 }
@@ -77,6 +253,10 @@ func (signal BranchPatternsSignal) Enabled() bool {
 	return len(signal) > 0
 }
 
+func (signal HeadBranchesSignal) Enabled() bool {
+	return len(signal) > 0
+}
+
 func (signal MaxCommitsSignal) Enabled() bool {
 	return signal > 0
 }
@@ -85,15 +265,137 @@ func (signal AutoMergeSignal) Enabled() bool {
 	return bool(signal)
 }
 
+func (signal ApprovalsSignal) Enabled() bool {
+	return signal.Count > 0
+}
+
+func (signal CheckRunsSignal) Enabled() bool {
+	return len(signal) > 0
+}
+
+func (signal StatusesSignal) Enabled() bool {
+	return len(signal) > 0
+}
+
+func (signal ChecksSignal) Enabled() bool {
+	return signal.MinSuccessful > 0 || signal.AllComplete
+}
+
+func (signal DeploymentsSignal) Enabled() bool {
+	return len(signal) > 0
+}
+
+func (signal MilestonesSignal) Enabled() bool {
+	return len(signal) > 0
+}
+
+func (signal ProjectFieldsSignal) Enabled() bool {
+	return len(signal) > 0
+}
+
+func (signal PathsSignal) Enabled() bool {
+	return len(signal) > 0
+}
+
+func (signal OnlyPathsSignal) Enabled() bool {
+	return len(signal) > 0
+}
+
+func (signal DeniedFilesSignal) Enabled() bool {
+	return len(signal.Paths) > 0 || signal.MaxSize > 0
+}
+
+func (signal MaxChangedLinesSignal) Enabled() bool {
+	return signal > 0
+}
+
+func (signal MaxChangedFilesSignal) Enabled() bool {
+	return signal > 0
+}
+
+func (signal AuthorsSignal) Enabled() bool {
+	return len(signal) > 0
+}
+
+func (signal *MinAgeSignal) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return (*Duration)(signal).UnmarshalYAML(unmarshal)
+}
+
+func (signal MinAgeSignal) Enabled() bool {
+	return signal > 0
+}
+
+func (signal StaleApprovalsSignal) Enabled() bool {
+	return bool(signal)
+}
+
+func (signal OpenDependenciesSignal) Enabled() bool {
+	return bool(signal)
+}
+
+func (signal TicketsSignal) Enabled() bool {
+	return len(signal.Statuses) > 0
+}
+
+func (signal ChangesRequestedSignal) Enabled() bool {
+	return bool(signal)
+}
+
+func (signal PendingReviewersSignal) Enabled() bool {
+	return bool(signal)
+}
+
+func (signal CodeOwnersSignal) Enabled() bool {
+	return bool(signal)
+}
+
+func (signal AllOfSignal) Enabled() bool {
+	return len(signal) > 0
+}
+
+func (signal AnyOfSignal) Enabled() bool {
+	return len(signal) > 0
+}
+
+func (signal *NotSignal) Enabled() bool {
+	return signal != nil && Signals(*signal).Enabled()
+}
+
 func (s Signals) Enabled() bool {
 	return s.Labels.Enabled() ||
 		s.CommentSubstrings.Enabled() ||
 		s.Comments.Enabled() ||
 		s.PRBodySubstrings.Enabled() ||
+		s.TitlePatterns.Enabled() ||
+		s.BodyPatterns.Enabled() ||
 		s.Branches.Enabled() ||
 		s.BranchPatterns.Enabled() ||
+		s.HeadBranches.Enabled() ||
 		s.MaxCommits.Enabled() ||
-		s.AutoMerge.Enabled()
+		s.AutoMerge.Enabled() ||
+		s.Approvals.Enabled() ||
+		s.CheckRuns.Enabled() ||
+		s.Statuses.Enabled() ||
+		s.Checks.Enabled() ||
+		s.Deployments.Enabled() ||
+		s.Milestones.Enabled() ||
+		s.ProjectFields.Enabled() ||
+		s.Paths.Enabled() ||
+		s.OnlyPaths.Enabled() ||
+		s.DeniedFiles.Enabled() ||
+		s.MaxChangedLines.Enabled() ||
+		s.MaxChangedFiles.Enabled() ||
+		s.Authors.Enabled() ||
+		s.MinAge.Enabled() ||
+		s.StaleApprovals.Enabled() ||
+		s.OpenDependencies.Enabled() ||
+		s.Tickets.Enabled() ||
+		s.ChangesRequested.Enabled() ||
+		s.PendingReviewers.Enabled() ||
+		s.CodeOwners.Enabled() ||
+		s.AllOf.Enabled() ||
+		s.AnyOf.Enabled() ||
+		s.Not.Enabled()
 }
 
 // MatchesAll returns true if the pull request matches ALL of the signals. It also
@@ -110,10 +412,36 @@ func (s Signals) MatchesAll(ctx context.Context, pullCtx pull.Context, tag strin
 		&s.CommentSubstrings,
 		&s.Comments,
 		&s.PRBodySubstrings,
+		&s.TitlePatterns,
+		&s.BodyPatterns,
 		&s.Branches,
 		&s.BranchPatterns,
+		&s.HeadBranches,
 		&s.MaxCommits,
 		&s.AutoMerge,
+		&s.Approvals,
+		&s.CheckRuns,
+		&s.Statuses,
+		&s.Checks,
+		&s.Deployments,
+		&s.Milestones,
+		&s.ProjectFields,
+		&s.Paths,
+		&s.OnlyPaths,
+		&s.DeniedFiles,
+		&s.MaxChangedLines,
+		&s.MaxChangedFiles,
+		&s.Authors,
+		&s.MinAge,
+		&s.StaleApprovals,
+		&s.OpenDependencies,
+		&s.Tickets,
+		&s.ChangesRequested,
+		&s.PendingReviewers,
+		&s.CodeOwners,
+		&s.AllOf,
+		&s.AnyOf,
+		s.Not,
 	}
 
 	for _, signal := range signals {
@@ -146,9 +474,35 @@ func (s Signals) MatchesAny(ctx context.Context, pullCtx pull.Context, tag strin
 		&s.CommentSubstrings,
 		&s.Comments,
 		&s.PRBodySubstrings,
+		&s.TitlePatterns,
+		&s.BodyPatterns,
 		&s.Branches,
 		&s.BranchPatterns,
+		&s.HeadBranches,
 		&s.AutoMerge,
+		&s.Approvals,
+		&s.CheckRuns,
+		&s.Statuses,
+		&s.Checks,
+		&s.Deployments,
+		&s.Milestones,
+		&s.ProjectFields,
+		&s.Paths,
+		&s.OnlyPaths,
+		&s.DeniedFiles,
+		&s.MaxChangedLines,
+		&s.MaxChangedFiles,
+		&s.Authors,
+		&s.MinAge,
+		&s.StaleApprovals,
+		&s.OpenDependencies,
+		&s.Tickets,
+		&s.ChangesRequested,
+		&s.PendingReviewers,
+		&s.CodeOwners,
+		&s.AllOf,
+		&s.AnyOf,
+		s.Not,
 	}
 
 	for _, signal := range signals {
@@ -165,6 +519,75 @@ func (s Signals) MatchesAny(ctx context.Context, pullCtx pull.Context, tag strin
 	return false, fmt.Sprintf("pull request does not match the %s", tag), nil
 }
 
+// SignalResult is the result of matching a single signal against a pull
+// request.
+type SignalResult struct {
+	// Name is the key of the signal in the configuration, like "labels"
+	Name    string `json:"name"`
+	Matched bool   `json:"matched"`
+
+	// Description describes the match. It is empty if the signal did not
+	// match.
+	Description string `json:"description,omitempty"`
+}
+
+// Explain matches each enabled signal that MatchesAny considers against the
+// pull request. Unlike MatchesAny, it does not stop at the first match, so the
+// result includes every enabled signal, in the same order as MatchesAny.
+func (s Signals) Explain(ctx context.Context, pullCtx pull.Context, tag string) ([]SignalResult, error) {
+	signals := []struct {
+		name   string
+		signal Signal
+	}{
+		{"labels", &s.Labels},
+		{"comment_substrings", &s.CommentSubstrings},
+		{"comments", &s.Comments},
+		{"pr_body_substrings", &s.PRBodySubstrings},
+		{"title_patterns", &s.TitlePatterns},
+		{"body_patterns", &s.BodyPatterns},
+		{"branches", &s.Branches},
+		{"branch_patterns", &s.BranchPatterns},
+		{"head_branches", &s.HeadBranches},
+		{"auto_merge", &s.AutoMerge},
+		{"approvals", &s.Approvals},
+		{"check_runs", &s.CheckRuns},
+		{"statuses", &s.Statuses},
+		{"checks", &s.Checks},
+		{"deployments", &s.Deployments},
+		{"milestones", &s.Milestones},
+		{"project_fields", &s.ProjectFields},
+		{"paths", &s.Paths},
+		{"only_paths", &s.OnlyPaths},
+		{"denied_files", &s.DeniedFiles},
+		{"max_changed_lines", &s.MaxChangedLines},
+		{"max_changed_files", &s.MaxChangedFiles},
+		{"authors", &s.Authors},
+		{"min_age", &s.MinAge},
+		{"stale_approvals", &s.StaleApprovals},
+		{"open_dependencies", &s.OpenDependencies},
+		{"tickets", &s.Tickets},
+		{"changes_requested", &s.ChangesRequested},
+		{"pending_reviewers", &s.PendingReviewers},
+		{"code_owners", &s.CodeOwners},
+		{"all_of", &s.AllOf},
+		{"any_of", &s.AnyOf},
+		{"not", s.Not},
+	}
+
+	var results []SignalResult
+	for _, named := range signals {
+		if !named.signal.Enabled() {
+			continue
+		}
+		matches, description, err := named.signal.Matches(ctx, pullCtx, tag)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to match %s signal", named.name)
+		}
+		results = append(results, SignalResult{Name: named.name, Matched: matches, Description: description})
+	}
+	return results, nil
+}
+
 // Matches Determines which label signals match the given PR. It returns:
 // - A boolean to indicate if a signal matched
 // - A description of the first matched signal
@@ -292,6 +715,48 @@ func (signal PRBodySubstringsSignal) Matches(ctx context.Context, pullCtx pull.C
 	return false, "", nil
 }
 
+// Matches returns true if the title of the pull request matches one of the
+// regular expressions.
+func (signal TitlePatternsSignal) Matches(ctx context.Context, pullCtx pull.Context, tag string) (bool, string, error) {
+	if !signal.Enabled() {
+		return false, "", nil
+	}
+
+	if pattern, ok := matchesAnyPattern(ctx, signal, pullCtx.Title()); ok {
+		return true, fmt.Sprintf("pull request title matches a %s pattern: %q", tag, pattern), nil
+	}
+	return false, "", nil
+}
+
+// Matches returns true if the body of the pull request matches one of the
+// regular expressions.
+func (signal BodyPatternsSignal) Matches(ctx context.Context, pullCtx pull.Context, tag string) (bool, string, error) {
+	if !signal.Enabled() {
+		return false, "", nil
+	}
+
+	if pattern, ok := matchesAnyPattern(ctx, signal, pullCtx.Body()); ok {
+		return true, fmt.Sprintf("pull request body matches a %s pattern: %q", tag, pattern), nil
+	}
+	return false, "", nil
+}
+
+// matchesAnyPattern returns the first regular expression that matches the
+// text. Invalid regular expressions are logged and match nothing.
+func matchesAnyPattern(ctx context.Context, patterns []string, text string) (string, bool) {
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			zerolog.Ctx(ctx).Warn().Err(err).Msgf("Invalid pattern %q", pattern)
+			continue
+		}
+		if re.MatchString(text) {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
 // Matches Determines which branch signals match the given PR. It returns:
 // - A boolean to indicate if a signal matched
 // - A description of the first matched signal
@@ -330,6 +795,25 @@ func (signal BranchPatternsSignal) Matches(ctx context.Context, pullCtx pull.Con
 	return false, "", nil
 }
 
+// Matches returns true if the head branch of the pull request matches one of
+// the patterns. Branches in forks are prefixed with the owner of the fork and
+// a colon, so patterns only match them if they include the prefix.
+func (signal HeadBranchesSignal) Matches(ctx context.Context, pullCtx pull.Context, tag string) (bool, string, error) {
+	if !signal.Enabled() {
+		return false, "", nil
+	}
+
+	_, headBranch := pullCtx.Branches()
+
+	for _, pattern := range signal {
+		if nameMatches(pattern, headBranch) {
+			return true, fmt.Sprintf("pull request head branch (%q) matches %s pattern: %q", headBranch, tag, pattern), nil
+		}
+	}
+
+	return false, "", nil
+}
+
 // Matches Determines if the number of commits in a PR is at or below a given max. It returns:
 // - An empty list if there is no match, otherwise a single string description of the match
 // - A match value of 0 if there is no match, otherwise the value of the max commits signal
@@ -366,3 +850,784 @@ func (signal AutoMergeSignal) Matches(ctx context.Context, pullCtx pull.Context,
 
 	return false, "", nil
 }
+
+// Matches returns true if the pull request has at least the configured number
+// of approvals. Only the latest review from each user counts and authors
+// cannot approve their own pull requests.
+func (signal ApprovalsSignal) Matches(ctx context.Context, pullCtx pull.Context, tag string) (bool, string, error) {
+	logger := zerolog.Ctx(ctx)
+
+	if !signal.Enabled() {
+		logger.Debug().Msgf("No valid approval count has been provided to match against")
+		return false, "", nil
+	}
+
+	reviews, err := pullCtx.Reviews(ctx)
+	if err != nil {
+		return false, "", errors.Wrap(err, "unable to list pull request reviews")
+	}
+
+	var allowed map[string]bool
+	if len(signal.Teams) > 0 {
+		allowed = make(map[string]bool)
+		for _, team := range signal.Teams {
+			org, slug, ok := strings.Cut(team, "/")
+			if !ok {
+				org, slug = pullCtx.Owner(), team
+			}
+
+			members, err := pullCtx.TeamMembers(ctx, org, slug)
+			if err != nil {
+				return false, "", errors.Wrapf(err, "unable to list members of team %s", team)
+			}
+			for _, member := range members {
+				allowed[strings.ToLower(member)] = true
+			}
+		}
+	}
+
+	var approvals int
+	for author, state := range latestReviewStates(reviews) {
+		if state != pull.ReviewApproved || strings.EqualFold(author, pullCtx.Author()) {
+			continue
+		}
+		if allowed != nil && !allowed[author] {
+			continue
+		}
+		approvals++
+	}
+
+	if approvals >= signal.Count {
+		return true, fmt.Sprintf("pull request has %d approvals, which is at least the minimum of %d", approvals, signal.Count), nil
+	}
+
+	return false, "", nil
+}
+
+// Matches returns true if any check run matching a name has one of the
+// conclusions configured for that name. Conclusions are case-insensitive.
+func (signal CheckRunsSignal) Matches(ctx context.Context, pullCtx pull.Context, tag string) (bool, string, error) {
+	logger := zerolog.Ctx(ctx)
+
+	if !signal.Enabled() {
+		logger.Debug().Msgf("No check runs have been provided to match against")
+		return false, "", nil
+	}
+
+	checkRuns, err := pullCtx.CheckRuns(ctx)
+	if err != nil {
+		return false, "", errors.Wrap(err, "unable to list check runs")
+	}
+
+	for _, run := range checkRuns {
+		conclusion := run.Conclusion
+		if run.Status != "completed" {
+			conclusion = "pending"
+		}
+
+		for name, conclusions := range signal {
+			if !nameMatches(name, run.Name) {
+				continue
+			}
+			for _, c := range conclusions {
+				if strings.EqualFold(c, conclusion) {
+					return true, fmt.Sprintf("pull request has a %s check run %q with conclusion %q", tag, run.Name, conclusion), nil
+				}
+			}
+		}
+	}
+
+	return false, "", nil
+}
+
+// Matches returns true if the latest status of any context matching a
+// pattern has one of the states configured for that pattern. States are
+// case-insensitive.
+func (signal StatusesSignal) Matches(ctx context.Context, pullCtx pull.Context, tag string) (bool, string, error) {
+	logger := zerolog.Ctx(ctx)
+
+	if !signal.Enabled() {
+		logger.Debug().Msgf("No statuses have been provided to match against")
+		return false, "", nil
+	}
+
+	statuses, err := pullCtx.Statuses(ctx)
+	if err != nil {
+		return false, "", errors.Wrap(err, "unable to list statuses")
+	}
+
+	for _, status := range statuses {
+		for pattern, states := range signal {
+			if !nameMatches(pattern, status.Context) {
+				continue
+			}
+			for _, s := range states {
+				if strings.EqualFold(s, status.State) {
+					return true, fmt.Sprintf("pull request has a %s status %q with state %q", tag, status.Context, status.State), nil
+				}
+			}
+		}
+	}
+
+	return false, "", nil
+}
+
+// Matches returns true if enough statuses and check runs succeeded and, if
+// required, all of them are complete and none failed. If both conditions are
+// set, both must be true.
+func (signal ChecksSignal) Matches(ctx context.Context, pullCtx pull.Context, tag string) (bool, string, error) {
+	logger := zerolog.Ctx(ctx)
+
+	if !signal.Enabled() {
+		logger.Debug().Msgf("No check requirements have been provided to match against")
+		return false, "", nil
+	}
+
+	results, err := checkResults(ctx, pullCtx)
+	if err != nil {
+		return false, "", err
+	}
+
+	var successful, pending, failed int
+	for _, result := range results {
+		switch result {
+		case pull.CheckSuccess:
+			successful++
+		case pull.CheckPending:
+			pending++
+		case pull.CheckFailure:
+			failed++
+		}
+	}
+
+	if successful < signal.MinSuccessful {
+		logger.Debug().Msgf("Pull request has %d successful checks, which is less than the minimum of %d", successful, signal.MinSuccessful)
+		return false, "", nil
+	}
+	if signal.AllComplete && (len(results) == 0 || pending > 0 || failed > 0) {
+		logger.Debug().Msgf("Pull request has %d checks, of which %d are pending and %d failed", len(results), pending, failed)
+		return false, "", nil
+	}
+
+	if signal.AllComplete {
+		return true, fmt.Sprintf("pull request has %d %s checks, which are all complete and successful", len(results), tag), nil
+	}
+	return true, fmt.Sprintf("pull request has %d successful checks, which is at least the %s minimum of %d", successful, tag, signal.MinSuccessful), nil
+}
+
+// checkResults returns the result of each commit status and check run for
+// the head commit, keyed by context or check run name. The results of check
+// runs depend on the check conclusions of the context. Check runs created by
+// bulldozer are not included, since they do not say whether the pull request
+// is ready, but runs with similar names from other apps are.
+func checkResults(ctx context.Context, pullCtx pull.Context) (map[string]pull.CheckResult, error) {
+	statuses, err := pullCtx.Statuses(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list statuses")
+	}
+
+	checkRuns, err := pullCtx.CheckRuns(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list check runs")
+	}
+
+	results := make(map[string]pull.CheckResult)
+	for _, s := range statuses {
+		switch s.State {
+		case "success":
+			results[s.Context] = pull.CheckSuccess
+		case "pending":
+			results[s.Context] = pull.CheckPending
+		default:
+			results[s.Context] = pull.CheckFailure
+		}
+	}
+
+	conclusions := pullCtx.CheckConclusions()
+	for _, run := range checkRuns {
+		if IsBulldozerCheckRun(run.Name, run.AppID, pullCtx.AppID()) {
+			continue
+		}
+		results[run.Name] = conclusions.Result(run)
+	}
+	return results, nil
+}
+
+// Matches returns true if the latest deployment of the head commit to any
+// named environment has one of the states configured for that environment.
+// Environments and states are case-insensitive.
+func (signal DeploymentsSignal) Matches(ctx context.Context, pullCtx pull.Context, tag string) (bool, string, error) {
+	logger := zerolog.Ctx(ctx)
+
+	if !signal.Enabled() {
+		logger.Debug().Msgf("No deployments have been provided to match against")
+		return false, "", nil
+	}
+
+	deployments, err := pullCtx.Deployments(ctx)
+	if err != nil {
+		return false, "", errors.Wrap(err, "unable to list deployments")
+	}
+
+	for _, d := range deployments {
+		for environment, states := range signal {
+			if !strings.EqualFold(environment, d.Environment) {
+				continue
+			}
+			for _, state := range states {
+				if strings.EqualFold(state, d.State) {
+					return true, fmt.Sprintf("pull request has a %s deployment to %q with state %q", tag, d.Environment, d.State), nil
+				}
+			}
+		}
+	}
+
+	return false, "", nil
+}
+
+// Matches returns true if the title of the milestone of the pull request
+// matches one of the titles.
+func (signal MilestonesSignal) Matches(ctx context.Context, pullCtx pull.Context, tag string) (bool, string, error) {
+	logger := zerolog.Ctx(ctx)
+
+	if !signal.Enabled() {
+		logger.Debug().Msgf("No milestones have been provided to match against")
+		return false, "", nil
+	}
+
+	milestone := pullCtx.Milestone()
+	if milestone == "" {
+		return false, "", nil
+	}
+
+	for _, title := range signal {
+		if nameMatches(title, milestone) {
+			return true, fmt.Sprintf("pull request has a %s milestone %q", tag, milestone), nil
+		}
+	}
+
+	return false, "", nil
+}
+
+// Matches returns true if any project item for the pull request has a field
+// with one of the values configured for that field.
+func (signal ProjectFieldsSignal) Matches(ctx context.Context, pullCtx pull.Context, tag string) (bool, string, error) {
+	logger := zerolog.Ctx(ctx)
+
+	if !signal.Enabled() {
+		logger.Debug().Msgf("No project fields have been provided to match against")
+		return false, "", nil
+	}
+
+	fields, err := pullCtx.ProjectFields(ctx)
+	if err != nil {
+		return false, "", errors.Wrap(err, "unable to list project fields")
+	}
+
+	for _, f := range fields {
+		for name, values := range signal {
+			if !strings.EqualFold(name, f.Field) {
+				continue
+			}
+			for _, v := range values {
+				if strings.EqualFold(v, f.Value) {
+					return true, fmt.Sprintf("pull request has a %s project field %q with value %q in project %q", tag, f.Field, f.Value, f.Project), nil
+				}
+			}
+		}
+	}
+
+	return false, "", nil
+}
+
+// Matches returns true if any file changed by the pull request matches one of
+// the globs.
+func (signal PathsSignal) Matches(ctx context.Context, pullCtx pull.Context, tag string) (bool, string, error) {
+	if !signal.Enabled() {
+		return false, "", nil
+	}
+
+	files, err := pullCtx.ChangedFiles(ctx)
+	if err != nil {
+		return false, "", errors.Wrap(err, "unable to list changed files")
+	}
+
+	for _, file := range files {
+		if glob, ok := matchesAnyGlob(signal, file); ok {
+			return true, fmt.Sprintf("pull request changes file %q, which matches a %s path: %q", file, tag, glob), nil
+		}
+	}
+
+	return false, "", nil
+}
+
+// Matches returns true if every file changed by the pull request matches one
+// of the globs. Pull requests that change no files do not match.
+func (signal OnlyPathsSignal) Matches(ctx context.Context, pullCtx pull.Context, tag string) (bool, string, error) {
+	if !signal.Enabled() {
+		return false, "", nil
+	}
+
+	files, err := pullCtx.ChangedFiles(ctx)
+	if err != nil {
+		return false, "", errors.Wrap(err, "unable to list changed files")
+	}
+	if len(files) == 0 {
+		return false, "", nil
+	}
+
+	for _, file := range files {
+		if _, ok := matchesAnyGlob(signal, file); !ok {
+			return false, "", nil
+		}
+	}
+
+	return true, fmt.Sprintf("pull request only changes files that match the %s paths", tag), nil
+}
+
+// Matches returns true if the pull request changes a file that matches one of
+// the globs or that is larger than the maximum size.
+func (signal DeniedFilesSignal) Matches(ctx context.Context, pullCtx pull.Context, tag string) (bool, string, error) {
+	if !signal.Enabled() {
+		return false, "", nil
+	}
+
+	files, err := pullCtx.ChangedFiles(ctx)
+	if err != nil {
+		return false, "", errors.Wrap(err, "unable to list changed files")
+	}
+	for _, file := range files {
+		if glob, ok := matchesAnyGlob(signal.Paths, file); ok {
+			return true, fmt.Sprintf("pull request changes file %q, which matches a %s denied path: %q", file, tag, glob), nil
+		}
+	}
+
+	if signal.MaxSize <= 0 {
+		return false, "", nil
+	}
+
+	sizes, err := pullCtx.ChangedFileSizes(ctx)
+	if err != nil {
+		return false, "", errors.Wrap(err, "unable to get changed file sizes")
+	}
+	for _, file := range files {
+		if size, ok := sizes[file]; ok && size > int64(signal.MaxSize) {
+			return true, fmt.Sprintf("pull request changes file %q with size %s, which is larger than the %s maximum of %s", file, ByteSize(size), tag, signal.MaxSize), nil
+		}
+	}
+
+	return false, "", nil
+}
+
+// Matches returns true if the pull request changes more lines than the
+// maximum.
+func (signal MaxChangedLinesSignal) Matches(ctx context.Context, pullCtx pull.Context, tag string) (bool, string, error) {
+	if !signal.Enabled() {
+		return false, "", nil
+	}
+
+	stats, err := pullCtx.DiffStats(ctx)
+	if err != nil {
+		return false, "", errors.Wrap(err, "unable to get diff stats")
+	}
+
+	if lines := stats.Additions + stats.Deletions; lines > int(signal) {
+		return true, fmt.Sprintf("pull request changes %d lines, which is more than the %s maximum of %d", lines, tag, signal), nil
+	}
+
+	return false, "", nil
+}
+
+// Matches returns true if the pull request changes more files than the
+// maximum.
+func (signal MaxChangedFilesSignal) Matches(ctx context.Context, pullCtx pull.Context, tag string) (bool, string, error) {
+	if !signal.Enabled() {
+		return false, "", nil
+	}
+
+	stats, err := pullCtx.DiffStats(ctx)
+	if err != nil {
+		return false, "", errors.Wrap(err, "unable to get diff stats")
+	}
+
+	if stats.ChangedFiles > int(signal) {
+		return true, fmt.Sprintf("pull request changes %d files, which is more than the %s maximum of %d", stats.ChangedFiles, tag, signal), nil
+	}
+
+	return false, "", nil
+}
+
+// Matches returns true if the author of the pull request is one of the users
+// or a member of one of the teams.
+func (signal AuthorsSignal) Matches(ctx context.Context, pullCtx pull.Context, tag string) (bool, string, error) {
+	if !signal.Enabled() {
+		return false, "", nil
+	}
+
+	author := pullCtx.Author()
+	for _, entry := range signal {
+		org, team, isTeam := strings.Cut(entry, "/")
+		if !isTeam {
+			if strings.EqualFold(entry, author) {
+				return true, fmt.Sprintf("pull request author %q is a %s author", author, tag), nil
+			}
+			continue
+		}
+
+		members, err := pullCtx.TeamMembers(ctx, org, team)
+		if err != nil {
+			return false, "", errors.Wrapf(err, "unable to list members of team %s", entry)
+		}
+		for _, member := range members {
+			if strings.EqualFold(member, author) {
+				return true, fmt.Sprintf("pull request author %q is a member of %s team %q", author, tag, entry), nil
+			}
+		}
+	}
+
+	return false, "", nil
+}
+
+// Matches returns true if every group matches. The description combines the
+// descriptions of each group.
+func (signal AllOfSignal) Matches(ctx context.Context, pullCtx pull.Context, tag string) (bool, string, error) {
+	if !signal.Enabled() {
+		return false, "", nil
+	}
+
+	descriptions := make([]string, 0, len(signal))
+	for i, group := range signal {
+		matches, description, err := group.MatchesAny(ctx, pullCtx, tag)
+		if err != nil {
+			return false, "", errors.Wrapf(err, "failed to match all_of group %d", i)
+		}
+		if !matches {
+			return false, "", nil
+		}
+		descriptions = append(descriptions, description)
+	}
+
+	return true, strings.Join(descriptions, " and "), nil
+}
+
+// Matches returns true if any group matches, with the description of the
+// first group that matches.
+func (signal AnyOfSignal) Matches(ctx context.Context, pullCtx pull.Context, tag string) (bool, string, error) {
+	if !signal.Enabled() {
+		return false, "", nil
+	}
+
+	for i, group := range signal {
+		matches, description, err := group.MatchesAny(ctx, pullCtx, tag)
+		if err != nil {
+			return false, "", errors.Wrapf(err, "failed to match any_of group %d", i)
+		}
+		if matches {
+			return true, description, nil
+		}
+	}
+
+	return false, "", nil
+}
+
+// Matches returns true if the group does not match.
+func (signal *NotSignal) Matches(ctx context.Context, pullCtx pull.Context, tag string) (bool, string, error) {
+	if !signal.Enabled() {
+		return false, "", nil
+	}
+
+	matches, _, err := Signals(*signal).MatchesAny(ctx, pullCtx, tag)
+	if err != nil {
+		return false, "", errors.Wrap(err, "failed to match not group")
+	}
+	if matches {
+		return false, "", nil
+	}
+
+	return true, fmt.Sprintf("pull request does not match the negated %s signals", tag), nil
+}
+
+// latestReviewStates returns the state of the latest review from each user,
+// keyed by lowercase login. Comments do not change the state of a previous
+// review.
+func latestReviewStates(reviews []*pull.Review) map[string]pull.ReviewState {
+	states := make(map[string]pull.ReviewState)
+	for _, r := range reviews {
+		if r.State == pull.ReviewCommented {
+			continue
+		}
+		states[strings.ToLower(r.Author)] = r.State
+	}
+	return states
+}
+
+// Matches returns true if the pull request was opened less than the minimum
+// age ago.
+func (signal MinAgeSignal) Matches(ctx context.Context, pullCtx pull.Context, tag string) (bool, string, error) {
+	if !signal.Enabled() {
+		return false, "", nil
+	}
+
+	minAge := time.Duration(signal)
+	if age := time.Since(pullCtx.CreatedAt()); age < minAge {
+		return true, fmt.Sprintf("pull request was opened %s ago, which is less than the %s minimum age of %s", age.Round(time.Second), tag, minAge), nil
+	}
+
+	return false, "", nil
+}
+
+// Matches returns true if the most recent approval from a user other than the
+// author was submitted when the pull request had a different head commit.
+func (signal StaleApprovalsSignal) Matches(ctx context.Context, pullCtx pull.Context, tag string) (bool, string, error) {
+	if !signal.Enabled() {
+		return false, "", nil
+	}
+
+	reviews, err := pullCtx.Reviews(ctx)
+	if err != nil {
+		return false, "", errors.Wrap(err, "unable to list pull request reviews")
+	}
+
+	for i := len(reviews) - 1; i >= 0; i-- {
+		r := reviews[i]
+		if r.State != pull.ReviewApproved || strings.EqualFold(r.Author, pullCtx.Author()) {
+			continue
+		}
+		if r.CommitSHA != pullCtx.HeadSHA() {
+			return true, fmt.Sprintf("pull request has a stale approval: the latest approval, by %s, was submitted before the latest push", r.Author), nil
+		}
+		return false, "", nil
+	}
+
+	return false, "", nil
+}
+
+// Matches returns true if any dependency declared in the body of the pull
+// request is an open issue or a pull request that is not merged. Dependencies
+// that do not exist or that bulldozer cannot access also match, because they
+// cannot be checked.
+func (signal OpenDependenciesSignal) Matches(ctx context.Context, pullCtx pull.Context, tag string) (bool, string, error) {
+	if !signal.Enabled() {
+		return false, "", nil
+	}
+
+	for _, d := range ParseDependencies(pullCtx.Body(), pullCtx.Owner(), pullCtx.Repo()) {
+		ref, err := pullCtx.Reference(ctx, d.Owner, d.Repo, d.Number)
+		switch {
+		case errors.Is(err, pull.ErrNotFound):
+			return true, fmt.Sprintf("pull request depends on %s, which does not exist or cannot be read", d), nil
+		case err != nil:
+			return false, "", errors.Wrapf(err, "unable to get dependency %s", d)
+		case ref.PullRequest && !ref.Merged:
+			return true, fmt.Sprintf("pull request depends on %s, which is not merged", d), nil
+		case !ref.PullRequest && !ref.Closed:
+			return true, fmt.Sprintf("pull request depends on %s, which is not closed", d), nil
+		}
+	}
+
+	return false, "", nil
+}
+
+// Matches returns true if a ticket that the pull request refers to is not in
+// one of the allowed statuses. Tickets that do not exist also match, as do all
+// tickets if the server does not define an issue tracker, because they cannot
+// be checked.
+func (signal TicketsSignal) Matches(ctx context.Context, pullCtx pull.Context, tag string) (bool, string, error) {
+	if !signal.Enabled() {
+		return false, "", nil
+	}
+
+	_, head := pullCtx.Branches()
+	keys, err := TicketKeys(signal.Pattern, pullCtx.Title(), head)
+	if err != nil {
+		return false, "", err
+	}
+	if len(keys) == 0 {
+		if signal.Required {
+			return true, "pull request does not refer to a ticket in its title or branch", nil
+		}
+		return false, "", nil
+	}
+
+	for _, key := range keys {
+		status, err := TicketStatus(ctx, pullCtx, key)
+		switch {
+		case errors.Is(err, ErrNoTicketTracker):
+			return true, fmt.Sprintf("pull request refers to ticket %s, which cannot be checked because the server does not define an issue tracker", key), nil
+		case errors.Is(err, pull.ErrNotFound):
+			return true, fmt.Sprintf("pull request refers to ticket %s, which does not exist or cannot be read", key), nil
+		case err != nil:
+			return false, "", errors.Wrapf(err, "unable to get status of ticket %s", key)
+		case !containsFold(signal.Statuses, status):
+			return true, fmt.Sprintf("pull request refers to ticket %s, which is %q instead of one of %q", key, status, []string(signal.Statuses)), nil
+		}
+	}
+
+	return false, "", nil
+}
+
+// Matches returns true if the latest review from any user requests changes.
+// Comments do not replace a previous review, while approvals and dismissals
+// do.
+func (signal ChangesRequestedSignal) Matches(ctx context.Context, pullCtx pull.Context, tag string) (bool, string, error) {
+	if !signal.Enabled() {
+		return false, "", nil
+	}
+
+	reviews, err := pullCtx.Reviews(ctx)
+	if err != nil {
+		return false, "", errors.Wrap(err, "unable to list pull request reviews")
+	}
+
+	var requesters []string
+	for _, r := range reviews {
+		if r.State == pull.ReviewChangesRequested && !containsFold(requesters, r.Author) {
+			requesters = append(requesters, r.Author)
+		}
+	}
+
+	states := latestReviewStates(reviews)
+	var pending []string
+	for _, author := range requesters {
+		if states[strings.ToLower(author)] == pull.ReviewChangesRequested {
+			pending = append(pending, author)
+		}
+	}
+	if len(pending) > 0 {
+		return true, fmt.Sprintf("pull request has changes requested by %s", strings.Join(pending, ", ")), nil
+	}
+
+	return false, "", nil
+}
+
+// Matches returns true if any user or team whose review is requested has not
+// submitted a review.
+func (signal PendingReviewersSignal) Matches(ctx context.Context, pullCtx pull.Context, tag string) (bool, string, error) {
+	if !signal.Enabled() {
+		return false, "", nil
+	}
+
+	if reviewers := pullCtx.RequestedReviewers(); len(reviewers) > 0 {
+		return true, fmt.Sprintf("pull request is waiting for requested reviews from %s", strings.Join(reviewers, ", ")), nil
+	}
+
+	return false, "", nil
+}
+
+// Matches returns true if an owner approved the pull request for each entry
+// of the CODEOWNERS file that applies to a changed file. It does not match if
+// the target branch has no CODEOWNERS file or no changed file has owners.
+// Owners identified by email address cannot approve.
+func (signal CodeOwnersSignal) Matches(ctx context.Context, pullCtx pull.Context, tag string) (bool, string, error) {
+	logger := zerolog.Ctx(ctx)
+
+	if !signal.Enabled() {
+		return false, "", nil
+	}
+
+	codeOwners, err := pullCtx.CodeOwners(ctx)
+	if err != nil {
+		return false, "", errors.Wrap(err, "unable to get code owners")
+	}
+	if codeOwners == nil {
+		logger.Debug().Msg("Target branch has no CODEOWNERS file")
+		return false, "", nil
+	}
+
+	files, err := pullCtx.ChangedFiles(ctx)
+	if err != nil {
+		return false, "", errors.Wrap(err, "unable to list changed files")
+	}
+
+	var entries []*pull.CodeOwnersEntry
+	for _, file := range files {
+		entry := codeOwners.Match(file)
+		if entry == nil || len(entry.Owners) == 0 {
+			continue
+		}
+		if !containsEntry(entries, entry) {
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) == 0 {
+		logger.Debug().Msg("No changed files have code owners")
+		return false, "", nil
+	}
+
+	reviews, err := pullCtx.Reviews(ctx)
+	if err != nil {
+		return false, "", errors.Wrap(err, "unable to list pull request reviews")
+	}
+
+	approvers := make(map[string]bool)
+	for author, state := range latestReviewStates(reviews) {
+		if state == pull.ReviewApproved && !strings.EqualFold(author, pullCtx.Author()) {
+			approvers[author] = true
+		}
+	}
+
+	for _, entry := range entries {
+		approved, err := ownerApproved(ctx, pullCtx, entry.Owners, approvers)
+		if err != nil {
+			return false, "", err
+		}
+		if !approved {
+			logger.Debug().Msgf("No owner of %s approved the pull request", entry.Pattern)
+			return false, "", nil
+		}
+	}
+
+	return true, fmt.Sprintf("pull request is approved by code owners of all %d changed CODEOWNERS entries", len(entries)), nil
+}
+
+func containsEntry(entries []*pull.CodeOwnersEntry, entry *pull.CodeOwnersEntry) bool {
+	for _, e := range entries {
+		if e == entry {
+			return true
+		}
+	}
+	return false
+}
+
+// ownerApproved returns true if any of the owners, which are users or teams
+// formatted as "org/team-slug", is or contains one of the approvers.
+func ownerApproved(ctx context.Context, pullCtx pull.Context, owners []string, approvers map[string]bool) (bool, error) {
+	for _, owner := range owners {
+		if strings.Contains(owner, "@") {
+			continue
+		}
+
+		org, team, isTeam := strings.Cut(owner, "/")
+		if !isTeam {
+			if approvers[strings.ToLower(owner)] {
+				return true, nil
+			}
+			continue
+		}
+
+		members, err := pullCtx.TeamMembers(ctx, org, team)
+		if err != nil {
+			return false, errors.Wrapf(err, "unable to list members of team %s", owner)
+		}
+		for _, member := range members {
+			if approvers[strings.ToLower(member)] {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// MinAgeReachedAt returns when the pull request is old enough that the
+// min_age ignore signal no longer matches. It returns the zero time if the
+// signal is not enabled or already does not match.
+func MinAgeReachedAt(pullCtx pull.Context, mergeConfig MergeConfig) time.Time {
+	if !mergeConfig.Ignore.MinAge.Enabled() {
+		return time.Time{}
+	}
+	at := pullCtx.CreatedAt().Add(time.Duration(mergeConfig.Ignore.MinAge))
+	if !time.Now().Before(at) {
+		return time.Time{}
+	}
+	return at
+}
@@ -16,12 +16,15 @@ package bulldozer
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/palantir/bulldozer/pull"
 	"github.com/palantir/bulldozer/pull/pulltest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
 )
 
 func TestSignalsMatchesAny(t *testing.T) {
@@ -371,3 +374,1346 @@ func TestSignalsMatchesAllNoSignals(t *testing.T) {
 		})
 	}
 }
+
+func TestSignalsApprovals(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Signals     Signals
+		PullContext pull.Context
+		Matches     bool
+		Reason      string
+	}{
+		"matchWithEnoughApprovals": {
+			Signals: Signals{Approvals: ApprovalsSignal{Count: 2}},
+			PullContext: &pulltest.MockPullContext{
+				ReviewsValue: []*pull.Review{
+					{Author: "alice", State: pull.ReviewApproved},
+					{Author: "bob", State: pull.ReviewApproved},
+				},
+			},
+			Matches: true,
+			Reason:  `pull request has 2 approvals, which is at least the minimum of 2`,
+		},
+		"noMatchWithTooFewApprovals": {
+			Signals: Signals{Approvals: ApprovalsSignal{Count: 2}},
+			PullContext: &pulltest.MockPullContext{
+				ReviewsValue: []*pull.Review{
+					{Author: "alice", State: pull.ReviewApproved},
+					{Author: "ALICE", State: pull.ReviewApproved},
+				},
+			},
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+		"latestReviewWins": {
+			Signals: Signals{Approvals: ApprovalsSignal{Count: 1}},
+			PullContext: &pulltest.MockPullContext{
+				ReviewsValue: []*pull.Review{
+					{Author: "alice", State: pull.ReviewApproved},
+					{Author: "alice", State: pull.ReviewCommented},
+					{Author: "alice", State: pull.ReviewChangesRequested},
+					{Author: "bob", State: pull.ReviewApproved},
+					{Author: "bob", State: pull.ReviewDismissed},
+				},
+			},
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+		"commentsDoNotRemoveApproval": {
+			Signals: Signals{Approvals: ApprovalsSignal{Count: 1}},
+			PullContext: &pulltest.MockPullContext{
+				ReviewsValue: []*pull.Review{
+					{Author: "alice", State: pull.ReviewApproved},
+					{Author: "alice", State: pull.ReviewCommented},
+				},
+			},
+			Matches: true,
+			Reason:  `pull request has 1 approvals, which is at least the minimum of 1`,
+		},
+		"authorCannotApprove": {
+			Signals: Signals{Approvals: ApprovalsSignal{Count: 1}},
+			PullContext: &pulltest.MockPullContext{
+				AuthorValue: "Alice",
+				ReviewsValue: []*pull.Review{
+					{Author: "alice", State: pull.ReviewApproved},
+				},
+			},
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+		"onlyTeamMembersCount": {
+			Signals: Signals{Approvals: ApprovalsSignal{Count: 2, Teams: []string{"reviewers", "otherorg/admins"}}},
+			PullContext: &pulltest.MockPullContext{
+				OwnerValue: "testorg",
+				ReviewsValue: []*pull.Review{
+					{Author: "alice", State: pull.ReviewApproved},
+					{Author: "bob", State: pull.ReviewApproved},
+					{Author: "carol", State: pull.ReviewApproved},
+				},
+				TeamMembersValue: map[string][]string{
+					"testorg/reviewers": {"Alice"},
+					"otherorg/admins":   {"carol"},
+				},
+			},
+			Matches: true,
+			Reason:  `pull request has 2 approvals, which is at least the minimum of 2`,
+		},
+		"nonTeamMembersDoNotCount": {
+			Signals: Signals{Approvals: ApprovalsSignal{Count: 2, Teams: []string{"reviewers"}}},
+			PullContext: &pulltest.MockPullContext{
+				OwnerValue: "testorg",
+				ReviewsValue: []*pull.Review{
+					{Author: "alice", State: pull.ReviewApproved},
+					{Author: "bob", State: pull.ReviewApproved},
+				},
+				TeamMembersValue: map[string][]string{
+					"testorg/reviewers": {"alice"},
+				},
+			},
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			matches, reason, err := test.Signals.MatchesAny(ctx, test.PullContext, "testlist")
+			require.NoError(t, err)
+
+			if test.Matches {
+				assert.True(t, matches, "expected pull request to match, but it didn't")
+			} else {
+				assert.False(t, matches, "expected pull request to not match, but it did")
+			}
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsCheckRuns(t *testing.T) {
+	signals := Signals{
+		CheckRuns: CheckRunsSignal{
+			"ci/build":      {"success"},
+			"security-scan": {"action_required", "pending"},
+		},
+	}
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		PullContext pull.Context
+		Matches     bool
+		Reason      string
+	}{
+		"matchConclusion": {
+			PullContext: &pulltest.MockPullContext{
+				CheckRunsValue: []*pull.CheckRun{
+					{Name: "ci/test", Status: "completed", Conclusion: "success"},
+					{Name: "CI/Build", Status: "completed", Conclusion: "SUCCESS"},
+				},
+			},
+			Matches: true,
+			Reason:  `pull request has a testlist check run "CI/Build" with conclusion "SUCCESS"`,
+		},
+		"noMatchOtherConclusion": {
+			PullContext: &pulltest.MockPullContext{
+				CheckRunsValue: []*pull.CheckRun{
+					{Name: "ci/build", Status: "completed", Conclusion: "failure"},
+					{Name: "security-scan", Status: "completed", Conclusion: "success"},
+				},
+			},
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+		"matchPending": {
+			PullContext: &pulltest.MockPullContext{
+				CheckRunsValue: []*pull.CheckRun{
+					{Name: "security-scan", Status: "in_progress"},
+				},
+			},
+			Matches: true,
+			Reason:  `pull request has a testlist check run "security-scan" with conclusion "pending"`,
+		},
+		"noMatchIncompleteRun": {
+			PullContext: &pulltest.MockPullContext{
+				CheckRunsValue: []*pull.CheckRun{
+					{Name: "ci/build", Status: "queued"},
+				},
+			},
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+		"noMatchNoRuns": {
+			PullContext: &pulltest.MockPullContext{},
+			Matches:     false,
+			Reason:      `pull request does not match the testlist`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			matches, reason, err := signals.MatchesAny(ctx, test.PullContext, "testlist")
+			require.NoError(t, err)
+
+			if test.Matches {
+				assert.True(t, matches, "expected pull request to match, but it didn't")
+			} else {
+				assert.False(t, matches, "expected pull request to not match, but it did")
+			}
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsStatuses(t *testing.T) {
+	signals := Signals{
+		Statuses: StatusesSignal{
+			"ci/*":                {"success"},
+			`/^deploy\/preview-/`: {"pending", "failure"},
+		},
+	}
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Statuses []*pull.Status
+		Matches  bool
+		Reason   string
+	}{
+		"matchGlob": {
+			Statuses: []*pull.Status{
+				{Context: "ci/test (ubuntu)", State: "pending"},
+				{Context: "ci/test (macos)", State: "success"},
+			},
+			Matches: true,
+			Reason:  `pull request has a testlist status "ci/test (macos)" with state "success"`,
+		},
+		"matchRegexp": {
+			Statuses: []*pull.Status{
+				{Context: "deploy/preview-42", State: "failure"},
+			},
+			Matches: true,
+			Reason:  `pull request has a testlist status "deploy/preview-42" with state "failure"`,
+		},
+		"noMatch": {
+			Statuses: []*pull.Status{
+				{Context: "ci/test/unit", State: "success"},
+				{Context: "deploy/preview-42", State: "success"},
+			},
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			matches, reason, err := signals.MatchesAny(ctx, &pulltest.MockPullContext{StatusesValue: test.Statuses}, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsChecks(t *testing.T) {
+	ctx := context.Background()
+
+	statuses := []*pull.Status{
+		{Context: "ci/build", State: "success"},
+		{Context: "ci/lint", State: "success"},
+	}
+
+	tests := map[string]struct {
+		Signal      ChecksSignal
+		Statuses    []*pull.Status
+		CheckRuns   []*pull.CheckRun
+		Conclusions pull.CheckConclusions
+		Matches     bool
+		Reason      string
+	}{
+		"minSuccessful": {
+			Signal:   ChecksSignal{MinSuccessful: 3},
+			Statuses: statuses,
+			CheckRuns: []*pull.CheckRun{
+				{Name: "test (ubuntu)", Status: "completed", Conclusion: "skipped"},
+				{Name: "test (macos)", Status: "in_progress"},
+			},
+			Matches: true,
+			Reason:  "pull request has 3 successful checks, which is at least the trigger minimum of 3",
+		},
+		"tooFewSuccessful": {
+			Signal:   ChecksSignal{MinSuccessful: 3},
+			Statuses: statuses,
+			CheckRuns: []*pull.CheckRun{
+				{Name: "test (ubuntu)", Status: "completed", Conclusion: "failure"},
+			},
+			Matches: false,
+		},
+		"allComplete": {
+			Signal:   ChecksSignal{AllComplete: true},
+			Statuses: statuses,
+			CheckRuns: []*pull.CheckRun{
+				{Name: "test (ubuntu)", Status: "completed", Conclusion: "success"},
+			},
+			Matches: true,
+			Reason:  "pull request has 3 trigger checks, which are all complete and successful",
+		},
+		"pending": {
+			Signal:   ChecksSignal{AllComplete: true},
+			Statuses: append([]*pull.Status{{Context: "ci/deploy", State: "pending"}}, statuses...),
+			Matches:  false,
+		},
+		"failed": {
+			Signal: ChecksSignal{AllComplete: true, MinSuccessful: 1},
+			CheckRuns: []*pull.CheckRun{
+				{Name: "test (ubuntu)", Status: "completed", Conclusion: "success"},
+				{Name: "test (macos)", Status: "completed", Conclusion: "timed_out"},
+			},
+			Matches: false,
+		},
+		"noChecks": {
+			Signal:  ChecksSignal{AllComplete: true},
+			Matches: false,
+		},
+		"onlyBulldozerChecks": {
+			Signal: ChecksSignal{AllComplete: true, MinSuccessful: 1},
+			CheckRuns: []*pull.CheckRun{
+				{Name: CheckRunName, AppID: 42, Status: "completed", Conclusion: "neutral"},
+				{Name: ValidationCheckRunName, AppID: 42, Status: "completed", Conclusion: "success"},
+			},
+			Matches: false,
+		},
+		"otherAppWithBulldozerName": {
+			Signal: ChecksSignal{AllComplete: true, MinSuccessful: 1},
+			CheckRuns: []*pull.CheckRun{
+				{Name: CheckRunName, AppID: 42, Status: "completed", Conclusion: "neutral"},
+				{Name: "bulldozer/integration", AppID: 7, Status: "completed", Conclusion: "success"},
+			},
+			Matches: true,
+			Reason:  "pull request has 1 trigger checks, which are all complete and successful",
+		},
+		"configuredConclusion": {
+			Signal:   ChecksSignal{MinSuccessful: 3},
+			Statuses: statuses,
+			CheckRuns: []*pull.CheckRun{
+				{Name: "test (ubuntu)", Status: "completed", Conclusion: "neutral"},
+			},
+			Conclusions: pull.CheckConclusions{"neutral": pull.CheckFailure},
+			Matches:     false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pullCtx := &pulltest.MockPullContext{
+				StatusesValue:         test.Statuses,
+				CheckRunsValue:        test.CheckRuns,
+				CheckConclusionsValue: test.Conclusions,
+				AppIDValue:            42,
+			}
+
+			matches, reason, err := test.Signal.Matches(ctx, pullCtx, "trigger")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsDeployments(t *testing.T) {
+	signals := Signals{
+		Deployments: DeploymentsSignal{
+			"staging": {"success"},
+			"preview": {"pending", "in_progress"},
+		},
+	}
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		PullContext pull.Context
+		Matches     bool
+		Reason      string
+	}{
+		"matchState": {
+			PullContext: &pulltest.MockPullContext{
+				DeploymentsValue: []*pull.Deployment{
+					{Environment: "production", State: "success"},
+					{Environment: "Staging", State: "SUCCESS"},
+				},
+			},
+			Matches: true,
+			Reason:  `pull request has a testlist deployment to "Staging" with state "SUCCESS"`,
+		},
+		"matchPending": {
+			PullContext: &pulltest.MockPullContext{
+				DeploymentsValue: []*pull.Deployment{
+					{Environment: "preview", State: "pending"},
+				},
+			},
+			Matches: true,
+			Reason:  `pull request has a testlist deployment to "preview" with state "pending"`,
+		},
+		"noMatchOtherState": {
+			PullContext: &pulltest.MockPullContext{
+				DeploymentsValue: []*pull.Deployment{
+					{Environment: "staging", State: "failure"},
+					{Environment: "preview", State: "success"},
+				},
+			},
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+		"noMatchNoDeployments": {
+			PullContext: &pulltest.MockPullContext{},
+			Matches:     false,
+			Reason:      `pull request does not match the testlist`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			matches, reason, err := signals.MatchesAny(ctx, test.PullContext, "testlist")
+			require.NoError(t, err)
+
+			if test.Matches {
+				assert.True(t, matches, "expected pull request to match, but it didn't")
+			} else {
+				assert.False(t, matches, "expected pull request to not match, but it did")
+			}
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsTitleAndBodyPatterns(t *testing.T) {
+	trigger := Signals{
+		TitlePatterns: TitlePatternsSignal{`^chore\(deps\)`},
+		BodyPatterns:  BodyPatternsSignal{"(?m)^Merge-When-Ready: true$"},
+	}
+	ignore := Signals{
+		TitlePatterns: TitlePatternsSignal{"(", `(?i)\bWIP\b`},
+		BodyPatterns:  BodyPatternsSignal{"(?i)do not merge"},
+	}
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Title   string
+		Body    string
+		Signals Signals
+		Matches bool
+		Reason  string
+	}{
+		"matchTitle": {
+			Title:   "chore(deps): update lodash",
+			Signals: trigger,
+			Matches: true,
+			Reason:  `pull request title matches a testlist pattern: "^chore\\(deps\\)"`,
+		},
+		"matchBody": {
+			Title:   "Update lodash",
+			Body:    "Updates lodash.\n\nMerge-When-Ready: true\n",
+			Signals: trigger,
+			Matches: true,
+			Reason:  `pull request body matches a testlist pattern: "(?m)^Merge-When-Ready: true$"`,
+		},
+		"noMatchUnanchored": {
+			Title:   "fix: chore(deps) typo",
+			Body:    "Not Merge-When-Ready: true",
+			Signals: trigger,
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+		"matchCaseInsensitive": {
+			Title:   "[wip] Refactor parser",
+			Signals: ignore,
+			Matches: true,
+			Reason:  `pull request title matches a testlist pattern: "(?i)\\bWIP\\b"`,
+		},
+		"noMatchWord": {
+			Title:   "Fix wiping of caches",
+			Body:    "Please merge",
+			Signals: ignore,
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pullCtx := &pulltest.MockPullContext{TitleValue: test.Title, BodyValue: test.Body}
+
+			matches, reason, err := test.Signals.MatchesAny(ctx, pullCtx, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsHeadBranches(t *testing.T) {
+	signals := Signals{
+		HeadBranches: HeadBranchesSignal{"renovate/**", "/^dependabot\\/npm_and_yarn\\//"},
+	}
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Head    string
+		Matches bool
+		Reason  string
+	}{
+		"matchGlob": {
+			Head:    "renovate/lodash-4.x",
+			Matches: true,
+			Reason:  `pull request head branch ("renovate/lodash-4.x") matches testlist pattern: "renovate/**"`,
+		},
+		"matchRegexp": {
+			Head:    "dependabot/npm_and_yarn/lodash-4.17.21",
+			Matches: true,
+			Reason:  `pull request head branch ("dependabot/npm_and_yarn/lodash-4.17.21") matches testlist pattern: "/^dependabot\\/npm_and_yarn\\//"`,
+		},
+		"noMatchFork": {
+			Head:    "octocat:renovate/lodash-4.x",
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+		"noMatchOtherBranch": {
+			Head:    "experiment/renovate",
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pullCtx := &pulltest.MockPullContext{BranchBase: "develop", BranchName: test.Head}
+
+			matches, reason, err := signals.MatchesAny(ctx, pullCtx, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsDeniedFiles(t *testing.T) {
+	signals := Signals{
+		DeniedFiles: DeniedFilesSignal{
+			Paths:   []string{"**/*.pem", ".bulldozer.yml"},
+			MaxSize: 5 << 20,
+		},
+	}
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Files   []string
+		Sizes   map[string]int64
+		Matches bool
+		Reason  string
+	}{
+		"matchPath": {
+			Files:   []string{"README.md", "certs/server.pem"},
+			Matches: true,
+			Reason:  `pull request changes file "certs/server.pem", which matches a testlist denied path: "**/*.pem"`,
+		},
+		"matchConfig": {
+			Files:   []string{".bulldozer.yml"},
+			Matches: true,
+			Reason:  `pull request changes file ".bulldozer.yml", which matches a testlist denied path: ".bulldozer.yml"`,
+		},
+		"matchSize": {
+			Files:   []string{"README.md", "assets/video.mp4"},
+			Sizes:   map[string]int64{"README.md": 1024, "assets/video.mp4": 6 << 20},
+			Matches: true,
+			Reason:  `pull request changes file "assets/video.mp4" with size 6.0MB, which is larger than the testlist maximum of 5.0MB`,
+		},
+		"noMatchDeletedFile": {
+			Files:   []string{"README.md", "assets/video.mp4"},
+			Sizes:   map[string]int64{"README.md": 1024},
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+		"noMatch": {
+			Files:   []string{"docs/config/.bulldozer.yml"},
+			Sizes:   map[string]int64{"docs/config/.bulldozer.yml": 5 << 20},
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pullCtx := &pulltest.MockPullContext{
+				ChangedFilesValue:     test.Files,
+				ChangedFileSizesValue: test.Sizes,
+			}
+
+			matches, reason, err := signals.MatchesAny(ctx, pullCtx, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+
+	t.Run("sizeError", func(t *testing.T) {
+		pullCtx := &pulltest.MockPullContext{
+			ChangedFilesValue:        []string{"README.md"},
+			ChangedFileSizesErrValue: errors.New("tree is truncated"),
+		}
+
+		_, _, err := signals.MatchesAny(ctx, pullCtx, "testlist")
+		assert.Error(t, err)
+	})
+}
+
+func TestByteSize(t *testing.T) {
+	tests := map[string]struct {
+		Input  string
+		Output ByteSize
+		Error  bool
+	}{
+		"integer":   {Input: "1024", Output: 1024},
+		"bytes":     {Input: "100B", Output: 100},
+		"kilobytes": {Input: "512KB", Output: 512 << 10},
+		"megabytes": {Input: "5 mb", Output: 5 << 20},
+		"gigabytes": {Input: "1.5GB", Output: 3 << 29},
+		"invalid":   {Input: "big", Error: true},
+		"negative":  {Input: "-1MB", Error: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var size ByteSize
+			err := yaml.Unmarshal([]byte(test.Input), &size)
+			if test.Error {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.Output, size)
+		})
+	}
+
+	assert.Equal(t, "512B", ByteSize(512).String())
+	assert.Equal(t, "1.5KB", ByteSize(1536).String())
+}
+
+func TestSignalsOpenDependencies(t *testing.T) {
+	signals := Signals{
+		OpenDependencies: true,
+	}
+	ctx := context.Background()
+
+	references := map[string]*pull.Reference{
+		"testorg/testrepo#1":   {PullRequest: true, Closed: true, Merged: true},
+		"testorg/testrepo#2":   {PullRequest: true, Closed: true},
+		"testorg/testrepo#3":   {Closed: true},
+		"testorg/testrepo#4":   {},
+		"testorg/otherrepo#10": {PullRequest: true},
+	}
+
+	tests := map[string]struct {
+		Body    string
+		Matches bool
+		Reason  string
+	}{
+		"noDependencies": {
+			Body:    "Fixes #4",
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+		"resolved": {
+			Body:    "Depends-on: #1, #3",
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+		"closedPullRequest": {
+			Body:    "Depends-on: #1 #2",
+			Matches: true,
+			Reason:  `pull request depends on testorg/testrepo#2, which is not merged`,
+		},
+		"openIssue": {
+			Body:    "Depends-on: #4",
+			Matches: true,
+			Reason:  `pull request depends on testorg/testrepo#4, which is not closed`,
+		},
+		"otherRepository": {
+			Body:    "Depends-on: testorg/otherrepo#10",
+			Matches: true,
+			Reason:  `pull request depends on testorg/otherrepo#10, which is not merged`,
+		},
+		"missing": {
+			Body:    "Depends-on: #5",
+			Matches: true,
+			Reason:  `pull request depends on testorg/testrepo#5, which does not exist or cannot be read`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pullCtx := &pulltest.MockPullContext{
+				OwnerValue:      "testorg",
+				RepoValue:       "testrepo",
+				BodyValue:       test.Body,
+				ReferencesValue: references,
+			}
+
+			matches, reason, err := signals.MatchesAny(ctx, pullCtx, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsTickets(t *testing.T) {
+	ctx := context.Background()
+	statuses := map[string]string{
+		"ABC-1": "Ready to Merge",
+		"ABC-2": "In Progress",
+	}
+
+	tests := map[string]struct {
+		Signal    TicketsSignal
+		Title     string
+		Branch    string
+		NoTracker bool
+		Matches   bool
+		Reason    string
+	}{
+		"allowed": {
+			Signal:  TicketsSignal{Statuses: []string{"ready to merge"}},
+			Title:   "ABC-1: add the client",
+			Matches: false,
+		},
+		"notAllowedInBranch": {
+			Signal:  TicketsSignal{Statuses: []string{"Ready to Merge"}},
+			Title:   "ABC-1: add the client",
+			Branch:  "ABC-2-client",
+			Matches: true,
+			Reason:  `pull request refers to ticket ABC-2, which is "In Progress" instead of one of ["Ready to Merge"]`,
+		},
+		"missing": {
+			Signal:  TicketsSignal{Statuses: []string{"Ready to Merge"}},
+			Title:   "ABC-3: add the client",
+			Matches: true,
+			Reason:  `pull request refers to ticket ABC-3, which does not exist or cannot be read`,
+		},
+		"noTickets": {
+			Signal:  TicketsSignal{Statuses: []string{"Ready to Merge"}},
+			Title:   "Add the client",
+			Matches: false,
+		},
+		"required": {
+			Signal:  TicketsSignal{Statuses: []string{"Ready to Merge"}, Required: true},
+			Title:   "Add the client",
+			Matches: true,
+			Reason:  `pull request does not refer to a ticket in its title or branch`,
+		},
+		"noTracker": {
+			Signal:    TicketsSignal{Statuses: []string{"Ready to Merge"}},
+			Title:     "ABC-1: add the client",
+			NoTracker: true,
+			Matches:   true,
+			Reason:    `pull request refers to ticket ABC-1, which cannot be checked because the server does not define an issue tracker`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pullCtx := &pulltest.MockPullContext{
+				TitleValue:          test.Title,
+				BranchName:          test.Branch,
+				TicketStatusesValue: statuses,
+			}
+			if test.NoTracker {
+				pullCtx.NoTicketTrackerValue = true
+			}
+
+			matches, reason, err := test.Signal.Matches(ctx, pullCtx, "ignore")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMilestones(t *testing.T) {
+	signals := Signals{
+		Milestones: MilestonesSignal{"v2.*", "/^release-[0-9]+$/"},
+	}
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Milestone string
+		Matches   bool
+		Reason    string
+	}{
+		"matchGlob": {
+			Milestone: "V2.1",
+			Matches:   true,
+			Reason:    `pull request has a testlist milestone "V2.1"`,
+		},
+		"matchRegexp": {
+			Milestone: "release-12",
+			Matches:   true,
+			Reason:    `pull request has a testlist milestone "release-12"`,
+		},
+		"noMatchOtherMilestone": {
+			Milestone: "v3.0",
+			Matches:   false,
+			Reason:    `pull request does not match the testlist`,
+		},
+		"noMatchNoMilestone": {
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			matches, reason, err := signals.MatchesAny(ctx, &pulltest.MockPullContext{MilestoneValue: test.Milestone}, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsProjectFields(t *testing.T) {
+	signals := Signals{
+		ProjectFields: ProjectFieldsSignal{
+			"Status": {"Ready to merge"},
+		},
+	}
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Fields  []*pull.ProjectField
+		Matches bool
+		Reason  string
+	}{
+		"matchValue": {
+			Fields: []*pull.ProjectField{
+				{Project: "Planning", Field: "Status", Value: "In progress"},
+				{Project: "Release", Field: "status", Value: "ready to merge"},
+			},
+			Matches: true,
+			Reason:  `pull request has a testlist project field "status" with value "ready to merge" in project "Release"`,
+		},
+		"noMatchOtherField": {
+			Fields: []*pull.ProjectField{
+				{Project: "Release", Field: "Notes", Value: "Ready to merge"},
+			},
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+		"noMatchNoProjects": {
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			matches, reason, err := signals.MatchesAny(ctx, &pulltest.MockPullContext{ProjectFieldsValue: test.Fields}, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsComposition(t *testing.T) {
+	// label A AND (comment B OR check run C) AND NOT label D
+	var signals Signals
+	require.NoError(t, yaml.UnmarshalStrict([]byte(`
+all_of:
+  - labels: ["A"]
+  - comments: ["B"]
+    check_runs:
+      "C": ["success"]
+  - not:
+      labels: ["D"]
+`), &signals))
+	require.True(t, signals.Enabled())
+
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		PullContext pull.Context
+		Matches     bool
+		Reason      string
+	}{
+		"matchComment": {
+			PullContext: &pulltest.MockPullContext{
+				LabelValue:   []string{"A"},
+				CommentValue: []string{"B"},
+			},
+			Matches: true,
+			Reason:  `pull request has a testlist label: "A" and pull request has a testlist comment: "B" and pull request does not match the negated testlist signals`,
+		},
+		"matchCheckRun": {
+			PullContext: &pulltest.MockPullContext{
+				LabelValue:     []string{"A", "E"},
+				CheckRunsValue: []*pull.CheckRun{{Name: "C", Status: "completed", Conclusion: "success"}},
+			},
+			Matches: true,
+			Reason:  `pull request has a testlist label: "A" and pull request has a testlist check run "C" with conclusion "success" and pull request does not match the negated testlist signals`,
+		},
+		"noMatchNegated": {
+			PullContext: &pulltest.MockPullContext{
+				LabelValue:   []string{"A", "D"},
+				CommentValue: []string{"B"},
+			},
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+		"noMatchMissingGroup": {
+			PullContext: &pulltest.MockPullContext{
+				LabelValue: []string{"A"},
+			},
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			matches, reason, err := signals.MatchesAny(ctx, test.PullContext, "testlist")
+			require.NoError(t, err)
+
+			if test.Matches {
+				assert.True(t, matches, "expected pull request to match, but it didn't")
+			} else {
+				assert.False(t, matches, "expected pull request to not match, but it did")
+			}
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+
+	t.Run("anyOf", func(t *testing.T) {
+		signals := Signals{
+			Labels: []string{"A"},
+			AnyOf: AnyOfSignal{
+				{Labels: []string{"B"}},
+				{AllOf: AllOfSignal{{Labels: []string{"C"}}, {Comments: []string{"D"}}}},
+			},
+		}
+
+		matches, reason, err := signals.MatchesAny(ctx, &pulltest.MockPullContext{LabelValue: []string{"C"}, CommentValue: []string{"D"}}, "testlist")
+		require.NoError(t, err)
+		assert.True(t, matches)
+		assert.Equal(t, `pull request has a testlist label: "C" and pull request has a testlist comment: "D"`, reason)
+
+		matches, _, err = signals.MatchesAny(ctx, &pulltest.MockPullContext{LabelValue: []string{"C"}}, "testlist")
+		require.NoError(t, err)
+		assert.False(t, matches)
+	})
+
+	t.Run("notEnabled", func(t *testing.T) {
+		assert.False(t, Signals{Not: &NotSignal{}}.Enabled(), "empty not group should not be enabled")
+	})
+}
+
+func TestSignalsPaths(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Signals     Signals
+		PullContext pull.Context
+		Matches     bool
+		Reason      string
+	}{
+		"pathsMatchAnyFile": {
+			Signals: Signals{Paths: []string{"migrations/**", ".github/workflows/**"}},
+			PullContext: &pulltest.MockPullContext{
+				ChangedFilesValue: []string{"server/server.go", "migrations/002_add_table.sql"},
+			},
+			Matches: true,
+			Reason:  `pull request changes file "migrations/002_add_table.sql", which matches a testlist path: "migrations/**"`,
+		},
+		"pathsNoMatch": {
+			Signals: Signals{Paths: []string{"migrations/**"}},
+			PullContext: &pulltest.MockPullContext{
+				ChangedFilesValue: []string{"server/server.go"},
+			},
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+		"onlyPathsMatchAllFiles": {
+			Signals: Signals{OnlyPaths: []string{"docs/**", "*.md"}},
+			PullContext: &pulltest.MockPullContext{
+				ChangedFilesValue: []string{"README.md", "docs/guide/setup.md"},
+			},
+			Matches: true,
+			Reason:  `pull request only changes files that match the testlist paths`,
+		},
+		"onlyPathsNoMatchWithOtherFile": {
+			Signals: Signals{OnlyPaths: []string{"docs/**", "*.md"}},
+			PullContext: &pulltest.MockPullContext{
+				ChangedFilesValue: []string{"README.md", "main.go"},
+			},
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+		"onlyPathsNoMatchWithNoFiles": {
+			Signals:     Signals{OnlyPaths: []string{"docs/**"}},
+			PullContext: &pulltest.MockPullContext{},
+			Matches:     false,
+			Reason:      `pull request does not match the testlist`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			matches, reason, err := test.Signals.MatchesAny(ctx, test.PullContext, "testlist")
+			require.NoError(t, err)
+
+			if test.Matches {
+				assert.True(t, matches, "expected pull request to match, but it didn't")
+			} else {
+				assert.False(t, matches, "expected pull request to not match, but it did")
+			}
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+
+	t.Run("errorIgnoresPullRequest", func(t *testing.T) {
+		signals := Signals{Paths: []string{"migrations/**"}}
+		pullCtx := &pulltest.MockPullContext{ChangedFilesErrValue: errors.New("failed")}
+
+		ignored, _, err := IsPRIgnored(ctx, pullCtx, signals)
+		assert.Error(t, err)
+		assert.True(t, ignored)
+	})
+}
+
+func TestSignalsMaxChanges(t *testing.T) {
+	signals := Signals{
+		MaxChangedLines: 500,
+		MaxChangedFiles: 20,
+	}
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		PullContext pull.Context
+		Matches     bool
+		Reason      string
+	}{
+		"matchTooManyLines": {
+			PullContext: &pulltest.MockPullContext{
+				DiffStatsValue: &pull.DiffStats{Additions: 400, Deletions: 101, ChangedFiles: 3},
+			},
+			Matches: true,
+			Reason:  `pull request changes 501 lines, which is more than the testlist maximum of 500`,
+		},
+		"matchTooManyFiles": {
+			PullContext: &pulltest.MockPullContext{
+				DiffStatsValue: &pull.DiffStats{Additions: 21, ChangedFiles: 21},
+			},
+			Matches: true,
+			Reason:  `pull request changes 21 files, which is more than the testlist maximum of 20`,
+		},
+		"noMatchAtMaximum": {
+			PullContext: &pulltest.MockPullContext{
+				DiffStatsValue: &pull.DiffStats{Additions: 250, Deletions: 250, ChangedFiles: 20},
+			},
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			matches, reason, err := signals.MatchesAny(ctx, test.PullContext, "testlist")
+			require.NoError(t, err)
+
+			if test.Matches {
+				assert.True(t, matches, "expected pull request to match, but it didn't")
+			} else {
+				assert.False(t, matches, "expected pull request to not match, but it did")
+			}
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsAuthors(t *testing.T) {
+	signals := Signals{
+		Authors: []string{"dependabot[bot]", "renovate[bot]", "testorg/maintainers"},
+	}
+	ctx := context.Background()
+	teams := map[string][]string{
+		"testorg/maintainers": {"alice", "bob"},
+	}
+
+	tests := map[string]struct {
+		PullContext pull.Context
+		Matches     bool
+		Reason      string
+	}{
+		"matchUser": {
+			PullContext: &pulltest.MockPullContext{AuthorValue: "Dependabot[bot]", TeamMembersValue: teams},
+			Matches:     true,
+			Reason:      `pull request author "Dependabot[bot]" is a testlist author`,
+		},
+		"matchTeamMember": {
+			PullContext: &pulltest.MockPullContext{AuthorValue: "Bob", TeamMembersValue: teams},
+			Matches:     true,
+			Reason:      `pull request author "Bob" is a member of testlist team "testorg/maintainers"`,
+		},
+		"noMatch": {
+			PullContext: &pulltest.MockPullContext{AuthorValue: "mallory", TeamMembersValue: teams},
+			Matches:     false,
+			Reason:      `pull request does not match the testlist`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			matches, reason, err := signals.MatchesAny(ctx, test.PullContext, "testlist")
+			require.NoError(t, err)
+
+			if test.Matches {
+				assert.True(t, matches, "expected pull request to match, but it didn't")
+			} else {
+				assert.False(t, matches, "expected pull request to not match, but it did")
+			}
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMinAge(t *testing.T) {
+	ctx := context.Background()
+
+	var signals Signals
+	require.NoError(t, yaml.Unmarshal([]byte(`min_age: 1h`), &signals))
+	assert.Equal(t, MinAgeSignal(time.Hour), signals.MinAge)
+
+	young := &pulltest.MockPullContext{CreatedValue: time.Now().Add(-10 * time.Minute)}
+	matches, reason, err := signals.MatchesAny(ctx, young, "ignore")
+	require.NoError(t, err)
+	assert.True(t, matches, "young pull request did not match")
+	assert.Contains(t, reason, "which is less than the ignore minimum age of 1h0m0s")
+
+	old := &pulltest.MockPullContext{CreatedValue: time.Now().Add(-2 * time.Hour)}
+	matches, _, err = signals.MatchesAny(ctx, old, "ignore")
+	require.NoError(t, err)
+	assert.False(t, matches, "old pull request matched")
+
+	mergeConfig := MergeConfig{Ignore: signals}
+	assert.WithinDuration(t, young.CreatedValue.Add(time.Hour), MinAgeReachedAt(young, mergeConfig), time.Second)
+	assert.True(t, MinAgeReachedAt(old, mergeConfig).IsZero())
+	assert.True(t, MinAgeReachedAt(young, MergeConfig{}).IsZero())
+}
+
+func TestSignalsStaleApprovals(t *testing.T) {
+	signals := Signals{StaleApprovals: true}
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Reviews []*pull.Review
+		Matches bool
+		Reason  string
+	}{
+		"staleApproval": {
+			Reviews: []*pull.Review{
+				{Author: "bob", State: pull.ReviewApproved, CommitSHA: "old"},
+			},
+			Matches: true,
+			Reason:  "pull request has a stale approval: the latest approval, by bob, was submitted before the latest push",
+		},
+		"freshApproval": {
+			Reviews: []*pull.Review{
+				{Author: "bob", State: pull.ReviewApproved, CommitSHA: "old"},
+				{Author: "carol", State: pull.ReviewApproved, CommitSHA: "head"},
+			},
+			Matches: false,
+		},
+		"ignoresAuthorAndComments": {
+			Reviews: []*pull.Review{
+				{Author: "bob", State: pull.ReviewApproved, CommitSHA: "head"},
+				{Author: "carol", State: pull.ReviewCommented, CommitSHA: "old"},
+				{Author: "alice", State: pull.ReviewApproved, CommitSHA: "old"},
+			},
+			Matches: false,
+		},
+		"noApprovals": {
+			Reviews: []*pull.Review{
+				{Author: "bob", State: pull.ReviewChangesRequested, CommitSHA: "old"},
+			},
+			Matches: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pullCtx := &pulltest.MockPullContext{AuthorValue: "alice", HeadSHAValue: "head", ReviewsValue: test.Reviews}
+
+			matches, reason, err := signals.StaleApprovals.Matches(ctx, pullCtx, "ignore")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsChangesRequested(t *testing.T) {
+	signals := Signals{ChangesRequested: true}
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Reviews []*pull.Review
+		Matches bool
+		Reason  string
+	}{
+		"changesRequested": {
+			Reviews: []*pull.Review{
+				{Author: "bob", State: pull.ReviewChangesRequested},
+				{Author: "carol", State: pull.ReviewApproved},
+				{Author: "bob", State: pull.ReviewCommented},
+			},
+			Matches: true,
+			Reason:  "pull request has changes requested by bob",
+		},
+		"approvedAfterChanges": {
+			Reviews: []*pull.Review{
+				{Author: "bob", State: pull.ReviewChangesRequested},
+				{Author: "bob", State: pull.ReviewApproved},
+			},
+			Matches: false,
+		},
+		"dismissed": {
+			Reviews: []*pull.Review{
+				{Author: "bob", State: pull.ReviewDismissed},
+				{Author: "carol", State: pull.ReviewApproved},
+			},
+			Matches: false,
+		},
+		"multipleReviewers": {
+			Reviews: []*pull.Review{
+				{Author: "carol", State: pull.ReviewChangesRequested},
+				{Author: "bob", State: pull.ReviewChangesRequested},
+				{Author: "Carol", State: pull.ReviewChangesRequested},
+			},
+			Matches: true,
+			Reason:  "pull request has changes requested by carol, bob",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pullCtx := &pulltest.MockPullContext{AuthorValue: "alice", ReviewsValue: test.Reviews}
+
+			matches, reason, err := signals.ChangesRequested.Matches(ctx, pullCtx, "ignore")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsPendingReviewers(t *testing.T) {
+	signals := Signals{PendingReviewers: true}
+	ctx := context.Background()
+
+	matches, reason, err := signals.MatchesAny(ctx, &pulltest.MockPullContext{RequestedReviewersValue: []string{"bob", "testorg/reviewers"}}, "ignore")
+	require.NoError(t, err)
+	assert.True(t, matches)
+	assert.Equal(t, "pull request is waiting for requested reviews from bob, testorg/reviewers", reason)
+
+	matches, _, err = signals.MatchesAny(ctx, &pulltest.MockPullContext{}, "ignore")
+	require.NoError(t, err)
+	assert.False(t, matches)
+}
+
+func TestSignalsCodeOwners(t *testing.T) {
+	signals := Signals{CodeOwners: true}
+	ctx := context.Background()
+
+	codeOwners, err := pull.ParseCodeOwners(`
+*         @testorg/maintainers
+/docs/    @carol docs@example.com
+/vendor/
+`)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		Author     string
+		CodeOwners *pull.CodeOwners
+		Files      []string
+		Reviews    []*pull.Review
+		Matches    bool
+		Reason     string
+	}{
+		"allEntriesApproved": {
+			CodeOwners: codeOwners,
+			Files:      []string{"main.go", "docs/index.md", "vendor/lib.go"},
+			Reviews: []*pull.Review{
+				{Author: "bob", State: pull.ReviewApproved},
+				{Author: "carol", State: pull.ReviewApproved},
+			},
+			Matches: true,
+			Reason:  "pull request is approved by code owners of all 2 changed CODEOWNERS entries",
+		},
+		"entryNotApproved": {
+			CodeOwners: codeOwners,
+			Files:      []string{"main.go", "docs/index.md"},
+			Reviews: []*pull.Review{
+				{Author: "bob", State: pull.ReviewApproved},
+			},
+			Matches: false,
+		},
+		"authorApprovalDoesNotCount": {
+			Author:     "carol",
+			CodeOwners: codeOwners,
+			Files:      []string{"docs/index.md"},
+			Reviews: []*pull.Review{
+				{Author: "carol", State: pull.ReviewApproved},
+			},
+			Matches: false,
+		},
+		"teamApprovalWithdrawn": {
+			CodeOwners: codeOwners,
+			Files:      []string{"main.go"},
+			Reviews: []*pull.Review{
+				{Author: "bob", State: pull.ReviewApproved},
+				{Author: "bob", State: pull.ReviewChangesRequested},
+			},
+			Matches: false,
+		},
+		"noOwnedFiles": {
+			CodeOwners: codeOwners,
+			Files:      []string{"vendor/lib.go"},
+			Reviews: []*pull.Review{
+				{Author: "bob", State: pull.ReviewApproved},
+			},
+			Matches: false,
+		},
+		"noCodeOwnersFile": {
+			Files:   []string{"main.go"},
+			Matches: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			author := test.Author
+			if author == "" {
+				author = "alice"
+			}
+			pullCtx := &pulltest.MockPullContext{
+				AuthorValue:       author,
+				CodeOwnersValue:   test.CodeOwners,
+				ChangedFilesValue: test.Files,
+				ReviewsValue:      test.Reviews,
+				TeamMembersValue:  map[string][]string{"testorg/maintainers": {"alice", "bob"}},
+			}
+
+			matches, reason, err := signals.CodeOwners.Matches(ctx, pullCtx, "trigger")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
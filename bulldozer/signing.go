@@ -0,0 +1,106 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/google/go-github/v50/github"
+	"github.com/pkg/errors"
+)
+
+// CommitSigner signs the commits that bulldozer creates with the git data
+// API using a GPG key, so that they are verified on branches that require
+// signed commits. Commits are authored and committed by the primary identity
+// of the key, which must match a verified email of the GitHub account that has
+// the public key. A nil signer creates unsigned commits.
+type CommitSigner struct {
+	key   *openpgp.Entity
+	name  string
+	email string
+	now   func() time.Time
+}
+
+// NewCommitSigner creates a signer from an ASCII armored private key,
+// decrypting it with the passphrase if it is encrypted.
+func NewCommitSigner(armoredKey, passphrase string) (*CommitSigner, error) {
+	keys, err := openpgp.ReadArmoredKeyRing(strings.NewReader(armoredKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read signing key")
+	}
+	if len(keys) != 1 {
+		return nil, errors.Errorf("signing key must contain exactly one key, but found %d", len(keys))
+	}
+	key := keys[0]
+
+	if key.PrivateKey == nil {
+		return nil, errors.New("signing key must be a private key")
+	}
+	if err := decryptKey(key.PrivateKey, passphrase); err != nil {
+		return nil, err
+	}
+	for _, subkey := range key.Subkeys {
+		if err := decryptKey(subkey.PrivateKey, passphrase); err != nil {
+			return nil, err
+		}
+	}
+
+	identity := key.PrimaryIdentity()
+	if identity == nil || identity.UserId == nil || identity.UserId.Email == "" {
+		return nil, errors.New("signing key must have an identity with an email")
+	}
+
+	return &CommitSigner{
+		key:   key,
+		name:  identity.UserId.Name,
+		email: identity.UserId.Email,
+		now:   time.Now,
+	}, nil
+}
+
+func decryptKey(key *packet.PrivateKey, passphrase string) error {
+	if key == nil || !key.Encrypted {
+		return nil
+	}
+	if passphrase == "" {
+		return errors.New("signing key is encrypted, but there is no passphrase")
+	}
+	if err := key.Decrypt([]byte(passphrase)); err != nil {
+		return errors.Wrap(err, "failed to decrypt signing key")
+	}
+	return nil
+}
+
+// sign sets the author, committer, and signing key of a commit before it is
+// created. It does nothing if the signer is nil.
+func (s *CommitSigner) sign(commit *github.Commit) {
+	if s == nil {
+		return
+	}
+
+	// signatures only include whole seconds
+	now := &github.Timestamp{Time: s.now().Truncate(time.Second)}
+	identity := &github.CommitAuthor{
+		Name:  github.String(s.name),
+		Email: github.String(s.email),
+		Date:  now,
+	}
+	commit.Author = identity
+	commit.Committer = identity
+	commit.SigningKey = s.key
+}
@@ -0,0 +1,175 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/bulldozer/pull/pulltest"
+)
+
+func newTestSigningKey(t *testing.T, passphrase string) (*openpgp.Entity, string) {
+	key, err := openpgp.NewEntity("Bulldozer", "", "bulldozer@example.com", nil)
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	w, err := armor.Encode(&b, openpgp.PrivateKeyType, nil)
+	require.NoError(t, err)
+	if passphrase != "" {
+		require.NoError(t, key.PrivateKey.Encrypt([]byte(passphrase)))
+		for _, subkey := range key.Subkeys {
+			require.NoError(t, subkey.PrivateKey.Encrypt([]byte(passphrase)))
+		}
+		require.NoError(t, key.SerializePrivateWithoutSigning(w, nil))
+	} else {
+		require.NoError(t, key.SerializePrivate(w, nil))
+	}
+	require.NoError(t, w.Close())
+	return key, b.String()
+}
+
+func TestNewCommitSigner(t *testing.T) {
+	_, armored := newTestSigningKey(t, "")
+	signer, err := NewCommitSigner(armored, "")
+	require.NoError(t, err)
+	assert.Equal(t, "Bulldozer", signer.name)
+	assert.Equal(t, "bulldozer@example.com", signer.email)
+
+	_, encrypted := newTestSigningKey(t, "secret")
+	_, err = NewCommitSigner(encrypted, "")
+	assert.EqualError(t, err, "signing key is encrypted, but there is no passphrase")
+
+	_, err = NewCommitSigner(encrypted, "wrong")
+	assert.Error(t, err)
+
+	_, err = NewCommitSigner(encrypted, "secret")
+	assert.NoError(t, err)
+
+	_, err = NewCommitSigner("not a key", "")
+	assert.Error(t, err)
+}
+
+func TestUpdatePRSigned(t *testing.T) {
+	key, armored := newTestSigningKey(t, "")
+	signer, err := NewCommitSigner(armored, "")
+	require.NoError(t, err)
+	signer.now = func() time.Time { return time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC) }
+
+	var created map[string]interface{}
+	var refs []string
+	var deleted []string
+	var tempExists bool
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/testorg/testrepo/pulls/1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"number": 1, "state": "open", "head": {"ref": "feature", "sha": "head", "repo": {"fork": false}}}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/compare/develop...head", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"behind_by": 2}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/git/refs", func(w http.ResponseWriter, r *http.Request) {
+		var ref map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&ref))
+		assert.Equal(t, map[string]string{"ref": "refs/heads/bulldozer/update-1", "sha": "head"}, ref)
+		if tempExists {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = io.WriteString(w, `{"message": "Reference already exists"}`)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/git/refs/heads/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodDelete:
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodPatch:
+			var ref map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&ref))
+			refs = append(refs, fmt.Sprintf("%s=%s force=%v", r.URL.Path, ref["sha"], ref["force"]))
+			_, _ = io.WriteString(w, `{}`)
+		}
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/merges", func(w http.ResponseWriter, r *http.Request) {
+		var req github.RepositoryMergeRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "bulldozer/update-1", req.GetBase())
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{"sha": "unsigned", "commit": {"tree": {"sha": "tree"}}, "parents": [{"sha": "head"}, {"sha": "base"}]}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/git/commits", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{"sha": "signed"}`)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	pullCtx := &pulltest.MockPullContext{OwnerValue: "testorg", RepoValue: "testrepo", NumberValue: 1}
+	result := UpdatePR(context.Background(), pullCtx, client, nil, UpdateConfig{Signer: signer}, "develop")
+	require.True(t, result.Updated)
+
+	assert.Equal(t, []string{"/repos/testorg/testrepo/git/refs/heads/feature=signed force=false"}, refs)
+	assert.Equal(t, []string{"/repos/testorg/testrepo/git/refs/heads/bulldozer/update-1"}, deleted)
+
+	assert.Equal(t, "tree", created["tree"])
+	assert.Equal(t, []interface{}{"head", "base"}, created["parents"])
+
+	message := strings.Join([]string{
+		"tree tree",
+		"parent head",
+		"parent base",
+		"author Bulldozer <bulldozer@example.com> 1780315200 +0000",
+		"committer Bulldozer <bulldozer@example.com> 1780315200 +0000",
+		"",
+		"Merge branch 'develop' into feature",
+	}, "\n")
+	_, err = openpgp.CheckArmoredDetachedSignature(openpgp.EntityList{key}, strings.NewReader(message), strings.NewReader(created["signature"].(string)), nil)
+	assert.NoError(t, err, "commit signature is not valid")
+
+	// a temporary branch left by an earlier update is reset instead of
+	// failing every later update
+	tempExists = true
+	refs, deleted = nil, nil
+	result = UpdatePR(context.Background(), pullCtx, client, nil, UpdateConfig{Signer: signer}, "develop")
+	require.True(t, result.Updated)
+
+	assert.Equal(t, []string{
+		"/repos/testorg/testrepo/git/refs/heads/bulldozer/update-1=head force=true",
+		"/repos/testorg/testrepo/git/refs/heads/feature=signed force=false",
+	}, refs)
+	assert.Equal(t, []string{"/repos/testorg/testrepo/git/refs/heads/bulldozer/update-1"}, deleted)
+}
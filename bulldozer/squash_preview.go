@@ -0,0 +1,125 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/pkg/errors"
+
+	"github.com/palantir/bulldozer/pull"
+)
+
+const previewMarker = "<!-- bulldozer:squash-preview -->"
+
+// PreviewConfig defines the comment that shows the squash commit message
+// before bulldozer merges a pull request.
+type PreviewConfig struct {
+	// Enabled posts the preview comment before squashing pull requests
+	Enabled bool `yaml:"enabled"`
+
+	// ObjectionWindow is how long bulldozer waits after posting or changing
+	// the preview before it merges the pull request, so that reviewers can
+	// change the message or stop the merge
+	ObjectionWindow Duration `yaml:"objection_window"`
+}
+
+// PreviewSquashCommit posts or updates a comment on the pull request that
+// shows the squash commit message, if previews are enabled and the pull
+// request merges with the squash method. It returns the time when the
+// objection window for the current message ends, which is the zero time if
+// there is nothing to wait for.
+//
+// The window starts again when the message changes, for example because the
+// title or body of the pull request was edited. The comment is the record of
+// when the message was shown, so the window survives restarts.
+func PreviewSquashCommit(ctx context.Context, pullCtx pull.Context, client *github.Client, mergeConfig MergeConfig) (time.Time, error) {
+	opt := mergeConfig.Options.Squash
+	if opt == nil || !opt.Preview.Enabled {
+		return time.Time{}, nil
+	}
+
+	method, err := DetermineMergeMethod(ctx, pullCtx, mergeConfig)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if method != SquashAndMerge {
+		return time.Time{}, nil
+	}
+
+	msg, err := squashCommitMessage(ctx, pullCtx, opt)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	window := time.Duration(opt.Preview.ObjectionWindow)
+	body := formatPreview(msg, window)
+
+	comment, err := upsertMarkedComment(ctx, pullCtx, client, previewMarker, body)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to post squash preview comment")
+	}
+
+	shownAt := comment.GetUpdatedAt().Time
+	if shownAt.IsZero() {
+		shownAt = comment.GetCreatedAt().Time
+	}
+	if shownAt.IsZero() {
+		shownAt = time.Now()
+	}
+	return shownAt.Add(window), nil
+}
+
+// formatPreview creates the body of the preview comment. GitHub chooses the
+// title and message when they are empty, so the comment says so instead of
+// showing an empty commit.
+func formatPreview(msg CommitMessage, window time.Duration) string {
+	var b strings.Builder
+	b.WriteString(previewMarker + "\n")
+	if window > 0 {
+		fmt.Fprintf(&b, "This pull request will be squashed and merged with the following commit after %s.", window)
+	} else {
+		b.WriteString("This pull request will be squashed and merged with the following commit.")
+	}
+	b.WriteString(" To change the commit, edit the title or description of the pull request. To stop the merge, remove the trigger or add an ignore label.\n\n")
+
+	title := msg.Title
+	if title == "" {
+		title = "(the default title chosen by GitHub)"
+	}
+	message := msg.Message
+	switch message {
+	case "":
+		message = "(a summary of the commits chosen by GitHub)"
+	case " ":
+		message = ""
+	}
+
+	commit := title
+	if message != "" {
+		commit += "\n\n" + message
+	}
+
+	fence := "```"
+	for strings.Contains(commit, fence) {
+		fence += "`"
+	}
+	fmt.Fprintf(&b, "%s\n%s\n%s\n", fence, commit, fence)
+	return b.String()
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/bulldozer/pull/pulltest"
+)
+
+func TestPreviewSquashCommit(t *testing.T) {
+	shownAt := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	var comments []*github.IssueComment
+	var created, edited int
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/testorg/testrepo/issues/1/comments", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			var c github.IssueComment
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&c))
+			c.ID = github.Int64(int64(len(comments) + 1))
+			c.UpdatedAt = &github.Timestamp{Time: shownAt}
+			comments = append(comments, &c)
+			created++
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(c)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(comments)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/issues/comments/1", func(w http.ResponseWriter, r *http.Request) {
+		var c github.IssueComment
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&c))
+		comments[0].Body = c.Body
+		comments[0].UpdatedAt = &github.Timestamp{Time: shownAt.Add(time.Hour)}
+		edited++
+		_ = json.NewEncoder(w).Encode(comments[0])
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	ctx := context.Background()
+	pullCtx := &pulltest.MockPullContext{
+		OwnerValue:  "testorg",
+		RepoValue:   "testrepo",
+		NumberValue: 1,
+		TitleValue:  "Fix the bug",
+		BodyValue:   "Fixes the bug in the parser.",
+	}
+	mergeConfig := MergeConfig{
+		Method: SquashAndMerge,
+		Options: MergeOptions{
+			Squash: &SquashOptions{
+				Body:    PullRequestBody,
+				Preview: PreviewConfig{Enabled: true, ObjectionWindow: Duration(30 * time.Minute)},
+			},
+		},
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		readyAt, err := PreviewSquashCommit(ctx, pullCtx, client, MergeConfig{Method: SquashAndMerge})
+		require.NoError(t, err)
+		assert.True(t, readyAt.IsZero())
+		assert.Empty(t, comments)
+	})
+
+	t.Run("notSquash", func(t *testing.T) {
+		config := mergeConfig
+		config.Method = RebaseAndMerge
+
+		readyAt, err := PreviewSquashCommit(ctx, pullCtx, client, config)
+		require.NoError(t, err)
+		assert.True(t, readyAt.IsZero())
+		assert.Empty(t, comments)
+	})
+
+	t.Run("create", func(t *testing.T) {
+		readyAt, err := PreviewSquashCommit(ctx, pullCtx, client, mergeConfig)
+		require.NoError(t, err)
+		assert.Equal(t, shownAt.Add(30*time.Minute), readyAt)
+
+		require.Len(t, comments, 1)
+		assert.Contains(t, comments[0].GetBody(), "```\nFix the bug (#1)\n\nFixes the bug in the parser.\n```")
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		readyAt, err := PreviewSquashCommit(ctx, pullCtx, client, mergeConfig)
+		require.NoError(t, err)
+		assert.Equal(t, shownAt.Add(30*time.Minute), readyAt)
+		assert.Equal(t, 1, created)
+		assert.Equal(t, 0, edited)
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		pullCtx.TitleValue = "Fix the parser bug"
+
+		readyAt, err := PreviewSquashCommit(ctx, pullCtx, client, mergeConfig)
+		require.NoError(t, err)
+		assert.Equal(t, shownAt.Add(90*time.Minute), readyAt)
+		assert.Equal(t, 1, created)
+		assert.Equal(t, 1, edited)
+		assert.Contains(t, comments[0].GetBody(), "Fix the parser bug (#1)")
+	})
+}
+
+func TestFormatPreview(t *testing.T) {
+	body := formatPreview(CommitMessage{Message: "```go\nfmt.Println()\n```"}, 0)
+	assert.Contains(t, body, "````\n(the default title chosen by GitHub)\n\n```go\nfmt.Println()\n```\n````")
+
+	body = formatPreview(CommitMessage{Title: "Fix the bug (#1)"}, time.Hour)
+	assert.Contains(t, body, "after 1h0m0s")
+	assert.Contains(t, body, "(a summary of the commits chosen by GitHub)")
+
+	body = formatPreview(CommitMessage{Title: "Fix the bug (#1)", Message: " "}, 0)
+	assert.Contains(t, body, "```\nFix the bug (#1)\n```")
+}
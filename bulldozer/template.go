@@ -0,0 +1,119 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/palantir/bulldozer/pull"
+	"github.com/pkg/errors"
+)
+
+// CommitMessageData is the data available to commit message templates.
+type CommitMessageData struct {
+	Title      string
+	Number     int
+	Author     string
+	Body       string
+	Labels     []string
+	BaseBranch string
+	HeadBranch string
+
+	// Commits are the commits in the pull request, from oldest to newest.
+	Commits []*pull.Commit
+
+	// CoAuthors are the distinct authors of the commits in the pull request,
+	// formatted as "Name <email>" for use in "Co-authored-by" trailers.
+	CoAuthors []string
+}
+
+var templateFuncs = template.FuncMap{
+	"join":  func(elems []string, sep string) string { return strings.Join(elems, sep) },
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"trim":  strings.TrimSpace,
+	"regexFind": func(pattern, s string) (string, error) {
+		r, err := regexp.Compile(pattern)
+		if err != nil {
+			return "", err
+		}
+		return r.FindString(s), nil
+	},
+}
+
+func parseCommitTemplate(name, text string) (*template.Template, error) {
+	t, err := template.New(name).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s", name)
+	}
+	return t, nil
+}
+
+func newCommitMessageData(ctx context.Context, pullCtx pull.Context) (*CommitMessageData, error) {
+	labels, err := pullCtx.Labels(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get labels")
+	}
+
+	commits, err := pullCtx.Commits(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get commits")
+	}
+
+	var coAuthors []string
+	seen := make(map[string]bool)
+	for _, c := range commits {
+		if c.AuthorEmail == "" || seen[strings.ToLower(c.AuthorEmail)] {
+			continue
+		}
+		seen[strings.ToLower(c.AuthorEmail)] = true
+		coAuthors = append(coAuthors, fmt.Sprintf("%s <%s>", c.AuthorName, c.AuthorEmail))
+	}
+
+	base, head := pullCtx.Branches()
+	return &CommitMessageData{
+		Title:      pullCtx.Title(),
+		Number:     pullCtx.Number(),
+		Author:     pullCtx.Author(),
+		Body:       pullCtx.Body(),
+		Labels:     labels,
+		BaseBranch: base,
+		HeadBranch: head,
+		Commits:    commits,
+		CoAuthors:  coAuthors,
+	}, nil
+}
+
+func executeCommitTemplate(ctx context.Context, pullCtx pull.Context, name, text string) (string, error) {
+	t, err := parseCommitTemplate(name, text)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := newCommitMessageData(ctx, pullCtx)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", errors.Wrapf(err, "failed to execute %s", name)
+	}
+	return strings.TrimSpace(b.String()), nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/palantir/bulldozer/pull"
+	"github.com/palantir/bulldozer/pull/pulltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitMessageTemplates(t *testing.T) {
+	pullCtx := &pulltest.MockPullContext{
+		NumberValue: 42,
+		TitleValue:  "Add the thing",
+		BodyValue:   "This adds the thing.",
+		AuthorValue: "mhaypenny",
+		BranchBase:  "develop",
+		BranchName:  "feature/PROJ-123-thing",
+		LabelValue:  []string{"feat", "api"},
+		CommitsValue: []*pull.Commit{
+			{Message: "first", AuthorName: "Alice", AuthorEmail: "alice@example.com"},
+			{Message: "second", AuthorName: "Bob", AuthorEmail: "bob@example.com"},
+			{Message: "third", AuthorName: "Alice", AuthorEmail: "ALICE@example.com"},
+		},
+	}
+
+	tests := map[string]struct {
+		Template string
+		Output   string
+	}{
+		"fields": {
+			Template: "{{.Title}} (#{{.Number}}) by {{.Author}} into {{.BaseBranch}}",
+			Output:   "Add the thing (#42) by mhaypenny into develop",
+		},
+		"conventionalCommit": {
+			Template: `{{index .Labels 0}}: {{regexFind "[A-Z]+-[0-9]+" .HeadBranch}} {{lower .Title}}`,
+			Output:   "feat: PROJ-123 add the thing",
+		},
+		"labels": {
+			Template: `{{join .Labels ", "}}`,
+			Output:   "feat, api",
+		},
+		"coAuthors": {
+			Template: "{{.Body}}\n\n{{range .CoAuthors}}Co-authored-by: {{.}}\n{{end}}",
+			Output:   "This adds the thing.\n\nCo-authored-by: Alice <alice@example.com>\nCo-authored-by: Bob <bob@example.com>",
+		},
+		"commits": {
+			Template: "{{range .Commits}}* {{.Message}}\n{{end}}",
+			Output:   "* first\n* second\n* third",
+		},
+	}
+
+	ctx := context.Background()
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			title, err := calculateCommitTitle(ctx, pullCtx, SquashOptions{TitleTemplate: test.Template})
+			require.NoError(t, err)
+			assert.Equal(t, test.Output, title, "calculated title is incorrect")
+
+			body, err := calculateCommitMessage(ctx, pullCtx, SquashOptions{BodyTemplate: test.Template})
+			require.NoError(t, err)
+			assert.Equal(t, test.Output, body, "calculated body is incorrect")
+		})
+	}
+
+	t.Run("emptyBody", func(t *testing.T) {
+		body, err := calculateCommitMessage(ctx, pullCtx, SquashOptions{BodyTemplate: "{{if false}}x{{end}}"})
+		require.NoError(t, err)
+		assert.Equal(t, " ", body)
+	})
+
+	t.Run("executeError", func(t *testing.T) {
+		_, err := calculateCommitTitle(ctx, pullCtx, SquashOptions{TitleTemplate: "{{.Missing}}"})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalidTemplateInConfig", func(t *testing.T) {
+		_, err := ParseConfig([]byte(`
+version: 1
+merge:
+  options:
+    squash:
+      body_template: "{{.Body"
+`))
+		assert.Error(t, err)
+	})
+}
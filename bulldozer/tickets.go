@@ -0,0 +1,66 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/palantir/bulldozer/pull"
+	"github.com/pkg/errors"
+)
+
+// ErrNoTicketTracker means the server does not define an issue tracker, so
+// the status of tickets cannot be checked.
+var ErrNoTicketTracker = errors.New("no issue tracker is configured")
+
+// DefaultTicketPattern matches ticket keys like "ABC-123", the format used by
+// Jira.
+const DefaultTicketPattern = `\b[A-Z][A-Z0-9]+-[0-9]+\b`
+
+// TicketKeys returns the ticket keys that the pattern finds in the texts,
+// without duplicates, in the order they first appear. If the pattern is
+// empty, it uses DefaultTicketPattern.
+func TicketKeys(pattern string, texts ...string) ([]string, error) {
+	if pattern == "" {
+		pattern = DefaultTicketPattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid ticket pattern %q", pattern)
+	}
+
+	var keys []string
+	seen := make(map[string]bool)
+	for _, text := range texts {
+		for _, key := range re.FindAllString(text, -1) {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys, nil
+}
+
+// TicketStatus returns the status of the ticket in the issue tracker defined
+// by the server. It returns ErrNoTicketTracker if the server does not define
+// an issue tracker.
+func TicketStatus(ctx context.Context, pullCtx pull.Context, key string) (string, error) {
+	if !pullCtx.HasTicketTracker() {
+		return "", ErrNoTicketTracker
+	}
+	return pullCtx.TicketStatus(ctx, key)
+}
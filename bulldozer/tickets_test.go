@@ -0,0 +1,35 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTicketKeys(t *testing.T) {
+	keys, err := TicketKeys("", "ABC-12: fix OPS-3 and ABC-12", "feature/OPS-3-retry", "abc-4 x-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ABC-12", "OPS-3"}, keys)
+
+	keys, err = TicketKeys(`#[0-9]+`, "Fix #12")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"#12"}, keys)
+
+	_, err = TicketKeys("(", "ABC-12")
+	assert.EqualError(t, err, "invalid ticket pattern \"(\": error parsing regexp: missing closing ): `(`")
+}
@@ -0,0 +1,170 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/palantir/bulldozer/pull"
+)
+
+const (
+	coAuthoredBy = "Co-authored-by"
+	signedOffBy  = "Signed-off-by"
+
+	customTrailerTemplate = "custom trailer"
+)
+
+var trailerPattern = regexp.MustCompile(`^([A-Za-z0-9-]+):\s*(\S.*)$`)
+
+// TrailerConfig defines the trailers that are added to squash commit
+// messages.
+type TrailerConfig struct {
+	// CoAuthors adds the "Co-authored-by" trailers from every commit in the
+	// pull request
+	CoAuthors bool `yaml:"co_authors"`
+
+	// SignedOffBy adds the "Signed-off-by" trailers from every commit in the
+	// pull request
+	SignedOffBy bool `yaml:"signed_off_by"`
+
+	// Custom are templates for additional trailers, like "PR: #{{.Number}}".
+	// They use the same data and functions as the commit message templates.
+	// Trailers that are empty after executing the template are skipped.
+	Custom []string `yaml:"custom"`
+}
+
+func (c TrailerConfig) enabled() bool {
+	return c.CoAuthors || c.SignedOffBy || len(c.Custom) > 0
+}
+
+// addTrailers appends the configured trailers to a squash commit message,
+// skipping duplicates and trailers that are already in the message. If the
+// message is the GitHub default, it is replaced by the same summary of the
+// commits GitHub would create, without the collected trailers, so that the
+// trailers can be added after it.
+func addTrailers(ctx context.Context, pullCtx pull.Context, message string, config TrailerConfig) (string, error) {
+	if !config.enabled() {
+		return message, nil
+	}
+
+	var keys []string
+	if config.CoAuthors {
+		keys = append(keys, coAuthoredBy)
+	}
+	if config.SignedOffBy {
+		keys = append(keys, signedOffBy)
+	}
+
+	var trailers []string
+	var commits []*pull.Commit
+	if len(keys) > 0 || message == "" {
+		var err error
+		if commits, err = pullCtx.Commits(ctx); err != nil {
+			return "", errors.Wrap(err, "failed to get commits")
+		}
+	}
+	for _, c := range commits {
+		trailers = append(trailers, findTrailers(c.Message, keys)...)
+	}
+
+	for _, text := range config.Custom {
+		trailer, err := executeCommitTemplate(ctx, pullCtx, customTrailerTemplate, text)
+		if err != nil {
+			return "", err
+		}
+		if trailer != "" {
+			trailers = append(trailers, trailer)
+		}
+	}
+
+	switch message {
+	case "":
+		message = summarizeCommits(commits, keys)
+	case " ":
+		message = ""
+	}
+
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(message, "\n") {
+		seen[strings.ToLower(strings.TrimSpace(line))] = true
+	}
+
+	var added []string
+	for _, trailer := range trailers {
+		if key := strings.ToLower(trailer); !seen[key] {
+			seen[key] = true
+			added = append(added, trailer)
+		}
+	}
+
+	switch {
+	case len(added) == 0 && message == "":
+		// a non-empty message that GitHub interprets as empty
+		return " ", nil
+	case len(added) == 0:
+		return message, nil
+	case message == "":
+		return strings.Join(added, "\n"), nil
+	}
+	return strings.TrimRight(message, "\n") + "\n\n" + strings.Join(added, "\n"), nil
+}
+
+// findTrailers returns the trailers in the message with one of the keys,
+// using the canonical spelling of the key.
+func findTrailers(message string, keys []string) []string {
+	var trailers []string
+	for _, line := range strings.Split(message, "\n") {
+		if key, value, ok := parseTrailer(line, keys); ok {
+			trailers = append(trailers, key+": "+value)
+		}
+	}
+	return trailers
+}
+
+func parseTrailer(line string, keys []string) (string, string, bool) {
+	m := trailerPattern.FindStringSubmatch(strings.TrimSpace(line))
+	if m == nil {
+		return "", "", false
+	}
+	for _, key := range keys {
+		if strings.EqualFold(m[1], key) {
+			return key, strings.TrimSpace(m[2]), true
+		}
+	}
+	return "", "", false
+}
+
+// summarizeCommits lists the messages of the commits like the default GitHub
+// squash message, removing trailers with one of the keys.
+func summarizeCommits(commits []*pull.Commit, keys []string) string {
+	summaries := make([]string, 0, len(commits))
+	for _, c := range commits {
+		var lines []string
+		for _, line := range strings.Split(c.Message, "\n") {
+			if _, _, ok := parseTrailer(line, keys); !ok {
+				lines = append(lines, line)
+			}
+		}
+		if summary := strings.TrimSpace(strings.Join(lines, "\n")); summary != "" {
+			summaries = append(summaries, "* "+summary)
+		}
+	}
+	return strings.Join(summaries, "\n\n")
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/bulldozer/pull"
+	"github.com/palantir/bulldozer/pull/pulltest"
+)
+
+func TestAddTrailers(t *testing.T) {
+	pullCtx := &pulltest.MockPullContext{
+		NumberValue: 123,
+		CommitsValue: []*pull.Commit{
+			{SHA: "a6b1b2c", Message: "Fix the parser\n\nCo-authored-by: Alice <alice@example.com>\nSigned-off-by: Bob <bob@example.com>"},
+			{SHA: "d4e5f6a", Message: "Add tests\n\nco-authored-by: Alice <alice@example.com>\nCo-authored-by: Carol <carol@example.com>"},
+		},
+	}
+
+	tests := map[string]struct {
+		Message  string
+		Config   TrailerConfig
+		Expected string
+	}{
+		"disabled": {
+			Message:  "Fixes the parser.",
+			Expected: "Fixes the parser.",
+		},
+		"coAuthors": {
+			Message:  "Fixes the parser.",
+			Config:   TrailerConfig{CoAuthors: true},
+			Expected: "Fixes the parser.\n\nCo-authored-by: Alice <alice@example.com>\nCo-authored-by: Carol <carol@example.com>",
+		},
+		"allTrailers": {
+			Message:  "Fixes the parser.",
+			Config:   TrailerConfig{CoAuthors: true, SignedOffBy: true, Custom: []string{"PR: #{{.Number}}", "{{if .Labels}}Labels: {{join .Labels \",\"}}{{end}}"}},
+			Expected: "Fixes the parser.\n\nCo-authored-by: Alice <alice@example.com>\nSigned-off-by: Bob <bob@example.com>\nCo-authored-by: Carol <carol@example.com>\nPR: #123",
+		},
+		"alreadyInMessage": {
+			Message:  "Fixes the parser.\n\nCo-authored-by: Carol <carol@example.com>",
+			Config:   TrailerConfig{CoAuthors: true},
+			Expected: "Fixes the parser.\n\nCo-authored-by: Carol <carol@example.com>\n\nCo-authored-by: Alice <alice@example.com>",
+		},
+		"emptyMessage": {
+			Message:  " ",
+			Config:   TrailerConfig{SignedOffBy: true},
+			Expected: "Signed-off-by: Bob <bob@example.com>",
+		},
+		"emptyMessageWithoutTrailers": {
+			Message:  " ",
+			Config:   TrailerConfig{Custom: []string{"{{.Author}}"}},
+			Expected: " ",
+		},
+		"summarizeCommits": {
+			Message:  "",
+			Config:   TrailerConfig{CoAuthors: true},
+			Expected: "* Fix the parser\n\nSigned-off-by: Bob <bob@example.com>\n\n* Add tests\n\nCo-authored-by: Alice <alice@example.com>\nCo-authored-by: Carol <carol@example.com>",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			message, err := addTrailers(context.Background(), pullCtx, test.Message, test.Config)
+			require.NoError(t, err)
+			assert.Equal(t, test.Expected, message)
+		})
+	}
+}
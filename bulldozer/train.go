@@ -0,0 +1,124 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+type trainKey struct {
+	owner string
+	repo  string
+	base  string
+}
+
+type trainWaiter struct {
+	priority int
+	ready    chan struct{}
+}
+
+type trainCar struct {
+	waiting []*trainWaiter
+}
+
+// MergeTrain serializes merges into the same base branch. Callers acquire the
+// branch before evaluating and merging a pull request and release it after the
+// merge completes, so each pull request is evaluated against the result of the
+// previous merge. Waiting callers acquire the branch in order of priority, and
+// callers with the same priority acquire it in the order they started waiting.
+// Different branches do not block each other. It is safe for concurrent use.
+type MergeTrain struct {
+	mu   sync.Mutex
+	cars map[trainKey]*trainCar
+}
+
+func NewMergeTrain() *MergeTrain {
+	return &MergeTrain{
+		cars: make(map[trainKey]*trainCar),
+	}
+}
+
+// Acquire blocks until no other caller holds the base branch of the repository
+// or the context is done. On success, the caller must call the returned
+// function to release the branch.
+func (t *MergeTrain) Acquire(ctx context.Context, owner, repo, base string, priority int) (func(), error) {
+	key := trainKey{
+		owner: strings.ToLower(owner),
+		repo:  strings.ToLower(repo),
+		base:  base,
+	}
+
+	t.mu.Lock()
+	car, ok := t.cars[key]
+	if !ok {
+		t.cars[key] = &trainCar{}
+		t.mu.Unlock()
+		return t.releaser(key), nil
+	}
+
+	w := &trainWaiter{priority: priority, ready: make(chan struct{})}
+	i := sort.Search(len(car.waiting), func(i int) bool {
+		return car.waiting[i].priority < priority
+	})
+	car.waiting = append(car.waiting, nil)
+	copy(car.waiting[i+1:], car.waiting[i:])
+	car.waiting[i] = w
+	t.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return t.releaser(key), nil
+	case <-ctx.Done():
+	}
+
+	t.mu.Lock()
+	for i, other := range car.waiting {
+		if other == w {
+			car.waiting = append(car.waiting[:i], car.waiting[i+1:]...)
+			t.mu.Unlock()
+			return nil, errors.Wrapf(ctx.Err(), "stopped waiting to merge into %s/%s:%s", owner, repo, base)
+		}
+	}
+	t.mu.Unlock()
+
+	// the branch was handed to this caller after the context was done
+	t.releaser(key)()
+	return nil, errors.Wrapf(ctx.Err(), "stopped waiting to merge into %s/%s:%s", owner, repo, base)
+}
+
+func (t *MergeTrain) releaser(key trainKey) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+
+			car := t.cars[key]
+			if len(car.waiting) == 0 {
+				delete(t.cars, key)
+				return
+			}
+
+			next := car.waiting[0]
+			car.waiting = car.waiting[1:]
+			close(next.ready)
+		})
+	}
+}
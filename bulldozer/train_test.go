@@ -0,0 +1,127 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeTrain(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("serializesSameBranch", func(t *testing.T) {
+		train := NewMergeTrain()
+
+		var active, maxActive int32
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				release, err := train.Acquire(ctx, "testorg", "testrepo", "develop", 0)
+				if !assert.NoError(t, err) {
+					return
+				}
+				defer release()
+
+				n := atomic.AddInt32(&active, 1)
+				for {
+					m := atomic.LoadInt32(&maxActive)
+					if n <= m || atomic.CompareAndSwapInt32(&maxActive, m, n) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				atomic.AddInt32(&active, -1)
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), maxActive, "multiple merges ran at the same time")
+		assert.Empty(t, train.cars, "released branches were not removed")
+	})
+
+	t.Run("differentBranchesDoNotBlock", func(t *testing.T) {
+		train := NewMergeTrain()
+
+		release, err := train.Acquire(ctx, "testorg", "testrepo", "develop", 0)
+		require.NoError(t, err)
+		defer release()
+
+		other, err := train.Acquire(ctx, "testorg", "testrepo", "release", 0)
+		require.NoError(t, err)
+		other()
+	})
+
+	t.Run("stopsWaitingWhenContextIsDone", func(t *testing.T) {
+		train := NewMergeTrain()
+
+		release, err := train.Acquire(ctx, "testorg", "testrepo", "develop", 0)
+		require.NoError(t, err)
+
+		waitCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		_, err = train.Acquire(waitCtx, "TestOrg", "testrepo", "develop", 0)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		release()
+		release()
+		assert.Empty(t, train.cars, "released branches were not removed")
+	})
+
+	t.Run("ordersWaitersByPriority", func(t *testing.T) {
+		train := NewMergeTrain()
+
+		release, err := train.Acquire(ctx, "testorg", "testrepo", "develop", 0)
+		require.NoError(t, err)
+
+		var mu sync.Mutex
+		var order []int
+		var wg sync.WaitGroup
+		for i, priority := range []int{0, 10, -10, 10} {
+			wg.Add(1)
+			go func(id, priority int) {
+				defer wg.Done()
+				release, err := train.Acquire(ctx, "testorg", "testrepo", "develop", priority)
+				if !assert.NoError(t, err) {
+					return
+				}
+				mu.Lock()
+				order = append(order, id)
+				mu.Unlock()
+				release()
+			}(i, priority)
+
+			// wait for each caller to join the queue to fix the arrival order
+			require.Eventually(t, func() bool {
+				train.mu.Lock()
+				defer train.mu.Unlock()
+				return len(train.cars[trainKey{"testorg", "testrepo", "develop"}].waiting) == i+1
+			}, time.Second, time.Millisecond)
+		}
+
+		release()
+		wg.Wait()
+		assert.Equal(t, []int{1, 3, 0, 2}, order)
+	})
+}
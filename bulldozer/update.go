@@ -16,51 +16,224 @@ package bulldozer
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/google/go-github/v50/github"
 	"github.com/palantir/bulldozer/pull"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
+	"github.com/shurcooL/githubv4"
 )
 
-func UpdatePR(ctx context.Context, pullCtx pull.Context, client *github.Client, updateConfig UpdateConfig, baseRef string) bool {
+// UpdatePullRequestBranchInput is the input to the updatePullRequestBranch
+// mutation. The name of this type must match the name of the type in the
+// GraphQL schema. It is defined here because the vendored type does not
+// include the update method.
+type UpdatePullRequestBranchInput struct {
+	PullRequestID   githubv4.ID           `json:"pullRequestId"`
+	ExpectedHeadOid *githubv4.GitObjectID `json:"expectedHeadOid,omitempty"`
+	UpdateMethod    *githubv4.String      `json:"updateMethod,omitempty"`
+}
+
+// UpdateResult describes the outcome of UpdatePR.
+type UpdateResult struct {
+	// Updated is true if the pull request branch was updated.
+	Updated bool
+
+	// Conflict is true if the update failed because the pull request has
+	// conflicts with the base branch.
+	Conflict bool
+}
+
+// UpdatePR updates the pull request branch with changes from the base branch
+// if it is out of date.
+func UpdatePR(ctx context.Context, pullCtx pull.Context, client *github.Client, v4client GraphQLClient, updateConfig UpdateConfig, baseRef string) UpdateResult {
 	logger := zerolog.Ctx(ctx)
 
 	pr, _, err := client.PullRequests.Get(ctx, pullCtx.Owner(), pullCtx.Repo(), pullCtx.Number())
 	if err != nil {
 		logger.Error().Err(errors.WithStack(err)).Msgf("Failed to retrieve pull request %q", pullCtx.Locator())
-		return false
+		return UpdateResult{}
 	}
 
 	if pr.GetState() == "closed" {
 		logger.Debug().Msg("Pull request already closed")
-		return false
+		return UpdateResult{}
 	}
 
 	if pr.Head.Repo.GetFork() {
 		logger.Debug().Msg("Pull request is from a fork, cannot keep it up to date with base ref")
-		return false
+		return UpdateResult{}
 	}
 
 	comparison, _, err := client.Repositories.CompareCommits(ctx, pullCtx.Owner(), pullCtx.Repo(), baseRef, pr.GetHead().GetSHA(), nil)
 	if err != nil {
 		logger.Error().Err(errors.WithStack(err)).Msgf("Cannot compare %s and %s for %q", baseRef, pr.GetHead().GetSHA(), pullCtx.Locator())
-		return false
+		return UpdateResult{}
 	}
 	if comparison.GetBehindBy() == 0 {
 		logger.Debug().Msg("Pull request is not out of date, not updating")
-		return false
+		if err := clearConflict(ctx, pullCtx, client, updateConfig.Conflicts); err != nil {
+			logger.Error().Err(errors.WithStack(err)).Msg("Failed to clear conflict notification")
+		}
+		return UpdateResult{}
+	}
+
+	if behindBy := comparison.GetBehindBy(); behindBy < updateConfig.MinBehindBy {
+		logger.Debug().Msgf("Pull request is only %d commits out of date, not updating until it is %d commits out of date", behindBy, updateConfig.MinBehindBy)
+		return UpdateResult{}
+	}
+
+	if interval := time.Duration(updateConfig.MinInterval); interval > 0 {
+		head, _, err := client.Git.GetCommit(ctx, pullCtx.Owner(), pullCtx.Repo(), pr.GetHead().GetSHA())
+		if err != nil {
+			logger.Error().Err(errors.WithStack(err)).Msgf("Failed to get head commit %s", pr.GetHead().GetSHA())
+			return UpdateResult{}
+		}
+		if next := head.GetCommitter().GetDate().Add(interval); time.Now().Before(next) {
+			logger.Debug().Msgf("Pull request was changed less than %s ago, not updating until %s", interval, next.Format(time.RFC3339))
+			return UpdateResult{}
+		}
+	}
+
+	// approvals are dismissed by the update, so find them first
+	var approvers []string
+	if updateConfig.Reapproval.enabled() {
+		approvers, err = dismissedApprovers(ctx, pullCtx)
+		if err != nil {
+			logger.Error().Err(err).Msg("Failed to find approvals dismissed by the update")
+		}
+	}
+
+	var sha string
+	if updateConfig.Method == UpdateRebase {
+		logger.Debug().Msg("Pull request is not up to date, attempting a rebase")
+		sha, err = rebasePR(ctx, pr, v4client)
+	} else {
+		logger.Debug().Msg("Pull request is not up to date, attempting an update")
+		if updateConfig.Signer != nil {
+			sha, err = signedMergeBase(ctx, pullCtx, pr, client, updateConfig.Signer, baseRef)
+		} else {
+			var mergeCommit *github.RepositoryCommit
+			mergeCommit, _, err = client.Repositories.Merge(ctx, pullCtx.Owner(), pullCtx.Repo(), &github.RepositoryMergeRequest{
+				Base: github.String(pr.Head.GetRef()),
+				Head: github.String(baseRef),
+			})
+			sha = mergeCommit.GetSHA()
+		}
+	}
+
+	if err != nil {
+		if isConflict(err) {
+			logger.Info().Msg("Update failed because the pull request has conflicts with the base ref")
+			if err := reportConflict(ctx, pullCtx, client, updateConfig.Conflicts); err != nil {
+				logger.Error().Err(errors.WithStack(err)).Msg("Failed to report conflicts")
+			}
+			return UpdateResult{Conflict: true}
+		}
+		logger.Error().Err(errors.WithStack(err)).Msg("Update failed unexpectedly")
+		return UpdateResult{}
+	}
+
+	logger.Info().Msgf("Successfully updated pull request from base ref %s as %s", baseRef, sha)
+	if err := clearConflict(ctx, pullCtx, client, updateConfig.Conflicts); err != nil {
+		logger.Error().Err(errors.WithStack(err)).Msg("Failed to clear conflict notification")
+	}
+	if err := requestReapproval(ctx, pullCtx, client, updateConfig.Reapproval, approvers); err != nil {
+		logger.Error().Err(err).Msg("Failed to request approvals again")
+	}
+	return UpdateResult{Updated: true}
+}
+
+// signedMergeBase merges the base branch into the head branch of the pull
+// request with a signed merge commit. GitHub creates unsigned merge commits,
+// so the merge happens on a temporary branch and a signed commit with the
+// same tree and parents is pushed to the head branch.
+func signedMergeBase(ctx context.Context, pullCtx pull.Context, pr *github.PullRequest, client *github.Client, signer *CommitSigner, baseRef string) (string, error) {
+	logger := zerolog.Ctx(ctx)
+	owner, repo := pullCtx.Owner(), pullCtx.Repo()
+	headRef, headSHA := pr.GetHead().GetRef(), pr.GetHead().GetSHA()
+	temp := fmt.Sprintf("bulldozer/update-%d", pr.GetNumber())
+
+	ref := &github.Reference{
+		Ref:    github.String("refs/heads/" + temp),
+		Object: &github.GitObject{SHA: github.String(headSHA)},
+	}
+	if _, _, err := client.Git.CreateRef(ctx, owner, repo, ref); err != nil {
+		if !isExistingRef(err) {
+			return "", errors.Wrapf(err, "failed to create %s", temp)
+		}
+		// an earlier update failed before deleting the branch, so reset it
+		logger.Debug().Msgf("Resetting %s, which was left by an earlier update", temp)
+		if _, _, err := client.Git.UpdateRef(ctx, owner, repo, ref, true); err != nil {
+			return "", errors.Wrapf(err, "failed to reset %s", temp)
+		}
 	}
+	defer func() {
+		if _, err := client.Git.DeleteRef(ctx, owner, repo, "heads/"+temp); err != nil {
+			logger.Error().Err(errors.WithStack(err)).Msgf("Failed to delete %s", temp)
+		}
+	}()
 
-	logger.Debug().Msg("Pull request is not up to date, attempting an update")
-	mergeCommit, _, err := client.Repositories.Merge(ctx, pullCtx.Owner(), pullCtx.Repo(), &github.RepositoryMergeRequest{
-		Base: github.String(pr.Head.GetRef()),
-		Head: github.String(baseRef),
+	message := fmt.Sprintf("Merge branch '%s' into %s", baseRef, headRef)
+	merged, _, err := client.Repositories.Merge(ctx, owner, repo, &github.RepositoryMergeRequest{
+		Base:          github.String(temp),
+		Head:          github.String(baseRef),
+		CommitMessage: github.String(message),
 	})
 	if err != nil {
-		logger.Error().Err(errors.WithStack(err)).Msg("Update merge failed unexpectedly")
-		return false
+		return "", err
+	}
+	if merged == nil {
+		return headSHA, nil
+	}
+
+	parents := make([]*github.Commit, 0, len(merged.Parents))
+	for _, p := range merged.Parents {
+		parents = append(parents, &github.Commit{SHA: p.SHA})
+	}
+	commit := &github.Commit{
+		Message: github.String(message),
+		Tree:    &github.Tree{SHA: merged.GetCommit().GetTree().SHA},
+		Parents: parents,
+	}
+	signer.sign(commit)
+
+	signed, _, err := client.Git.CreateCommit(ctx, owner, repo, commit)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create signed merge commit")
+	}
+
+	ref.Ref = github.String("refs/heads/" + headRef)
+	ref.Object.SHA = signed.SHA
+	if _, _, err := client.Git.UpdateRef(ctx, owner, repo, ref, false); err != nil {
+		return "", errors.Wrapf(err, "failed to update %s", headRef)
+	}
+	return signed.GetSHA(), nil
+}
+
+// rebasePR rebases the head branch of the pull request on its base branch and
+// returns the new head SHA. The REST API only supports merging the base
+// branch, so it uses the GraphQL API.
+func rebasePR(ctx context.Context, pr *github.PullRequest, v4client GraphQLClient) (string, error) {
+	var mutation struct {
+		UpdatePullRequestBranch struct {
+			PullRequest struct {
+				HeadRefOid string
+			}
+		} `graphql:"updatePullRequestBranch(input: $input)"`
+	}
+
+	expectedHead := githubv4.GitObjectID(pr.GetHead().GetSHA())
+	method := githubv4.String("REBASE")
+	input := UpdatePullRequestBranchInput{
+		PullRequestID:   githubv4.ID(pr.GetNodeID()),
+		ExpectedHeadOid: &expectedHead,
+		UpdateMethod:    &method,
+	}
+	if err := v4client.Mutate(ctx, &mutation, input, nil); err != nil {
+		return "", errors.Wrap(err, "failed to rebase pull request")
 	}
-	logger.Info().Msgf("Successfully updated pull request from base ref %s as merge %s", baseRef, mergeCommit.GetSHA())
-	return true
+	return mutation.UpdatePullRequestBranch.PullRequest.HeadRefOid, nil
 }
@@ -13,3 +13,245 @@
 // limitations under the License.
 
 package bulldozer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/pull"
+	"github.com/palantir/bulldozer/pull/pulltest"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdatePR(t *testing.T) {
+	var merges, rebases int
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/testorg/testrepo/pulls/1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"number": 1, "node_id": "PR_1", "state": "open", "head": {"ref": "feature", "sha": "head", "repo": {"fork": false}}}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/compare/develop...head", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"behind_by": 2}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/merges", func(w http.ResponseWriter, r *http.Request) {
+		merges++
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{"sha": "merged"}`)
+	})
+	mux.HandleFunc("/api/graphql", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]interface{}{
+			"pullRequestId":   "PR_1",
+			"expectedHeadOid": "head",
+			"updateMethod":    "REBASE",
+		}, body.Variables["input"])
+
+		rebases++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"data": {"updatePullRequestBranch": {"pullRequest": {"headRefOid": "rebased"}}}}`)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+	v4client := githubv4.NewEnterpriseClient(srv.URL+"/api/graphql", srv.Client())
+
+	ctx := context.Background()
+	pullCtx := &pulltest.MockPullContext{OwnerValue: "testorg", RepoValue: "testrepo", NumberValue: 1}
+
+	assert.True(t, UpdatePR(ctx, pullCtx, client, v4client, UpdateConfig{}, "develop").Updated)
+	assert.Equal(t, 1, merges, "default update did not merge")
+	assert.Equal(t, 0, rebases, "default update incorrectly rebased")
+
+	assert.True(t, UpdatePR(ctx, pullCtx, client, v4client, UpdateConfig{Method: UpdateRebase}, "develop").Updated)
+	assert.Equal(t, 1, merges, "rebase update incorrectly merged")
+	assert.Equal(t, 1, rebases, "rebase update did not rebase")
+}
+
+func TestUpdatePRConflicts(t *testing.T) {
+	behindBy := 2
+	var added, removed, comments []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/testorg/testrepo/pulls/1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"number": 1, "state": "open", "head": {"ref": "feature", "sha": "head", "repo": {"fork": false}}}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/compare/develop...head", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"behind_by": %d}`, behindBy)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/merges", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		_, _ = io.WriteString(w, `{"message": "Merge conflict"}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/issues/1/labels", func(w http.ResponseWriter, r *http.Request) {
+		var labels []string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&labels))
+		added = append(added, labels...)
+		_, _ = io.WriteString(w, `[]`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/issues/1/labels/conflicts", func(w http.ResponseWriter, r *http.Request) {
+		removed = append(removed, "conflicts")
+		_, _ = io.WriteString(w, `[]`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/issues/1/comments", func(w http.ResponseWriter, r *http.Request) {
+		var comment github.IssueComment
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&comment))
+		comments = append(comments, comment.GetBody())
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{}`)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	ctx := context.Background()
+	updateConfig := UpdateConfig{
+		Conflicts: ConflictConfig{
+			Comment:     "This pull request has conflicts.",
+			Label:       "conflicts",
+			RemoveLabel: true,
+		},
+	}
+
+	pullCtx := &pulltest.MockPullContext{OwnerValue: "testorg", RepoValue: "testrepo", NumberValue: 1}
+	result := UpdatePR(ctx, pullCtx, client, nil, updateConfig, "develop")
+	assert.False(t, result.Updated)
+	assert.True(t, result.Conflict)
+	assert.Equal(t, []string{"conflicts"}, added)
+	assert.Equal(t, []string{"This pull request has conflicts."}, comments)
+
+	pullCtx.LabelValue = []string{"conflicts"}
+	assert.False(t, UpdatePR(ctx, pullCtx, client, nil, updateConfig, "develop").Updated)
+	assert.Len(t, added, 1, "conflict label was added again")
+	assert.Len(t, comments, 1, "conflict comment was posted again")
+
+	behindBy = 0
+	assert.False(t, UpdatePR(ctx, pullCtx, client, nil, updateConfig, "develop").Updated)
+	assert.Equal(t, []string{"conflicts"}, removed, "conflict label was not removed")
+}
+
+func TestUpdatePRReapproval(t *testing.T) {
+	var reviewers, comments []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/testorg/testrepo/pulls/1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"number": 1, "state": "open", "head": {"ref": "feature", "sha": "head", "repo": {"fork": false}}}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/compare/develop...head", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"behind_by": 2}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/merges", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{"sha": "merged"}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/pulls/1/requested_reviewers", func(w http.ResponseWriter, r *http.Request) {
+		var request github.ReviewersRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		reviewers = append(reviewers, request.Reviewers...)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/issues/1/comments", func(w http.ResponseWriter, r *http.Request) {
+		var comment github.IssueComment
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&comment))
+		comments = append(comments, comment.GetBody())
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{}`)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	ctx := context.Background()
+	updateConfig := UpdateConfig{
+		Reapproval: ReapprovalConfig{
+			RequestReviews: true,
+			Comment:        "Please review this pull request again.",
+		},
+	}
+
+	pullCtx := &pulltest.MockPullContext{
+		OwnerValue:  "testorg",
+		RepoValue:   "testrepo",
+		NumberValue: 1,
+		AuthorValue: "alice",
+		ReviewsValue: []*pull.Review{
+			{Author: "carol", State: pull.ReviewApproved},
+			{Author: "bob", State: pull.ReviewApproved},
+			{Author: "dave", State: pull.ReviewApproved},
+			{Author: "dave", State: pull.ReviewChangesRequested},
+			{Author: "alice", State: pull.ReviewApproved},
+		},
+	}
+
+	assert.True(t, UpdatePR(ctx, pullCtx, client, nil, updateConfig, "develop").Updated)
+	assert.Empty(t, reviewers, "requested reviews for a branch that keeps approvals")
+	assert.Empty(t, comments, "commented for a branch that keeps approvals")
+
+	pullCtx.MergeRequirementsValue = &pull.MergeRequirements{DismissesStaleReviews: true}
+	assert.True(t, UpdatePR(ctx, pullCtx, client, nil, updateConfig, "develop").Updated)
+	assert.Equal(t, []string{"bob", "carol"}, reviewers)
+	assert.Equal(t, []string{"Please review this pull request again."}, comments)
+}
+
+func TestUpdatePRThrottling(t *testing.T) {
+	var merges int
+	committed := time.Now()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/testorg/testrepo/pulls/1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"number": 1, "state": "open", "head": {"ref": "feature", "sha": "head", "repo": {"fork": false}}}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/compare/develop...head", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"behind_by": 2}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/git/commits/head", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"sha": "head", "committer": {"date": %q}}`, committed.Format(time.RFC3339))
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/merges", func(w http.ResponseWriter, r *http.Request) {
+		merges++
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{"sha": "merged"}`)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	ctx := context.Background()
+	pullCtx := &pulltest.MockPullContext{OwnerValue: "testorg", RepoValue: "testrepo", NumberValue: 1}
+
+	assert.False(t, UpdatePR(ctx, pullCtx, client, nil, UpdateConfig{MinBehindBy: 3}, "develop").Updated)
+	assert.Equal(t, 0, merges, "pull request was updated before it was far enough behind")
+
+	updateConfig := UpdateConfig{MinBehindBy: 2, MinInterval: Duration(10 * time.Minute)}
+	assert.False(t, UpdatePR(ctx, pullCtx, client, nil, updateConfig, "develop").Updated)
+	assert.Equal(t, 0, merges, "pull request was updated too soon after the latest commit")
+
+	committed = time.Now().Add(-time.Hour)
+	assert.True(t, UpdatePR(ctx, pullCtx, client, nil, updateConfig, "develop").Updated)
+	assert.Equal(t, 1, merges, "pull request was not updated")
+}
@@ -0,0 +1,273 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// ValidationCheckRunName is the name of the check run that shows the result
+// of validating a configuration file changed by a pull request.
+const ValidationCheckRunName = "bulldozer/config"
+
+// maxAnnotations is the number of annotations GitHub accepts in one request.
+const maxAnnotations = 50
+
+// ValidationIssue is a problem found in a configuration file.
+type ValidationIssue struct {
+	// Line is the line of the file with the problem, starting from 1. It is
+	// zero if the problem does not have a specific location.
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+func (i ValidationIssue) String() string {
+	if i.Line > 0 {
+		return fmt.Sprintf("line %d: %s", i.Line, i.Message)
+	}
+	return i.Message
+}
+
+// ValidationResult describes the problems found in a configuration file.
+type ValidationResult struct {
+	Valid bool `json:"valid"`
+
+	// Errors are problems that prevent bulldozer from using the file. Unknown
+	// keys are errors, because bulldozer rejects files that contain them.
+	Errors []ValidationIssue `json:"errors"`
+
+	// Warnings are problems that do not prevent bulldozer from using the
+	// file, like deprecated keys.
+	Warnings []ValidationIssue `json:"warnings"`
+}
+
+type deprecation struct {
+	path    []string
+	message string
+}
+
+var deprecations = []deprecation{
+	{[]string{"merge", "whitelist"}, `"merge.whitelist" is deprecated, use "merge.trigger" instead`},
+	{[]string{"merge", "blacklist"}, `"merge.blacklist" is deprecated, use "merge.ignore" instead`},
+	{[]string{"merge", "branch_method"}, `"merge.branch_method" is deprecated, use "merge.merge_method" instead`},
+	{[]string{"update", "whitelist"}, `"update.whitelist" is deprecated, use "update.trigger" instead`},
+	{[]string{"update", "blacklist"}, `"update.blacklist" is deprecated, use "update.ignore" instead`},
+}
+
+// ignoreOnlySignal is a signal that matches pull requests that must be held
+// back, like pull requests that are too new. In a trigger, it would merge or
+// update exactly the pull requests it is meant to hold back, so it is only
+// allowed in ignore sections.
+type ignoreOnlySignal struct {
+	key     string
+	enabled func(Signals) bool
+}
+
+var ignoreOnlySignals = []ignoreOnlySignal{
+	{"min_age", func(s Signals) bool { return s.MinAge.Enabled() }},
+	{"stale_approvals", func(s Signals) bool { return s.StaleApprovals.Enabled() }},
+	{"open_dependencies", func(s Signals) bool { return s.OpenDependencies.Enabled() }},
+	{"tickets", func(s Signals) bool { return s.Tickets.Enabled() }},
+	{"denied_files", func(s Signals) bool { return s.DeniedFiles.Enabled() }},
+	{"changes_requested", func(s Signals) bool { return s.ChangesRequested.Enabled() }},
+	{"pending_reviewers", func(s Signals) bool { return s.PendingReviewers.Enabled() }},
+}
+
+// validateTrigger returns an error if the trigger signals at path use a
+// signal that is only allowed in ignore sections. Signals under "not" are
+// allowed, since negating them gives them trigger semantics.
+func validateTrigger(path string, signals Signals) error {
+	for _, s := range ignoreOnlySignals {
+		if s.enabled(signals) {
+			return errors.Errorf("%q cannot use %q, which matches pull requests that must be held back; use it in an ignore section", path, s.key)
+		}
+	}
+	for _, group := range signals.AllOf {
+		if err := validateTrigger(path+".all_of", group); err != nil {
+			return err
+		}
+	}
+	for _, group := range signals.AnyOf {
+		if err := validateTrigger(path+".any_of", group); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var (
+	yamlLinePattern     = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+	unknownFieldPattern = regexp.MustCompile(`^field (\S+) not found in type \S+$`)
+)
+
+// ValidateConfig checks a configuration file and reports all of the problems
+// it finds, with line numbers when possible. Unlike ParseConfig, it reports
+// every type error and unknown key in the file instead of only the first.
+func ValidateConfig(bytes []byte) *ValidationResult {
+	result := &ValidationResult{
+		Errors:   []ValidationIssue{},
+		Warnings: []ValidationIssue{},
+	}
+
+	var config Config
+	if err := yaml.UnmarshalStrict(bytes, &config); err != nil {
+		if _, v0err := parseConfigV0(bytes); v0err == nil {
+			result.Valid = true
+			result.Warnings = append(result.Warnings, ValidationIssue{
+				Message: "version 0 configuration is deprecated, use version 1 instead",
+			})
+			return result
+		}
+		result.Errors = append(result.Errors, yamlIssues(err)...)
+		return result
+	}
+
+	if _, err := parseConfigV1(bytes); err != nil {
+		result.Errors = append(result.Errors, ValidationIssue{Message: err.Error()})
+	}
+
+	var root yamlv3.Node
+	if err := yamlv3.Unmarshal(bytes, &root); err == nil {
+		for _, d := range deprecations {
+			if line := findKeyLine(&root, d.path...); line > 0 {
+				result.Warnings = append(result.Warnings, ValidationIssue{Line: line, Message: d.message})
+			}
+		}
+	}
+
+	result.Valid = len(result.Errors) == 0
+	return result
+}
+
+// yamlIssues converts errors from the YAML library, which include line
+// numbers in the messages, into issues.
+func yamlIssues(err error) []ValidationIssue {
+	var messages []string
+	if typeErr, ok := err.(*yaml.TypeError); ok {
+		messages = typeErr.Errors
+	} else {
+		messages = []string{err.Error()}
+	}
+
+	issues := make([]ValidationIssue, 0, len(messages))
+	for _, msg := range messages {
+		var issue ValidationIssue
+		if m := yamlLinePattern.FindStringSubmatch(msg); m != nil {
+			issue.Line, _ = strconv.Atoi(m[1])
+			msg = m[2]
+		}
+		if m := unknownFieldPattern.FindStringSubmatch(msg); m != nil {
+			msg = fmt.Sprintf("unknown key %q", m[1])
+		}
+		issue.Message = strings.TrimPrefix(msg, "yaml: ")
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// findKeyLine returns the line of the key at the path of nested maps, or zero
+// if the key does not exist.
+func findKeyLine(node *yamlv3.Node, path ...string) int {
+	if node.Kind == yamlv3.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	for i, key := range path {
+		if node.Kind != yamlv3.MappingNode {
+			return 0
+		}
+		var value *yamlv3.Node
+		for j := 0; j+1 < len(node.Content); j += 2 {
+			if node.Content[j].Value == key {
+				if i == len(path)-1 {
+					return node.Content[j].Line
+				}
+				value = node.Content[j+1]
+				break
+			}
+		}
+		if value == nil {
+			return 0
+		}
+		node = value
+	}
+	return 0
+}
+
+// PublishValidationCheckRun creates a check run on the commit that shows the
+// result of validating the configuration file at path. The check run fails if
+// the file is invalid, and each issue with a line number is added as an
+// annotation.
+func PublishValidationCheckRun(ctx context.Context, client *github.Client, owner, repo, sha, path string, result *ValidationResult) error {
+	var annotations []*github.CheckRunAnnotation
+	annotate := func(level string, issues []ValidationIssue) {
+		for _, issue := range issues {
+			if len(annotations) == maxAnnotations {
+				return
+			}
+			line := issue.Line
+			if line == 0 {
+				line = 1
+			}
+			annotations = append(annotations, &github.CheckRunAnnotation{
+				Path:            github.String(path),
+				StartLine:       github.Int(line),
+				EndLine:         github.Int(line),
+				AnnotationLevel: github.String(level),
+				Message:         github.String(issue.Message),
+			})
+		}
+	}
+	annotate("failure", result.Errors)
+	annotate("warning", result.Warnings)
+
+	var text strings.Builder
+	for _, issue := range result.Errors {
+		fmt.Fprintf(&text, "- :x: %s\n", issue)
+	}
+	for _, issue := range result.Warnings {
+		fmt.Fprintf(&text, "- :warning: %s\n", issue)
+	}
+
+	title, conclusion := "Configuration is valid", "success"
+	if !result.Valid {
+		title, conclusion = "Configuration is invalid", "failure"
+	}
+
+	now := github.Timestamp{Time: time.Now()}
+	_, _, err := client.Checks.CreateCheckRun(ctx, owner, repo, github.CreateCheckRunOptions{
+		Name:        ValidationCheckRunName,
+		HeadSHA:     sha,
+		Status:      github.String("completed"),
+		Conclusion:  github.String(conclusion),
+		CompletedAt: &now,
+		Output: &github.CheckRunOutput{
+			Title:       github.String(title),
+			Summary:     github.String(fmt.Sprintf("`%s` has %d errors and %d warnings.", path, len(result.Errors), len(result.Warnings))),
+			Text:        github.String(text.String()),
+			Annotations: annotations,
+		},
+	})
+	return errors.Wrap(err, "failed to create check run")
+}
@@ -0,0 +1,230 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateConfig(t *testing.T) {
+	tests := map[string]struct {
+		Config   string
+		Valid    bool
+		Errors   []ValidationIssue
+		Warnings []ValidationIssue
+	}{
+		"valid": {
+			Config: `
+version: 1
+merge:
+  trigger:
+    labels: ["merge when ready"]
+  method: squash
+`,
+			Valid: true,
+		},
+		"syntaxError": {
+			Config: `
+version: 1
+merge:
+  trigger: [
+`,
+			Errors: []ValidationIssue{
+				{Line: 4, Message: "did not find expected node content"},
+			},
+		},
+		"unknownKeysAndTypes": {
+			Config: `
+version: 1
+merge:
+  triger:
+    labels: ["merge when ready"]
+  delete_after_merge: sometimes
+update:
+  ignore_draft: true
+`,
+			Errors: []ValidationIssue{
+				{Line: 4, Message: `unknown key "triger"`},
+				{Line: 6, Message: "cannot unmarshal !!str `sometimes` into bool"},
+				{Line: 8, Message: `unknown key "ignore_draft"`},
+			},
+		},
+		"invalidValue": {
+			Config: `
+version: 1
+update:
+  method: squash
+`,
+			Errors: []ValidationIssue{
+				{Message: `invalid update method "squash"`},
+			},
+		},
+		"ignoreOnlySignalInTrigger": {
+			Config: `
+version: 1
+merge:
+  trigger:
+    min_age: 1h
+`,
+			Errors: []ValidationIssue{
+				{Message: `"merge.trigger" cannot use "min_age", which matches pull requests that must be held back; use it in an ignore section`},
+			},
+		},
+		"ignoreOnlySignalInNestedTrigger": {
+			Config: `
+version: 1
+update:
+  trigger:
+    any_of:
+      - labels: ["update me"]
+      - min_age: 1h
+`,
+			Errors: []ValidationIssue{
+				{Message: `"update.trigger.any_of" cannot use "min_age", which matches pull requests that must be held back; use it in an ignore section`},
+			},
+		},
+		"staleApprovalsInTrigger": {
+			Config: `
+version: 1
+merge:
+  trigger:
+    stale_approvals: true
+`,
+			Errors: []ValidationIssue{
+				{Message: `"merge.trigger" cannot use "stale_approvals", which matches pull requests that must be held back; use it in an ignore section`},
+			},
+		},
+		"openDependenciesInRollupTrigger": {
+			Config: `
+version: 1
+merge:
+  rollup:
+    trigger:
+      all_of:
+        - labels: ["rollup"]
+        - open_dependencies: true
+`,
+			Errors: []ValidationIssue{
+				{Message: `"merge.rollup.trigger.all_of" cannot use "open_dependencies", which matches pull requests that must be held back; use it in an ignore section`},
+			},
+		},
+		"ticketsInTrigger": {
+			Config: `
+version: 1
+merge:
+  trigger:
+    tickets:
+      statuses: ["Done"]
+`,
+			Errors: []ValidationIssue{
+				{Message: `"merge.trigger" cannot use "tickets", which matches pull requests that must be held back; use it in an ignore section`},
+			},
+		},
+		"deniedFilesInTrigger": {
+			Config: `
+version: 1
+merge:
+  trigger:
+    denied_files:
+      paths: ["**/*.pem"]
+`,
+			Errors: []ValidationIssue{
+				{Message: `"merge.trigger" cannot use "denied_files", which matches pull requests that must be held back; use it in an ignore section`},
+			},
+		},
+		"changesRequestedInTrigger": {
+			Config: `
+version: 1
+update:
+  trigger:
+    changes_requested: true
+`,
+			Errors: []ValidationIssue{
+				{Message: `"update.trigger" cannot use "changes_requested", which matches pull requests that must be held back; use it in an ignore section`},
+			},
+		},
+		"pendingReviewersInTrigger": {
+			Config: `
+version: 1
+merge:
+  trigger:
+    any_of:
+      - pending_reviewers: true
+`,
+			Errors: []ValidationIssue{
+				{Message: `"merge.trigger.any_of" cannot use "pending_reviewers", which matches pull requests that must be held back; use it in an ignore section`},
+			},
+		},
+		"ignoreOnlySignalNegatedInTrigger": {
+			Config: `
+version: 1
+merge:
+  trigger:
+    not:
+      min_age: 1h
+  ignore:
+    min_age: 1h
+`,
+			Valid: true,
+		},
+		"deprecated": {
+			Config: `
+version: 1
+merge:
+  whitelist:
+    labels: ["merge when ready"]
+  branch_method:
+    develop: squash
+update:
+  blacklist:
+    labels: ["do not update"]
+`,
+			Valid: true,
+			Warnings: []ValidationIssue{
+				{Line: 4, Message: `"merge.whitelist" is deprecated, use "merge.trigger" instead`},
+				{Line: 6, Message: `"merge.branch_method" is deprecated, use "merge.merge_method" instead`},
+				{Line: 9, Message: `"update.blacklist" is deprecated, use "update.ignore" instead`},
+			},
+		},
+		"versionZero": {
+			Config: `
+mode: whitelist
+strategy: squash
+`,
+			Valid: true,
+			Warnings: []ValidationIssue{
+				{Message: "version 0 configuration is deprecated, use version 1 instead"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := ValidateConfig([]byte(test.Config))
+			assert.Equal(t, test.Valid, result.Valid)
+
+			if test.Errors == nil {
+				test.Errors = []ValidationIssue{}
+			}
+			if test.Warnings == nil {
+				test.Warnings = []ValidationIssue{}
+			}
+			assert.Equal(t, test.Errors, result.Errors)
+			assert.Equal(t, test.Warnings, result.Warnings)
+		})
+	}
+}
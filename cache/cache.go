@@ -0,0 +1,85 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cache provides caches for GitHub API responses. The caches are used
+// by an HTTP caching transport that sends conditional requests, so responses
+// that have not changed do not count against the rate limit.
+package cache
+
+import (
+	"github.com/gregjones/httpcache"
+	"github.com/rcrowley/go-metrics"
+)
+
+const (
+	MetricsKeyHits   = "github.cache.hits"
+	MetricsKeyMisses = "github.cache.misses"
+)
+
+// Metered wraps a cache to count hits and misses in the registry.
+func Metered(c httpcache.Cache, registry metrics.Registry) httpcache.Cache {
+	return &metered{
+		Cache:  c,
+		hits:   metrics.GetOrRegisterCounter(MetricsKeyHits, registry),
+		misses: metrics.GetOrRegisterCounter(MetricsKeyMisses, registry),
+	}
+}
+
+type metered struct {
+	httpcache.Cache
+	hits   metrics.Counter
+	misses metrics.Counter
+}
+
+func (m *metered) Get(key string) ([]byte, bool) {
+	b, ok := m.Cache.Get(key)
+	if ok {
+		m.hits.Inc(1)
+	} else {
+		m.misses.Inc(1)
+	}
+	return b, ok
+}
+
+// Tiered returns a cache that reads from local before remote. Responses read
+// from remote are added to local. Writes and deletes go to both.
+func Tiered(local, remote httpcache.Cache) httpcache.Cache {
+	return &tiered{local: local, remote: remote}
+}
+
+type tiered struct {
+	local  httpcache.Cache
+	remote httpcache.Cache
+}
+
+func (t *tiered) Get(key string) ([]byte, bool) {
+	if b, ok := t.local.Get(key); ok {
+		return b, true
+	}
+	b, ok := t.remote.Get(key)
+	if ok {
+		t.local.Set(key, b)
+	}
+	return b, ok
+}
+
+func (t *tiered) Set(key string, b []byte) {
+	t.local.Set(key, b)
+	t.remote.Set(key, b)
+}
+
+func (t *tiered) Delete(key string) {
+	t.local.Delete(key)
+	t.remote.Delete(key)
+}
@@ -0,0 +1,171 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/die-net/lrucache"
+	"github.com/rcrowley/go-metrics"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRedis is a Redis server that supports the commands used by Redis.
+type fakeRedis struct {
+	mu       sync.Mutex
+	values   map[string]string
+	commands []string
+}
+
+func startFakeRedis(t *testing.T) (*fakeRedis, string) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+
+	f := &fakeRedis{values: make(map[string]string)}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return f, l.Addr().String()
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		n, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+		args := make([]string, n)
+		for i := range args {
+			line, _ = r.ReadString('\n')
+			size, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+			b := make([]byte, size+2)
+			_, _ = io.ReadFull(r, b)
+			args[i] = string(b[:size])
+		}
+
+		f.mu.Lock()
+		f.commands = append(f.commands, args[0])
+		switch args[0] {
+		case "AUTH", "SELECT":
+			fmt.Fprint(conn, "+OK\r\n")
+		case "GET":
+			if v, ok := f.values[args[1]]; ok {
+				fmt.Fprintf(conn, "$%d\r\n%s\r\n", len(v), v)
+			} else {
+				fmt.Fprint(conn, "$-1\r\n")
+			}
+		case "SET":
+			f.values[args[1]] = args[2]
+			fmt.Fprint(conn, "+OK\r\n")
+		case "DEL":
+			delete(f.values, args[1])
+			fmt.Fprint(conn, ":1\r\n")
+		default:
+			fmt.Fprintf(conn, "-ERR unknown command %s\r\n", args[0])
+		}
+		f.mu.Unlock()
+	}
+}
+
+func TestRedis(t *testing.T) {
+	server, addr := startFakeRedis(t)
+
+	r, err := NewRedis(RedisConfig{Address: addr, Password: "secret", DB: 2}, zerolog.Nop())
+	require.NoError(t, err)
+
+	_, ok := r.Get("https://api.github.com/repos/testorg/testrepo")
+	assert.False(t, ok)
+
+	response := "HTTP/1.1 200 OK\r\nEtag: \"abc\"\r\n\r\n{}"
+	r.Set("https://api.github.com/repos/testorg/testrepo", []byte(response))
+
+	b, ok := r.Get("https://api.github.com/repos/testorg/testrepo")
+	assert.True(t, ok)
+	assert.Equal(t, response, string(b))
+	server.mu.Lock()
+	assert.Contains(t, server.values, "bulldozer:https://api.github.com/repos/testorg/testrepo")
+	server.mu.Unlock()
+
+	r.Delete("https://api.github.com/repos/testorg/testrepo")
+	_, ok = r.Get("https://api.github.com/repos/testorg/testrepo")
+	assert.False(t, ok)
+
+	// connections are reused, so authentication happens once
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	assert.Equal(t, []string{"AUTH", "SELECT", "GET", "SET", "GET", "DEL", "GET"}, server.commands)
+}
+
+func TestRedisUnavailable(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	r, err := NewRedis(RedisConfig{Address: addr}, zerolog.Nop())
+	require.NoError(t, err)
+
+	r.Set("key", []byte("value"))
+	_, ok := r.Get("key")
+	assert.False(t, ok)
+}
+
+func TestTieredMetered(t *testing.T) {
+	registry := metrics.NewRegistry()
+	local := lrucache.New(1024, 0)
+	remote := lrucache.New(1024, 0)
+	c := Metered(Tiered(local, remote), registry)
+
+	remote.Set("remote", []byte("a"))
+	c.Set("both", []byte("b"))
+
+	b, ok := c.Get("remote")
+	assert.True(t, ok)
+	assert.Equal(t, "a", string(b))
+	_, ok = local.Get("remote")
+	assert.True(t, ok, "remote responses are added to the local cache")
+
+	_, ok = remote.Get("both")
+	assert.True(t, ok)
+
+	_, ok = c.Get("missing")
+	assert.False(t, ok)
+
+	c.Delete("both")
+	_, ok = remote.Get("both")
+	assert.False(t, ok)
+
+	assert.Equal(t, int64(1), registry.Get(MetricsKeyHits).(metrics.Counter).Count())
+	assert.Equal(t, int64(1), registry.Get(MetricsKeyMisses).(metrics.Counter).Count())
+}
@@ -0,0 +1,210 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+const (
+	DefaultRedisTTL       = 24 * time.Hour
+	DefaultRedisKeyPrefix = "bulldozer:"
+	DefaultRedisPoolSize  = 10
+
+	redisTimeout = 2 * time.Second
+)
+
+// RedisConfig configures a cache stored in Redis, so that it is shared by
+// multiple servers and survives restarts.
+type RedisConfig struct {
+	// Address is the host and port of the Redis server.
+	Address  string `yaml:"address"`
+	Password string `yaml:"password"`
+	DB       int    `yaml:"db"`
+
+	// KeyPrefix is added to all keys. The default is "bulldozer:".
+	KeyPrefix string `yaml:"key_prefix"`
+
+	// TTL is how long responses are kept. The default is 24 hours.
+	TTL time.Duration `yaml:"ttl"`
+
+	// PoolSize is the maximum number of idle connections. The default is 10.
+	PoolSize int `yaml:"pool_size"`
+}
+
+// Redis is a cache stored in Redis. It implements the small subset of the
+// Redis protocol needed for GET, SET, and DEL. Errors are logged and treated
+// as misses, so an unavailable Redis server only disables caching.
+type Redis struct {
+	config RedisConfig
+	logger zerolog.Logger
+	conns  chan *redisConn
+}
+
+// NewRedis creates a Redis cache. Connections are created when needed.
+func NewRedis(config RedisConfig, logger zerolog.Logger) (*Redis, error) {
+	if config.Address == "" {
+		return nil, errors.New("address is required")
+	}
+	if config.KeyPrefix == "" {
+		config.KeyPrefix = DefaultRedisKeyPrefix
+	}
+	if config.TTL <= 0 {
+		config.TTL = DefaultRedisTTL
+	}
+	if config.PoolSize <= 0 {
+		config.PoolSize = DefaultRedisPoolSize
+	}
+	return &Redis{
+		config: config,
+		logger: logger,
+		conns:  make(chan *redisConn, config.PoolSize),
+	}, nil
+}
+
+func (r *Redis) Get(key string) ([]byte, bool) {
+	v, err := r.do("GET", r.config.KeyPrefix+key)
+	if err != nil {
+		r.logger.Warn().Err(err).Msg("Failed to get response from Redis cache")
+		return nil, false
+	}
+	b, ok := v.([]byte)
+	return b, ok
+}
+
+func (r *Redis) Set(key string, b []byte) {
+	ttl := strconv.Itoa(int(r.config.TTL / time.Second))
+	if _, err := r.do("SET", r.config.KeyPrefix+key, string(b), "EX", ttl); err != nil {
+		r.logger.Warn().Err(err).Msg("Failed to store response in Redis cache")
+	}
+}
+
+func (r *Redis) Delete(key string) {
+	if _, err := r.do("DEL", r.config.KeyPrefix+key); err != nil {
+		r.logger.Warn().Err(err).Msg("Failed to delete response from Redis cache")
+	}
+}
+
+// do sends a command and returns the reply. Connections are returned to the
+// pool only if the command succeeds.
+func (r *Redis) do(args ...string) (interface{}, error) {
+	conn, err := r.conn()
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := conn.do(args...)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	select {
+	case r.conns <- conn:
+	default:
+		_ = conn.Close()
+	}
+	return v, nil
+}
+
+func (r *Redis) conn() (*redisConn, error) {
+	select {
+	case conn := <-r.conns:
+		return conn, nil
+	default:
+	}
+
+	c, err := net.DialTimeout("tcp", r.config.Address, redisTimeout)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to redis")
+	}
+	conn := &redisConn{Conn: c, r: bufio.NewReader(c)}
+
+	if r.config.Password != "" {
+		if _, err := conn.do("AUTH", r.config.Password); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+	if r.config.DB != 0 {
+		if _, err := conn.do("SELECT", strconv.Itoa(r.config.DB)); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+type redisConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *redisConn) do(args ...string) (interface{}, error) {
+	if err := c.SetDeadline(time.Now().Add(redisTimeout)); err != nil {
+		return nil, errors.Wrap(err, "failed to set redis deadline")
+	}
+
+	cmd := fmt.Sprintf("*%d\r\n", len(args))
+	for _, arg := range args {
+		cmd += fmt.Sprintf("$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(c.Conn, cmd); err != nil {
+		return nil, errors.Wrap(err, "failed to send redis command")
+	}
+	return c.reply()
+}
+
+// reply reads a reply. Bulk strings are returned as []byte, nil bulk strings
+// as nil, and simple strings and integers as strings.
+func (c *redisConn) reply() (interface{}, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read redis reply")
+	}
+	if len(line) < 3 {
+		return nil, errors.Errorf("invalid redis reply %q", line)
+	}
+	line = line[:len(line)-2]
+
+	switch line[0] {
+	case '+', ':':
+		return line[1:], nil
+	case '-':
+		return nil, errors.Errorf("redis error: %s", line[1:])
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, errors.Errorf("invalid redis bulk length %q", line)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		b := make([]byte, n+2)
+		if _, err := io.ReadFull(c.r, b); err != nil {
+			return nil, errors.Wrap(err, "failed to read redis reply")
+		}
+		return b[:n], nil
+	}
+	return nil, errors.Errorf("unsupported redis reply %q", line)
+}
@@ -0,0 +1,243 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/bulldozer"
+	"github.com/palantir/bulldozer/pull"
+	"github.com/palantir/bulldozer/server/handler"
+	"github.com/palantir/go-githubapp/appconfig"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var configCmdConfig struct {
+	Config    string
+	Token     string
+	GithubURL string
+}
+
+var ConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Validates configuration files and simulates evaluations.",
+	Long:  "Validates configuration files and simulates evaluations of pull requests, without running a server.",
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate <file>...",
+	Short: "Validates configuration files.",
+	Long:  "Validates configuration files and prints each error and warning. Exits with an error if any file is invalid.",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  configValidate,
+}
+
+var configSimulateCmd = &cobra.Command{
+	Use:   "simulate <owner/repo> <number>",
+	Short: "Evaluates a pull request without merging or updating it.",
+	Long: "Evaluates a pull request using the configuration in the repository, or the file given by --config, " +
+		"and prints which signals matched and what bulldozer would do. The token only needs read access.",
+	Args: cobra.ExactArgs(2),
+	RunE: configSimulate,
+}
+
+func configValidate(cmd *cobra.Command, args []string) error {
+	var invalid int
+	for _, path := range args {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s", path)
+		}
+
+		result := bulldozer.ValidateConfig(b)
+		printIssues(cmd.OutOrStdout(), path, "error", result.Errors)
+		printIssues(cmd.OutOrStdout(), path, "warning", result.Warnings)
+		if !result.Valid {
+			invalid++
+			continue
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s: valid\n", path)
+	}
+	if invalid > 0 {
+		return errors.Errorf("%d of %d files are invalid", invalid, len(args))
+	}
+	return nil
+}
+
+func printIssues(w io.Writer, path, severity string, issues []bulldozer.ValidationIssue) {
+	for _, issue := range issues {
+		if issue.Line > 0 {
+			fmt.Fprintf(w, "%s:%d: %s: %s\n", path, issue.Line, severity, issue.Message)
+		} else {
+			fmt.Fprintf(w, "%s: %s: %s\n", path, severity, issue.Message)
+		}
+	}
+}
+
+func configSimulate(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	owner, repo, ok := strings.Cut(args[0], "/")
+	if !ok || owner == "" || repo == "" {
+		return errors.Errorf("invalid repository %q, expected owner/repo", args[0])
+	}
+	number, err := strconv.Atoi(args[1])
+	if err != nil {
+		return errors.Errorf("invalid pull request number %q", args[1])
+	}
+
+	token := configCmdConfig.Token
+	if token == "" {
+		token = os.Getenv("GITHUB_TOKEN")
+	}
+	if token == "" {
+		return errors.New("a token is required, use --token or set GITHUB_TOKEN")
+	}
+	client := github.NewTokenClient(ctx, token)
+	if configCmdConfig.GithubURL != "" {
+		if client, err = github.NewEnterpriseClient(configCmdConfig.GithubURL, configCmdConfig.GithubURL, client.Client()); err != nil {
+			return errors.Wrap(err, "invalid GitHub URL")
+		}
+	}
+
+	pr, _, err := client.PullRequests.Get(ctx, owner, repo, number)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get pull request %s/%s#%d", owner, repo, number)
+	}
+
+	config, err := simulateConfig(ctx, client, pr)
+	if err != nil {
+		return err
+	}
+
+	pullCtx := pull.NewGithubContext(client, pr)
+	e, err := bulldozer.ExplainEligibility(ctx, pullCtx, *config)
+	if err != nil {
+		return err
+	}
+
+	merge := "would not merge"
+	if e.Mergeable {
+		method, err := bulldozer.DetermineMergeMethod(ctx, pullCtx, config.Merge)
+		if err != nil {
+			return errors.Wrap(err, "failed to determine merge method")
+		}
+		merge = fmt.Sprintf("would merge with method %s", method)
+	}
+	update := "would not update"
+	if e.Updateable {
+		update = fmt.Sprintf("would update from %s if out of date", pr.GetBase().GetRef())
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "%s/%s#%d: %s\n\n", owner, repo, number, pr.GetTitle())
+	fmt.Fprintf(out, "Merge:  %s, because %s\n", merge, e.Reason)
+	fmt.Fprintf(out, "Update: %s, because %s\n", update, e.UpdateReason)
+
+	printSignals(out, "Trigger signals", e.Trigger)
+	printSignals(out, "Ignore signals", e.Ignore)
+
+	fmt.Fprintf(out, "\nRequired statuses:\n")
+	unsatisfied := make(map[string]bool)
+	for _, s := range e.UnsatisfiedStatuses {
+		unsatisfied[s] = true
+	}
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	for _, s := range e.RequiredStatuses {
+		fmt.Fprintf(w, "  %s\t%s\n", s, passed(!unsatisfied[s]))
+	}
+	return w.Flush()
+}
+
+// simulateConfig returns the configuration from --config, or the
+// configuration for the target branch of the pull request.
+func simulateConfig(ctx context.Context, client *github.Client, pr *github.PullRequest) (*bulldozer.Config, error) {
+	branch := pr.GetBase().GetRef()
+
+	if path := configCmdConfig.Config; path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", path)
+		}
+		if extends, _ := bulldozer.ReadExtends(b); extends != "" {
+			return nil, errors.Errorf("%s extends %s, which is not supported for local files", path, extends)
+		}
+		config, err := bulldozer.ParseConfig(b)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid configuration in %s", path)
+		}
+		return config.ForBranch(branch), nil
+	}
+
+	owner := pr.GetBase().GetRepo().GetOwner().GetLogin()
+	repo := pr.GetBase().GetRepo().GetName()
+	fetcher := handler.NewConfigFetcher(appconfig.NewLoader([]string{handler.DefaultConfigurationPath}), nil)
+
+	fc := fetcher.Config(ctx, client, owner, repo, branch)
+	switch {
+	case fc.LoadError != nil:
+		return nil, errors.Wrapf(fc.LoadError, "failed to load configuration: %s: %s", fc.Source, fc.Path)
+	case fc.ParseError != nil:
+		return nil, errors.Wrapf(fc.ParseError, "invalid configuration in %s: %s", fc.Source, fc.Path)
+	case fc.Config == nil:
+		return nil, errors.Errorf("no configuration found in %s/%s@%s", owner, repo, branch)
+	}
+	return fc.Config.ForBranch(branch), nil
+}
+
+func printSignals(w io.Writer, heading string, results []bulldozer.SignalResult) {
+	fmt.Fprintf(w, "\n%s:\n", heading)
+	if len(results) == 0 {
+		fmt.Fprintln(w, "  none configured")
+		return
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, r := range results {
+		fmt.Fprintf(tw, "  %s\t%s\t%s\n", r.Name, matched(r.Matched), r.Description)
+	}
+	_ = tw.Flush()
+}
+
+func matched(ok bool) string {
+	if ok {
+		return "matched"
+	}
+	return "not matched"
+}
+
+func passed(ok bool) string {
+	if ok {
+		return "passed"
+	}
+	return "pending"
+}
+
+func init() {
+	RootCmd.AddCommand(ConfigCmd)
+	ConfigCmd.AddCommand(configValidateCmd)
+	ConfigCmd.AddCommand(configSimulateCmd)
+
+	configSimulateCmd.Flags().StringVar(&configCmdConfig.Config, "config", "", "configuration file to use instead of the file in the repository")
+	configSimulateCmd.Flags().StringVar(&configCmdConfig.Token, "token", "", "GitHub token with read access to the repository (default $GITHUB_TOKEN)")
+	configSimulateCmd.Flags().StringVar(&configCmdConfig.GithubURL, "github-url", "", "API URL for GitHub Enterprise, like https://github.example.com/api/v3/")
+}
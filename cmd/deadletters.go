@@ -0,0 +1,135 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var deadLettersCmdConfig struct {
+	URL   string
+	Token string
+}
+
+var DeadLettersCmd = &cobra.Command{
+	Use:   "deadletters",
+	Short: "Lists and replays webhook deliveries that failed to process.",
+	Long:  "Lists and replays webhook deliveries that failed to process, using the admin API of a running bulldozer server.",
+}
+
+var deadLettersListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists webhook deliveries that failed to process.",
+	Args:  cobra.NoArgs,
+	RunE:  deadLettersList,
+}
+
+var deadLettersReplayCmd = &cobra.Command{
+	Use:   "replay <id>...",
+	Short: "Processes webhook deliveries again.",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  deadLettersReplay,
+}
+
+func adminRequest(method, path string, out interface{}) error {
+	token := deadLettersCmdConfig.Token
+	if token == "" {
+		token = os.Getenv("BULLDOZER_OPTIONS_ADMIN_TOKEN")
+	}
+
+	req, err := http.NewRequest(method, strings.TrimSuffix(deadLettersCmdConfig.URL, "/")+"/api/admin"+path, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	client := &http.Client{Timeout: 5 * time.Minute}
+	res, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "request failed")
+	}
+	defer res.Body.Close()
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response")
+	}
+	if res.StatusCode != http.StatusOK {
+		var msg struct {
+			Error string `json:"error"`
+		}
+		_ = json.Unmarshal(b, &msg)
+		return errors.Errorf("request failed with status %d: %s", res.StatusCode, msg.Error)
+	}
+	if out != nil {
+		return errors.Wrap(json.Unmarshal(b, out), "failed to parse response")
+	}
+	return nil
+}
+
+func deadLettersList(cmd *cobra.Command, args []string) error {
+	var letters []struct {
+		ID        string    `json:"id"`
+		EventType string    `json:"event_type"`
+		Error     string    `json:"error"`
+		Time      time.Time `json:"time"`
+		Attempts  int       `json:"attempts"`
+	}
+	if err := adminRequest(http.MethodGet, "/deadletters", &letters); err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tEVENT\tTIME\tATTEMPTS\tERROR")
+	for _, l := range letters {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", l.ID, l.EventType, l.Time.Format(time.RFC3339), l.Attempts, l.Error)
+	}
+	return w.Flush()
+}
+
+func deadLettersReplay(cmd *cobra.Command, args []string) error {
+	var failed int
+	for _, id := range args {
+		if err := adminRequest(http.MethodPost, "/deadletters/"+id+"/replay", nil); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "%s: %s\n", id, err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s: replayed\n", id)
+	}
+	if failed > 0 {
+		return errors.Errorf("failed to replay %d of %d deliveries", failed, len(args))
+	}
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(DeadLettersCmd)
+	DeadLettersCmd.AddCommand(deadLettersListCmd)
+	DeadLettersCmd.AddCommand(deadLettersReplayCmd)
+
+	DeadLettersCmd.PersistentFlags().StringVar(&deadLettersCmdConfig.URL, "url", "http://localhost:8080", "address of the bulldozer server")
+	DeadLettersCmd.PersistentFlags().StringVar(&deadLettersCmdConfig.Token, "token", "", "admin token for the server (default $BULLDOZER_OPTIONS_ADMIN_TOKEN)")
+}
@@ -72,6 +72,10 @@ func serverCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	go s.ReloadOnHangup(func() (*server.Config, error) {
+		return readServerConfig(serverCmdConfig.Path)
+	})
+
 	return errors.Wrap(s.Start(), "server terminated")
 }
 
@@ -0,0 +1,222 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package notify sends notifications about bulldozer activity to chat
+// services and webhooks.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type EventType string
+
+const (
+	MergeSucceeded   EventType = "merge_succeeded"
+	MergeFailed      EventType = "merge_failed"
+	ConflictDetected EventType = "conflict_detected"
+	ConfigError      EventType = "config_error"
+)
+
+// DefaultSuppressDuration is how long identical events are suppressed after
+// they are sent, so that repeated evaluations do not send the same
+// notification many times.
+const DefaultSuppressDuration = time.Hour
+
+// Event is something that happened to a repository or pull request.
+type Event struct {
+	Type    EventType `json:"type"`
+	Owner   string    `json:"owner"`
+	Repo    string    `json:"repo"`
+	Number  int       `json:"number,omitempty"`
+	Title   string    `json:"title,omitempty"`
+	URL     string    `json:"url,omitempty"`
+	Message string    `json:"message"`
+}
+
+// Text returns a one-line description of the event for chat messages.
+func (e Event) Text() string {
+	subject := fmt.Sprintf("%s/%s", e.Owner, e.Repo)
+	if e.Number > 0 {
+		subject = fmt.Sprintf("%s#%d", subject, e.Number)
+	}
+	if e.Title != "" {
+		subject = fmt.Sprintf("%s (%s)", subject, e.Title)
+	}
+	if e.URL != "" {
+		return fmt.Sprintf("%s: %s %s", subject, e.Message, e.URL)
+	}
+	return fmt.Sprintf("%s: %s", subject, e.Message)
+}
+
+func (e Event) key() string {
+	return fmt.Sprintf("%s:%s/%s#%d:%s", e.Type, e.Owner, e.Repo, e.Number, e.Message)
+}
+
+// Route sends events from a repository to a sink defined by the server. It is
+// part of the repository configuration.
+type Route struct {
+	// Sink is the name of a sink in the server configuration.
+	Sink string `yaml:"sink"`
+
+	// Events are the types of events sent to the sink. If empty, all events
+	// are sent.
+	Events []EventType `yaml:"events"`
+}
+
+func (r Route) matches(t EventType) bool {
+	if len(r.Events) == 0 {
+		return true
+	}
+	for _, e := range r.Events {
+		if e == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Notifier sends events to the sinks defined by the server.
+type Notifier struct {
+	suppress time.Duration
+	now      func() time.Time
+
+	mu     sync.Mutex
+	sinks  map[string]Sink
+	global map[string][]EventType
+	sent   map[string]time.Time
+}
+
+// NewNotifier creates a Notifier with sinks created from the configuration.
+func NewNotifier(configs map[string]SinkConfig, client *http.Client) (*Notifier, error) {
+	n := &Notifier{
+		sinks:    make(map[string]Sink),
+		global:   make(map[string][]EventType),
+		suppress: DefaultSuppressDuration,
+		now:      time.Now,
+		sent:     make(map[string]time.Time),
+	}
+	for name, config := range configs {
+		sink, err := NewSink(config, client)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid notification sink %q", name)
+		}
+		n.sinks[name] = sink
+		n.global[name] = config.Events
+	}
+	return n, nil
+}
+
+// Reload replaces the sinks with sinks created from the configuration. If
+// the configuration is invalid, it returns an error and keeps the existing
+// sinks. Identical events sent before the reload are still suppressed.
+func (n *Notifier) Reload(configs map[string]SinkConfig, client *http.Client) error {
+	next, err := NewNotifier(configs, client)
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.sinks = next.sinks
+	n.global = next.global
+	return nil
+}
+
+// Notify sends the event to each sink that receives it from all
+// repositories or that is routed to by the repository configuration. It logs
+// any errors. A nil Notifier does nothing.
+func (n *Notifier) Notify(ctx context.Context, routes []Route, event Event) {
+	if n == nil {
+		return
+	}
+	logger := zerolog.Ctx(ctx)
+
+	n.mu.Lock()
+	sinks, global := n.sinks, n.global
+	n.mu.Unlock()
+	if len(sinks) == 0 {
+		return
+	}
+
+	names := sinksFor(ctx, sinks, global, routes, event.Type)
+	if len(names) == 0 || n.suppressed(event) {
+		return
+	}
+
+	for _, name := range names {
+		if err := sinks[name].Send(ctx, event); err != nil {
+			logger.Error().Err(err).Msgf("Failed to send %s notification to %s", event.Type, name)
+			continue
+		}
+		logger.Debug().Msgf("Sent %s notification to %s", event.Type, name)
+	}
+}
+
+func sinksFor(ctx context.Context, sinks map[string]Sink, global map[string][]EventType, routes []Route, t EventType) []string {
+	selected := make(map[string]bool)
+	for name, events := range global {
+		for _, e := range events {
+			if e == t {
+				selected[name] = true
+			}
+		}
+	}
+	for _, r := range routes {
+		if _, ok := sinks[r.Sink]; !ok {
+			zerolog.Ctx(ctx).Warn().Msgf("Notification sink %q is not defined by the server", r.Sink)
+			continue
+		}
+		if r.matches(t) {
+			selected[r.Sink] = true
+		}
+	}
+
+	names := make([]string, 0, len(selected))
+	for name := range selected {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// suppressed returns true if an identical event was sent recently and
+// otherwise records that the event is sent.
+func (n *Notifier) suppressed(event Event) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	now := n.now()
+	for key, at := range n.sent {
+		if now.Sub(at) >= n.suppress {
+			delete(n.sent, key)
+		}
+	}
+
+	key := event.key()
+	if _, ok := n.sent[key]; ok {
+		return true
+	}
+	n.sent[key] = now
+	return false
+}
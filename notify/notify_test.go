@@ -0,0 +1,136 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifier(t *testing.T) {
+	received := make(map[string][]map[string]interface{})
+	var auth string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		received[r.URL.Path] = append(received[r.URL.Path], body)
+		if r.URL.Path == "/webhook" {
+			auth = r.Header.Get("Authorization")
+		}
+	}))
+	defer srv.Close()
+
+	n, err := NewNotifier(map[string]SinkConfig{
+		"slack":   {Type: SinkSlack, URL: srv.URL + "/slack"},
+		"teams":   {Type: SinkTeams, URL: srv.URL + "/teams"},
+		"webhook": {Type: SinkWebhook, URL: srv.URL + "/webhook", Headers: map[string]string{"Authorization": "Bearer token"}, Events: []EventType{ConfigError}},
+	}, srv.Client())
+	require.NoError(t, err)
+
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	n.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	routes := []Route{
+		{Sink: "slack", Events: []EventType{MergeFailed}},
+		{Sink: "teams"},
+		{Sink: "undefined"},
+	}
+	event := Event{
+		Type:    MergeFailed,
+		Owner:   "testorg",
+		Repo:    "testrepo",
+		Number:  1,
+		Title:   "Fix the bug",
+		Message: "bulldozer could not merge the pull request",
+	}
+
+	n.Notify(ctx, routes, event)
+	require.Len(t, received["/slack"], 1)
+	assert.Equal(t, "testorg/testrepo#1 (Fix the bug): bulldozer could not merge the pull request", received["/slack"][0]["text"])
+	require.Len(t, received["/teams"], 1)
+	assert.Equal(t, "MessageCard", received["/teams"][0]["@type"])
+	assert.Empty(t, received["/webhook"])
+
+	n.Notify(ctx, routes, event)
+	assert.Len(t, received["/slack"], 1, "identical event was not suppressed")
+
+	now = now.Add(DefaultSuppressDuration)
+	n.Notify(ctx, routes, event)
+	assert.Len(t, received["/slack"], 2, "event was suppressed after the suppression window")
+
+	n.Notify(ctx, routes, Event{Type: MergeSucceeded, Owner: "testorg", Repo: "testrepo", Number: 1, Message: "merged"})
+	assert.Len(t, received["/slack"], 2, "event was sent to a sink not routed for it")
+	assert.Len(t, received["/teams"], 3)
+
+	n.Notify(ctx, nil, Event{Type: ConfigError, Owner: "testorg", Repo: "testrepo", Message: "invalid configuration"})
+	require.Len(t, received["/webhook"], 1)
+	assert.Equal(t, "config_error", received["/webhook"][0]["type"])
+	assert.Equal(t, "invalid configuration", received["/webhook"][0]["message"])
+	assert.Equal(t, "Bearer token", auth)
+}
+
+func TestNotifierReload(t *testing.T) {
+	var received []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.URL.Path)
+	}))
+	defer srv.Close()
+
+	n, err := NewNotifier(map[string]SinkConfig{
+		"slack": {Type: SinkSlack, URL: srv.URL + "/old"},
+	}, srv.Client())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	routes := []Route{{Sink: "slack"}}
+
+	n.Notify(ctx, routes, Event{Type: MergeFailed, Owner: "testorg", Repo: "testrepo", Number: 1})
+	assert.Equal(t, []string{"/old"}, received)
+
+	err = n.Reload(map[string]SinkConfig{"slack": {Type: SinkSlack}}, srv.Client())
+	assert.EqualError(t, err, `invalid notification sink "slack": url is required`)
+
+	require.NoError(t, n.Reload(map[string]SinkConfig{
+		"slack": {Type: SinkSlack, URL: srv.URL + "/new"},
+	}, srv.Client()))
+
+	n.Notify(ctx, routes, Event{Type: MergeFailed, Owner: "testorg", Repo: "testrepo", Number: 1})
+	assert.Equal(t, []string{"/old"}, received, "identical event was not suppressed after reload")
+
+	n.Notify(ctx, routes, Event{Type: MergeFailed, Owner: "testorg", Repo: "testrepo", Number: 2})
+	assert.Equal(t, []string{"/old", "/new"}, received)
+}
+
+func TestNewSink(t *testing.T) {
+	_, err := NewSink(SinkConfig{Type: SinkSlack}, nil)
+	assert.EqualError(t, err, "url is required")
+
+	_, err = NewSink(SinkConfig{Type: "email", URL: "https://example.com"}, nil)
+	assert.EqualError(t, err, `unknown sink type "email"`)
+}
+
+func TestNilNotifier(t *testing.T) {
+	var n *Notifier
+	n.Notify(context.Background(), []Route{{Sink: "slack"}}, Event{Type: MergeFailed})
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+type SinkType string
+
+const (
+	SinkSlack   SinkType = "slack"
+	SinkWebhook SinkType = "webhook"
+	SinkTeams   SinkType = "teams"
+)
+
+// SinkConfig defines a sink in the server configuration. It contains the
+// credentials needed to send to the sink, so it is never part of the
+// repository configuration.
+type SinkConfig struct {
+	Type SinkType `yaml:"type"`
+	URL  string   `yaml:"url"`
+
+	// Headers are added to requests to generic webhooks, for example to
+	// provide an authorization token.
+	Headers map[string]string `yaml:"headers"`
+
+	// Events are the types of events from all repositories sent to the sink,
+	// in addition to events routed to the sink by repository configuration.
+	// Use this for events like config_error, which happen when the
+	// repository configuration cannot be read.
+	Events []EventType `yaml:"events"`
+}
+
+// Sink sends events to an external service.
+type Sink interface {
+	Send(ctx context.Context, event Event) error
+}
+
+// NewSink creates a sink from the configuration. If client is nil, it uses
+// http.DefaultClient.
+func NewSink(config SinkConfig, client *http.Client) (Sink, error) {
+	if config.URL == "" {
+		return nil, errors.New("url is required")
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	switch config.Type {
+	case SinkSlack:
+		return &SlackSink{client: client, url: config.URL}, nil
+	case SinkWebhook:
+		return &WebhookSink{client: client, url: config.URL, headers: config.Headers}, nil
+	case SinkTeams:
+		return &TeamsSink{client: client, url: config.URL}, nil
+	}
+	return nil, errors.Errorf("unknown sink type %q", config.Type)
+}
+
+// SlackSink posts events to a Slack incoming webhook.
+type SlackSink struct {
+	client *http.Client
+	url    string
+}
+
+func (s *SlackSink) Send(ctx context.Context, event Event) error {
+	return postJSON(ctx, s.client, s.url, nil, map[string]string{
+		"text": event.Text(),
+	})
+}
+
+// TeamsSink posts events to a Microsoft Teams incoming webhook.
+type TeamsSink struct {
+	client *http.Client
+	url    string
+}
+
+func (s *TeamsSink) Send(ctx context.Context, event Event) error {
+	return postJSON(ctx, s.client, s.url, nil, map[string]string{
+		"@type":    "MessageCard",
+		"@context": "https://schema.org/extensions",
+		"summary":  string(event.Type),
+		"text":     event.Text(),
+	})
+}
+
+// WebhookSink posts events as JSON to an arbitrary URL.
+type WebhookSink struct {
+	client  *http.Client
+	url     string
+	headers map[string]string
+}
+
+func (s *WebhookSink) Send(ctx context.Context, event Event) error {
+	return postJSON(ctx, s.client, s.url, s.headers, event)
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, body interface{}) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal notification")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return errors.Wrap(err, "failed to create notification request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send notification")
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return errors.Errorf("notification failed with status %d", res.StatusCode)
+	}
+	return nil
+}
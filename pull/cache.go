@@ -0,0 +1,228 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+)
+
+const (
+	methodForSHA    = "sha"
+	methodForRef    = "ref"
+	methodForAllSHA = "all_sha"
+)
+
+// CacheMetrics observes the effectiveness of a LookupCache. Implementations
+// must be safe for concurrent use.
+type CacheMetrics interface {
+	// ObserveCacheLookup is called for each lookup with the kind of lookup,
+	// like "sha" or "ref", and whether the result was in the cache.
+	ObserveCacheLookup(method string, hit bool)
+}
+
+type cacheKey struct {
+	method string
+	owner  string
+	repo   string
+	value  string
+}
+
+type cacheEntry struct {
+	key     cacheKey
+	prs     []*github.PullRequest
+	expires time.Time
+}
+
+// LookupCache stores the results of pull request lookups for a fixed amount
+// of time. When the cache is full, the least recently used entry is evicted.
+// It is safe for concurrent use and may be shared by many CachingListers.
+type LookupCache struct {
+	ttl     time.Duration
+	maxSize int
+	now     func() time.Time
+	metrics CacheMetrics
+
+	mu      sync.Mutex
+	entries map[cacheKey]*list.Element
+	lru     *list.List
+}
+
+// NewLookupCache creates a cache where entries expire after ttl. If maxSize
+// is less than 1, the number of entries is not limited.
+func NewLookupCache(ttl time.Duration, maxSize int) *LookupCache {
+	return &LookupCache{
+		ttl:     ttl,
+		maxSize: maxSize,
+		now:     time.Now,
+		entries: make(map[cacheKey]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// SetMetrics reports the hits and misses of lookups in the cache to m.
+func (c *LookupCache) SetMetrics(m CacheMetrics) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.metrics = m
+}
+
+// GetAllPossibleOpenPullRequestsForSHA returns the cached result of
+// GetAllPossibleOpenPullRequestsForSHA for the SHA in the repository, calling
+// it with the client on a miss. Failed lookups are not cached.
+func (c *LookupCache) GetAllPossibleOpenPullRequestsForSHA(ctx context.Context, client *github.Client, owner, repo, sha string, opts ...ListOption) ([]*github.PullRequest, error) {
+	return c.lookup(methodForAllSHA, owner, repo, sha, func() ([]*github.PullRequest, error) {
+		return GetAllPossibleOpenPullRequestsForSHA(ctx, client, owner, repo, sha, opts...)
+	})
+}
+
+// Invalidate removes the results of all lookups for the SHA or ref in the
+// repository. Callers should invalidate the SHA or ref when they know it has
+// changed, for example when receiving a push event.
+func (c *LookupCache) Invalidate(owner, repo, sha string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	owner, repo = strings.ToLower(owner), strings.ToLower(repo)
+	for key, elem := range c.entries {
+		if key.owner == owner && key.repo == repo && key.value == sha {
+			c.remove(elem)
+		}
+	}
+}
+
+// Len returns the number of entries in the cache, including expired entries
+// that have not been removed yet.
+func (c *LookupCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+func (c *LookupCache) lookup(method, owner, repo, value string, fn func() ([]*github.PullRequest, error)) ([]*github.PullRequest, error) {
+	key := cacheKey{
+		method: method,
+		owner:  strings.ToLower(owner),
+		repo:   strings.ToLower(repo),
+		value:  value,
+	}
+
+	if prs, ok := c.get(key); ok {
+		return prs, nil
+	}
+
+	prs, err := fn()
+	if err != nil {
+		return prs, err
+	}
+
+	c.add(key, prs)
+	return prs, nil
+}
+
+func (c *LookupCache) get(key cacheKey) ([]*github.PullRequest, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	prs, ok := c.getLocked(key)
+	if c.metrics != nil {
+		c.metrics.ObserveCacheLookup(key.method, ok)
+	}
+	return prs, ok
+}
+
+func (c *LookupCache) getLocked(key cacheKey) ([]*github.PullRequest, bool) {
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*cacheEntry)
+	if !c.now().Before(entry.expires) {
+		c.remove(elem)
+		return nil, false
+	}
+
+	c.lru.MoveToFront(elem)
+	return append([]*github.PullRequest(nil), entry.prs...), true
+}
+
+func (c *LookupCache) add(key cacheKey, prs []*github.PullRequest) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &cacheEntry{
+		key:     key,
+		prs:     append([]*github.PullRequest(nil), prs...),
+		expires: c.now().Add(c.ttl),
+	}
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(entry)
+	for c.maxSize > 0 && c.lru.Len() > c.maxSize {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *LookupCache) remove(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.entries, elem.Value.(*cacheEntry).key)
+}
+
+// CachingLister is a Lister that returns cached results for lookups that were
+// made recently, delegating to another Lister on a cache miss. Failed lookups
+// are not cached.
+type CachingLister struct {
+	inner Lister
+	cache *LookupCache
+}
+
+func NewCachingLister(inner Lister, cache *LookupCache) *CachingLister {
+	return &CachingLister{
+		inner: inner,
+		cache: cache,
+	}
+}
+
+func (l *CachingLister) ListOpenPullRequestsForSHA(ctx context.Context, owner, repo, sha string) ([]*github.PullRequest, error) {
+	return l.cache.lookup(methodForSHA, owner, repo, sha, func() ([]*github.PullRequest, error) {
+		return l.inner.ListOpenPullRequestsForSHA(ctx, owner, repo, sha)
+	})
+}
+
+func (l *CachingLister) ListOpenPullRequestsForRef(ctx context.Context, owner, repo, ref string) ([]*github.PullRequest, error) {
+	return l.cache.lookup(methodForRef, owner, repo, ref, func() ([]*github.PullRequest, error) {
+		return l.inner.ListOpenPullRequestsForRef(ctx, owner, repo, ref)
+	})
+}
+
+// Invalidate removes the results of all lookups for the SHA or ref in the
+// repository from the underlying cache.
+func (l *CachingLister) Invalidate(owner, repo, sha string) {
+	l.cache.Invalidate(owner, repo, sha)
+}
+
+// type assertion
+var _ Lister = &CachingLister{}
@@ -0,0 +1,231 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingLister struct {
+	Err error
+
+	mu       sync.Mutex
+	SHACalls int
+	RefCalls int
+}
+
+func (l *countingLister) ListOpenPullRequestsForSHA(ctx context.Context, owner, repo, sha string) ([]*github.PullRequest, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.SHACalls++
+	return []*github.PullRequest{testPR(l.SHACalls, "develop", sha)}, l.Err
+}
+
+func (l *countingLister) ListOpenPullRequestsForRef(ctx context.Context, owner, repo, ref string) ([]*github.PullRequest, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.RefCalls++
+	return []*github.PullRequest{testPR(l.RefCalls, ref, "a")}, l.Err
+}
+
+type testClock struct {
+	t time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.t
+}
+
+func newTestCache(ttl time.Duration, maxSize int) (*LookupCache, *testClock) {
+	clock := &testClock{t: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	cache := NewLookupCache(ttl, maxSize)
+	cache.now = clock.Now
+	return cache, clock
+}
+
+func TestCachingLister(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("hitWithinTTL", func(t *testing.T) {
+		inner := &countingLister{}
+		cache, clock := newTestCache(time.Minute, 10)
+		lister := NewCachingLister(inner, cache)
+
+		prs, err := lister.ListOpenPullRequestsForSHA(ctx, "testorg", "testrepo", "abc")
+		require.NoError(t, err)
+		assert.Equal(t, []int{1}, numbers(prs))
+
+		clock.t = clock.t.Add(30 * time.Second)
+
+		prs, err = lister.ListOpenPullRequestsForSHA(ctx, "TestOrg", "testrepo", "abc")
+		require.NoError(t, err)
+		assert.Equal(t, []int{1}, numbers(prs))
+		assert.Equal(t, 1, inner.SHACalls, "second lookup was not cached")
+	})
+
+	t.Run("missAfterTTL", func(t *testing.T) {
+		inner := &countingLister{}
+		cache, clock := newTestCache(time.Minute, 10)
+		lister := NewCachingLister(inner, cache)
+
+		_, _ = lister.ListOpenPullRequestsForSHA(ctx, "testorg", "testrepo", "abc")
+		clock.t = clock.t.Add(time.Minute)
+
+		prs, err := lister.ListOpenPullRequestsForSHA(ctx, "testorg", "testrepo", "abc")
+		require.NoError(t, err)
+		assert.Equal(t, []int{2}, numbers(prs))
+		assert.Equal(t, 2, inner.SHACalls, "expired lookup was cached")
+	})
+
+	t.Run("methodsAreSeparate", func(t *testing.T) {
+		inner := &countingLister{}
+		cache, _ := newTestCache(time.Minute, 10)
+		lister := NewCachingLister(inner, cache)
+
+		_, _ = lister.ListOpenPullRequestsForSHA(ctx, "testorg", "testrepo", "abc")
+		_, _ = lister.ListOpenPullRequestsForRef(ctx, "testorg", "testrepo", "abc")
+		_, _ = lister.ListOpenPullRequestsForRef(ctx, "testorg", "testrepo", "abc")
+
+		assert.Equal(t, 1, inner.SHACalls)
+		assert.Equal(t, 1, inner.RefCalls)
+	})
+
+	t.Run("invalidate", func(t *testing.T) {
+		inner := &countingLister{}
+		cache, _ := newTestCache(time.Minute, 10)
+		lister := NewCachingLister(inner, cache)
+
+		_, _ = lister.ListOpenPullRequestsForSHA(ctx, "testorg", "testrepo", "abc")
+		_, _ = lister.ListOpenPullRequestsForSHA(ctx, "testorg", "testrepo", "def")
+		lister.Invalidate("testorg", "testrepo", "abc")
+
+		_, _ = lister.ListOpenPullRequestsForSHA(ctx, "testorg", "testrepo", "abc")
+		_, _ = lister.ListOpenPullRequestsForSHA(ctx, "testorg", "testrepo", "def")
+		assert.Equal(t, 3, inner.SHACalls, "only the invalidated SHA should be fetched again")
+	})
+
+	t.Run("evictsLeastRecentlyUsed", func(t *testing.T) {
+		inner := &countingLister{}
+		cache, _ := newTestCache(time.Minute, 2)
+		lister := NewCachingLister(inner, cache)
+
+		_, _ = lister.ListOpenPullRequestsForSHA(ctx, "testorg", "testrepo", "a")
+		_, _ = lister.ListOpenPullRequestsForSHA(ctx, "testorg", "testrepo", "b")
+		_, _ = lister.ListOpenPullRequestsForSHA(ctx, "testorg", "testrepo", "a")
+		_, _ = lister.ListOpenPullRequestsForSHA(ctx, "testorg", "testrepo", "c")
+		assert.Equal(t, 2, cache.Len())
+		assert.Equal(t, 3, inner.SHACalls)
+
+		_, _ = lister.ListOpenPullRequestsForSHA(ctx, "testorg", "testrepo", "a")
+		assert.Equal(t, 3, inner.SHACalls, "recently used entry was evicted")
+
+		_, _ = lister.ListOpenPullRequestsForSHA(ctx, "testorg", "testrepo", "b")
+		assert.Equal(t, 4, inner.SHACalls, "least recently used entry was not evicted")
+	})
+
+	t.Run("errorsAreNotCached", func(t *testing.T) {
+		inner := &countingLister{Err: errors.New("failed")}
+		cache, _ := newTestCache(time.Minute, 10)
+		lister := NewCachingLister(inner, cache)
+
+		_, err := lister.ListOpenPullRequestsForSHA(ctx, "testorg", "testrepo", "abc")
+		assert.Error(t, err)
+		_, err = lister.ListOpenPullRequestsForSHA(ctx, "testorg", "testrepo", "abc")
+		assert.Error(t, err)
+		assert.Equal(t, 2, inner.SHACalls)
+	})
+
+	t.Run("concurrentUse", func(t *testing.T) {
+		inner := &countingLister{}
+		cache, _ := newTestCache(time.Minute, 5)
+		lister := NewCachingLister(inner, cache)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				sha := string(rune('a' + i%8))
+				_, _ = lister.ListOpenPullRequestsForSHA(ctx, "testorg", "testrepo", sha)
+				lister.Invalidate("testorg", "testrepo", sha)
+			}(i)
+		}
+		wg.Wait()
+		assert.LessOrEqual(t, cache.Len(), 5)
+	})
+}
+
+type recordingCacheMetrics struct {
+	mu     sync.Mutex
+	hits   map[string]int
+	misses map[string]int
+}
+
+func (m *recordingCacheMetrics) ObserveCacheLookup(method string, hit bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if hit {
+		m.hits[method]++
+	} else {
+		m.misses[method]++
+	}
+}
+
+func TestLookupCacheGetAllPossibleOpenPullRequestsForSHA(t *testing.T) {
+	var requests int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/testorg/testrepo/commits/a/pulls", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_ = json.NewEncoder(w).Encode([]*github.PullRequest{testPR(1, "develop", "a")})
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	ctx := context.Background()
+	metrics := &recordingCacheMetrics{hits: map[string]int{}, misses: map[string]int{}}
+	cache, _ := newTestCache(time.Minute, 10)
+	cache.SetMetrics(metrics)
+
+	for i := 0; i < 3; i++ {
+		prs, err := cache.GetAllPossibleOpenPullRequestsForSHA(ctx, client, "testorg", "testrepo", "a")
+		require.NoError(t, err)
+		assert.Equal(t, []int{1}, numbers(prs))
+	}
+	assert.Equal(t, 1, requests, "repeated lookups were not cached")
+
+	cache.Invalidate("testorg", "testrepo", "a")
+	_, err := cache.GetAllPossibleOpenPullRequestsForSHA(ctx, client, "testorg", "testrepo", "a")
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests, "invalidated lookup was cached")
+
+	assert.Equal(t, map[string]int{methodForAllSHA: 2}, metrics.hits)
+	assert.Equal(t, map[string]int{methodForAllSHA: 2}, metrics.misses)
+}
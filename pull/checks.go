@@ -0,0 +1,70 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"github.com/pkg/errors"
+)
+
+// CheckResult is how a commit status or check run affects the evaluation of
+// a pull request.
+type CheckResult string
+
+const (
+	CheckSuccess CheckResult = "success"
+	CheckFailure CheckResult = "failure"
+	CheckPending CheckResult = "pending"
+)
+
+// CheckConclusions maps the conclusions of completed check runs to results,
+// since CI providers use conclusions like "neutral", "skipped", and "stale"
+// inconsistently. Conclusions that are not in the map use the result in
+// DefaultCheckConclusions.
+type CheckConclusions map[string]CheckResult
+
+// DefaultCheckConclusions are the results of conclusions that are not
+// configured. Other conclusions, like "stale" and "timed_out", are failures.
+var DefaultCheckConclusions = CheckConclusions{
+	"success": CheckSuccess,
+	"neutral": CheckSuccess,
+	"skipped": CheckSuccess,
+}
+
+// Validate returns an error if any conclusion maps to an unknown result.
+func (c CheckConclusions) Validate() error {
+	for conclusion, result := range c {
+		switch result {
+		case CheckSuccess, CheckFailure, CheckPending:
+		default:
+			return errors.Errorf("invalid result %q for conclusion %q", result, conclusion)
+		}
+	}
+	return nil
+}
+
+// Result returns the result of the check run. Check runs that are not
+// completed are pending.
+func (c CheckConclusions) Result(run *CheckRun) CheckResult {
+	if run.Status != "completed" {
+		return CheckPending
+	}
+	if result, ok := c[run.Conclusion]; ok {
+		return result
+	}
+	if result, ok := DefaultCheckConclusions[run.Conclusion]; ok {
+		return result
+	}
+	return CheckFailure
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckConclusionsResult(t *testing.T) {
+	conclusions := CheckConclusions{
+		"neutral": CheckFailure,
+		"stale":   CheckPending,
+	}
+
+	tests := map[string]struct {
+		Conclusions CheckConclusions
+		Run         CheckRun
+		Result      CheckResult
+	}{
+		"inProgress": {
+			Conclusions: conclusions,
+			Run:         CheckRun{Status: "in_progress"},
+			Result:      CheckPending,
+		},
+		"success": {
+			Conclusions: conclusions,
+			Run:         CheckRun{Status: "completed", Conclusion: "success"},
+			Result:      CheckSuccess,
+		},
+		"configured": {
+			Conclusions: conclusions,
+			Run:         CheckRun{Status: "completed", Conclusion: "neutral"},
+			Result:      CheckFailure,
+		},
+		"stale": {
+			Conclusions: conclusions,
+			Run:         CheckRun{Status: "completed", Conclusion: "stale"},
+			Result:      CheckPending,
+		},
+		"default": {
+			Conclusions: conclusions,
+			Run:         CheckRun{Status: "completed", Conclusion: "skipped"},
+			Result:      CheckSuccess,
+		},
+		"unconfigured": {
+			Run:    CheckRun{Status: "completed", Conclusion: "stale"},
+			Result: CheckFailure,
+		},
+		"failure": {
+			Run:    CheckRun{Status: "completed", Conclusion: "timed_out"},
+			Result: CheckFailure,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.Result, test.Conclusions.Result(&test.Run))
+		})
+	}
+}
+
+func TestCheckConclusionsValidate(t *testing.T) {
+	assert.NoError(t, CheckConclusions{"neutral": CheckFailure, "stale": CheckPending}.Validate())
+	assert.EqualError(t, CheckConclusions{"neutral": "ignore"}.Validate(), `invalid result "ignore" for conclusion "neutral"`)
+}
@@ -0,0 +1,217 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"bufio"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CodeOwnersPaths are the locations GitHub reads the CODEOWNERS file from, in
+// order of precedence.
+var CodeOwnersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// CodeOwnersEntry is a line of a CODEOWNERS file.
+type CodeOwnersEntry struct {
+	// Pattern is the pattern of paths, as written in the file.
+	Pattern string
+
+	// Owners are the users, formatted as "login", and teams, formatted as
+	// "org/team-slug", that own matching paths. Owners identified by email
+	// address are included as written. If empty, matching paths have no
+	// owners.
+	Owners []string
+
+	re *regexp.Regexp
+}
+
+// CodeOwners is a parsed CODEOWNERS file.
+type CodeOwners struct {
+	entries []*CodeOwnersEntry
+}
+
+// ParseCodeOwners parses the content of a CODEOWNERS file. Patterns follow
+// the gitignore-like rules that GitHub documents for CODEOWNERS files.
+func ParseCodeOwners(content string) (*CodeOwners, error) {
+	var c CodeOwners
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		entry := &CodeOwnersEntry{Pattern: fields[0]}
+		for _, owner := range fields[1:] {
+			if strings.HasPrefix(owner, "#") {
+				break
+			}
+			entry.Owners = append(entry.Owners, strings.TrimPrefix(owner, "@"))
+		}
+
+		re, err := codeOwnersRegexp(strings.TrimPrefix(entry.Pattern, `\`))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid pattern %q on line %d", entry.Pattern, n)
+		}
+		entry.re = re
+		c.entries = append(c.entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read CODEOWNERS file")
+	}
+	return &c, nil
+}
+
+// Match returns the entry that applies to the path, which is the last entry
+// with a matching pattern, or nil if no entry matches.
+func (c *CodeOwners) Match(path string) *CodeOwnersEntry {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(c.entries) - 1; i >= 0; i-- {
+		if c.entries[i].re.MatchString(path) {
+			return c.entries[i]
+		}
+	}
+	return nil
+}
+
+// codeOwnersRegexp converts a CODEOWNERS pattern to a regular expression
+// that matches the paths of files the pattern applies to.
+func codeOwnersRegexp(pattern string) (*regexp.Regexp, error) {
+	trimmed := strings.Trim(pattern, "/")
+	if trimmed == "" {
+		return nil, errors.New("pattern is empty")
+	}
+
+	// patterns with a slash at the start or in the middle are relative to
+	// the root of the repository, other patterns match at any depth
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(trimmed, "/")
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(trimmed); i++ {
+		switch c := trimmed[i]; c {
+		case '*':
+			switch {
+			case strings.HasPrefix(trimmed[i:], "**/"):
+				b.WriteString("(?:.*/)?")
+				i += 2
+			case strings.HasPrefix(trimmed[i:], "**"):
+				b.WriteString(".*")
+				i++
+			default:
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	switch {
+	case strings.HasSuffix(pattern, "/"):
+		// directories match all files they contain
+		b.WriteString("/.*")
+	case strings.HasSuffix(trimmed, "/*") && !strings.HasSuffix(trimmed, "/**"):
+		// a trailing "/*" matches files in the directory but not below it
+	default:
+		b.WriteString("(?:/.*)?")
+	}
+	b.WriteString("$")
+
+	return regexp.Compile(b.String())
+}
+
+type codeOwnersKey struct {
+	owner string
+	repo  string
+	ref   string
+}
+
+type codeOwnersCacheEntry struct {
+	codeOwners *CodeOwners
+	expires    time.Time
+}
+
+// CodeOwnersCache stores the CODEOWNERS file of each branch for a fixed
+// amount of time, so that evaluating many pull requests does not fetch and
+// parse the same file each time. Branches without a CODEOWNERS file are
+// cached as nil. It is safe for concurrent use.
+type CodeOwnersCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[codeOwnersKey]codeOwnersCacheEntry
+}
+
+func NewCodeOwnersCache(ttl time.Duration) *CodeOwnersCache {
+	return &CodeOwnersCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[codeOwnersKey]codeOwnersCacheEntry),
+	}
+}
+
+func (c *CodeOwnersCache) get(owner, repo, ref string) (*CodeOwners, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := newCodeOwnersKey(owner, repo, ref)
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.codeOwners, true
+}
+
+func (c *CodeOwnersCache) add(owner, repo, ref string, codeOwners *CodeOwners) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+
+	c.entries[newCodeOwnersKey(owner, repo, ref)] = codeOwnersCacheEntry{
+		codeOwners: codeOwners,
+		expires:    now.Add(c.ttl),
+	}
+}
+
+func newCodeOwnersKey(owner, repo, ref string) codeOwnersKey {
+	return codeOwnersKey{
+		owner: strings.ToLower(owner),
+		repo:  strings.ToLower(repo),
+		ref:   ref,
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCodeOwners(t *testing.T) {
+	codeOwners, err := ParseCodeOwners(`
+# default owners
+*                   @testorg/everyone
+
+*.js                @alice # inline comment
+/build/logs/        @bob
+docs/*              docs@example.com
+apps/               @carol
+/scripts/**/run.sh  @testorg/ops
+/vendor/
+`)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		Pattern string
+		Owners  []string
+	}{
+		"README.md":                 {"*", []string{"testorg/everyone"}},
+		"src/index.js":              {"*.js", []string{"alice"}},
+		"build/logs/today.log":      {"/build/logs/", []string{"bob"}},
+		"build/logs/2026/today.log": {"/build/logs/", []string{"bob"}},
+		"src/build/logs/today.log":  {"*", []string{"testorg/everyone"}},
+		"docs/index.md":             {"docs/*", []string{"docs@example.com"}},
+		"docs/guide/index.md":       {"*", []string{"testorg/everyone"}},
+		"apps/web/main.go":          {"apps/", []string{"carol"}},
+		"src/apps/main.go":          {"apps/", []string{"carol"}},
+		"scripts/run.sh":            {"/scripts/**/run.sh", []string{"testorg/ops"}},
+		"scripts/a/b/run.sh":        {"/scripts/**/run.sh", []string{"testorg/ops"}},
+		"vendor/lib/lib.go":         {"/vendor/", nil},
+	}
+
+	for path, test := range tests {
+		t.Run(path, func(t *testing.T) {
+			entry := codeOwners.Match(path)
+			require.NotNil(t, entry)
+			assert.Equal(t, test.Pattern, entry.Pattern)
+			assert.Equal(t, test.Owners, entry.Owners)
+		})
+	}
+
+	t.Run("noMatch", func(t *testing.T) {
+		codeOwners, err := ParseCodeOwners("/docs/ @alice\n")
+		require.NoError(t, err)
+		assert.Nil(t, codeOwners.Match("src/docs/index.md"))
+	})
+}
+
+func TestGithubContextCodeOwners(t *testing.T) {
+	var requests int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/testorg/testrepo/contents/.github/CODEOWNERS", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.NotFound(w, r)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/contents/CODEOWNERS", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "develop", r.URL.Query().Get("ref"))
+		content := base64.StdEncoding.EncodeToString([]byte("* @alice\n"))
+		_, _ = fmt.Fprintf(w, `{"type": "file", "encoding": "base64", "content": %q}`, content)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	clock := &testClock{t: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	cache := NewCodeOwnersCache(time.Minute)
+	cache.now = clock.Now
+
+	ctx := context.Background()
+	newContext := func() Context {
+		pr := testPR(1, "develop", "abc")
+		pr.Base.Repo = &github.Repository{Name: github.String("testrepo"), Owner: &github.User{Login: github.String("testorg")}}
+		return NewGithubContext(client, pr, WithCodeOwnersCache(cache))
+	}
+
+	codeOwners, err := newContext().CodeOwners(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, codeOwners)
+	assert.Equal(t, []string{"alice"}, codeOwners.Match("README.md").Owners)
+	assert.Equal(t, 2, requests)
+
+	_, err = newContext().CodeOwners(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests, "CODEOWNERS file was not cached between contexts")
+
+	clock.t = clock.t.Add(time.Minute)
+
+	_, err = newContext().CodeOwners(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 4, requests, "expired CODEOWNERS file was cached")
+}
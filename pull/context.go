@@ -16,6 +16,7 @@ package pull
 
 import (
 	"context"
+	"time"
 )
 
 // Context is the context for a pull request. It defines methods to get
@@ -44,9 +45,19 @@ type Context interface {
 	// Body returns the pull request body.
 	Body() string
 
+	// Author returns the login of the user who opened the pull request.
+	Author() string
+
+	// CreatedAt returns the time when the pull request was opened.
+	CreatedAt() time.Time
+
 	// HeadSHA returns the SHA hash of the latest commit in the pull request.
 	HeadSHA() string
 
+	// Milestone returns the title of the milestone of the pull request, or
+	// an empty string if it has no milestone.
+	Milestone() string
+
 	// Branches returns the base (also known as target) and head branch names
 	// of this pull request. Branches in this repository have no prefix, while
 	// branches in forks are prefixed with the owner of the fork and a colon.
@@ -61,20 +72,68 @@ type Context interface {
 	// checks for the pull request.
 	RequiredStatuses(ctx context.Context) ([]string, error)
 
+	// MergeRequirements returns the rules that the target branch of the pull
+	// request enforces before merging.
+	MergeRequirements(ctx context.Context) (*MergeRequirements, error)
+
 	// PushRestrictions returns true if the target barnch of the pull request
 	// restricts the users or teams that have push access.
 	PushRestrictions(ctx context.Context) (bool, error)
 
+	// RequiresUpToDate returns true if the target branch of the pull request
+	// requires branches to be up to date before merging.
+	RequiresUpToDate(ctx context.Context) (bool, error)
+
 	// CurrentSuccessStatuses returns the names of all currently
 	// successful status checks for the pull request.
 	CurrentSuccessStatuses(ctx context.Context) ([]string, error)
 
+	// Statuses lists the latest commit status of each context for the head
+	// commit of the pull request.
+	Statuses(ctx context.Context) ([]*Status, error)
+
+	// CheckRuns lists the latest check runs for the head commit of the pull
+	// request.
+	CheckRuns(ctx context.Context) ([]*CheckRun, error)
+
+	// CheckConclusions returns the results of check run conclusions used to
+	// evaluate the pull request.
+	CheckConclusions() CheckConclusions
+
+	// AppID returns the ID of the GitHub App that bulldozer runs as, or zero
+	// if it is not known.
+	AppID() int64
+
+	// ProjectFields lists the field values of the project items for the
+	// pull request.
+	ProjectFields(ctx context.Context) ([]*ProjectField, error)
+
+	// Deployments lists the latest deployment of the head commit of the pull
+	// request to each environment.
+	Deployments(ctx context.Context) ([]*Deployment, error)
+
 	// Comments lists all comments on the pull request.
 	Comments(ctx context.Context) ([]string, error)
 
 	// Commits lists all commits on the pull request.
 	Commits(ctx context.Context) ([]*Commit, error)
 
+	// DiffStats returns the size of the changes in the pull request.
+	DiffStats(ctx context.Context) (*DiffStats, error)
+
+	// ChangedFiles lists the paths of all files changed by the pull request.
+	// Renamed files are listed using their new path.
+	ChangedFiles(ctx context.Context) ([]string, error)
+
+	// ChangedFileSizes returns the size in bytes of each file changed by the
+	// pull request at its head commit, keyed by path. Deleted files are not
+	// included.
+	ChangedFileSizes(ctx context.Context) (map[string]int64, error)
+
+	// CodeOwners returns the CODEOWNERS file of the target branch of the pull
+	// request. It returns nil if the branch has no CODEOWNERS file.
+	CodeOwners(ctx context.Context) (*CodeOwners, error)
+
 	// Labels lists all labels on the pull request.
 	Labels(ctx context.Context) ([]string, error)
 
@@ -82,11 +141,61 @@ type Context interface {
 	// target branch of other open PRs on the repository.
 	IsTargeted(ctx context.Context) (bool, error)
 
+	// DefaultBranch returns the name of the default branch of the repository.
+	DefaultBranch() string
+
+	// HeadBranch returns the current state of the head branch of the pull
+	// request. It returns nil if the branch does not exist or is in a fork.
+	HeadBranch(ctx context.Context) (*Branch, error)
+
 	// IsDraft returns true if the PR is in a draft state.
 	IsDraft(ctx context.Context) bool
 
 	// AutoMerge returns true if the PR is configured to be automatically merged.
 	AutoMerge(ctx context.Context) bool
+
+	// Reviews lists all submitted reviews on the pull request, from oldest to
+	// newest.
+	Reviews(ctx context.Context) ([]*Review, error)
+
+	// RequestedReviewers lists the users and teams whose review is requested
+	// and who have not submitted a review since the request. Teams are
+	// formatted as "org/team-slug".
+	RequestedReviewers() []string
+
+	// TeamMembers lists the logins of the members of a team in an
+	// organization, identified by its slug.
+	TeamMembers(ctx context.Context, org, team string) ([]string, error)
+
+	// Reference returns the state of an issue or pull request that the pull
+	// request refers to, which may be in another repository. It returns an
+	// error matching ErrNotFound if the issue does not exist or the client
+	// cannot access it.
+	Reference(ctx context.Context, owner, repo string, number int) (*Reference, error)
+
+	// HasTicketTracker returns true if the server defines an issue tracker
+	// that TicketStatus reads from.
+	HasTicketTracker() bool
+
+	// TicketStatus returns the status of a ticket in the issue tracker
+	// defined by the server. It returns an error matching ErrNotFound if the
+	// ticket does not exist and an error if there is no issue tracker.
+	TicketStatus(ctx context.Context, key string) (string, error)
+}
+
+// TicketTracker looks up the status of tickets in an issue tracker, like
+// Jira, by their keys.
+type TicketTracker interface {
+	Status(ctx context.Context, key string) (string, error)
+}
+
+// Reference is an issue or pull request that a pull request refers to.
+type Reference struct {
+	PullRequest bool
+	Closed      bool
+
+	// Merged is true if the reference is a merged pull request.
+	Merged bool
 }
 
 type MergeState struct {
@@ -94,7 +203,90 @@ type MergeState struct {
 	Mergeable *bool
 }
 
+// MergeRequirements are the rules that GitHub enforces when merging into a
+// branch, combined from branch protection and the active repository rulesets
+// for the branch.
+type MergeRequirements struct {
+	RequiredStatuses []string
+	RequiresUpToDate bool
+
+	RequiredApprovingReviews int
+	RequiresCodeOwnerReviews bool
+	DismissesStaleReviews    bool
+
+	RequiresSignedCommits bool
+	RequiresLinearHistory bool
+}
+
+type Branch struct {
+	Name      string
+	SHA       string
+	Protected bool
+}
+
+type DiffStats struct {
+	Additions    int
+	Deletions    int
+	ChangedFiles int
+}
+
+type CheckRun struct {
+	ID   int64
+	Name string
+
+	// AppID is the ID of the GitHub App that created the check run.
+	AppID int64
+
+	// Status is "queued", "in_progress", or "completed"
+	Status string
+
+	// Conclusion is the result of a completed check run, like "success" or
+	// "failure". It is empty if the check run is not completed.
+	Conclusion string
+}
+
+type Status struct {
+	Context string
+
+	// State is "error", "failure", "pending", or "success"
+	State string
+}
+
+type Deployment struct {
+	Environment string
+
+	// State is the state of the latest status of the deployment, like
+	// "success", "failure", or "in_progress". It is "pending" if the
+	// deployment has no statuses.
+	State string
+}
+
+type ReviewState string
+
+const (
+	ReviewApproved         ReviewState = "APPROVED"
+	ReviewChangesRequested ReviewState = "CHANGES_REQUESTED"
+	ReviewCommented        ReviewState = "COMMENTED"
+	ReviewDismissed        ReviewState = "DISMISSED"
+)
+
+type Review struct {
+	Author string
+	State  ReviewState
+
+	// CommitSHA is the head of the pull request when the review was submitted.
+	CommitSHA string
+
+	SubmittedAt time.Time
+}
+
 type Commit struct {
 	SHA     string
 	Message string
+
+	AuthorName  string
+	AuthorEmail string
+
+	// Verified is true if GitHub verified the signature of the commit.
+	Verified bool
 }
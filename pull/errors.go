@@ -0,0 +1,130 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/pkg/errors"
+)
+
+// Errors returned by functions in this package are classified by matching
+// one of these with errors.Is, so that callers can decide whether and when to
+// retry without inspecting GitHub responses.
+var (
+	// ErrRateLimited means the primary or secondary rate limit is exhausted.
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrNotFound means the requested resource does not exist or the client
+	// cannot access it.
+	ErrNotFound = errors.New("not found")
+
+	// ErrServerError means GitHub failed with a 5xx status.
+	ErrServerError = errors.New("server error")
+)
+
+// Error is a classified error from the GitHub API. It matches its class with
+// errors.Is and the underlying go-github error with errors.Cause and
+// errors.As.
+type Error struct {
+	kind       error
+	retryAfter time.Duration
+	err        error
+}
+
+func (e *Error) Error() string {
+	return e.err.Error()
+}
+
+// Cause returns the underlying go-github error.
+func (e *Error) Cause() error {
+	return errors.Cause(e.err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+func (e *Error) Is(target error) bool {
+	return target == e.kind
+}
+
+// IsRetryable returns true if the error is temporary, because a rate limit
+// is exhausted or GitHub failed with a server error.
+func IsRetryable(err error) bool {
+	return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrServerError)
+}
+
+// RetryAfter returns how long GitHub asked clients to wait before retrying
+// the request that caused the error. It returns zero if the error has no
+// hint, in which case callers should use their own backoff.
+func RetryAfter(err error) time.Duration {
+	var perr *Error
+	if errors.As(err, &perr) && perr.retryAfter > 0 {
+		return perr.retryAfter
+	}
+	return 0
+}
+
+// wrapAPIError annotates an error returned by the GitHub API with a message
+// and classifies it. Errors that are already classified or that do not
+// belong to a class are only annotated.
+func wrapAPIError(err error, format string, args ...interface{}) error {
+	wrapped := errors.Wrapf(err, format, args...)
+
+	var perr *Error
+	if errors.As(err, &perr) {
+		return wrapped
+	}
+
+	kind, retryAfter := classify(errors.Cause(err))
+	if kind == nil {
+		return wrapped
+	}
+	return &Error{kind: kind, retryAfter: retryAfter, err: wrapped}
+}
+
+func classify(err error) (error, time.Duration) {
+	switch e := err.(type) {
+	case *github.RateLimitError:
+		return ErrRateLimited, time.Until(e.Rate.Reset.Time)
+	case *github.AbuseRateLimitError:
+		return ErrRateLimited, e.GetRetryAfter()
+	case *github.ErrorResponse:
+		if e.Response == nil {
+			return nil, 0
+		}
+		switch code := e.Response.StatusCode; {
+		case code == http.StatusNotFound:
+			return ErrNotFound, 0
+		case code >= http.StatusInternalServerError:
+			return ErrServerError, retryAfterHeader(e.Response)
+		}
+	}
+	return nil, 0
+}
+
+// retryAfterHeader returns the delay in the Retry-After header of the
+// response, if it contains a number of seconds.
+func retryAfterHeader(res *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(res.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorClassification(t *testing.T) {
+	reset := time.Now().Add(time.Hour)
+
+	tests := map[string]struct {
+		Status  int
+		Headers map[string]string
+		Body    string
+
+		Kind       error
+		Retryable  bool
+		RetryAfter time.Duration
+	}{
+		"notFound": {
+			Status: http.StatusNotFound,
+			Body:   `{"message": "Not Found"}`,
+			Kind:   ErrNotFound,
+		},
+		"serverError": {
+			Status:     http.StatusBadGateway,
+			Headers:    map[string]string{"Retry-After": "30"},
+			Body:       `{"message": "Bad Gateway"}`,
+			Kind:       ErrServerError,
+			Retryable:  true,
+			RetryAfter: 30 * time.Second,
+		},
+		"primaryRateLimit": {
+			Status: http.StatusForbidden,
+			Headers: map[string]string{
+				"X-RateLimit-Limit":     "5000",
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Reset":     strconv.FormatInt(reset.Unix(), 10),
+			},
+			Body:       `{"message": "API rate limit exceeded"}`,
+			Kind:       ErrRateLimited,
+			Retryable:  true,
+			RetryAfter: time.Hour,
+		},
+		"secondaryRateLimit": {
+			Status:     http.StatusForbidden,
+			Headers:    map[string]string{"Retry-After": "60"},
+			Body:       `{"message": "You have exceeded a secondary rate limit", "documentation_url": "https://docs.github.com/rest/overview/resources-in-the-rest-api#secondary-rate-limits"}`,
+			Kind:       ErrRateLimited,
+			Retryable:  true,
+			RetryAfter: time.Minute,
+		},
+		"unclassified": {
+			Status: http.StatusUnprocessableEntity,
+			Body:   `{"message": "Validation Failed"}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for k, v := range test.Headers {
+					w.Header().Set(k, v)
+				}
+				w.WriteHeader(test.Status)
+				_, _ = w.Write([]byte(test.Body))
+			}))
+
+			_, err := ListOpenPullRequestsForSHA(context.Background(), client, "testorg", "testrepo", "a")
+			require.Error(t, err)
+
+			for _, kind := range []error{ErrNotFound, ErrServerError, ErrRateLimited} {
+				assert.Equal(t, kind == test.Kind, errors.Is(err, kind), "errors.Is(err, %v)", kind)
+			}
+			assert.Equal(t, test.Retryable, IsRetryable(err))
+			assert.InDelta(t, test.RetryAfter, RetryAfter(err), float64(5*time.Second))
+			assert.Contains(t, err.Error(), "failed to list pull requests for repository testorg/testrepo")
+
+			var gerr *github.ErrorResponse
+			var rerr *github.RateLimitError
+			var aerr *github.AbuseRateLimitError
+			assert.True(t, errors.As(err, &gerr) || errors.As(err, &rerr) || errors.As(err, &aerr), "underlying error is not a GitHub error")
+		})
+	}
+}
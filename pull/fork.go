@@ -0,0 +1,65 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/pkg/errors"
+)
+
+// ListOpenUpstreamPullRequestsForFork returns the open pull requests in the
+// repositories that a fork was created from where the source branch is the
+// given branch of the fork. The ref may include a "refs/heads/" prefix. The
+// upstream repositories are the parent of the fork and, if different, the
+// root of the fork network. If the repository is not a fork, it returns no
+// pull requests.
+//
+// If listing the pull requests of an upstream repository fails, for example
+// because the client cannot access it, this returns the pull requests found in
+// the other upstream repositories along with the error.
+func ListOpenUpstreamPullRequestsForFork(ctx context.Context, client *github.Client, forkOwner, forkRepoName, ref string, opts ...ListOption) ([]*github.PullRequest, error) {
+	fork, _, err := client.Repositories.Get(ctx, forkOwner, forkRepoName)
+	if err != nil {
+		return nil, wrapAPIError(err, "failed to get repository %s/%s", forkOwner, forkRepoName)
+	}
+	if !fork.GetFork() {
+		return nil, nil
+	}
+
+	var upstreams []*github.Repository
+	for _, upstream := range []*github.Repository{fork.GetParent(), fork.GetSource()} {
+		if upstream == nil || (len(upstreams) > 0 && upstreams[0].GetFullName() == upstream.GetFullName()) {
+			continue
+		}
+		upstreams = append(upstreams, upstream)
+	}
+
+	headRef := fmt.Sprintf("%s:%s", forkOwner, strings.TrimPrefix(ref, "refs/heads/"))
+
+	var results []*github.PullRequest
+	var firstErr error
+	for _, upstream := range upstreams {
+		prs, err := ListOpenPullRequestsForHeadRef(ctx, client, upstream.GetOwner().GetLogin(), upstream.GetName(), headRef, opts...)
+		results = append(results, prs...)
+		if err != nil && firstErr == nil {
+			firstErr = errors.Wrapf(err, "failed to list pull requests in upstream repository %s", upstream.GetFullName())
+		}
+	}
+	return results, firstErr
+}
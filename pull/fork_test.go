@@ -0,0 +1,108 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListOpenUpstreamPullRequestsForFork(t *testing.T) {
+	headPR := func(number int, owner, ref string) *github.PullRequest {
+		pr := testPR(number, "develop", "a")
+		pr.Head.Ref = github.String(ref)
+		pr.Head.Label = github.String(owner + ":" + ref)
+		return pr
+	}
+
+	newClient := func(t *testing.T, forkJSON string, upstreams map[string]http.Handler) *github.Client {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/repos/forker/testrepo", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(forkJSON))
+		})
+		for name, h := range upstreams {
+			mux.Handle(fmt.Sprintf("/repos/%s/pulls", name), h)
+		}
+
+		srv := httptest.NewServer(mux)
+		t.Cleanup(srv.Close)
+
+		client := github.NewClient(srv.Client())
+		client.BaseURL, _ = url.Parse(srv.URL + "/")
+		return client
+	}
+
+	upstream := &pullsEndpoint{Pages: [][]*github.PullRequest{
+		{headPR(1, "forker", "feature/foo"), headPR(2, "testorg", "feature/foo"), headPR(3, "forker", "feature/bar")},
+	}}
+	root := &pullsEndpoint{Pages: [][]*github.PullRequest{
+		{headPR(4, "forker", "feature/foo")},
+	}}
+
+	ctx := context.Background()
+
+	t.Run("parent", func(t *testing.T) {
+		client := newClient(t, `{
+			"fork": true,
+			"parent": {"name": "testrepo", "full_name": "testorg/testrepo", "owner": {"login": "testorg"}},
+			"source": {"name": "testrepo", "full_name": "testorg/testrepo", "owner": {"login": "testorg"}}
+		}`, map[string]http.Handler{"testorg/testrepo": upstream})
+
+		prs, err := ListOpenUpstreamPullRequestsForFork(ctx, client, "forker", "testrepo", "refs/heads/feature/foo")
+		require.NoError(t, err)
+		assert.Equal(t, []int{1}, numbers(prs))
+	})
+
+	t.Run("parentAndSource", func(t *testing.T) {
+		client := newClient(t, `{
+			"fork": true,
+			"parent": {"name": "testrepo", "full_name": "testorg/testrepo", "owner": {"login": "testorg"}},
+			"source": {"name": "testrepo", "full_name": "rootorg/testrepo", "owner": {"login": "rootorg"}}
+		}`, map[string]http.Handler{"testorg/testrepo": upstream, "rootorg/testrepo": root})
+
+		prs, err := ListOpenUpstreamPullRequestsForFork(ctx, client, "forker", "testrepo", "feature/foo")
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 4}, numbers(prs))
+	})
+
+	t.Run("inaccessibleUpstream", func(t *testing.T) {
+		client := newClient(t, `{
+			"fork": true,
+			"parent": {"name": "testrepo", "full_name": "testorg/testrepo", "owner": {"login": "testorg"}},
+			"source": {"name": "testrepo", "full_name": "rootorg/testrepo", "owner": {"login": "rootorg"}}
+		}`, map[string]http.Handler{"rootorg/testrepo": root})
+
+		prs, err := ListOpenUpstreamPullRequestsForFork(ctx, client, "forker", "testrepo", "feature/foo")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "testorg/testrepo")
+		assert.Equal(t, []int{4}, numbers(prs))
+	})
+
+	t.Run("notFork", func(t *testing.T) {
+		client := newClient(t, `{"fork": false}`, nil)
+
+		prs, err := ListOpenUpstreamPullRequestsForFork(ctx, client, "forker", "testrepo", "feature/foo")
+		require.NoError(t, err)
+		assert.Empty(t, prs)
+	})
+}
@@ -18,6 +18,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/google/go-github/v50/github"
 	"github.com/pkg/errors"
@@ -33,15 +35,78 @@ type GithubContext struct {
 	number int
 	pr     *github.PullRequest
 
+	teamCache       *TeamMembershipCache
+	codeOwnersCache *CodeOwnersCache
+
+	checkConclusions CheckConclusions
+	ticketTracker    TicketTracker
+	appID            int64
+
 	// cached fields
 	comments         []string
 	commits          []*Commit
-	branchProtection *github.Protection
+	branchProtection *branchProtection
+	requirements     *MergeRequirements
 	successStatuses  []string
+	statuses         []*Status
+	checkRuns        []*CheckRun
+	deployments      []*Deployment
+	changedFiles     []string
+	changedFileSizes map[string]int64
+	codeOwners       *CodeOwners
+	codeOwnersLoaded bool
+	reviews          []*Review
+	teamMembers      map[string][]string
+	projectFields    []*ProjectField
+	references       map[string]*Reference
+	ticketStatuses   map[string]string
+}
+
+// ContextOption configures optional behavior of a GithubContext.
+type ContextOption func(*GithubContext)
+
+// WithTeamMembershipCache shares the members of teams listed by the context
+// with other contexts using the same cache.
+func WithTeamMembershipCache(cache *TeamMembershipCache) ContextOption {
+	return func(ghc *GithubContext) {
+		ghc.teamCache = cache
+	}
+}
+
+// WithCodeOwnersCache shares the CODEOWNERS files fetched by the context with
+// other contexts using the same cache.
+func WithCodeOwnersCache(cache *CodeOwnersCache) ContextOption {
+	return func(ghc *GithubContext) {
+		ghc.codeOwnersCache = cache
+	}
+}
+
+// WithCheckConclusions sets the results of check run conclusions. If it is
+// not set, the context uses DefaultCheckConclusions.
+func WithCheckConclusions(conclusions CheckConclusions) ContextOption {
+	return func(ghc *GithubContext) {
+		ghc.checkConclusions = conclusions
+	}
+}
+
+// WithAppID sets the ID of the GitHub App that bulldozer runs as, so check
+// runs it created can be told apart from others with similar names.
+func WithAppID(id int64) ContextOption {
+	return func(ghc *GithubContext) {
+		ghc.appID = id
+	}
 }
 
-func NewGithubContext(client *github.Client, pr *github.PullRequest) Context {
-	return &GithubContext{
+// WithTicketTracker sets the issue tracker that ticket statuses are read
+// from. If it is not set, HasTicketTracker returns false.
+func WithTicketTracker(tracker TicketTracker) ContextOption {
+	return func(ghc *GithubContext) {
+		ghc.ticketTracker = tracker
+	}
+}
+
+func NewGithubContext(client *github.Client, pr *github.PullRequest, opts ...ContextOption) Context {
+	ghc := &GithubContext{
 		client: client,
 
 		pr:     pr,
@@ -49,6 +114,10 @@ func NewGithubContext(client *github.Client, pr *github.PullRequest) Context {
 		repo:   pr.GetBase().GetRepo().GetName(),
 		number: pr.GetNumber(),
 	}
+	for _, opt := range opts {
+		opt(ghc)
+	}
+	return ghc
 }
 
 func (ghc *GithubContext) Owner() string {
@@ -67,6 +136,14 @@ func (ghc *GithubContext) Locator() string {
 	return fmt.Sprintf("%s/%s#%d", ghc.owner, ghc.repo, ghc.number)
 }
 
+func (ghc *GithubContext) Author() string {
+	return ghc.pr.GetUser().GetLogin()
+}
+
+func (ghc *GithubContext) CreatedAt() time.Time {
+	return ghc.pr.GetCreatedAt().Time
+}
+
 func (ghc *GithubContext) Title() string {
 	return ghc.pr.GetTitle()
 }
@@ -75,13 +152,22 @@ func (ghc *GithubContext) Body() string {
 	return ghc.pr.GetBody()
 }
 
+func (ghc *GithubContext) Milestone() string {
+	return ghc.pr.GetMilestone().GetTitle()
+}
+
 func (ghc *GithubContext) HeadSHA() string {
 	return ghc.pr.GetHead().GetSHA()
 }
 
+// mergeStateTimeout is how long MergeState waits for GitHub to compute
+// whether the pull request is mergeable before it reports that the
+// mergeability is unknown.
+const mergeStateTimeout = 10 * time.Second
+
 func (ghc *GithubContext) MergeState(ctx context.Context) (*MergeState, error) {
-	pr, _, err := ghc.client.PullRequests.Get(ctx, ghc.owner, ghc.repo, ghc.number)
-	if err != nil {
+	pr, err := WaitForMergeable(ctx, ghc.client, ghc.owner, ghc.repo, ghc.number, mergeStateTimeout)
+	if err != nil && !errors.Is(err, ErrMergeabilityTimeout) {
 		return nil, errors.Wrap(err, "failed to get pull request merge state")
 	}
 
@@ -98,7 +184,7 @@ func (ghc *GithubContext) Comments(ctx context.Context) ([]string, error) {
 		for {
 			comments, res, err := ghc.client.PullRequests.ListComments(ctx, ghc.owner, ghc.repo, ghc.number, prCommentOpts)
 			if err != nil {
-				return nil, errors.Wrap(err, "failed to list pull request comments")
+				return nil, wrapAPIError(err, "failed to list pull request comments")
 			}
 
 			for _, c := range comments {
@@ -115,7 +201,7 @@ func (ghc *GithubContext) Comments(ctx context.Context) ([]string, error) {
 		for {
 			comments, res, err := ghc.client.Issues.ListComments(ctx, ghc.owner, ghc.repo, ghc.number, issueCommentOpts)
 			if err != nil {
-				return nil, errors.Wrap(err, "failed to list issue comments")
+				return nil, wrapAPIError(err, "failed to list issue comments")
 			}
 
 			for _, c := range comments {
@@ -142,7 +228,7 @@ func (ghc *GithubContext) Commits(ctx context.Context) ([]*Commit, error) {
 		for {
 			commits, resp, err := ghc.client.PullRequests.ListCommits(ctx, ghc.owner, ghc.repo, ghc.number, opts)
 			if err != nil {
-				return nil, errors.Wrap(err, "failed to list pull request commits")
+				return nil, wrapAPIError(err, "failed to list pull request commits")
 			}
 			allCommits = append(allCommits, commits...)
 			if resp.NextPage == 0 {
@@ -155,24 +241,145 @@ func (ghc *GithubContext) Commits(ctx context.Context) ([]*Commit, error) {
 		ghc.commits = make([]*Commit, len(allCommits))
 		for i, c := range allCommits {
 			ghc.commits[i] = &Commit{
-				SHA:     c.GetCommit().GetSHA(),
-				Message: c.GetCommit().GetMessage(),
+				SHA:         c.GetCommit().GetSHA(),
+				Message:     c.GetCommit().GetMessage(),
+				AuthorName:  c.GetCommit().GetAuthor().GetName(),
+				AuthorEmail: c.GetCommit().GetAuthor().GetEmail(),
+				Verified:    c.GetCommit().GetVerification().GetVerified(),
 			}
 		}
 	}
 	return ghc.commits, nil
 }
 
-func (ghc *GithubContext) RequiredStatuses(ctx context.Context) ([]string, error) {
-	if ghc.branchProtection == nil {
-		if err := ghc.loadBranchProtection(ctx); err != nil {
+func (ghc *GithubContext) DiffStats(ctx context.Context) (*DiffStats, error) {
+	// pull requests returned by list operations do not include diff stats
+	if ghc.pr.ChangedFiles == nil {
+		pr, _, err := ghc.client.PullRequests.Get(ctx, ghc.owner, ghc.repo, ghc.number)
+		if err != nil {
+			return nil, wrapAPIError(err, "failed to get pull request diff stats")
+		}
+		ghc.pr.Additions = github.Int(pr.GetAdditions())
+		ghc.pr.Deletions = github.Int(pr.GetDeletions())
+		ghc.pr.ChangedFiles = github.Int(pr.GetChangedFiles())
+	}
+
+	return &DiffStats{
+		Additions:    ghc.pr.GetAdditions(),
+		Deletions:    ghc.pr.GetDeletions(),
+		ChangedFiles: ghc.pr.GetChangedFiles(),
+	}, nil
+}
+
+func (ghc *GithubContext) ChangedFiles(ctx context.Context) ([]string, error) {
+	if ghc.changedFiles == nil {
+		opts := &github.ListOptions{PerPage: 100}
+
+		files := []string{}
+		for {
+			page, res, err := ghc.client.PullRequests.ListFiles(ctx, ghc.owner, ghc.repo, ghc.number, opts)
+			if err != nil {
+				return nil, wrapAPIError(err, "failed to list pull request files")
+			}
+
+			for _, f := range page {
+				files = append(files, f.GetFilename())
+			}
+
+			if res.NextPage == 0 {
+				break
+			}
+			opts.Page = res.NextPage
+		}
+		ghc.changedFiles = files
+	}
+	return ghc.changedFiles, nil
+}
+
+func (ghc *GithubContext) ChangedFileSizes(ctx context.Context) (map[string]int64, error) {
+	if ghc.changedFileSizes == nil {
+		files, err := ghc.ChangedFiles(ctx)
+		if err != nil {
 			return nil, err
 		}
+
+		// the tree includes the sizes of all files in one request, which is
+		// fewer than getting the contents of each changed file
+		sha := ghc.pr.GetHead().GetSHA()
+		tree, _, err := ghc.client.Git.GetTree(ctx, ghc.owner, ghc.repo, sha, true)
+		if err != nil {
+			return nil, wrapAPIError(err, "cannot get tree for SHA %s on %s", sha, ghc.Locator())
+		}
+		if tree.GetTruncated() {
+			return nil, errors.Errorf("tree for SHA %s on %s is too large to list file sizes", sha, ghc.Locator())
+		}
+
+		changed := make(map[string]bool, len(files))
+		for _, f := range files {
+			changed[f] = true
+		}
+
+		sizes := make(map[string]int64)
+		for _, entry := range tree.Entries {
+			if entry.GetType() == "blob" && changed[entry.GetPath()] {
+				sizes[entry.GetPath()] = int64(entry.GetSize())
+			}
+		}
+		ghc.changedFileSizes = sizes
+	}
+	return ghc.changedFileSizes, nil
+}
+
+func (ghc *GithubContext) CodeOwners(ctx context.Context) (*CodeOwners, error) {
+	if ghc.codeOwnersLoaded {
+		return ghc.codeOwners, nil
+	}
+
+	base := ghc.pr.GetBase().GetRef()
+	if ghc.codeOwnersCache != nil {
+		if codeOwners, ok := ghc.codeOwnersCache.get(ghc.owner, ghc.repo, base); ok {
+			ghc.codeOwners, ghc.codeOwnersLoaded = codeOwners, true
+			return codeOwners, nil
+		}
 	}
-	if checks := ghc.branchProtection.GetRequiredStatusChecks(); checks != nil {
-		return checks.Contexts, nil
+
+	var codeOwners *CodeOwners
+	for _, path := range CodeOwnersPaths {
+		file, _, _, err := ghc.client.Repositories.GetContents(ctx, ghc.owner, ghc.repo, path, &github.RepositoryContentGetOptions{Ref: base})
+		if err != nil {
+			err = wrapAPIError(err, "failed to get %s on %s", path, ghc.Locator())
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		if file == nil {
+			continue
+		}
+
+		content, err := file.GetContent()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode %s on %s", path, ghc.Locator())
+		}
+		if codeOwners, err = ParseCodeOwners(content); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %s on %s", path, ghc.Locator())
+		}
+		break
+	}
+
+	ghc.codeOwners, ghc.codeOwnersLoaded = codeOwners, true
+	if ghc.codeOwnersCache != nil {
+		ghc.codeOwnersCache.add(ghc.owner, ghc.repo, base, codeOwners)
+	}
+	return codeOwners, nil
+}
+
+func (ghc *GithubContext) RequiredStatuses(ctx context.Context) ([]string, error) {
+	requirements, err := ghc.MergeRequirements(ctx)
+	if err != nil {
+		return nil, err
 	}
-	return nil, nil
+	return requirements.RequiredStatuses, nil
 }
 
 func (ghc *GithubContext) PushRestrictions(ctx context.Context) (bool, error) {
@@ -187,16 +394,37 @@ func (ghc *GithubContext) PushRestrictions(ctx context.Context) (bool, error) {
 	return false, nil
 }
 
+func (ghc *GithubContext) RequiresUpToDate(ctx context.Context) (bool, error) {
+	requirements, err := ghc.MergeRequirements(ctx)
+	if err != nil {
+		return false, err
+	}
+	return requirements.RequiresUpToDate, nil
+}
+
+// branchProtection adds the required signatures setting, which the client
+// does not include in the response, to branch protection.
+type branchProtection struct {
+	github.Protection
+	RequiredSignatures *github.SignaturesProtectedBranch `json:"required_signatures"`
+}
+
 func (ghc *GithubContext) loadBranchProtection(ctx context.Context) error {
-	protection, _, err := ghc.client.Repositories.GetBranchProtection(ctx, ghc.owner, ghc.repo, ghc.pr.GetBase().GetRef())
+	u := fmt.Sprintf("repos/%s/%s/branches/%s/protection", ghc.owner, ghc.repo, url.PathEscape(ghc.pr.GetBase().GetRef()))
+	req, err := ghc.client.NewRequest(http.MethodGet, u, nil)
 	if err != nil {
-		if isNotFound(err) || err == github.ErrBranchNotProtected {
-			ghc.branchProtection = &github.Protection{}
+		return errors.Wrap(err, "failed to create branch protection request")
+	}
+
+	var protection branchProtection
+	if _, err := ghc.client.Do(ctx, req, &protection); err != nil {
+		if isNotFound(err) {
+			ghc.branchProtection = &branchProtection{}
 			return nil
 		}
-		return errors.Wrapf(err, "cannot get branch protection for %s", ghc.Locator())
+		return wrapAPIError(err, "cannot get branch protection for %s", ghc.Locator())
 	}
-	ghc.branchProtection = protection
+	ghc.branchProtection = &protection
 	return nil
 }
 
@@ -207,24 +435,58 @@ func isNotFound(err error) bool {
 
 func (ghc *GithubContext) CurrentSuccessStatuses(ctx context.Context) ([]string, error) {
 	if ghc.successStatuses == nil {
-		opts := &github.ListOptions{PerPage: 100}
 		var successStatuses []string
-		allowedCheckConclusions := map[string]bool{
-			"success": true,
-			"neutral": true,
-			"skipped": true,
+
+		statuses, err := ghc.Statuses(ctx)
+		if err != nil {
+			return ghc.successStatuses, err
+		}
+		for _, s := range statuses {
+			if s.State == "success" {
+				successStatuses = append(successStatuses, s.Context)
+			}
 		}
 
+		checkRuns, err := ghc.CheckRuns(ctx)
+		if err != nil {
+			return ghc.successStatuses, err
+		}
+		for _, run := range checkRuns {
+			if ghc.checkConclusions.Result(run) == CheckSuccess {
+				successStatuses = append(successStatuses, run.Name)
+			}
+		}
+
+		ghc.successStatuses = successStatuses
+	}
+
+	return ghc.successStatuses, nil
+}
+
+func (ghc *GithubContext) CheckConclusions() CheckConclusions {
+	return ghc.checkConclusions
+}
+
+func (ghc *GithubContext) AppID() int64 {
+	return ghc.appID
+}
+
+func (ghc *GithubContext) Statuses(ctx context.Context) ([]*Status, error) {
+	if ghc.statuses == nil {
+		opts := &github.ListOptions{PerPage: 100}
+
+		statuses := []*Status{}
 		for {
 			combinedStatus, res, err := ghc.client.Repositories.GetCombinedStatus(ctx, ghc.owner, ghc.repo, ghc.pr.GetHead().GetSHA(), opts)
 			if err != nil {
-				return ghc.successStatuses, errors.Wrapf(err, "cannot get combined status for SHA %s on %s", ghc.pr.GetHead().GetSHA(), ghc.Locator())
+				return nil, wrapAPIError(err, "cannot get combined status for SHA %s on %s", ghc.pr.GetHead().GetSHA(), ghc.Locator())
 			}
 
 			for _, s := range combinedStatus.Statuses {
-				if s.GetState() == "success" {
-					successStatuses = append(successStatuses, s.GetContext())
-				}
+				statuses = append(statuses, &Status{
+					Context: s.GetContext(),
+					State:   s.GetState(),
+				})
 			}
 
 			if res.NextPage == 0 {
@@ -232,30 +494,86 @@ func (ghc *GithubContext) CurrentSuccessStatuses(ctx context.Context) ([]string,
 			}
 			opts.Page = res.NextPage
 		}
+		ghc.statuses = statuses
+	}
+	return ghc.statuses, nil
+}
+
+func (ghc *GithubContext) CheckRuns(ctx context.Context) ([]*CheckRun, error) {
+	if ghc.checkRuns == nil {
+		opts := &github.ListCheckRunsOptions{ListOptions: github.ListOptions{PerPage: 100}}
 
-		checkOpts := &github.ListCheckRunsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+		checkRuns := []*CheckRun{}
 		for {
-			checkRuns, res, err := ghc.client.Checks.ListCheckRunsForRef(ctx, ghc.owner, ghc.repo, ghc.pr.GetHead().GetSHA(), checkOpts)
+			page, res, err := ghc.client.Checks.ListCheckRunsForRef(ctx, ghc.owner, ghc.repo, ghc.pr.GetHead().GetSHA(), opts)
 			if err != nil {
-				return ghc.successStatuses, errors.Wrapf(err, "cannot get check runs for SHA %s on %s", ghc.pr.GetHead().GetSHA(), ghc.Locator())
+				return nil, wrapAPIError(err, "cannot get check runs for SHA %s on %s", ghc.pr.GetHead().GetSHA(), ghc.Locator())
 			}
 
-			for _, s := range checkRuns.CheckRuns {
-				if allowedCheckConclusions[s.GetConclusion()] {
-					successStatuses = append(successStatuses, s.GetName())
-				}
+			for _, run := range page.CheckRuns {
+				checkRuns = append(checkRuns, &CheckRun{
+					ID:         run.GetID(),
+					Name:       run.GetName(),
+					AppID:      run.GetApp().GetID(),
+					Status:     run.GetStatus(),
+					Conclusion: run.GetConclusion(),
+				})
 			}
 
 			if res.NextPage == 0 {
 				break
 			}
-			checkOpts.Page = res.NextPage
+			opts.Page = res.NextPage
 		}
-
-		ghc.successStatuses = successStatuses
+		ghc.checkRuns = checkRuns
 	}
+	return ghc.checkRuns, nil
+}
 
-	return ghc.successStatuses, nil
+func (ghc *GithubContext) Deployments(ctx context.Context) ([]*Deployment, error) {
+	if ghc.deployments == nil {
+		sha := ghc.pr.GetHead().GetSHA()
+		opts := &github.DeploymentsListOptions{SHA: sha, ListOptions: github.ListOptions{PerPage: 100}}
+
+		// deployments are listed from newest to oldest, so the first
+		// deployment to each environment is the latest
+		deployments := []*Deployment{}
+		seen := make(map[string]bool)
+		for {
+			page, res, err := ghc.client.Repositories.ListDeployments(ctx, ghc.owner, ghc.repo, opts)
+			if err != nil {
+				return nil, wrapAPIError(err, "cannot get deployments for SHA %s on %s", sha, ghc.Locator())
+			}
+
+			for _, d := range page {
+				if seen[d.GetEnvironment()] {
+					continue
+				}
+				seen[d.GetEnvironment()] = true
+
+				statuses, _, err := ghc.client.Repositories.ListDeploymentStatuses(ctx, ghc.owner, ghc.repo, d.GetID(), &github.ListOptions{PerPage: 1})
+				if err != nil {
+					return nil, wrapAPIError(err, "cannot get statuses for deployment %d on %s", d.GetID(), ghc.Locator())
+				}
+
+				state := "pending"
+				if len(statuses) > 0 {
+					state = statuses[0].GetState()
+				}
+				deployments = append(deployments, &Deployment{
+					Environment: d.GetEnvironment(),
+					State:       state,
+				})
+			}
+
+			if res.NextPage == 0 {
+				break
+			}
+			opts.Page = res.NextPage
+		}
+		ghc.deployments = deployments
+	}
+	return ghc.deployments, nil
 }
 
 func (ghc *GithubContext) Branches() (base string, head string) {
@@ -289,6 +607,31 @@ func (ghc *GithubContext) IsTargeted(ctx context.Context) (bool, error) {
 	return len(prs) > 0, nil
 }
 
+func (ghc *GithubContext) DefaultBranch() string {
+	return ghc.pr.GetBase().GetRepo().GetDefaultBranch()
+}
+
+func (ghc *GithubContext) HeadBranch(ctx context.Context) (*Branch, error) {
+	head := ghc.pr.GetHead()
+	if head.GetRepo().GetFullName() != ghc.pr.GetBase().GetRepo().GetFullName() {
+		return nil, nil
+	}
+
+	branch, _, err := ghc.client.Repositories.GetBranch(ctx, ghc.owner, ghc.repo, head.GetRef(), false)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, wrapAPIError(err, "failed to get head branch for %s", ghc.Locator())
+	}
+
+	return &Branch{
+		Name:      branch.GetName(),
+		SHA:       branch.GetCommit().GetSHA(),
+		Protected: branch.GetProtected(),
+	}, nil
+}
+
 func (ghc *GithubContext) IsDraft(ctx context.Context) bool {
 	return ghc.pr.GetDraft()
 }
@@ -299,5 +642,140 @@ func (ghc *GithubContext) AutoMerge(ctx context.Context) bool {
 	return autoMerge != nil
 }
 
+func (ghc *GithubContext) Reviews(ctx context.Context) ([]*Review, error) {
+	if ghc.reviews == nil {
+		opts := &github.ListOptions{PerPage: 100}
+
+		reviews := []*Review{}
+		for {
+			page, res, err := ghc.client.PullRequests.ListReviews(ctx, ghc.owner, ghc.repo, ghc.number, opts)
+			if err != nil {
+				return nil, wrapAPIError(err, "failed to list pull request reviews")
+			}
+
+			for _, r := range page {
+				reviews = append(reviews, &Review{
+					Author:      r.GetUser().GetLogin(),
+					State:       ReviewState(r.GetState()),
+					CommitSHA:   r.GetCommitID(),
+					SubmittedAt: r.GetSubmittedAt().Time,
+				})
+			}
+
+			if res.NextPage == 0 {
+				break
+			}
+			opts.Page = res.NextPage
+		}
+		ghc.reviews = reviews
+	}
+	return ghc.reviews, nil
+}
+
+func (ghc *GithubContext) RequestedReviewers() []string {
+	var reviewers []string
+	for _, u := range ghc.pr.RequestedReviewers {
+		reviewers = append(reviewers, u.GetLogin())
+	}
+	for _, t := range ghc.pr.RequestedTeams {
+		reviewers = append(reviewers, fmt.Sprintf("%s/%s", ghc.owner, t.GetSlug()))
+	}
+	return reviewers
+}
+
+func (ghc *GithubContext) TeamMembers(ctx context.Context, org, team string) ([]string, error) {
+	key := fmt.Sprintf("%s/%s", org, team)
+	if members, ok := ghc.teamMembers[key]; ok {
+		return members, nil
+	}
+	if ghc.teamCache != nil {
+		if members, ok := ghc.teamCache.get(org, team); ok {
+			return members, nil
+		}
+	}
+
+	opts := &github.TeamListTeamMembersOptions{ListOptions: github.ListOptions{PerPage: 100}}
+
+	var members []string
+	for {
+		users, res, err := ghc.client.Teams.ListTeamMembersBySlug(ctx, org, team, opts)
+		if err != nil {
+			return nil, wrapAPIError(err, "failed to list members of team %s", key)
+		}
+
+		for _, u := range users {
+			members = append(members, u.GetLogin())
+		}
+
+		if res.NextPage == 0 {
+			break
+		}
+		opts.Page = res.NextPage
+	}
+
+	if ghc.teamMembers == nil {
+		ghc.teamMembers = make(map[string][]string)
+	}
+	ghc.teamMembers[key] = members
+	if ghc.teamCache != nil {
+		ghc.teamCache.add(org, team, members)
+	}
+	return members, nil
+}
+
 // type assertion
 var _ Context = &GithubContext{}
+
+func (ghc *GithubContext) Reference(ctx context.Context, owner, repo string, number int) (*Reference, error) {
+	key := fmt.Sprintf("%s/%s#%d", owner, repo, number)
+	if ref, ok := ghc.references[key]; ok {
+		return ref, nil
+	}
+
+	issue, _, err := ghc.client.Issues.Get(ctx, owner, repo, number)
+	if err != nil {
+		return nil, wrapAPIError(err, "failed to get issue %s", key)
+	}
+
+	ref := &Reference{
+		PullRequest: issue.IsPullRequest(),
+		Closed:      issue.GetState() == "closed",
+	}
+	if ref.PullRequest && ref.Closed {
+		merged, _, err := ghc.client.PullRequests.IsMerged(ctx, owner, repo, number)
+		if err != nil {
+			return nil, wrapAPIError(err, "failed to get merge status of pull request %s", key)
+		}
+		ref.Merged = merged
+	}
+
+	if ghc.references == nil {
+		ghc.references = make(map[string]*Reference)
+	}
+	ghc.references[key] = ref
+	return ref, nil
+}
+
+func (ghc *GithubContext) HasTicketTracker() bool {
+	return ghc.ticketTracker != nil
+}
+
+func (ghc *GithubContext) TicketStatus(ctx context.Context, key string) (string, error) {
+	if ghc.ticketTracker == nil {
+		return "", errors.New("no issue tracker is configured")
+	}
+	if status, ok := ghc.ticketStatuses[key]; ok {
+		return status, nil
+	}
+
+	status, err := ghc.ticketTracker.Status(ctx, key)
+	if err != nil {
+		return "", err
+	}
+
+	if ghc.ticketStatuses == nil {
+		ghc.ticketStatuses = make(map[string]string)
+	}
+	ghc.ticketStatuses[key] = status
+	return status, nil
+}
@@ -0,0 +1,200 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/pkg/errors"
+	"github.com/shurcooL/githubv4"
+)
+
+// GraphQLClient is the subset of the GitHub v4 (GraphQL) client used by this
+// package. It is satisfied by *githubv4.Client.
+type GraphQLClient interface {
+	Query(ctx context.Context, q interface{}, variables map[string]interface{}) error
+}
+
+type graphqlPageInfo struct {
+	EndCursor   githubv4.String
+	HasNextPage bool
+}
+
+// graphqlPullRequest contains the fields of a pull request that callers of the
+// List* functions read.
+type graphqlPullRequest struct {
+	Number    int
+	State     githubv4.PullRequestState
+	IsDraft   bool
+	UpdatedAt githubv4.DateTime
+
+	HeadRefOid          string
+	HeadRefName         string
+	HeadRepositoryOwner struct {
+		Login string
+	}
+	BaseRefName string
+}
+
+func (pr *graphqlPullRequest) toPullRequest() *github.PullRequest {
+	return &github.PullRequest{
+		Number:    github.Int(pr.Number),
+		State:     github.String("open"),
+		Draft:     github.Bool(pr.IsDraft),
+		UpdatedAt: &github.Timestamp{Time: pr.UpdatedAt.Time},
+		Head: &github.PullRequestBranch{
+			SHA:   github.String(pr.HeadRefOid),
+			Ref:   github.String(pr.HeadRefName),
+			Label: github.String(fmt.Sprintf("%s:%s", pr.HeadRepositoryOwner.Login, pr.HeadRefName)),
+		},
+		Base: &github.PullRequestBranch{
+			Ref: github.String(pr.BaseRefName),
+		},
+	}
+}
+
+// ListOpenPullRequestsForSHAGraphQL returns all open pull requests where the
+// head of the source branch matches the given SHA, like
+// ListOpenPullRequestsForSHA. Instead of listing every open pull request, it
+// queries the pull requests associated with the commit, which usually takes a
+// single request.
+//
+// The returned pull requests only contain the number, state, draft status,
+// update time, and the head and base branch information.
+func ListOpenPullRequestsForSHAGraphQL(ctx context.Context, client GraphQLClient, owner, repoName, SHA string, opts ...ListOption) ([]*github.PullRequest, error) {
+	listOpts := newListOptions(opts)
+
+	var q struct {
+		Repository struct {
+			Object struct {
+				Commit struct {
+					AssociatedPullRequests struct {
+						Nodes    []graphqlPullRequest
+						PageInfo graphqlPageInfo
+					} `graphql:"associatedPullRequests(first: 100, after: $cursor)"`
+				} `graphql:"... on Commit"`
+			} `graphql:"object(oid: $sha)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+
+	vars := map[string]interface{}{
+		"owner":  githubv4.String(owner),
+		"name":   githubv4.String(repoName),
+		"sha":    githubv4.GitObjectID(SHA),
+		"cursor": (*githubv4.String)(nil),
+	}
+
+	var results []*github.PullRequest
+	var pages int
+	var err error
+
+	for {
+		if err = contextErr(ctx, owner, repoName); err != nil {
+			break
+		}
+
+		pages++
+		if err = client.Query(ctx, &q, vars); err != nil {
+			err = errors.Wrapf(err, "failed to query pull requests for commit %s in repository %s/%s", SHA, owner, repoName)
+			break
+		}
+
+		prs := q.Repository.Object.Commit.AssociatedPullRequests
+		for _, pr := range prs.Nodes {
+			// associated pull requests include any pull request containing
+			// the commit, not just those where it is the head
+			if pr.State == githubv4.PullRequestStateOpen && pr.HeadRefOid == SHA {
+				results = append(results, pr.toPullRequest())
+			}
+		}
+
+		if !prs.PageInfo.HasNextPage {
+			break
+		}
+		vars["cursor"] = githubv4.NewString(prs.PageInfo.EndCursor)
+	}
+
+	listOpts.sortPullRequests(results)
+	results = listOpts.applyLimit(results)
+
+	if listOpts.metrics != nil {
+		listOpts.metrics.ObserveListCall("ListOpenPullRequestsForSHAGraphQL", pages, len(results), err)
+	}
+	return results, err
+}
+
+// ListOpenPullRequestsForRefGraphQL returns all open pull requests that target
+// the given ref, like ListOpenPullRequestsForRef. The ref may include a
+// "refs/heads/" prefix. Instead of listing every open pull request, it queries
+// only the pull requests with a matching base branch.
+//
+// The returned pull requests contain the same fields as those returned by
+// ListOpenPullRequestsForSHAGraphQL.
+func ListOpenPullRequestsForRefGraphQL(ctx context.Context, client GraphQLClient, owner, repoName, ref string, opts ...ListOption) ([]*github.PullRequest, error) {
+	listOpts := newListOptions(opts)
+
+	var q struct {
+		Repository struct {
+			PullRequests struct {
+				Nodes    []graphqlPullRequest
+				PageInfo graphqlPageInfo
+			} `graphql:"pullRequests(states: OPEN, baseRefName: $base, first: 100, after: $cursor)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+
+	vars := map[string]interface{}{
+		"owner":  githubv4.String(owner),
+		"name":   githubv4.String(repoName),
+		"base":   githubv4.String(strings.TrimPrefix(ref, "refs/heads/")),
+		"cursor": (*githubv4.String)(nil),
+	}
+
+	var results []*github.PullRequest
+	var pages int
+	var err error
+
+	for {
+		if err = contextErr(ctx, owner, repoName); err != nil {
+			break
+		}
+
+		pages++
+		if err = client.Query(ctx, &q, vars); err != nil {
+			err = errors.Wrapf(err, "failed to query pull requests for ref %s in repository %s/%s", ref, owner, repoName)
+			break
+		}
+
+		prs := q.Repository.PullRequests
+		for _, pr := range prs.Nodes {
+			results = append(results, pr.toPullRequest())
+		}
+
+		if !prs.PageInfo.HasNextPage {
+			break
+		}
+		vars["cursor"] = githubv4.NewString(prs.PageInfo.EndCursor)
+	}
+
+	listOpts.sortPullRequests(results)
+	results = listOpts.applyLimit(results)
+
+	if listOpts.metrics != nil {
+		listOpts.metrics.ObserveListCall("ListOpenPullRequestsForRefGraphQL", pages, len(results), err)
+	}
+	return results, err
+}
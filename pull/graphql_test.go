@@ -0,0 +1,175 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// graphqlEndpoint serves a fixed sequence of GraphQL responses, selected by
+// the value of the "cursor" variable in each query.
+type graphqlEndpoint struct {
+	// Responses maps cursor values to the JSON "data" object returned for
+	// queries with that cursor. The empty string is the first page.
+	Responses map[string]string
+
+	mu        sync.Mutex
+	variables []map[string]interface{}
+}
+
+func (e *graphqlEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	e.mu.Lock()
+	e.variables = append(e.variables, req.Variables)
+	e.mu.Unlock()
+
+	cursor, _ := req.Variables["cursor"].(string)
+	data, ok := e.Responses[cursor]
+	if !ok {
+		_, _ = w.Write([]byte(`{"errors": [{"message": "unknown cursor"}]}`))
+		return
+	}
+	_, _ = w.Write([]byte(`{"data": ` + data + `}`))
+}
+
+func newTestGraphQLClient(t *testing.T, h http.Handler) *githubv4.Client {
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	return githubv4.NewEnterpriseClient(srv.URL, srv.Client())
+}
+
+func TestListOpenPullRequestsForSHAGraphQL(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("filtersToOpenHeads", func(t *testing.T) {
+		endpoint := &graphqlEndpoint{
+			Responses: map[string]string{
+				"": `{"repository": {"object": {"associatedPullRequests": {
+					"nodes": [
+						{"number": 1, "state": "OPEN", "isDraft": false, "updatedAt": "2026-01-02T15:04:05Z", "headRefOid": "abc", "headRefName": "feature", "headRepositoryOwner": {"login": "testorg"}, "baseRefName": "develop"},
+						{"number": 2, "state": "MERGED", "headRefOid": "abc", "headRefName": "feature", "headRepositoryOwner": {"login": "testorg"}, "baseRefName": "develop"},
+						{"number": 3, "state": "OPEN", "headRefOid": "def", "headRefName": "other", "headRepositoryOwner": {"login": "testorg"}, "baseRefName": "develop"}
+					],
+					"pageInfo": {"endCursor": "c1", "hasNextPage": true}
+				}}}}`,
+				"c1": `{"repository": {"object": {"associatedPullRequests": {
+					"nodes": [
+						{"number": 4, "state": "OPEN", "isDraft": true, "headRefOid": "abc", "headRefName": "feature", "headRepositoryOwner": {"login": "forker"}, "baseRefName": "main"}
+					],
+					"pageInfo": {"endCursor": "c2", "hasNextPage": false}
+				}}}}`,
+			},
+		}
+		client := newTestGraphQLClient(t, endpoint)
+		metrics := &recordingMetrics{}
+
+		prs, err := ListOpenPullRequestsForSHAGraphQL(ctx, client, "testorg", "testrepo", "abc", WithMetrics(metrics))
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 4}, numbers(prs))
+
+		assert.Equal(t, "open", prs[0].GetState())
+		assert.Equal(t, "abc", prs[0].GetHead().GetSHA())
+		assert.Equal(t, "feature", prs[0].GetHead().GetRef())
+		assert.Equal(t, "testorg:feature", prs[0].GetHead().GetLabel())
+		assert.Equal(t, "develop", prs[0].GetBase().GetRef())
+		assert.Equal(t, 2026, prs[0].GetUpdatedAt().Year())
+
+		assert.True(t, prs[1].GetDraft())
+		assert.Equal(t, "forker:feature", prs[1].GetHead().GetLabel())
+		assert.Equal(t, "main", prs[1].GetBase().GetRef())
+
+		assert.Equal(t, "abc", endpoint.variables[0]["sha"])
+		assert.Equal(t, []listCall{{Method: "ListOpenPullRequestsForSHAGraphQL", Pages: 2, Results: 2}}, metrics.calls)
+	})
+
+	t.Run("missingCommit", func(t *testing.T) {
+		client := newTestGraphQLClient(t, &graphqlEndpoint{
+			Responses: map[string]string{
+				"": `{"repository": {"object": null}}`,
+			},
+		})
+
+		prs, err := ListOpenPullRequestsForSHAGraphQL(ctx, client, "testorg", "testrepo", "abc")
+		require.NoError(t, err)
+		assert.Empty(t, prs)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client := newTestGraphQLClient(t, &graphqlEndpoint{})
+
+		_, err := ListOpenPullRequestsForSHAGraphQL(ctx, client, "testorg", "testrepo", "abc")
+		assert.Error(t, err)
+	})
+}
+
+func TestListOpenPullRequestsForRefGraphQL(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("paginates", func(t *testing.T) {
+		endpoint := &graphqlEndpoint{
+			Responses: map[string]string{
+				"": `{"repository": {"pullRequests": {
+					"nodes": [
+						{"number": 3, "state": "OPEN", "headRefOid": "def", "headRefName": "other", "headRepositoryOwner": {"login": "testorg"}, "baseRefName": "develop"},
+						{"number": 1, "state": "OPEN", "headRefOid": "abc", "headRefName": "feature", "headRepositoryOwner": {"login": "testorg"}, "baseRefName": "develop"}
+					],
+					"pageInfo": {"endCursor": "c1", "hasNextPage": true}
+				}}}`,
+				"c1": `{"repository": {"pullRequests": {
+					"nodes": [
+						{"number": 2, "state": "OPEN", "isDraft": true, "headRefOid": "123", "headRefName": "feature", "headRepositoryOwner": {"login": "forker"}, "baseRefName": "develop"}
+					],
+					"pageInfo": {"endCursor": "c2", "hasNextPage": false}
+				}}}`,
+			},
+		}
+		client := newTestGraphQLClient(t, endpoint)
+		metrics := &recordingMetrics{}
+
+		prs, err := ListOpenPullRequestsForRefGraphQL(ctx, client, "testorg", "testrepo", "refs/heads/develop", WithMetrics(metrics))
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 2, 3}, numbers(prs))
+		assert.Equal(t, "forker:feature", prs[1].GetHead().GetLabel())
+
+		require.Len(t, endpoint.variables, 2)
+		assert.Equal(t, "develop", endpoint.variables[0]["base"])
+		assert.Equal(t, "c1", endpoint.variables[1]["cursor"])
+		assert.Equal(t, []listCall{{Method: "ListOpenPullRequestsForRefGraphQL", Pages: 2, Results: 3}}, metrics.calls)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client := newTestGraphQLClient(t, &graphqlEndpoint{})
+
+		_, err := ListOpenPullRequestsForRefGraphQL(ctx, client, "testorg", "testrepo", "refs/heads/develop")
+		assert.Error(t, err)
+	})
+}
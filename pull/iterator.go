@@ -0,0 +1,146 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"context"
+
+	"github.com/google/go-github/v50/github"
+)
+
+// PullRequestIterator returns open pull requests one at a time, fetching a
+// page only when the previous page is used up, so that callers can stop early
+// without listing every pull request. Use it like a bufio.Scanner:
+//
+//	it := pull.NewOpenPullRequestIterator(client, owner, repo)
+//	for it.Next(ctx) {
+//		pr := it.PullRequest()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+//
+// It is not safe for concurrent use.
+type PullRequestIterator struct {
+	client *github.Client
+	owner  string
+	repo   string
+	prOpts github.PullRequestListOptions
+	limit  int
+	match  func(*github.PullRequest) bool
+
+	page     []*github.PullRequest
+	current  *github.PullRequest
+	returned int
+	pages    int
+	lastPage bool
+	err      error
+}
+
+// NewOpenPullRequestIterator returns an iterator over the open pull requests
+// in the repository, in the order set by WithSort. It also supports
+// WithLimit. Other options are ignored.
+func NewOpenPullRequestIterator(client *github.Client, owner, repoName string, opts ...ListOption) *PullRequestIterator {
+	return newPullRequestIterator(client, owner, repoName, github.PullRequestListOptions{State: "open"}, newListOptions(opts), nil)
+}
+
+func newPullRequestIterator(client *github.Client, owner, repoName string, prOpts github.PullRequestListOptions, listOpts *listOptions, match func(*github.PullRequest) bool) *PullRequestIterator {
+	prOpts.Sort, prOpts.Direction = listOpts.apiSort()
+	prOpts.ListOptions = github.ListOptions{PerPage: 100}
+
+	return &PullRequestIterator{
+		client: client,
+		owner:  owner,
+		repo:   repoName,
+		prOpts: prOpts,
+		limit:  listOpts.limit,
+		match:  match,
+	}
+}
+
+// Next advances to the next pull request, which is then available from
+// PullRequest. It returns false when there are no more pull requests, the
+// limit is reached, or an error occurs.
+func (it *PullRequestIterator) Next(ctx context.Context) bool {
+	it.current = nil
+	if it.err != nil || (it.limit > 0 && it.returned >= it.limit) {
+		return false
+	}
+
+	for {
+		for len(it.page) > 0 {
+			pr := it.page[0]
+			it.page = it.page[1:]
+			if it.match == nil || it.match(pr) {
+				it.current = pr
+				it.returned++
+				return true
+			}
+		}
+		if it.lastPage {
+			return false
+		}
+
+		if err := contextErr(ctx, it.owner, it.repo); err != nil {
+			it.err = err
+			return false
+		}
+
+		it.pages++
+		prs, resp, err := it.client.PullRequests.List(ctx, it.owner, it.repo, &it.prOpts)
+		if err != nil {
+			it.err = wrapAPIError(err, "failed to list pull requests for repository %s/%s", it.owner, it.repo)
+			return false
+		}
+		it.page = prs
+		it.lastPage = resp.NextPage == 0
+		it.prOpts.ListOptions.Page = resp.NextPage
+	}
+}
+
+// PullRequest returns the current pull request. It returns nil before the
+// first call to Next and after Next returns false.
+func (it *PullRequestIterator) PullRequest() *github.PullRequest {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *PullRequestIterator) Err() error {
+	return it.err
+}
+
+// Pages returns the number of pages requested so far.
+func (it *PullRequestIterator) Pages() int {
+	return it.pages
+}
+
+// ForEachOpenPullRequest calls fn for each open pull request in the
+// repository, in the order set by WithSort, until fn returns false or an
+// error. Pages are fetched as needed, so stopping early avoids requests for
+// the remaining pages. It returns the first error from fn or from GitHub.
+func ForEachOpenPullRequest(ctx context.Context, client *github.Client, owner, repoName string, fn func(*github.PullRequest) (bool, error), opts ...ListOption) error {
+	it := NewOpenPullRequestIterator(client, owner, repoName, opts...)
+	for it.Next(ctx) {
+		more, err := fn(it.PullRequest())
+		if err != nil {
+			return err
+		}
+		if !more {
+			return nil
+		}
+	}
+	return it.Err()
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullRequestIterator(t *testing.T) {
+	pages := [][]*github.PullRequest{
+		{testPR(1, "develop", "a"), testPR(2, "master", "b")},
+		{testPR(3, "develop", "c"), testPR(4, "develop", "d")},
+		{testPR(5, "master", "e")},
+	}
+	ctx := context.Background()
+
+	t.Run("all", func(t *testing.T) {
+		endpoint := &pullsEndpoint{Pages: pages}
+		it := NewOpenPullRequestIterator(newTestClient(t, endpoint), "testorg", "testrepo")
+
+		var prs []*github.PullRequest
+		for it.Next(ctx) {
+			prs = append(prs, it.PullRequest())
+		}
+		require.NoError(t, it.Err())
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, numbers(prs))
+		assert.Nil(t, it.PullRequest())
+		assert.Equal(t, 3, it.Pages())
+
+		query := endpoint.Queries()[0]
+		assert.Equal(t, "created", query.Get("sort"))
+		assert.Equal(t, "asc", query.Get("direction"))
+	})
+
+	t.Run("stopEarly", func(t *testing.T) {
+		endpoint := &pullsEndpoint{Pages: pages}
+
+		var found *github.PullRequest
+		err := ForEachOpenPullRequest(ctx, newTestClient(t, endpoint), "testorg", "testrepo", func(pr *github.PullRequest) (bool, error) {
+			if pr.GetBase().GetRef() == "master" {
+				found = pr
+				return false, nil
+			}
+			return true, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, found.GetNumber())
+		assert.Equal(t, []int{1}, endpoint.Requested(), "fetched pages after the match")
+	})
+
+	t.Run("updatedDesc", func(t *testing.T) {
+		endpoint := &pullsEndpoint{Pages: pages}
+		it := NewOpenPullRequestIterator(newTestClient(t, endpoint), "testorg", "testrepo", WithSort(SortByUpdatedDesc))
+		require.True(t, it.Next(ctx))
+
+		query := endpoint.Queries()[0]
+		assert.Equal(t, "updated", query.Get("sort"))
+		assert.Equal(t, "desc", query.Get("direction"))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		endpoint := &pullsEndpoint{Pages: pages, ErrorPage: 2}
+		it := NewOpenPullRequestIterator(newTestClient(t, endpoint), "testorg", "testrepo")
+
+		var prs []*github.PullRequest
+		for it.Next(ctx) {
+			prs = append(prs, it.PullRequest())
+		}
+		assert.Error(t, it.Err())
+		assert.Equal(t, []int{1, 2}, numbers(prs))
+		assert.False(t, it.Next(ctx), "iteration continued after an error")
+	})
+}
+
+func TestListOpenPullRequestsLimit(t *testing.T) {
+	pages := [][]*github.PullRequest{
+		{testPR(1, "develop", "a"), testPR(2, "master", "b")},
+		{testPR(3, "develop", "c"), testPR(4, "develop", "d")},
+		{testPR(5, "develop", "e")},
+	}
+	ctx := context.Background()
+
+	endpoint := &pullsEndpoint{Pages: pages}
+	metrics := &recordingMetrics{}
+	prs, err := ListOpenPullRequestsForRef(ctx, newTestClient(t, endpoint), "testorg", "testrepo", "refs/heads/develop", WithLimit(2), WithConcurrency(4), WithMetrics(metrics))
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 3}, numbers(prs))
+	assert.Equal(t, []int{1, 2}, endpoint.Requested(), "fetched pages after reaching the limit")
+	assert.Equal(t, []listCall{{Method: "ListOpenPullRequestsForRef", Pages: 2, Results: 2}}, metrics.calls)
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"context"
+
+	"github.com/google/go-github/v50/github"
+)
+
+// Lister finds the open pull requests in a repository that are affected by
+// changes to a commit or a branch.
+type Lister interface {
+	// ListOpenPullRequestsForSHA returns all open pull requests where the
+	// head of the source branch matches the given SHA.
+	ListOpenPullRequestsForSHA(ctx context.Context, owner, repo, sha string) ([]*github.PullRequest, error)
+
+	// ListOpenPullRequestsForRef returns all open pull requests that target
+	// the given ref, formatted as "refs/heads/<branch>".
+	ListOpenPullRequestsForRef(ctx context.Context, owner, repo, ref string) ([]*github.PullRequest, error)
+}
+
+// GitHubLister is a Lister that uses a GitHub client.
+type GitHubLister struct {
+	client *github.Client
+	opts   []ListOption
+}
+
+func NewGitHubLister(client *github.Client, opts ...ListOption) Lister {
+	return &GitHubLister{
+		client: client,
+		opts:   opts,
+	}
+}
+
+func (l *GitHubLister) ListOpenPullRequestsForSHA(ctx context.Context, owner, repo, sha string) ([]*github.PullRequest, error) {
+	return ListOpenPullRequestsForSHA(ctx, l.client, owner, repo, sha, l.opts...)
+}
+
+func (l *GitHubLister) ListOpenPullRequestsForRef(ctx context.Context, owner, repo, ref string) ([]*github.PullRequest, error) {
+	return ListOpenPullRequestsForRef(ctx, l.client, owner, repo, ref, l.opts...)
+}
+
+// GraphQLLister is a Lister that uses the GitHub GraphQL API. It usually needs
+// fewer requests than GitHubLister, but the returned pull requests only contain
+// the fields described by ListOpenPullRequestsForSHAGraphQL. Callers that need
+// other fields must get the full pull request.
+type GraphQLLister struct {
+	client GraphQLClient
+	opts   []ListOption
+}
+
+func NewGraphQLLister(client GraphQLClient, opts ...ListOption) Lister {
+	return &GraphQLLister{
+		client: client,
+		opts:   opts,
+	}
+}
+
+func (l *GraphQLLister) ListOpenPullRequestsForSHA(ctx context.Context, owner, repo, sha string) ([]*github.PullRequest, error) {
+	return ListOpenPullRequestsForSHAGraphQL(ctx, l.client, owner, repo, sha, l.opts...)
+}
+
+func (l *GraphQLLister) ListOpenPullRequestsForRef(ctx context.Context, owner, repo, ref string) ([]*github.PullRequest, error) {
+	return ListOpenPullRequestsForRefGraphQL(ctx, l.client, owner, repo, ref, l.opts...)
+}
+
+// type assertion
+var _ Lister = &GitHubLister{}
+var _ Lister = &GraphQLLister{}
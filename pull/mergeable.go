@@ -0,0 +1,82 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/pkg/errors"
+)
+
+// ErrMergeabilityTimeout is returned by WaitForMergeable when GitHub does not
+// compute whether the pull request is mergeable before the timeout.
+var ErrMergeabilityTimeout = errors.New("timed out waiting for GitHub to compute mergeability")
+
+// The first wait between polls of a pull request and the maximum wait. The
+// wait doubles after each poll, with jitter so that many waiting callers do
+// not poll together.
+var (
+	mergeablePollInitial = 500 * time.Millisecond
+	mergeablePollMax     = 8 * time.Second
+)
+
+// WaitForMergeable gets the pull request until GitHub has computed its
+// mergeable and mergeable_state fields, which are null and "unknown" for a
+// short time after the pull request or its base branch change. Closed pull
+// requests are returned immediately.
+//
+// If the state is still unknown after the timeout, it returns the last
+// version of the pull request and an error wrapping ErrMergeabilityTimeout.
+// It returns the context's error if the context ends first.
+func WaitForMergeable(ctx context.Context, client *github.Client, owner, repo string, number int, timeout time.Duration) (*github.PullRequest, error) {
+	deadline := time.Now().Add(timeout)
+	wait := mergeablePollInitial
+
+	for {
+		pr, _, err := client.PullRequests.Get(ctx, owner, repo, number)
+		if err != nil {
+			return nil, wrapAPIError(err, "failed to get pull request %s/%s#%d", owner, repo, number)
+		}
+		if pr.GetState() == "closed" || (pr.Mergeable != nil && pr.GetMergeableState() != "unknown") {
+			return pr, nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return pr, errors.Wrapf(ErrMergeabilityTimeout, "pull request %s/%s#%d", owner, repo, number)
+		}
+
+		// wait between half and all of the current backoff
+		sleep := wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+		if sleep > remaining {
+			sleep = remaining
+		}
+
+		timer := time.NewTimer(sleep)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		if wait *= 2; wait > mergeablePollMax {
+			wait = mergeablePollMax
+		}
+	}
+}
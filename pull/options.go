@@ -0,0 +1,144 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"sort"
+
+	"github.com/google/go-github/v50/github"
+)
+
+// DefaultConcurrency is the number of pages fetched in parallel when
+// concurrent pagination is enabled without an explicit limit.
+const DefaultConcurrency = 4
+
+// SortOrder is the order of the pull requests returned by the List* functions.
+type SortOrder int
+
+const (
+	// SortByNumber sorts pull requests by number, in ascending order. This is
+	// the default order.
+	SortByNumber SortOrder = iota
+
+	// SortByUpdatedDesc sorts pull requests by the time of their last update,
+	// starting with the most recently updated. Pull requests updated at the
+	// same time are sorted by number.
+	SortByUpdatedDesc
+)
+
+// ListOption configures how the List* functions fetch pull requests.
+type ListOption func(*listOptions)
+
+// Metrics observes the cost of listing pull requests. Implementations must be
+// safe for concurrent use.
+type Metrics interface {
+	// ObserveListCall is called once each time a List* function completes
+	// with the name of the function, the number of pages requested from
+	// GitHub, the number of pull requests returned, and the error, if any.
+	ObserveListCall(method string, pages int, results int, err error)
+}
+
+type listOptions struct {
+	concurrency int
+	metrics     Metrics
+	sort        SortOrder
+	limit       int
+}
+
+func newListOptions(opts []ListOption) *listOptions {
+	o := &listOptions{
+		concurrency: 1,
+		sort:        SortByNumber,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithConcurrency fetches all pages after the first in parallel, using at
+// most n concurrent requests. If n is less than 1, DefaultConcurrency is used.
+// Results are returned in the same order as sequential fetching. Without this
+// option, pages are fetched one at a time.
+func WithConcurrency(n int) ListOption {
+	return func(o *listOptions) {
+		if n < 1 {
+			n = DefaultConcurrency
+		}
+		o.concurrency = n
+	}
+}
+
+// WithMetrics reports the number of pages and results of each call to m.
+func WithMetrics(m Metrics) ListOption {
+	return func(o *listOptions) {
+		o.metrics = m
+	}
+}
+
+// WithSort sets the order of the returned pull requests. Without this option,
+// pull requests are sorted using SortByNumber.
+func WithSort(order SortOrder) ListOption {
+	return func(o *listOptions) {
+		o.sort = order
+	}
+}
+
+// WithLimit returns at most n pull requests, the first n in the configured
+// sort order. The REST API functions stop fetching pages once they find n
+// matching pull requests, so they fetch pages one at a time and ignore
+// WithConcurrency. If n is less than 1, all pull requests are returned.
+func WithLimit(n int) ListOption {
+	return func(o *listOptions) {
+		o.limit = n
+	}
+}
+
+// apiSort returns the sort parameters of the list endpoint that return pull
+// requests in the configured order, so that pages can be processed as they
+// arrive. Pull request numbers increase with creation time.
+func (o *listOptions) apiSort() (sort, direction string) {
+	switch o.sort {
+	case SortByUpdatedDesc:
+		return "updated", "desc"
+	default:
+		return "created", "asc"
+	}
+}
+
+// applyLimit truncates the sorted pull requests to the limit, if any.
+func (o *listOptions) applyLimit(prs []*github.PullRequest) []*github.PullRequest {
+	if o.limit > 0 && len(prs) > o.limit {
+		return prs[:o.limit]
+	}
+	return prs
+}
+
+func (o *listOptions) sortPullRequests(prs []*github.PullRequest) {
+	switch o.sort {
+	case SortByUpdatedDesc:
+		sort.SliceStable(prs, func(i, j int) bool {
+			ti, tj := prs[i].GetUpdatedAt().Time, prs[j].GetUpdatedAt().Time
+			if ti.Equal(tj) {
+				return prs[i].GetNumber() < prs[j].GetNumber()
+			}
+			return ti.After(tj)
+		})
+	default:
+		sort.SliceStable(prs, func(i, j int) bool {
+			return prs[i].GetNumber() < prs[j].GetNumber()
+		})
+	}
+}
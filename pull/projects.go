@@ -0,0 +1,126 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/shurcooL/githubv4"
+)
+
+// ProjectField is the value of a field of a project item for a pull request.
+type ProjectField struct {
+	Project string
+	Field   string
+	Value   string
+}
+
+type projectFieldName struct {
+	Common struct {
+		Name string
+	} `graphql:"... on ProjectV2FieldCommon"`
+}
+
+// projectFieldValue is a field value of a project item. The type name
+// determines which fragment is set, since fields with the same name, like
+// "field", are decoded into every fragment.
+type projectFieldValue struct {
+	Typename string `graphql:"__typename"`
+
+	SingleSelect struct {
+		Name  string
+		Field projectFieldName
+	} `graphql:"... on ProjectV2ItemFieldSingleSelectValue"`
+	Text struct {
+		Text  string
+		Field projectFieldName
+	} `graphql:"... on ProjectV2ItemFieldTextValue"`
+	Iteration struct {
+		Title string
+		Field projectFieldName
+	} `graphql:"... on ProjectV2ItemFieldIterationValue"`
+}
+
+func (v *projectFieldValue) toProjectField(project string) *ProjectField {
+	switch v.Typename {
+	case "ProjectV2ItemFieldSingleSelectValue":
+		return &ProjectField{Project: project, Field: v.SingleSelect.Field.Common.Name, Value: v.SingleSelect.Name}
+	case "ProjectV2ItemFieldTextValue":
+		return &ProjectField{Project: project, Field: v.Text.Field.Common.Name, Value: v.Text.Text}
+	case "ProjectV2ItemFieldIterationValue":
+		return &ProjectField{Project: project, Field: v.Iteration.Field.Common.Name, Value: v.Iteration.Title}
+	}
+	return nil
+}
+
+// newGraphQLClient returns a GraphQL client that uses the same transport and
+// server as the REST client.
+func newGraphQLClient(client *github.Client) GraphQLClient {
+	u := *client.BaseURL
+	if strings.HasSuffix(u.Path, "/api/v3/") {
+		// GitHub Enterprise serves GraphQL at /api/graphql
+		u.Path = strings.TrimSuffix(u.Path, "v3/") + "graphql"
+	} else {
+		u.Path += "graphql"
+	}
+	return githubv4.NewEnterpriseClient(u.String(), client.Client())
+}
+
+// ProjectFields lists the single select, text, and iteration field values of
+// the first 20 project items for the pull request, with up to 50 values each.
+func (ghc *GithubContext) ProjectFields(ctx context.Context) ([]*ProjectField, error) {
+	if ghc.projectFields != nil {
+		return ghc.projectFields, nil
+	}
+
+	var q struct {
+		Repository struct {
+			PullRequest struct {
+				ProjectItems struct {
+					Nodes []struct {
+						Project struct {
+							Title string
+						}
+						FieldValues struct {
+							Nodes []projectFieldValue
+						} `graphql:"fieldValues(first: 50)"`
+					}
+				} `graphql:"projectItems(first: 20)"`
+			} `graphql:"pullRequest(number: $number)"`
+		} `graphql:"repository(owner: $owner, name: $name)"`
+	}
+
+	vars := map[string]interface{}{
+		"owner":  githubv4.String(ghc.owner),
+		"name":   githubv4.String(ghc.repo),
+		"number": githubv4.Int(ghc.number),
+	}
+	if err := newGraphQLClient(ghc.client).Query(ctx, &q, vars); err != nil {
+		return nil, wrapAPIError(err, "cannot get project items for %s", ghc.Locator())
+	}
+
+	fields := []*ProjectField{}
+	for _, item := range q.Repository.PullRequest.ProjectItems.Nodes {
+		for _, v := range item.FieldValues.Nodes {
+			if field := v.toProjectField(item.Project.Title); field != nil {
+				fields = append(fields, field)
+			}
+		}
+	}
+	ghc.projectFields = fields
+	return fields, nil
+}
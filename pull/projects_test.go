@@ -0,0 +1,84 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectFields(t *testing.T) {
+	var queries int
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/graphql", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]interface{}{"owner": "testorg", "name": "testrepo", "number": float64(1)}, body.Variables)
+
+		queries++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"data": {"repository": {"pullRequest": {"projectItems": {"nodes": [
+			{"project": {"title": "Release"}, "fieldValues": {"nodes": [
+				{"__typename": "ProjectV2ItemFieldSingleSelectValue", "name": "Ready to merge", "field": {"name": "Status"}},
+				{"__typename": "ProjectV2ItemFieldTextValue", "text": "needs docs", "field": {"name": "Notes"}},
+				{"__typename": "ProjectV2ItemFieldDateValue"}
+			]}},
+			{"project": {"title": "Planning"}, "fieldValues": {"nodes": [
+				{"__typename": "ProjectV2ItemFieldIterationValue", "title": "Sprint 4", "field": {"name": "Iteration"}}
+			]}}
+		]}}}}}`)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	// use an enterprise URL to check that GraphQL requests use /api/graphql
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/api/v3/")
+
+	pullCtx := NewGithubContext(client, &github.PullRequest{
+		Number: github.Int(1),
+		Base: &github.PullRequestBranch{
+			Repo: &github.Repository{Name: github.String("testrepo"), Owner: &github.User{Login: github.String("testorg")}},
+		},
+	})
+
+	expected := []*ProjectField{
+		{Project: "Release", Field: "Status", Value: "Ready to merge"},
+		{Project: "Release", Field: "Notes", Value: "needs docs"},
+		{Project: "Planning", Field: "Iteration", Value: "Sprint 4"},
+	}
+
+	fields, err := pullCtx.ProjectFields(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, expected, fields)
+
+	fields, err = pullCtx.ProjectFields(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, expected, fields)
+	assert.Equal(t, 1, queries, "project fields were not cached")
+}
@@ -17,6 +17,9 @@ package pull
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/google/go-github/v50/github"
 	"github.com/pkg/errors"
@@ -25,68 +28,238 @@ import (
 
 // ListOpenPullRequestsForSHA returns all pull requests where the HEAD of the source branch
 // in the pull request matches the given SHA.
-func ListOpenPullRequestsForSHA(ctx context.Context, client *github.Client, owner, repoName, SHA string) ([]*github.PullRequest, error) {
-	var results []*github.PullRequest
+func ListOpenPullRequestsForSHA(ctx context.Context, client *github.Client, owner, repoName, SHA string, opts ...ListOption) ([]*github.PullRequest, error) {
+	return listOpenPullRequests(ctx, client, owner, repoName, "ListOpenPullRequestsForSHA", github.PullRequestListOptions{}, opts, func(openPR *github.PullRequest) bool {
+		return openPR.Head.GetSHA() == SHA
+	})
+}
+
+func ListOpenPullRequestsForRef(ctx context.Context, client *github.Client, owner, repoName, ref string, opts ...ListOption) ([]*github.PullRequest, error) {
+	return listOpenPullRequests(ctx, client, owner, repoName, "ListOpenPullRequestsForRef", github.PullRequestListOptions{}, opts, matchBaseRef(ctx, ref))
+}
 
-	openPRs, err := ListOpenPullRequests(ctx, client, owner, repoName)
+// ListOpenPullRequestsForRefAndSHA returns all open pull requests that target
+// the given ref and where the head of the source branch matches the given SHA.
+// The ref may include a "refs/heads/" prefix. If the SHA is empty, it returns
+// all open pull requests that target the ref.
+func ListOpenPullRequestsForRefAndSHA(ctx context.Context, client *github.Client, owner, repoName, ref, SHA string, opts ...ListOption) ([]*github.PullRequest, error) {
+	base := strings.TrimPrefix(ref, "refs/heads/")
 
-	if err != nil {
-		return nil, err
+	prOpts := github.PullRequestListOptions{
+		Base: base,
 	}
 
-	for _, openPR := range openPRs {
-		if openPR.Head.GetSHA() == SHA {
-			results = append(results, openPR)
-		}
+	return listOpenPullRequests(ctx, client, owner, repoName, "ListOpenPullRequestsForRefAndSHA", prOpts, opts, func(openPR *github.PullRequest) bool {
+		return openPR.GetBase().GetRef() == base && (SHA == "" || openPR.GetHead().GetSHA() == SHA)
+	})
+}
+
+// ListOpenNonDraftPullRequestsForRef returns all open pull requests targeting
+// the given ref that are not drafts. Draft pull requests can never be merged,
+// so callers that only act on mergeable pull requests should prefer this to
+// ListOpenPullRequestsForRef.
+func ListOpenNonDraftPullRequestsForRef(ctx context.Context, client *github.Client, owner, repoName, ref string, opts ...ListOption) ([]*github.PullRequest, error) {
+	matchRef := matchBaseRef(ctx, ref)
+	return listOpenPullRequests(ctx, client, owner, repoName, "ListOpenNonDraftPullRequestsForRef", github.PullRequestListOptions{}, opts, func(openPR *github.PullRequest) bool {
+		return matchRef(openPR) && !openPR.GetDraft()
+	})
+}
+
+// ListOpenPullRequestsForHeadRef returns all open pull requests where the
+// source branch is the given ref. The ref may include a "refs/heads/" prefix.
+// Branches in forks must be prefixed with the owner of the fork and a colon,
+// matching the head branch returned by Context.Branches; unprefixed branches
+// are assumed to be in the owner's repository.
+func ListOpenPullRequestsForHeadRef(ctx context.Context, client *github.Client, owner, repoName, headRef string, opts ...ListOption) ([]*github.PullRequest, error) {
+	headOwner, branch, isFork := strings.Cut(strings.TrimPrefix(headRef, "refs/heads/"), ":")
+	if !isFork {
+		headOwner, branch = owner, headOwner
 	}
 
-	return results, nil
+	prOpts := github.PullRequestListOptions{
+		Head: fmt.Sprintf("%s:%s", headOwner, branch),
+	}
+
+	// the API ignores head filters it cannot resolve, so check locally too
+	return listOpenPullRequests(ctx, client, owner, repoName, "ListOpenPullRequestsForHeadRef", prOpts, opts, func(openPR *github.PullRequest) bool {
+		labelOwner, _, _ := strings.Cut(openPR.GetHead().GetLabel(), ":")
+		return openPR.GetHead().GetRef() == branch && strings.EqualFold(labelOwner, headOwner)
+	})
 }
 
-func ListOpenPullRequestsForRef(ctx context.Context, client *github.Client, owner, repoName, ref string) ([]*github.PullRequest, error) {
-	var results []*github.PullRequest
+// ListOpenPullRequests returns all open pull requests in the repository.
+//
+// Unless the WithSort option is used, this and the other List* functions
+// return pull requests sorted by number in ascending order.
+//
+// If listing fails part way through, this and the other List* functions
+// return the matching pull requests from all pages fetched successfully
+// along with the error, so callers can decide whether to use partial results.
+func ListOpenPullRequests(ctx context.Context, client *github.Client, owner, repoName string, opts ...ListOption) ([]*github.PullRequest, error) {
+	return listOpenPullRequests(ctx, client, owner, repoName, "ListOpenPullRequests", github.PullRequestListOptions{}, opts, nil)
+}
+
+func matchBaseRef(ctx context.Context, ref string) func(*github.PullRequest) bool {
 	logger := zerolog.Ctx(ctx)
+	return func(openPR *github.PullRequest) bool {
+		formattedRef := fmt.Sprintf("refs/heads/%s", openPR.GetBase().GetRef())
+		logger.Debug().Msgf("found open pull request with base ref %s", formattedRef)
+		return formattedRef == ref
+	}
+}
+
+// listOpenPullRequests returns the open pull requests matching the filters in
+// prOpts for which match returns true, sorted as configured by opts. A nil
+// match function keeps all pull requests. The call is reported to any
+// configured Metrics using method.
+func listOpenPullRequests(ctx context.Context, client *github.Client, owner, repoName, method string, prOpts github.PullRequestListOptions, opts []ListOption, match func(*github.PullRequest) bool) ([]*github.PullRequest, error) {
+	listOpts := newListOptions(opts)
 
-	openPRs, err := ListOpenPullRequests(ctx, client, owner, repoName)
+	prOpts.State = "open"
+	if listOpts.limit > 0 {
+		it := newPullRequestIterator(client, owner, repoName, prOpts, listOpts, match)
+
+		var results []*github.PullRequest
+		for it.Next(ctx) {
+			results = append(results, it.PullRequest())
+		}
+		err := it.Err()
 
-	if err != nil {
-		return nil, err
+		if listOpts.metrics != nil {
+			listOpts.metrics.ObserveListCall(method, it.Pages(), len(results), err)
+		}
+		return results, err
 	}
 
+	openPRs, pages, err := listPullRequests(ctx, client, owner, repoName, prOpts, listOpts)
+
+	var results []*github.PullRequest
 	for _, openPR := range openPRs {
-		formattedRef := fmt.Sprintf("refs/heads/%s", openPR.GetBase().GetRef())
-		logger.Debug().Msgf("found open pull request with base ref %s", formattedRef)
-		if formattedRef == ref {
+		if match == nil || match(openPR) {
 			results = append(results, openPR)
 		}
 	}
 
-	return results, nil
+	listOpts.sortPullRequests(results)
+
+	if listOpts.metrics != nil {
+		listOpts.metrics.ObserveListCall(method, pages, len(results), err)
+	}
+	return results, err
 }
 
-func ListOpenPullRequests(ctx context.Context, client *github.Client, owner, repoName string) ([]*github.PullRequest, error) {
+// listPullRequests returns all pull requests matching the filters in prOpts,
+// handling pagination as configured by listOpts. It also returns the number
+// of pages requested.
+func listPullRequests(ctx context.Context, client *github.Client, owner, repoName string, prOpts github.PullRequestListOptions, listOpts *listOptions) ([]*github.PullRequest, int, error) {
 	var results []*github.PullRequest
+	var pages int
 
-	opts := &github.PullRequestListOptions{
-		State: "open",
-		ListOptions: github.ListOptions{
-			PerPage: 100,
-		},
+	prOpts.ListOptions = github.ListOptions{
+		PerPage: 100,
 	}
 
 	for {
-		prs, resp, err := client.PullRequests.List(ctx, owner, repoName, opts)
-		if err != nil {
-			return results, errors.Wrapf(err, "failed to list pull requests for repository %s/%s", owner, repoName)
+		if err := contextErr(ctx, owner, repoName); err != nil {
+			return results, pages, err
 		}
-		for _, pr := range prs {
-			results = append(results, pr)
+
+		pages++
+		prs, resp, err := client.PullRequests.List(ctx, owner, repoName, &prOpts)
+		if err != nil {
+			return results, pages, wrapAPIError(err, "failed to list pull requests for repository %s/%s", owner, repoName)
 		}
+		results = append(results, prs...)
 		if resp.NextPage == 0 {
 			break
 		}
-		opts.ListOptions.Page = resp.NextPage
+
+		// once the total number of pages is known, fetch the rest in parallel
+		if listOpts.concurrency > 1 && resp.LastPage > resp.NextPage {
+			prs, n, err := listPagesConcurrently(ctx, client, owner, repoName, prOpts, resp.NextPage, resp.LastPage, listOpts.concurrency)
+			results = append(results, prs...)
+			return results, pages + n, err
+		}
+		prOpts.ListOptions.Page = resp.NextPage
+	}
+
+	return results, pages, nil
+}
+
+// listPagesConcurrently fetches the pages from first to last (inclusive) using
+// at most concurrency parallel requests and returns the combined results in
+// page order, along with the number of pages requested. The first error
+// cancels all outstanding requests, but the results of pages that were
+// fetched successfully are still returned.
+func listPagesConcurrently(ctx context.Context, client *github.Client, owner, repoName string, prOpts github.PullRequestListOptions, first, last, concurrency int) ([]*github.PullRequest, int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+		requests int32
+	)
+
+	pages := make([][]*github.PullRequest, last-first+1)
+	pageNumbers := make(chan int)
+
+	for i := 0; i < concurrency && i < len(pages); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for page := range pageNumbers {
+				if ctx.Err() != nil {
+					continue
+				}
+
+				pageOpts := prOpts
+				pageOpts.ListOptions.Page = page
+
+				atomic.AddInt32(&requests, 1)
+				prs, _, err := client.PullRequests.List(ctx, owner, repoName, &pageOpts)
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = wrapAPIError(err, "failed to list pull requests for repository %s/%s", owner, repoName)
+						cancel()
+					})
+					continue
+				}
+				pages[page-first] = prs
+			}
+		}()
+	}
+
+Pages:
+	for page := first; page <= last; page++ {
+		select {
+		case pageNumbers <- page:
+		case <-ctx.Done():
+			break Pages
+		}
 	}
+	close(pageNumbers)
+	wg.Wait()
 
-	return results, nil
+	var results []*github.PullRequest
+	for _, prs := range pages {
+		results = append(results, prs...)
+	}
+
+	n := int(atomic.LoadInt32(&requests))
+	if firstErr != nil {
+		return results, n, firstErr
+	}
+	return results, n, contextErr(ctx, owner, repoName)
+}
+
+// contextErr returns an error if the context is canceled or its deadline has
+// passed. The error wraps the context error so that callers can distinguish
+// running out of time from GitHub failures using errors.Is.
+func contextErr(ctx context.Context, owner, repoName string) error {
+	if err := ctx.Err(); err != nil {
+		return errors.Wrapf(err, "stopped listing pull requests for repository %s/%s", owner, repoName)
+	}
+	return nil
 }
@@ -0,0 +1,567 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pullsEndpoint serves pages of pull requests like the GitHub list endpoint,
+// including the Link headers used for pagination.
+type pullsEndpoint struct {
+	Pages [][]*github.PullRequest
+
+	// ErrorPage is the page number that fails with a server error, if any
+	ErrorPage int
+
+	mu        sync.Mutex
+	requested []int
+	queries   []url.Values
+}
+
+func (e *pullsEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	page := 1
+	if p := r.URL.Query().Get("page"); p != "" {
+		page, _ = strconv.Atoi(p)
+	}
+
+	e.mu.Lock()
+	e.requested = append(e.requested, page)
+	e.queries = append(e.queries, r.URL.Query())
+	e.mu.Unlock()
+
+	if page == e.ErrorPage {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"message": "server error"}`))
+		return
+	}
+	if page < 1 || page > len(e.Pages) {
+		_, _ = w.Write([]byte(`[]`))
+		return
+	}
+
+	if page < len(e.Pages) {
+		w.Header().Set("Link", fmt.Sprintf(
+			`<%s?page=%d>; rel="next", <%s?page=%d>; rel="last"`,
+			r.URL.Path, page+1, r.URL.Path, len(e.Pages),
+		))
+	}
+	_ = json.NewEncoder(w).Encode(e.Pages[page-1])
+}
+
+func (e *pullsEndpoint) Requested() []int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]int(nil), e.requested...)
+}
+
+func (e *pullsEndpoint) Queries() []url.Values {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]url.Values(nil), e.queries...)
+}
+
+func newTestClient(t *testing.T, h http.Handler) *github.Client {
+	return newTestClientWithTransport(t, h, http.DefaultTransport)
+}
+
+func newTestClientWithTransport(t *testing.T, h http.Handler, rt http.RoundTripper) *github.Client {
+	mux := http.NewServeMux()
+	mux.Handle("/repos/testorg/testrepo/pulls", h)
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	client := github.NewClient(&http.Client{Transport: rt})
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+	return client
+}
+
+func testPR(number int, base, headSHA string) *github.PullRequest {
+	return &github.PullRequest{
+		Number: github.Int(number),
+		State:  github.String("open"),
+		Base:   &github.PullRequestBranch{Ref: github.String(base)},
+		Head:   &github.PullRequestBranch{SHA: github.String(headSHA)},
+	}
+}
+
+func numbers(prs []*github.PullRequest) []int {
+	var n []int
+	for _, pr := range prs {
+		n = append(n, pr.GetNumber())
+	}
+	return n
+}
+
+func TestListOpenNonDraftPullRequestsForRef(t *testing.T) {
+	draft := testPR(1, "develop", "a")
+	draft.Draft = github.Bool(true)
+
+	ready := testPR(2, "develop", "b")
+	ready.Draft = github.Bool(false)
+
+	undrafted := testPR(3, "develop", "c")
+
+	otherBase := testPR(4, "main", "d")
+
+	ctx := context.Background()
+
+	t.Run("excludesDrafts", func(t *testing.T) {
+		client := newTestClient(t, &pullsEndpoint{
+			Pages: [][]*github.PullRequest{{draft, ready}, {undrafted, otherBase}},
+		})
+
+		prs, err := ListOpenNonDraftPullRequestsForRef(ctx, client, "testorg", "testrepo", "refs/heads/develop")
+		require.NoError(t, err)
+		assert.Equal(t, []int{2, 3}, numbers(prs))
+	})
+
+	t.Run("allDrafts", func(t *testing.T) {
+		client := newTestClient(t, &pullsEndpoint{
+			Pages: [][]*github.PullRequest{{draft}},
+		})
+
+		prs, err := ListOpenNonDraftPullRequestsForRef(ctx, client, "testorg", "testrepo", "refs/heads/develop")
+		require.NoError(t, err)
+		assert.Empty(t, prs)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client := newTestClient(t, &pullsEndpoint{
+			Pages:     [][]*github.PullRequest{{draft, ready}, {undrafted}},
+			ErrorPage: 2,
+		})
+
+		prs, err := ListOpenNonDraftPullRequestsForRef(ctx, client, "testorg", "testrepo", "refs/heads/develop")
+		assert.Error(t, err)
+		assert.Equal(t, []int{2}, numbers(prs), "results from successful pages were not returned")
+	})
+}
+
+func TestListOpenPullRequestsConcurrency(t *testing.T) {
+	var pages [][]*github.PullRequest
+	for i := 0; i < 5; i++ {
+		pages = append(pages, []*github.PullRequest{
+			testPR(2*i+1, "develop", "a"),
+			testPR(2*i+2, "develop", "b"),
+		})
+	}
+
+	ctx := context.Background()
+
+	t.Run("sequentialByDefault", func(t *testing.T) {
+		endpoint := &pullsEndpoint{Pages: pages}
+		client := newTestClient(t, endpoint)
+
+		prs, err := ListOpenPullRequests(ctx, client, "testorg", "testrepo")
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, numbers(prs))
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, endpoint.Requested())
+	})
+
+	t.Run("concurrentPreservesOrder", func(t *testing.T) {
+		endpoint := &pullsEndpoint{Pages: pages}
+		client := newTestClient(t, endpoint)
+
+		prs, err := ListOpenPullRequests(ctx, client, "testorg", "testrepo", WithConcurrency(3))
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, numbers(prs))
+		assert.ElementsMatch(t, []int{1, 2, 3, 4, 5}, endpoint.Requested())
+	})
+
+	t.Run("concurrentSinglePage", func(t *testing.T) {
+		endpoint := &pullsEndpoint{Pages: pages[:1]}
+		client := newTestClient(t, endpoint)
+
+		prs, err := ListOpenPullRequests(ctx, client, "testorg", "testrepo", WithConcurrency(0))
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2}, numbers(prs))
+		assert.Equal(t, []int{1}, endpoint.Requested())
+	})
+
+	t.Run("concurrentError", func(t *testing.T) {
+		endpoint := &pullsEndpoint{Pages: pages, ErrorPage: 3}
+		client := newTestClient(t, endpoint)
+
+		prs, err := ListOpenPullRequests(ctx, client, "testorg", "testrepo", WithConcurrency(2))
+		assert.Error(t, err)
+		assert.NotContains(t, numbers(prs), 5, "results include the failed page")
+		assert.NotContains(t, numbers(prs), 6, "results include the failed page")
+		assert.Subset(t, numbers(prs), []int{1, 2}, "results do not include the first page")
+	})
+
+	t.Run("concurrentFiltered", func(t *testing.T) {
+		endpoint := &pullsEndpoint{Pages: pages}
+		client := newTestClient(t, endpoint)
+
+		prs, err := ListOpenPullRequestsForSHA(ctx, client, "testorg", "testrepo", "b", WithConcurrency(4))
+		require.NoError(t, err)
+		assert.Equal(t, []int{2, 4, 6, 8, 10}, numbers(prs))
+	})
+}
+
+func TestListOpenPullRequestsForHeadRef(t *testing.T) {
+	headPR := func(number int, owner, ref string) *github.PullRequest {
+		pr := testPR(number, "develop", "a")
+		pr.Head.Ref = github.String(ref)
+		pr.Head.Label = github.String(owner + ":" + ref)
+		return pr
+	}
+
+	pages := [][]*github.PullRequest{
+		{headPR(1, "testorg", "feature/foo"), headPR(2, "testorg", "feature/bar")},
+		{headPR(3, "forker", "feature/foo"), headPR(4, "TestOrg", "feature/foo")},
+	}
+
+	ctx := context.Background()
+
+	t.Run("sameRepository", func(t *testing.T) {
+		endpoint := &pullsEndpoint{Pages: pages}
+		client := newTestClient(t, endpoint)
+
+		prs, err := ListOpenPullRequestsForHeadRef(ctx, client, "testorg", "testrepo", "refs/heads/feature/foo")
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 4}, numbers(prs))
+		assert.Equal(t, "testorg:feature/foo", endpoint.Queries()[0].Get("head"))
+	})
+
+	t.Run("fork", func(t *testing.T) {
+		endpoint := &pullsEndpoint{Pages: pages}
+		client := newTestClient(t, endpoint)
+
+		prs, err := ListOpenPullRequestsForHeadRef(ctx, client, "testorg", "testrepo", "forker:feature/foo")
+		require.NoError(t, err)
+		assert.Equal(t, []int{3}, numbers(prs))
+		assert.Equal(t, "forker:feature/foo", endpoint.Queries()[0].Get("head"))
+	})
+
+	t.Run("noMatch", func(t *testing.T) {
+		client := newTestClient(t, &pullsEndpoint{Pages: pages})
+
+		prs, err := ListOpenPullRequestsForHeadRef(ctx, client, "testorg", "testrepo", "feature/baz")
+		require.NoError(t, err)
+		assert.Empty(t, prs)
+	})
+}
+
+type listCall struct {
+	Method  string
+	Pages   int
+	Results int
+	Err     error
+}
+
+type recordingMetrics struct {
+	calls []listCall
+}
+
+func (m *recordingMetrics) ObserveListCall(method string, pages int, results int, err error) {
+	m.calls = append(m.calls, listCall{Method: method, Pages: pages, Results: results, Err: err})
+}
+
+func TestListOpenPullRequestsMetrics(t *testing.T) {
+	pages := [][]*github.PullRequest{
+		{testPR(1, "develop", "a"), testPR(2, "develop", "b")},
+		{testPR(3, "develop", "a"), testPR(4, "main", "b")},
+		{testPR(5, "develop", "a")},
+	}
+
+	ctx := context.Background()
+
+	t.Run("sequential", func(t *testing.T) {
+		metrics := &recordingMetrics{}
+		client := newTestClient(t, &pullsEndpoint{Pages: pages})
+
+		_, err := ListOpenPullRequestsForSHA(ctx, client, "testorg", "testrepo", "a", WithMetrics(metrics))
+		require.NoError(t, err)
+		assert.Equal(t, []listCall{{Method: "ListOpenPullRequestsForSHA", Pages: 3, Results: 3}}, metrics.calls)
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		metrics := &recordingMetrics{}
+		client := newTestClient(t, &pullsEndpoint{Pages: pages})
+
+		_, err := ListOpenPullRequestsForRef(ctx, client, "testorg", "testrepo", "refs/heads/develop", WithMetrics(metrics), WithConcurrency(2))
+		require.NoError(t, err)
+		assert.Equal(t, []listCall{{Method: "ListOpenPullRequestsForRef", Pages: 3, Results: 4}}, metrics.calls)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		metrics := &recordingMetrics{}
+		client := newTestClient(t, &pullsEndpoint{Pages: pages, ErrorPage: 2})
+
+		_, err := ListOpenPullRequests(ctx, client, "testorg", "testrepo", WithMetrics(metrics))
+		assert.Error(t, err)
+		if assert.Len(t, metrics.calls, 1) {
+			assert.Equal(t, "ListOpenPullRequests", metrics.calls[0].Method)
+			assert.Equal(t, 2, metrics.calls[0].Pages)
+			assert.Error(t, metrics.calls[0].Err)
+		}
+	})
+}
+
+func TestListOpenPullRequestsPartialResults(t *testing.T) {
+	pages := [][]*github.PullRequest{
+		{testPR(1, "develop", "a"), testPR(2, "main", "a")},
+		{testPR(3, "develop", "a")},
+	}
+
+	ctx := context.Background()
+
+	t.Run("all", func(t *testing.T) {
+		client := newTestClient(t, &pullsEndpoint{Pages: pages, ErrorPage: 2})
+
+		prs, err := ListOpenPullRequests(ctx, client, "testorg", "testrepo")
+		assert.Error(t, err)
+		assert.Equal(t, []int{1, 2}, numbers(prs))
+	})
+
+	t.Run("forSHA", func(t *testing.T) {
+		client := newTestClient(t, &pullsEndpoint{Pages: pages, ErrorPage: 2})
+
+		prs, err := ListOpenPullRequestsForSHA(ctx, client, "testorg", "testrepo", "a")
+		assert.Error(t, err)
+		assert.Equal(t, []int{1, 2}, numbers(prs))
+	})
+
+	t.Run("forRef", func(t *testing.T) {
+		client := newTestClient(t, &pullsEndpoint{Pages: pages, ErrorPage: 2})
+
+		prs, err := ListOpenPullRequestsForRef(ctx, client, "testorg", "testrepo", "refs/heads/develop")
+		assert.Error(t, err)
+		assert.Equal(t, []int{1}, numbers(prs))
+	})
+}
+
+func TestListOpenPullRequestsForRefAndSHA(t *testing.T) {
+	pages := [][]*github.PullRequest{
+		{testPR(1, "develop", "a"), testPR(2, "develop", "b")},
+		{testPR(3, "main", "a"), testPR(4, "develop", "a")},
+	}
+
+	ctx := context.Background()
+
+	t.Run("match", func(t *testing.T) {
+		endpoint := &pullsEndpoint{Pages: pages}
+		client := newTestClient(t, endpoint)
+
+		prs, err := ListOpenPullRequestsForRefAndSHA(ctx, client, "testorg", "testrepo", "refs/heads/develop", "a")
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 4}, numbers(prs))
+		assert.Equal(t, "develop", endpoint.Queries()[0].Get("base"))
+	})
+
+	t.Run("refMatchesSHAMismatch", func(t *testing.T) {
+		client := newTestClient(t, &pullsEndpoint{Pages: pages})
+
+		prs, err := ListOpenPullRequestsForRefAndSHA(ctx, client, "testorg", "testrepo", "main", "b")
+		require.NoError(t, err)
+		assert.Empty(t, prs)
+	})
+
+	t.Run("emptySHA", func(t *testing.T) {
+		client := newTestClient(t, &pullsEndpoint{Pages: pages})
+
+		prs, err := ListOpenPullRequestsForRefAndSHA(ctx, client, "testorg", "testrepo", "refs/heads/develop", "")
+		require.NoError(t, err)
+
+		refPRs, err := ListOpenPullRequestsForRef(ctx, client, "testorg", "testrepo", "refs/heads/develop")
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 4}, numbers(prs))
+		assert.Equal(t, numbers(refPRs), numbers(prs))
+	})
+}
+
+func TestListOpenPullRequestsSort(t *testing.T) {
+	updatedPR := func(number int, hour int) *github.PullRequest {
+		pr := testPR(number, "develop", "a")
+		pr.UpdatedAt = &github.Timestamp{Time: time.Date(2026, 1, 1, hour, 0, 0, 0, time.UTC)}
+		return pr
+	}
+
+	pages := [][]*github.PullRequest{
+		{updatedPR(7, 1), updatedPR(3, 5), updatedPR(9, 2)},
+		{updatedPR(1, 3), updatedPR(5, 5), updatedPR(2, 4)},
+	}
+
+	ctx := context.Background()
+
+	t.Run("numberByDefault", func(t *testing.T) {
+		client := newTestClient(t, &pullsEndpoint{Pages: pages})
+
+		prs, err := ListOpenPullRequestsForSHA(ctx, client, "testorg", "testrepo", "a")
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3, 5, 7, 9}, numbers(prs))
+	})
+
+	t.Run("number", func(t *testing.T) {
+		client := newTestClient(t, &pullsEndpoint{Pages: pages})
+
+		prs, err := ListOpenPullRequests(ctx, client, "testorg", "testrepo", WithSort(SortByNumber))
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3, 5, 7, 9}, numbers(prs))
+	})
+
+	t.Run("updatedDesc", func(t *testing.T) {
+		client := newTestClient(t, &pullsEndpoint{Pages: pages})
+
+		prs, err := ListOpenPullRequestsForRef(ctx, client, "testorg", "testrepo", "refs/heads/develop", WithSort(SortByUpdatedDesc))
+		require.NoError(t, err)
+		assert.Equal(t, []int{3, 5, 2, 1, 9, 7}, numbers(prs))
+	})
+}
+
+// cancelAfterFirstPage cancels a context after the response for the first
+// page is received, simulating a deadline that passes between pages.
+type cancelAfterFirstPage struct {
+	base   http.RoundTripper
+	cancel context.CancelFunc
+}
+
+func (rt *cancelAfterFirstPage) RoundTrip(r *http.Request) (*http.Response, error) {
+	res, err := rt.base.RoundTrip(r)
+	if err != nil || r.URL.Query().Get("page") != "" {
+		return res, err
+	}
+
+	// read the body before canceling so the first page is still successful
+	body, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	rt.cancel()
+	return res, nil
+}
+
+func TestListOpenPullRequestsContextCanceled(t *testing.T) {
+	pages := [][]*github.PullRequest{
+		{testPR(1, "develop", "a")},
+		{testPR(2, "develop", "a")},
+		{testPR(3, "develop", "a")},
+	}
+
+	for name, opts := range map[string][]ListOption{
+		"sequential": nil,
+		"concurrent": {WithConcurrency(2)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			endpoint := &pullsEndpoint{Pages: pages}
+			client := newTestClientWithTransport(t, endpoint, &cancelAfterFirstPage{base: http.DefaultTransport, cancel: cancel})
+
+			prs, err := ListOpenPullRequestsForSHA(ctx, client, "testorg", "testrepo", "a", opts...)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, context.Canceled), "error does not match context.Canceled: %v", err)
+			assert.Equal(t, []int{1}, numbers(prs))
+			assert.Equal(t, []int{1}, endpoint.Requested(), "pages were requested after cancellation")
+		})
+	}
+
+	t.Run("deadline", func(t *testing.T) {
+		endpoint := &pullsEndpoint{Pages: pages}
+		client := newTestClient(t, endpoint)
+
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		defer cancel()
+
+		_, err := ListOpenPullRequests(ctx, client, "testorg", "testrepo")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded), "error does not match context.DeadlineExceeded: %v", err)
+		assert.Empty(t, endpoint.Requested())
+	})
+}
+
+func TestWaitForMergeable(t *testing.T) {
+	defer func(initial, max time.Duration) {
+		mergeablePollInitial, mergeablePollMax = initial, max
+	}(mergeablePollInitial, mergeablePollMax)
+	mergeablePollInitial, mergeablePollMax = time.Millisecond, 4*time.Millisecond
+
+	var mu sync.Mutex
+	polls := 0
+	unknownPolls := 2
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/testorg/testrepo/pulls/1", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		polls++
+		if polls <= unknownPolls {
+			_, _ = io.WriteString(w, `{"number": 1, "state": "open", "mergeable": null, "mergeable_state": "unknown"}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"number": 1, "state": "open", "mergeable": true, "mergeable_state": "clean"}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/pulls/2", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"number": 2, "state": "closed", "mergeable": null}`)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+	ctx := context.Background()
+
+	t.Run("computed", func(t *testing.T) {
+		pr, err := WaitForMergeable(ctx, client, "testorg", "testrepo", 1, time.Minute)
+		require.NoError(t, err)
+		assert.True(t, pr.GetMergeable())
+		assert.Equal(t, 3, polls)
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		pr, err := WaitForMergeable(ctx, client, "testorg", "testrepo", 2, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, "closed", pr.GetState())
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		polls, unknownPolls = 0, 1000
+		pr, err := WaitForMergeable(ctx, client, "testorg", "testrepo", 1, 20*time.Millisecond)
+		assert.True(t, errors.Is(err, ErrMergeabilityTimeout), "unexpected error: %v", err)
+		require.NotNil(t, pr)
+		assert.Nil(t, pr.Mergeable)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := WaitForMergeable(ctx, client, "testorg", "testrepo", 1, time.Minute)
+		assert.True(t, errors.Is(err, context.Canceled), "unexpected error: %v", err)
+	})
+}
@@ -16,8 +16,11 @@ package pulltest
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/palantir/bulldozer/pull"
+	"github.com/pkg/errors"
 )
 
 // MockPullContext is a dummy Context implementation.
@@ -26,10 +29,13 @@ type MockPullContext struct {
 	RepoValue   string
 	NumberValue int
 
-	TitleValue   string
-	BodyValue    string
-	HeadSHAValue string
-	LocatorValue string
+	TitleValue     string
+	BodyValue      string
+	AuthorValue    string
+	HeadSHAValue   string
+	MilestoneValue string
+	CreatedValue   time.Time
+	LocatorValue   string
 
 	BranchBase string
 	BranchName string
@@ -43,23 +49,85 @@ type MockPullContext struct {
 	CommentValue    []string
 	CommentErrValue error
 
+	StatusesValue    []*pull.Status
+	StatusesErrValue error
+
+	CheckRunsValue    []*pull.CheckRun
+	CheckRunsErrValue error
+
+	CheckConclusionsValue pull.CheckConclusions
+
+	AppIDValue int64
+
+	ProjectFieldsValue    []*pull.ProjectField
+	ProjectFieldsErrValue error
+
+	DeploymentsValue    []*pull.Deployment
+	DeploymentsErrValue error
+
 	CommitsValue    []*pull.Commit
 	CommitsErrValue error
 
+	DiffStatsValue    *pull.DiffStats
+	DiffStatsErrValue error
+
+	ChangedFilesValue    []string
+	ChangedFilesErrValue error
+
+	ChangedFileSizesValue    map[string]int64
+	ChangedFileSizesErrValue error
+
+	CodeOwnersValue    *pull.CodeOwners
+	CodeOwnersErrValue error
+
 	RequiredStatusesValue    []string
 	RequiredStatusesErrValue error
 
 	PushRestrictionsValue    bool
 	PushRestrictionsErrValue error
 
+	RequiresUpToDateValue    bool
+	RequiresUpToDateErrValue error
+
+	MergeRequirementsValue    *pull.MergeRequirements
+	MergeRequirementsErrValue error
+
 	SuccessStatusesValue    []string
 	SuccessStatusesErrValue error
 
 	IsTargetedValue    bool
 	IsTargetedErrValue error
 
+	DefaultBranchValue string
+
+	HeadBranchValue    *pull.Branch
+	HeadBranchErrValue error
+
 	IsDraftValue   bool
 	AutoMergeValue bool
+
+	ReviewsValue    []*pull.Review
+	ReviewsErrValue error
+
+	RequestedReviewersValue []string
+
+	// TeamMembersValue maps "org/team" to the logins of the team members
+	TeamMembersValue    map[string][]string
+	TeamMembersErrValue error
+
+	// ReferencesValue maps "owner/repo#number" to issues and pull requests.
+	// Other references do not exist.
+	ReferencesValue    map[string]*pull.Reference
+	ReferencesErrValue error
+
+	// TicketStatusesValue maps ticket keys to statuses. Missing tickets
+	// return an error matching pull.ErrNotFound.
+	TicketStatusesValue    map[string]string
+	TicketStatusesErrValue error
+
+	// NoTicketTrackerValue is true if the server does not define an issue
+	// tracker.
+	NoTicketTrackerValue bool
 }
 
 func (c *MockPullContext) Owner() string {
@@ -89,10 +157,22 @@ func (c *MockPullContext) Body() string {
 	return c.BodyValue
 }
 
+func (c *MockPullContext) Author() string {
+	return c.AuthorValue
+}
+
+func (c *MockPullContext) CreatedAt() time.Time {
+	return c.CreatedValue
+}
+
 func (c *MockPullContext) HeadSHA() string {
 	return c.HeadSHAValue
 }
 
+func (c *MockPullContext) Milestone() string {
+	return c.MilestoneValue
+}
+
 func (c *MockPullContext) Branches() (base string, head string) {
 	return c.BranchBase, c.BranchName
 }
@@ -101,6 +181,30 @@ func (c *MockPullContext) MergeState(ctx context.Context) (*pull.MergeState, err
 	return c.MergeStateValue, c.MergeStateErrValue
 }
 
+func (c *MockPullContext) Statuses(ctx context.Context) ([]*pull.Status, error) {
+	return c.StatusesValue, c.StatusesErrValue
+}
+
+func (c *MockPullContext) CheckRuns(ctx context.Context) ([]*pull.CheckRun, error) {
+	return c.CheckRunsValue, c.CheckRunsErrValue
+}
+
+func (c *MockPullContext) ProjectFields(ctx context.Context) ([]*pull.ProjectField, error) {
+	return c.ProjectFieldsValue, c.ProjectFieldsErrValue
+}
+
+func (c *MockPullContext) CheckConclusions() pull.CheckConclusions {
+	return c.CheckConclusionsValue
+}
+
+func (c *MockPullContext) AppID() int64 {
+	return c.AppIDValue
+}
+
+func (c *MockPullContext) Deployments(ctx context.Context) ([]*pull.Deployment, error) {
+	return c.DeploymentsValue, c.DeploymentsErrValue
+}
+
 func (c *MockPullContext) Comments(ctx context.Context) ([]string, error) {
 	return c.CommentValue, c.CommentErrValue
 }
@@ -109,14 +213,44 @@ func (c *MockPullContext) Commits(ctx context.Context) ([]*pull.Commit, error) {
 	return c.CommitsValue, c.CommitsErrValue
 }
 
+func (c *MockPullContext) DiffStats(ctx context.Context) (*pull.DiffStats, error) {
+	if c.DiffStatsValue == nil {
+		return &pull.DiffStats{}, c.DiffStatsErrValue
+	}
+	return c.DiffStatsValue, c.DiffStatsErrValue
+}
+
+func (c *MockPullContext) ChangedFiles(ctx context.Context) ([]string, error) {
+	return c.ChangedFilesValue, c.ChangedFilesErrValue
+}
+
+func (c *MockPullContext) ChangedFileSizes(ctx context.Context) (map[string]int64, error) {
+	return c.ChangedFileSizesValue, c.ChangedFileSizesErrValue
+}
+
+func (c *MockPullContext) CodeOwners(ctx context.Context) (*pull.CodeOwners, error) {
+	return c.CodeOwnersValue, c.CodeOwnersErrValue
+}
+
 func (c *MockPullContext) RequiredStatuses(ctx context.Context) ([]string, error) {
 	return c.RequiredStatusesValue, c.RequiredStatusesErrValue
 }
 
+func (c *MockPullContext) MergeRequirements(ctx context.Context) (*pull.MergeRequirements, error) {
+	if c.MergeRequirementsValue == nil && c.MergeRequirementsErrValue == nil {
+		return &pull.MergeRequirements{}, nil
+	}
+	return c.MergeRequirementsValue, c.MergeRequirementsErrValue
+}
+
 func (c *MockPullContext) PushRestrictions(ctx context.Context) (bool, error) {
 	return c.PushRestrictionsValue, c.PushRestrictionsErrValue
 }
 
+func (c *MockPullContext) RequiresUpToDate(ctx context.Context) (bool, error) {
+	return c.RequiresUpToDateValue, c.RequiresUpToDateErrValue
+}
+
 func (c *MockPullContext) CurrentSuccessStatuses(ctx context.Context) ([]string, error) {
 	return c.SuccessStatusesValue, c.SuccessStatusesErrValue
 }
@@ -129,6 +263,14 @@ func (c *MockPullContext) IsTargeted(ctx context.Context) (bool, error) {
 	return c.IsTargetedValue, c.IsTargetedErrValue
 }
 
+func (c *MockPullContext) DefaultBranch() string {
+	return c.DefaultBranchValue
+}
+
+func (c *MockPullContext) HeadBranch(ctx context.Context) (*pull.Branch, error) {
+	return c.HeadBranchValue, c.HeadBranchErrValue
+}
+
 func (c *MockPullContext) IsDraft(ctx context.Context) bool {
 	return c.IsDraftValue
 }
@@ -137,5 +279,45 @@ func (c *MockPullContext) AutoMerge(ctx context.Context) bool {
 	return c.AutoMergeValue
 }
 
+func (c *MockPullContext) Reviews(ctx context.Context) ([]*pull.Review, error) {
+	return c.ReviewsValue, c.ReviewsErrValue
+}
+
+func (c *MockPullContext) RequestedReviewers() []string {
+	return c.RequestedReviewersValue
+}
+
+func (c *MockPullContext) TeamMembers(ctx context.Context, org, team string) ([]string, error) {
+	return c.TeamMembersValue[org+"/"+team], c.TeamMembersErrValue
+}
+
+func (c *MockPullContext) Reference(ctx context.Context, owner, repo string, number int) (*pull.Reference, error) {
+	key := fmt.Sprintf("%s/%s#%d", owner, repo, number)
+	if ref, ok := c.ReferencesValue[key]; ok {
+		return ref, c.ReferencesErrValue
+	}
+	if c.ReferencesErrValue != nil {
+		return nil, c.ReferencesErrValue
+	}
+	return nil, errors.Wrapf(pull.ErrNotFound, "issue %s", key)
+}
+
+func (c *MockPullContext) HasTicketTracker() bool {
+	return !c.NoTicketTrackerValue
+}
+
+func (c *MockPullContext) TicketStatus(ctx context.Context, key string) (string, error) {
+	if c.NoTicketTrackerValue {
+		return "", errors.New("no issue tracker is configured")
+	}
+	if c.TicketStatusesErrValue != nil {
+		return "", c.TicketStatusesErrValue
+	}
+	if status, ok := c.TicketStatusesValue[key]; ok {
+		return status, nil
+	}
+	return "", errors.Wrapf(pull.ErrNotFound, "ticket %s", key)
+}
+
 // type assertion
 var _ pull.Context = &MockPullContext{}
@@ -0,0 +1,147 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+// Types of repository ruleset rules that affect merging.
+const (
+	ruleRequiredStatusChecks  = "required_status_checks"
+	rulePullRequest           = "pull_request"
+	ruleRequiredSignatures    = "required_signatures"
+	ruleRequiredLinearHistory = "required_linear_history"
+)
+
+// branchRule is a rule from a repository ruleset that applies to a branch.
+type branchRule struct {
+	Type       string          `json:"type"`
+	Parameters json.RawMessage `json:"parameters"`
+}
+
+type statusChecksParameters struct {
+	RequiredStatusChecks []struct {
+		Context string `json:"context"`
+	} `json:"required_status_checks"`
+	Strict bool `json:"strict_required_status_checks_policy"`
+}
+
+type pullRequestParameters struct {
+	RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+	RequireCodeOwnerReview       bool `json:"require_code_owner_review"`
+	DismissStaleReviewsOnPush    bool `json:"dismiss_stale_reviews_on_push"`
+}
+
+func (ghc *GithubContext) MergeRequirements(ctx context.Context) (*MergeRequirements, error) {
+	if ghc.requirements != nil {
+		return ghc.requirements, nil
+	}
+
+	if ghc.branchProtection == nil {
+		if err := ghc.loadBranchProtection(ctx); err != nil {
+			return nil, err
+		}
+	}
+	rules, err := ghc.listBranchRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &MergeRequirements{}
+	p := ghc.branchProtection
+	if checks := p.GetRequiredStatusChecks(); checks != nil {
+		r.RequiredStatuses = append(r.RequiredStatuses, checks.Contexts...)
+		r.RequiresUpToDate = checks.Strict
+	}
+	if reviews := p.GetRequiredPullRequestReviews(); reviews != nil {
+		r.RequiredApprovingReviews = reviews.RequiredApprovingReviewCount
+		r.RequiresCodeOwnerReviews = reviews.RequireCodeOwnerReviews
+		r.DismissesStaleReviews = reviews.DismissStaleReviews
+	}
+	r.RequiresSignedCommits = p.RequiredSignatures.GetEnabled()
+	r.RequiresLinearHistory = p.GetRequireLinearHistory() != nil && p.GetRequireLinearHistory().Enabled
+
+	for _, rule := range rules {
+		switch rule.Type {
+		case ruleRequiredStatusChecks:
+			var params statusChecksParameters
+			if err := json.Unmarshal(rule.Parameters, &params); err != nil {
+				return nil, errors.Wrapf(err, "invalid %s rule", rule.Type)
+			}
+			for _, check := range params.RequiredStatusChecks {
+				if !contains(r.RequiredStatuses, check.Context) {
+					r.RequiredStatuses = append(r.RequiredStatuses, check.Context)
+				}
+			}
+			r.RequiresUpToDate = r.RequiresUpToDate || params.Strict
+
+		case rulePullRequest:
+			var params pullRequestParameters
+			if err := json.Unmarshal(rule.Parameters, &params); err != nil {
+				return nil, errors.Wrapf(err, "invalid %s rule", rule.Type)
+			}
+			if params.RequiredApprovingReviewCount > r.RequiredApprovingReviews {
+				r.RequiredApprovingReviews = params.RequiredApprovingReviewCount
+			}
+			r.RequiresCodeOwnerReviews = r.RequiresCodeOwnerReviews || params.RequireCodeOwnerReview
+			r.DismissesStaleReviews = r.DismissesStaleReviews || params.DismissStaleReviewsOnPush
+
+		case ruleRequiredSignatures:
+			r.RequiresSignedCommits = true
+
+		case ruleRequiredLinearHistory:
+			r.RequiresLinearHistory = true
+		}
+	}
+
+	ghc.requirements = r
+	return r, nil
+}
+
+// listBranchRules lists the rules from active repository rulesets that apply
+// to the target branch. It returns no rules if rulesets are not available,
+// like on older versions of GitHub Enterprise.
+func (ghc *GithubContext) listBranchRules(ctx context.Context) ([]*branchRule, error) {
+	u := fmt.Sprintf("repos/%s/%s/rules/branches/%s?per_page=100", ghc.owner, ghc.repo, url.PathEscape(ghc.pr.GetBase().GetRef()))
+	req, err := ghc.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create rules request")
+	}
+
+	var rules []*branchRule
+	if _, err := ghc.client.Do(ctx, req, &rules); err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, wrapAPIError(err, "cannot list rules for %s", ghc.Locator())
+	}
+	return rules, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
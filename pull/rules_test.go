@@ -0,0 +1,113 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeRequirements(t *testing.T) {
+	newContext := func(t *testing.T, protection, rules string) Context {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/repos/testorg/testrepo/branches/develop/protection", func(w http.ResponseWriter, r *http.Request) {
+			if protection == "" {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message": "Branch not protected"}`))
+				return
+			}
+			_, _ = w.Write([]byte(protection))
+		})
+		mux.HandleFunc("/repos/testorg/testrepo/rules/branches/develop", func(w http.ResponseWriter, r *http.Request) {
+			if rules == "" {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+				return
+			}
+			_, _ = w.Write([]byte(rules))
+		})
+
+		srv := httptest.NewServer(mux)
+		t.Cleanup(srv.Close)
+
+		client := github.NewClient(nil)
+		client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+		return NewGithubContext(client, &github.PullRequest{
+			Number: github.Int(1),
+			Base: &github.PullRequestBranch{
+				Ref:  github.String("develop"),
+				Repo: &github.Repository{Name: github.String("testrepo"), Owner: &github.User{Login: github.String("testorg")}},
+			},
+		})
+	}
+
+	t.Run("unprotected", func(t *testing.T) {
+		r, err := newContext(t, "", "").MergeRequirements(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, &MergeRequirements{}, r)
+	})
+
+	t.Run("combined", func(t *testing.T) {
+		protection := `{
+			"required_status_checks": {"strict": false, "contexts": ["ci/build"]},
+			"required_pull_request_reviews": {"required_approving_review_count": 1},
+			"required_signatures": {"enabled": true}
+		}`
+		rules := `[
+			{"type": "required_status_checks", "parameters": {"strict_required_status_checks_policy": true, "required_status_checks": [{"context": "ci/build"}, {"context": "ci/test"}]}},
+			{"type": "pull_request", "parameters": {"required_approving_review_count": 2, "require_code_owner_review": true, "dismiss_stale_reviews_on_push": true}},
+			{"type": "required_linear_history"},
+			{"type": "deletion"}
+		]`
+
+		ctx := newContext(t, protection, rules)
+		r, err := ctx.MergeRequirements(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, &MergeRequirements{
+			RequiredStatuses:         []string{"ci/build", "ci/test"},
+			RequiresUpToDate:         true,
+			RequiredApprovingReviews: 2,
+			RequiresCodeOwnerReviews: true,
+			DismissesStaleReviews:    true,
+			RequiresSignedCommits:    true,
+			RequiresLinearHistory:    true,
+		}, r)
+
+		statuses, err := ctx.RequiredStatuses(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ci/build", "ci/test"}, statuses)
+	})
+
+	t.Run("dismissStaleReviews", func(t *testing.T) {
+		protection := `{
+			"required_pull_request_reviews": {"required_approving_review_count": 1, "dismiss_stale_reviews": true}
+		}`
+
+		r, err := newContext(t, protection, "").MergeRequirements(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, &MergeRequirements{
+			RequiredApprovingReviews: 1,
+			DismissesStaleReviews:    true,
+		}, r)
+	})
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// ErrSearchRateLimited is returned when the search API rate limit, which is
+// separate from and much lower than the limit of other REST API requests, is
+// exhausted.
+var ErrSearchRateLimited = errors.New("search API rate limit exceeded")
+
+// SearchOpenPullRequestsForSHA returns the open pull requests where the head
+// of the source branch matches the given SHA, using the search API. Search
+// finds pull requests by any commit they contain, so each result is fetched
+// to check its head. If the search rate limit is exhausted, it returns an
+// error wrapping ErrSearchRateLimited.
+func SearchOpenPullRequestsForSHA(ctx context.Context, client *github.Client, owner, repoName, SHA string) ([]*github.PullRequest, error) {
+	query := fmt.Sprintf("repo:%s/%s is:pr is:open %s", owner, repoName, SHA)
+	result, _, err := client.Search.Issues(ctx, query, &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 100}})
+	if err != nil {
+		switch errors.Cause(err).(type) {
+		case *github.RateLimitError, *github.AbuseRateLimitError:
+			return nil, errors.Wrapf(ErrSearchRateLimited, "failed to search pull requests for %s", SHA)
+		}
+		return nil, wrapAPIError(err, "failed to search pull requests for %s", SHA)
+	}
+
+	var results []*github.PullRequest
+	for _, issue := range result.Issues {
+		pr, _, err := client.PullRequests.Get(ctx, owner, repoName, issue.GetNumber())
+		if err != nil {
+			return results, wrapAPIError(err, "failed to get pull request %s/%s#%d", owner, repoName, issue.GetNumber())
+		}
+		if pr.GetState() == "open" && pr.GetHead().GetSHA() == SHA {
+			results = append(results, pr)
+		}
+	}
+	return results, nil
+}
+
+// GetAllPossibleOpenPullRequestsForSHA returns the open pull requests where the
+// head of the source branch matches the given SHA. It tries each strategy in
+// order of cost and returns the results of the first that finds any:
+//
+//  1. the pull requests that GitHub associates with the commit
+//  2. listing all open pull requests, like ListOpenPullRequestsForSHA
+//  3. searching for the SHA, which finds pull requests from forks with
+//     rewritten history that GitHub does not associate with the commit
+//
+// A search that fails because the search rate limit is exhausted is logged and
+// treated as finding nothing, so it does not fail the lookup.
+func GetAllPossibleOpenPullRequestsForSHA(ctx context.Context, client *github.Client, owner, repoName, SHA string, opts ...ListOption) ([]*github.PullRequest, error) {
+	logger := zerolog.Ctx(ctx)
+
+	associated, _, err := client.PullRequests.ListPullRequestsWithCommit(ctx, owner, repoName, SHA, &github.PullRequestListOptions{
+		State:       "open",
+		ListOptions: github.ListOptions{PerPage: 100},
+	})
+	if err != nil {
+		return nil, wrapAPIError(err, "failed to list pull requests for commit %s", SHA)
+	}
+
+	var results []*github.PullRequest
+	for _, pr := range associated {
+		if pr.GetState() == "open" && pr.GetHead().GetSHA() == SHA {
+			results = append(results, pr)
+		}
+	}
+	if len(results) > 0 {
+		return results, nil
+	}
+
+	results, err = ListOpenPullRequestsForSHA(ctx, client, owner, repoName, SHA, opts...)
+	if err != nil || len(results) > 0 {
+		return results, err
+	}
+
+	results, err = SearchOpenPullRequestsForSHA(ctx, client, owner, repoName, SHA)
+	if errors.Is(err, ErrSearchRateLimited) {
+		logger.Debug().Msgf("Not searching for pull requests with head %s: %s", SHA, err)
+		return nil, nil
+	}
+	return results, err
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAllPossibleOpenPullRequestsForSHA(t *testing.T) {
+	tests := map[string]struct {
+		Associated  []*github.PullRequest
+		Listed      []*github.PullRequest
+		Searched    []*github.PullRequest
+		RateLimited bool
+
+		Numbers  []int
+		Searches int
+	}{
+		"associated": {
+			Associated: []*github.PullRequest{testPR(1, "develop", "a"), testPR(2, "develop", "b")},
+			Listed:     []*github.PullRequest{testPR(3, "develop", "a")},
+			Numbers:    []int{1},
+		},
+		"listed": {
+			Listed:  []*github.PullRequest{testPR(2, "develop", "b"), testPR(3, "develop", "a")},
+			Numbers: []int{3},
+		},
+		"searched": {
+			Searched: []*github.PullRequest{testPR(4, "develop", "a"), testPR(5, "develop", "b")},
+			Numbers:  []int{4},
+			Searches: 1,
+		},
+		"searchRateLimited": {
+			RateLimited: true,
+			Searches:    1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			searches := 0
+			byNumber := make(map[int]*github.PullRequest)
+			for _, pr := range test.Searched {
+				byNumber[pr.GetNumber()] = pr
+			}
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/repos/testorg/testrepo/commits/a/pulls", func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewEncoder(w).Encode(test.Associated)
+			})
+			mux.Handle("/repos/testorg/testrepo/pulls", &pullsEndpoint{Pages: [][]*github.PullRequest{test.Listed}})
+			mux.HandleFunc("/repos/testorg/testrepo/pulls/", func(w http.ResponseWriter, r *http.Request) {
+				for number, pr := range byNumber {
+					if r.URL.Path == "/repos/testorg/testrepo/pulls/"+strconv.Itoa(number) {
+						_ = json.NewEncoder(w).Encode(pr)
+						return
+					}
+				}
+				http.NotFound(w, r)
+			})
+			mux.HandleFunc("/search/issues", func(w http.ResponseWriter, r *http.Request) {
+				searches++
+				assert.Equal(t, "repo:testorg/testrepo is:pr is:open a", r.URL.Query().Get("q"))
+				if test.RateLimited {
+					w.Header().Set("X-RateLimit-Limit", "30")
+					w.Header().Set("X-RateLimit-Remaining", "0")
+					w.Header().Set("X-RateLimit-Resource", "search")
+					w.WriteHeader(http.StatusForbidden)
+					_, _ = w.Write([]byte(`{"message": "API rate limit exceeded"}`))
+					return
+				}
+				var issues []*github.Issue
+				for _, pr := range test.Searched {
+					issues = append(issues, &github.Issue{Number: pr.Number})
+				}
+				_ = json.NewEncoder(w).Encode(&github.IssuesSearchResult{Total: github.Int(len(issues)), Issues: issues})
+			})
+
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			client := github.NewClient(srv.Client())
+			client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+			prs, err := GetAllPossibleOpenPullRequestsForSHA(context.Background(), client, "testorg", "testrepo", "a")
+			require.NoError(t, err)
+			assert.Equal(t, test.Numbers, numbers(prs))
+			assert.Equal(t, test.Searches, searches)
+		})
+	}
+}
+
+func TestSearchOpenPullRequestsForSHARateLimited(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/search/issues", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "30")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "API rate limit exceeded"}`))
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	_, err := SearchOpenPullRequestsForSHA(context.Background(), client, "testorg", "testrepo", "a")
+	assert.True(t, errors.Is(err, ErrSearchRateLimited), "unexpected error: %v", err)
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+type teamKey struct {
+	org  string
+	team string
+}
+
+type teamEntry struct {
+	members []string
+	expires time.Time
+}
+
+// TeamMembershipCache stores the members of teams for a fixed amount of time,
+// so that evaluating many pull requests does not list the same team each
+// time. Teams are identified by organization, and each organization has a
+// single installation of the app, so entries are never shared between
+// installations. It is safe for concurrent use.
+type TeamMembershipCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[teamKey]teamEntry
+}
+
+func NewTeamMembershipCache(ttl time.Duration) *TeamMembershipCache {
+	return &TeamMembershipCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[teamKey]teamEntry),
+	}
+}
+
+func (c *TeamMembershipCache) get(org, team string) ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := newTeamKey(org, team)
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.members, true
+}
+
+func (c *TeamMembershipCache) add(org, team string, members []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+
+	c.entries[newTeamKey(org, team)] = teamEntry{
+		members: members,
+		expires: now.Add(c.ttl),
+	}
+}
+
+func newTeamKey(org, team string) teamKey {
+	return teamKey{
+		org:  strings.ToLower(org),
+		team: strings.ToLower(team),
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamMembershipCache(t *testing.T) {
+	var requests int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/orgs/testorg/teams/maintainers/members", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`[{"login": "alice"}, {"login": "bob"}]`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	clock := &testClock{t: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	cache := NewTeamMembershipCache(time.Minute)
+	cache.now = clock.Now
+
+	ctx := context.Background()
+	newContext := func() Context {
+		return NewGithubContext(client, testPR(1, "develop", "abc"), WithTeamMembershipCache(cache))
+	}
+
+	members, err := newContext().TeamMembers(ctx, "testorg", "maintainers")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "bob"}, members)
+
+	members, err = newContext().TeamMembers(ctx, "TestOrg", "Maintainers")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "bob"}, members)
+	assert.Equal(t, 1, requests, "team members were not cached between contexts")
+
+	clock.t = clock.t.Add(time.Minute)
+
+	_, err = newContext().TeamMembers(ctx, "testorg", "maintainers")
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests, "expired team members were cached")
+}
@@ -0,0 +1,210 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ratelimit tracks the remaining GitHub API rate limit of each
+// installation and defers low priority requests when it is low, so that
+// merges can still happen when many pull requests are updated at once.
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/go-githubapp/githubapp"
+	"github.com/pkg/errors"
+	"github.com/rcrowley/go-metrics"
+)
+
+const (
+	// DefaultReserve is the number of requests kept for normal priority
+	// requests if no reserve is configured.
+	DefaultReserve = 500
+
+	MetricsKeyDeferred = "github.requests.deferred"
+)
+
+// ErrDeferred is returned for low priority requests while the rate limit of
+// the installation is low.
+var ErrDeferred = errors.New("request deferred because the installation rate limit is low")
+
+type Priority int
+
+const (
+	// PriorityNormal requests are always sent, like merges and the
+	// evaluations that lead to them.
+	PriorityNormal Priority = iota
+
+	// PriorityLow requests are deferred when the rate limit is low, like
+	// updates and listing pull requests in background sweeps.
+	PriorityLow
+)
+
+type priorityKey struct{}
+
+// WithPriority returns a context where GitHub requests have the priority.
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// PriorityFromContext returns the priority in the context or PriorityNormal.
+func PriorityFromContext(ctx context.Context) Priority {
+	p, _ := ctx.Value(priorityKey{}).(Priority)
+	return p
+}
+
+type state struct {
+	limit     int
+	remaining int
+	reset     time.Time
+
+	// blockedUntil is set when GitHub responds with a secondary rate limit
+	blockedUntil time.Time
+}
+
+// Budget tracks the rate limit of each installation from the headers of its
+// responses. It is safe for concurrent use.
+type Budget struct {
+	reserve  int
+	deferred metrics.Counter
+	now      func() time.Time
+
+	mu     sync.Mutex
+	states map[int64]state
+}
+
+// NewBudget creates a budget that defers low priority requests when an
+// installation has fewer than reserve requests remaining. If reserve is not
+// positive, it uses DefaultReserve. If registry is not nil, deferred requests
+// are counted in it.
+func NewBudget(reserve int, registry metrics.Registry) *Budget {
+	if reserve <= 0 {
+		reserve = DefaultReserve
+	}
+	b := &Budget{
+		reserve: reserve,
+		now:     time.Now,
+		states:  make(map[int64]state),
+	}
+	if registry != nil {
+		b.deferred = metrics.GetOrRegisterCounter(MetricsKeyDeferred, registry)
+	}
+	return b
+}
+
+// Observe updates the state of the installation from a response. Responses
+// from APIs with a separate rate limit, like search, are ignored, so that
+// their much lower limit does not defer other requests.
+func (b *Budget) Observe(installationID int64, res *http.Response) {
+	if resource := res.Header.Get("X-RateLimit-Resource"); resource != "" && resource != "core" {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	s := b.states[installationID]
+	if limit, err := strconv.Atoi(res.Header.Get("X-RateLimit-Limit")); err == nil {
+		s.limit = limit
+	}
+	if remaining, err := strconv.Atoi(res.Header.Get("X-RateLimit-Remaining")); err == nil {
+		s.remaining = remaining
+	}
+	if reset, err := strconv.ParseInt(res.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		s.reset = time.Unix(reset, 0)
+	}
+	if res.StatusCode == http.StatusForbidden || res.StatusCode == http.StatusTooManyRequests {
+		if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil {
+			s.blockedUntil = b.now().Add(time.Duration(seconds) * time.Second)
+		}
+	}
+	b.states[installationID] = s
+}
+
+// Low returns true if low priority requests for the installation should be
+// deferred.
+func (b *Budget) Low(installationID int64) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	s, ok := b.states[installationID]
+	if !ok {
+		return false
+	}
+	now := b.now()
+	if now.Before(s.blockedUntil) {
+		return true
+	}
+	return s.limit > 0 && s.remaining < b.reserve && now.Before(s.reset)
+}
+
+// Transport wraps a transport for an installation to observe its responses
+// and defer low priority requests.
+func (b *Budget) Transport(installationID int64, next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if PriorityFromContext(r.Context()) == PriorityLow && b.Low(installationID) {
+			if b.deferred != nil {
+				b.deferred.Inc(1)
+			}
+			return nil, ErrDeferred
+		}
+
+		res, err := next.RoundTrip(r)
+		if res != nil {
+			b.Observe(installationID, res)
+		}
+		return res, err
+	})
+}
+
+// ClientCreator wraps a client creator so that installation clients use the
+// budget. Only REST API clients are wrapped, because the GraphQL API has a
+// separate rate limit.
+func (b *Budget) ClientCreator(cc githubapp.ClientCreator) githubapp.ClientCreator {
+	return &clientCreator{ClientCreator: cc, budget: b}
+}
+
+type clientCreator struct {
+	githubapp.ClientCreator
+	budget *Budget
+}
+
+func (c *clientCreator) NewInstallationClient(installationID int64) (*github.Client, error) {
+	client, err := c.ClientCreator.NewInstallationClient(installationID)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := client.Client()
+	next := httpClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	httpClient.Transport = c.budget.Transport(installationID, next)
+
+	wrapped := github.NewClient(httpClient)
+	wrapped.BaseURL = client.BaseURL
+	wrapped.UploadURL = client.UploadURL
+	wrapped.UserAgent = client.UserAgent
+	return wrapped, nil
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (fn roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return fn(r)
+}
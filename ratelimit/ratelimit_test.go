@@ -0,0 +1,131 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudgetLow(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	reset := strconv.FormatInt(now.Add(time.Hour).Unix(), 10)
+
+	response := func(status int, headers map[string]string) *http.Response {
+		res := &http.Response{StatusCode: status, Header: http.Header{}}
+		for k, v := range headers {
+			res.Header.Set(k, v)
+		}
+		return res
+	}
+
+	tests := map[string]struct {
+		Responses []*http.Response
+		Low       bool
+	}{
+		"unknown": {
+			Low: false,
+		},
+		"aboveReserve": {
+			Responses: []*http.Response{
+				response(200, map[string]string{"X-RateLimit-Limit": "5000", "X-RateLimit-Remaining": "1000", "X-RateLimit-Reset": reset}),
+			},
+			Low: false,
+		},
+		"belowReserve": {
+			Responses: []*http.Response{
+				response(200, map[string]string{"X-RateLimit-Limit": "5000", "X-RateLimit-Remaining": "499", "X-RateLimit-Reset": reset}),
+			},
+			Low: true,
+		},
+		"belowReserveAfterReset": {
+			Responses: []*http.Response{
+				response(200, map[string]string{"X-RateLimit-Limit": "5000", "X-RateLimit-Remaining": "10", "X-RateLimit-Reset": strconv.FormatInt(now.Add(-time.Minute).Unix(), 10)}),
+			},
+			Low: false,
+		},
+		"secondaryRateLimit": {
+			Responses: []*http.Response{
+				response(200, map[string]string{"X-RateLimit-Limit": "5000", "X-RateLimit-Remaining": "4000", "X-RateLimit-Reset": reset}),
+				response(403, map[string]string{"Retry-After": "60"}),
+			},
+			Low: true,
+		},
+		"otherResource": {
+			Responses: []*http.Response{
+				response(200, map[string]string{"X-RateLimit-Limit": "30", "X-RateLimit-Remaining": "0", "X-RateLimit-Reset": reset, "X-RateLimit-Resource": "search"}),
+			},
+			Low: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			b := NewBudget(0, nil)
+			b.now = func() time.Time { return now }
+			for _, res := range test.Responses {
+				b.Observe(1, res)
+			}
+			assert.Equal(t, test.Low, b.Low(1))
+			assert.False(t, b.Low(2), "other installations are not affected")
+		})
+	}
+}
+
+func TestBudgetTransport(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "100")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		fmt.Fprint(w, `{"number":1}`)
+	}))
+	defer srv.Close()
+
+	registry := metrics.NewRegistry()
+	b := NewBudget(500, registry)
+
+	client := github.NewClient(&http.Client{Transport: b.Transport(1, http.DefaultTransport)})
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	ctx := context.Background()
+	lowCtx := WithPriority(ctx, PriorityLow)
+
+	// the first request is sent because the rate limit is not known yet
+	_, _, err := client.PullRequests.Get(lowCtx, "testorg", "testrepo", 1)
+	require.NoError(t, err)
+
+	_, _, err = client.PullRequests.Get(lowCtx, "testorg", "testrepo", 1)
+	assert.True(t, errors.Is(err, ErrDeferred), "expected deferred error, got %v", err)
+
+	_, _, err = client.PullRequests.Get(ctx, "testorg", "testrepo", 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, int64(1), registry.Get(MetricsKeyDeferred).(metrics.Counter).Count())
+}
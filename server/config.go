@@ -16,9 +16,13 @@ package server
 
 import (
 	"os"
+	"regexp"
 
 	"github.com/c2h5oh/datasize"
+	"github.com/palantir/bulldozer/audit"
+	"github.com/palantir/bulldozer/cache"
 	"github.com/palantir/bulldozer/server/handler"
+	"github.com/palantir/bulldozer/tracing"
 	"github.com/palantir/go-baseapp/baseapp"
 	"github.com/palantir/go-baseapp/baseapp/datadog"
 	"github.com/palantir/go-githubapp/githubapp"
@@ -30,14 +34,32 @@ const (
 	DefaultEnvPrefix = "BULLDOZER_"
 )
 
+var appNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
 type Config struct {
 	Server  baseapp.HTTPConfig `yaml:"server"`
 	Github  githubapp.Config   `yaml:"github"`
 	Options handler.Options    `yaml:"options"`
 	Logging LoggingConfig      `yaml:"logging"`
 	Datadog datadog.Config     `yaml:"datadog"`
+	Tracing tracing.Config     `yaml:"tracing"`
+	Audit   audit.Config       `yaml:"audit"`
 	Cache   CacheConfig        `yaml:"cache"`
 	Workers WorkerConfig       `yaml:"workers"`
+
+	// Apps are additional GitHub Apps served by the same server, like an app
+	// on a GitHub Enterprise instance. The app in the github section is the
+	// primary app.
+	Apps []AppConfig `yaml:"apps"`
+}
+
+// AppConfig defines an additional GitHub App. The app receives webhooks at
+// the default webhook route followed by "/" and its name.
+type AppConfig struct {
+	// Name identifies the app in webhook routes, metrics, and logs. It may
+	// only contain lowercase letters, numbers, and dashes.
+	Name   string           `yaml:"name"`
+	Github githubapp.Config `yaml:"github"`
 }
 
 type LoggingConfig struct {
@@ -47,11 +69,22 @@ type LoggingConfig struct {
 
 type CacheConfig struct {
 	MaxSize datasize.ByteSize `yaml:"max_size"`
+
+	// Redis stores responses in Redis in addition to memory, so they are
+	// shared by all servers and survive restarts. If the address is empty,
+	// responses are only stored in memory.
+	Redis cache.RedisConfig `yaml:"redis"`
 }
 
 type WorkerConfig struct {
 	Workers   int `yaml:"workers"`
 	QueueSize int `yaml:"queue_size"`
+
+	// PerInstallation and PerRepository limit how many events and scheduled
+	// evaluations run at the same time for each installation and
+	// repository. If zero, there is no limit.
+	PerInstallation int `yaml:"per_installation"`
+	PerRepository   int `yaml:"per_repository"`
 }
 
 func ParseConfig(bytes []byte) (*Config, error) {
@@ -70,5 +103,16 @@ func ParseConfig(bytes []byte) (*Config, error) {
 
 	c.Options.SetValuesFromEnv(envPrefix + "OPTIONS_")
 
+	names := make(map[string]bool)
+	for _, app := range c.Apps {
+		if !appNamePattern.MatchString(app.Name) {
+			return nil, errors.Errorf("invalid app name %q: names may only contain lowercase letters, numbers, and dashes", app.Name)
+		}
+		if names[app.Name] {
+			return nil, errors.Errorf("duplicate app name %q", app.Name)
+		}
+		names[app.Name] = true
+	}
+
 	return &c, nil
 }
@@ -0,0 +1,490 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/palantir/go-baseapp/baseapp"
+	"github.com/palantir/go-githubapp/githubapp"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"goji.io"
+	"goji.io/pat"
+	"gopkg.in/yaml.v2"
+)
+
+// PauseStore saves pauses so that they survive restarts. Implementations
+// must be safe for concurrent use.
+type PauseStore interface {
+	// LoadPauses returns the saved pauses.
+	LoadPauses(ctx context.Context) ([]Pause, error)
+
+	// SavePauses replaces the saved pauses.
+	SavePauses(ctx context.Context, pauses []Pause) error
+}
+
+// Pauses records organizations, repositories, and pull requests where
+// bulldozer does not merge or update pull requests. Pauses are kept in
+// memory, so they end when the server restarts, unless they are loaded from
+// and saved to a store. It is safe for concurrent use.
+type Pauses struct {
+	mu     sync.Mutex
+	paused map[string]pause
+	store  PauseStore
+}
+
+// pause is when a pause started and when it ends. If until is zero, the pause
+// lasts until it is removed.
+type pause struct {
+	since time.Time
+	until time.Time
+}
+
+func NewPauses() *Pauses {
+	return &Pauses{paused: make(map[string]pause)}
+}
+
+// LoadPauses creates a Pauses that starts with the pauses in the store and
+// saves every change to it. Pauses that ended while the server was stopped
+// are dropped.
+func LoadPauses(ctx context.Context, store PauseStore) (*Pauses, error) {
+	saved, err := store.LoadPauses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p := NewPauses()
+	p.store = store
+	for _, s := range saved {
+		entry := pause{since: s.Since}
+		if s.Until != nil {
+			if !time.Now().Before(*s.Until) {
+				continue
+			}
+			entry.until = *s.Until
+		}
+		p.paused[s.Target] = entry
+	}
+	return p, nil
+}
+
+func pauseKey(owner, repo string) string {
+	if repo == "" {
+		return strings.ToLower(owner)
+	}
+	return strings.ToLower(owner + "/" + repo)
+}
+
+func pullRequestPauseKey(owner, repo string, number int) string {
+	return fmt.Sprintf("%s#%d", pauseKey(owner, repo), number)
+}
+
+// Pause pauses the repository, or all repositories owned by owner if repo is
+// empty, until the given time, or until it is resumed if until is zero.
+func (p *Pauses) Pause(ctx context.Context, owner, repo string, until time.Time) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.paused[pauseKey(owner, repo)] = pause{since: time.Now(), until: until}
+	return p.save(ctx)
+}
+
+// PausePullRequest pauses the pull request until the given time, or until it
+// is resumed if until is zero.
+func (p *Pauses) PausePullRequest(ctx context.Context, owner, repo string, number int, until time.Time) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.paused[pullRequestPauseKey(owner, repo, number)] = pause{since: time.Now(), until: until}
+	return p.save(ctx)
+}
+
+// ResumePullRequest removes a pause created by PausePullRequest.
+func (p *Pauses) ResumePullRequest(ctx context.Context, owner, repo string, number int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.paused, pullRequestPauseKey(owner, repo, number))
+	return p.save(ctx)
+}
+
+// Resume removes a pause created by Pause with the same owner and repo. It
+// does not resume a repository if its owner is also paused.
+func (p *Pauses) Resume(ctx context.Context, owner, repo string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.paused, pauseKey(owner, repo))
+	return p.save(ctx)
+}
+
+// save writes the pauses that have not ended to the store, if there is one.
+// The caller must hold p.mu.
+func (p *Pauses) save(ctx context.Context) error {
+	if p.store == nil {
+		return nil
+	}
+	return errors.Wrap(p.store.SavePauses(ctx, p.list()), "failed to save pauses")
+}
+
+// IsPaused returns true if the repository or its owner is paused. A nil
+// Pauses never pauses anything.
+func (p *Pauses) IsPaused(owner, repo string) bool {
+	if p == nil {
+		return false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.isPaused(pauseKey(owner, "")) || p.isPaused(pauseKey(owner, repo))
+}
+
+// IsPullRequestPaused returns true if the pull request, its repository, or its
+// owner is paused. A nil Pauses never pauses anything.
+func (p *Pauses) IsPullRequestPaused(owner, repo string, number int) bool {
+	if p == nil {
+		return false
+	}
+	if p.IsPaused(owner, repo) {
+		return true
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.isPaused(pullRequestPauseKey(owner, repo, number))
+}
+
+// isPaused returns true if there is a pause for the key that has not ended,
+// removing the pause if it has ended. The caller must hold p.mu.
+func (p *Pauses) isPaused(key string) bool {
+	pause, ok := p.paused[key]
+	if ok && !pause.until.IsZero() && !time.Now().Before(pause.until) {
+		delete(p.paused, key)
+		return false
+	}
+	return ok
+}
+
+// Pause is an organization, repository, or pull request that is paused. Pull
+// requests are formatted as "owner/repo#number".
+type Pause struct {
+	Target string    `json:"target"`
+	Since  time.Time `json:"since"`
+
+	// Until is when the pause ends. It is nil if the pause lasts until it is
+	// removed.
+	Until *time.Time `json:"until,omitempty"`
+}
+
+// Get returns the pause for the repository, or for its owner if only the
+// owner is paused. It returns false if neither is paused. A nil Pauses never
+// pauses anything.
+func (p *Pauses) Get(owner, repo string) (Pause, bool) {
+	if p == nil {
+		return Pause{}, false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, target := range []string{pauseKey(owner, repo), pauseKey(owner, "")} {
+		if p.isPaused(target) {
+			return p.paused[target].export(target), true
+		}
+	}
+	return Pause{}, false
+}
+
+// List returns all pauses that have not ended, sorted by target.
+func (p *Pauses) List() []Pause {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.list()
+}
+
+// list implements List. The caller must hold p.mu.
+func (p *Pauses) list() []Pause {
+	pauses := make([]Pause, 0, len(p.paused))
+	for target, pause := range p.paused {
+		if !p.isPaused(target) {
+			continue
+		}
+		pauses = append(pauses, pause.export(target))
+	}
+	sort.Slice(pauses, func(i, j int) bool { return pauses[i].Target < pauses[j].Target })
+	return pauses
+}
+
+func (p pause) export(target string) Pause {
+	entry := Pause{Target: target, Since: p.since}
+	if !p.until.IsZero() {
+		until := p.until
+		entry.Until = &until
+	}
+	return entry
+}
+
+// Admin serves an API for operators. All requests must include the token as
+// a bearer token in the Authorization header.
+type Admin struct {
+	Base
+	Token string
+
+	// DeadLetters are the webhook deliveries that failed to process. If nil,
+	// the dead letter endpoints return an error.
+	DeadLetters *DeadLetters
+}
+
+type adminPending struct {
+	Scheduled []QueueItem `json:"scheduled"`
+	Paused    []Pause     `json:"paused"`
+}
+
+// adminDeadLetter is a dead letter without its payload.
+type adminDeadLetter struct {
+	ID        string    `json:"id"`
+	EventType string    `json:"event_type"`
+	Error     string    `json:"error"`
+	Time      time.Time `json:"time"`
+	Attempts  int       `json:"attempts"`
+}
+
+type adminConfig struct {
+	Source string `json:"source"`
+	Path   string `json:"path"`
+	Ref    string `json:"ref"`
+	Config string `json:"config,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Handler returns a handler for the admin API. It must be mounted with a
+// wildcard pattern, like "/api/admin/*".
+func (a *Admin) Handler() http.Handler {
+	mux := goji.SubMux()
+	mux.Use(func(next http.Handler) http.Handler { return requireToken(a.Token, next.ServeHTTP) })
+
+	mux.HandleFunc(pat.Get("/pending"), a.pending)
+	mux.HandleFunc(pat.Post("/evaluate/:owner/:repo/:number"), a.evaluate)
+	mux.HandleFunc(pat.Post("/pause/:owner"), a.setPaused(true, false))
+	mux.HandleFunc(pat.Post("/pause/:owner/:repo"), a.setPaused(true, true))
+	mux.HandleFunc(pat.Post("/resume/:owner"), a.setPaused(false, false))
+	mux.HandleFunc(pat.Post("/resume/:owner/:repo"), a.setPaused(false, true))
+	mux.HandleFunc(pat.Get("/config/:owner/:repo"), a.config)
+	mux.HandleFunc(pat.Get("/deadletters"), a.listDeadLetters)
+	mux.HandleFunc(pat.Post("/deadletters/:id/replay"), a.replayDeadLetter)
+	mux.HandleFunc(pat.Delete("/deadletters/:id"), a.deleteDeadLetter)
+	return mux
+}
+
+// requireToken returns a handler that calls next only if the request includes
+// token as a bearer token. If token is empty, it rejects all requests.
+func requireToken(token string, next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		given := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if token == "" || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			writeError(w, http.StatusUnauthorized, "invalid or missing admin token")
+			return
+		}
+		next(w, r)
+	})
+}
+
+func (a *Admin) pending(w http.ResponseWriter, r *http.Request) {
+	var res adminPending
+	if a.Scheduler != nil {
+		items, err := a.Scheduler.List(r.Context())
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		res.Scheduled = items
+	}
+	if a.Pauses != nil {
+		res.Paused = a.Pauses.List()
+	}
+	baseapp.WriteJSON(w, http.StatusOK, res)
+}
+
+func (a *Admin) evaluate(w http.ResponseWriter, r *http.Request) {
+	number, err := strconv.Atoi(pat.Param(r, "number"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid pull request number")
+		return
+	}
+	if a.Scheduler == nil {
+		writeError(w, http.StatusServiceUnavailable, "evaluations cannot be scheduled")
+		return
+	}
+
+	ref := PullRequestRef{Owner: pat.Param(r, "owner"), Repo: pat.Param(r, "repo"), Number: number}
+	zerolog.Ctx(r.Context()).Info().Msgf("Scheduling evaluation of %s/%s#%d by admin request", ref.Owner, ref.Repo, ref.Number)
+	a.Scheduler.Schedule(r.Context(), ref, time.Now())
+	baseapp.WriteJSON(w, http.StatusAccepted, ref)
+}
+
+func (a *Admin) setPaused(paused, hasRepo bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if a.Pauses == nil {
+			writeError(w, http.StatusServiceUnavailable, "pausing is not enabled")
+			return
+		}
+
+		owner, repo := pat.Param(r, "owner"), ""
+		if hasRepo {
+			repo = pat.Param(r, "repo")
+		}
+
+		var until time.Time
+		if ttl := r.URL.Query().Get("ttl"); paused && ttl != "" {
+			d, err := time.ParseDuration(ttl)
+			if err != nil || d <= 0 {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid ttl %q", ttl))
+				return
+			}
+			until = time.Now().Add(d)
+		}
+
+		var err error
+		action := "Resumed"
+		if paused {
+			action = "Paused"
+			err = a.Pauses.Pause(r.Context(), owner, repo, until)
+		} else {
+			err = a.Pauses.Resume(r.Context(), owner, repo)
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		logger := zerolog.Ctx(r.Context())
+		if until.IsZero() {
+			logger.Info().Msgf("%s %s by admin request", action, pauseKey(owner, repo))
+		} else {
+			logger.Info().Msgf("%s %s until %s by admin request", action, pauseKey(owner, repo), until.UTC().Format(time.RFC3339))
+		}
+		baseapp.WriteJSON(w, http.StatusOK, a.Pauses.List())
+	}
+}
+
+func (a *Admin) config(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	owner, repo := pat.Param(r, "owner"), pat.Param(r, "repo")
+
+	appClient, err := a.NewAppClient()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	installation, err := githubapp.NewInstallationsService(appClient).GetByRepository(ctx, owner, repo)
+	if err != nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no installation for %s/%s: %s", owner, repo, err))
+		return
+	}
+	client, err := a.NewInstallationClient(installation.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ref := r.URL.Query().Get("ref")
+	if ref == "" {
+		repository, _, err := client.Repositories.Get(ctx, owner, repo)
+		if err != nil {
+			writeError(w, http.StatusBadGateway, fmt.Sprintf("failed to get repository %s/%s: %s", owner, repo, err))
+			return
+		}
+		ref = repository.GetDefaultBranch()
+	}
+
+	fc := a.ConfigFetcher.Config(ctx, client, owner, repo, ref)
+	res := adminConfig{Source: fc.Source, Path: fc.Path, Ref: ref}
+	switch {
+	case fc.LoadError != nil:
+		res.Error = fc.LoadError.Error()
+	case fc.ParseError != nil:
+		res.Error = fc.ParseError.Error()
+	case fc.Config != nil:
+		b, err := yaml.Marshal(fc.Config)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		res.Config = string(b)
+	}
+	baseapp.WriteJSON(w, http.StatusOK, res)
+}
+
+func (a *Admin) listDeadLetters(w http.ResponseWriter, r *http.Request) {
+	if a.DeadLetters == nil {
+		writeError(w, http.StatusServiceUnavailable, "dead letters are not enabled")
+		return
+	}
+
+	letters, err := a.DeadLetters.List(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res := make([]adminDeadLetter, 0, len(letters))
+	for _, l := range letters {
+		res = append(res, adminDeadLetter{ID: l.ID, EventType: l.EventType, Error: l.Error, Time: l.Time, Attempts: l.Attempts})
+	}
+	baseapp.WriteJSON(w, http.StatusOK, res)
+}
+
+func (a *Admin) replayDeadLetter(w http.ResponseWriter, r *http.Request) {
+	if a.DeadLetters == nil {
+		writeError(w, http.StatusServiceUnavailable, "dead letters are not enabled")
+		return
+	}
+
+	id := pat.Param(r, "id")
+	zerolog.Ctx(r.Context()).Info().Msgf("Replaying delivery %s by admin request", id)
+
+	switch err := a.DeadLetters.Replay(r.Context(), id); {
+	case err == ErrDeadLetterNotFound:
+		writeError(w, http.StatusNotFound, err.Error())
+	case err != nil:
+		writeError(w, http.StatusBadGateway, fmt.Sprintf("replay failed: %s", err))
+	default:
+		baseapp.WriteJSON(w, http.StatusOK, map[string]string{"id": id})
+	}
+}
+
+func (a *Admin) deleteDeadLetter(w http.ResponseWriter, r *http.Request) {
+	if a.DeadLetters == nil {
+		writeError(w, http.StatusServiceUnavailable, "dead letters are not enabled")
+		return
+	}
+
+	id := pat.Param(r, "id")
+	switch err := a.DeadLetters.Delete(r.Context(), id); {
+	case err == ErrDeadLetterNotFound:
+		writeError(w, http.StatusNotFound, err.Error())
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	default:
+		zerolog.Ctx(r.Context()).Info().Msgf("Deleted dead letter %s by admin request", id)
+		baseapp.WriteJSON(w, http.StatusOK, map[string]string{"id": id})
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	baseapp.WriteJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goji.io"
+	"goji.io/pat"
+)
+
+func TestAdmin(t *testing.T) {
+	evaluated := make(chan PullRequestRef, 1)
+	scheduler := NewScheduler(NewMemoryQueue(), func(ctx context.Context, ref PullRequestRef) error {
+		evaluated <- ref
+		return nil
+	})
+	scheduler.Schedule(context.Background(), PullRequestRef{Owner: "testorg", Repo: "testrepo", Number: 2}, time.Now().Add(time.Hour))
+
+	deadLetters := NewDeadLetters(NewMemoryDeadLetterStore(0))
+	failing := &failingHandler{err: errors.New("github is down")}
+	_ = deadLetters.Wrap(failing).Handle(context.Background(), "status", "delivery-1", []byte(`{}`))
+
+	admin := &Admin{
+		Base:        Base{Scheduler: scheduler, Pauses: NewPauses()},
+		Token:       "secret",
+		DeadLetters: deadLetters,
+	}
+	mux := goji.NewMux()
+	mux.Handle(pat.New("/api/admin/*"), admin.Handler())
+
+	do := func(method, path, token string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("unauthorized", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/api/admin/pending", "").Code)
+		assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/api/admin/pending", "wrong").Code)
+	})
+
+	t.Run("pause", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, do(http.MethodPost, "/api/admin/pause/TestOrg/testrepo", "secret").Code)
+		assert.True(t, admin.Pauses.IsPaused("testorg", "testrepo"))
+		assert.False(t, admin.Pauses.IsPaused("testorg", "otherrepo"))
+
+		assert.Equal(t, http.StatusOK, do(http.MethodPost, "/api/admin/pause/testorg", "secret").Code)
+		assert.True(t, admin.Pauses.IsPaused("testorg", "otherrepo"))
+
+		assert.Equal(t, http.StatusOK, do(http.MethodPost, "/api/admin/resume/testorg", "secret").Code)
+		assert.False(t, admin.Pauses.IsPaused("testorg", "otherrepo"))
+		assert.True(t, admin.Pauses.IsPaused("testorg", "testrepo"))
+	})
+
+	t.Run("pauseTTL", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/api/admin/pause/otherorg?ttl=soon", "secret").Code)
+		assert.False(t, admin.Pauses.IsPaused("otherorg", "otherrepo"))
+
+		assert.Equal(t, http.StatusOK, do(http.MethodPost, "/api/admin/pause/otherorg?ttl=1h", "secret").Code)
+		pause, ok := admin.Pauses.Get("otherorg", "otherrepo")
+		require.True(t, ok, "organization pause does not apply to the repository")
+		assert.Equal(t, "otherorg", pause.Target)
+		require.NotNil(t, pause.Until)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), *pause.Until, time.Minute)
+
+		assert.Equal(t, http.StatusOK, do(http.MethodPost, "/api/admin/resume/otherorg", "secret").Code)
+		assert.False(t, admin.Pauses.IsPaused("otherorg", "otherrepo"))
+	})
+
+	t.Run("pending", func(t *testing.T) {
+		w := do(http.MethodGet, "/api/admin/pending", "secret")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var res adminPending
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		require.Len(t, res.Scheduled, 1)
+		assert.Equal(t, 2, res.Scheduled[0].Ref.Number)
+		require.Len(t, res.Paused, 1)
+		assert.Equal(t, "testorg/testrepo", res.Paused[0].Target)
+	})
+
+	t.Run("evaluate", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/api/admin/evaluate/testorg/testrepo/abc", "secret").Code)
+		assert.Equal(t, http.StatusAccepted, do(http.MethodPost, "/api/admin/evaluate/testorg/testrepo/1", "secret").Code)
+
+		select {
+		case ref := <-evaluated:
+			assert.Equal(t, PullRequestRef{Owner: "testorg", Repo: "testrepo", Number: 1}, ref)
+		case <-time.After(5 * time.Second):
+			t.Fatal("pull request was not evaluated")
+		}
+	})
+
+	t.Run("deadLetters", func(t *testing.T) {
+		w := do(http.MethodGet, "/api/admin/deadletters", "secret")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var letters []adminDeadLetter
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &letters))
+		require.Len(t, letters, 1)
+		assert.Equal(t, "delivery-1", letters[0].ID)
+		assert.Equal(t, "github is down", letters[0].Error)
+
+		assert.Equal(t, http.StatusBadGateway, do(http.MethodPost, "/api/admin/deadletters/delivery-1/replay", "secret").Code)
+		failing.err = nil
+		assert.Equal(t, http.StatusOK, do(http.MethodPost, "/api/admin/deadletters/delivery-1/replay", "secret").Code)
+		assert.Equal(t, http.StatusNotFound, do(http.MethodPost, "/api/admin/deadletters/delivery-1/replay", "secret").Code)
+		assert.Equal(t, http.StatusNotFound, do(http.MethodDelete, "/api/admin/deadletters/delivery-1", "secret").Code)
+	})
+}
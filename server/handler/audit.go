@@ -0,0 +1,75 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/palantir/bulldozer/audit"
+	"github.com/palantir/bulldozer/pull"
+	"github.com/palantir/go-githubapp/githubapp"
+)
+
+// recordMerge records the outcome of evaluating a pull request for merging
+// in the evaluations metric and the audit log.
+func (b *Base) recordMerge(ctx context.Context, pullCtx pull.Context, decision, reason string) {
+	if e, ok := ctx.Value(evaluationKey{}).(*evaluation); ok {
+		e.decision, e.reason = decision, reason
+	}
+	b.countEvaluation(decision)
+	b.record(ctx, pullCtx, audit.ActionMerge, decision, reason)
+}
+
+// recordUpdate records the outcome of evaluating a pull request for updating
+// in the audit log.
+func (b *Base) recordUpdate(ctx context.Context, pullCtx pull.Context, decision, reason string) {
+	b.record(ctx, pullCtx, audit.ActionUpdate, decision, reason)
+}
+
+func (b *Base) record(ctx context.Context, pullCtx pull.Context, action audit.Action, decision, reason string) {
+	r := audit.Record{
+		Action:   action,
+		Owner:    pullCtx.Owner(),
+		Repo:     pullCtx.Repo(),
+		Number:   pullCtx.Number(),
+		SHA:      pullCtx.HeadSHA(),
+		Decision: decision,
+		Reason:   reason,
+	}
+	b.AuditLogger.Record(ctx, r)
+	b.LastActions.Add(r)
+}
+
+// Audited wraps an event handler to add the sender and delivery of each
+// event to the context, so that audit records identify what caused them.
+func Audited(h githubapp.EventHandler) githubapp.EventHandler {
+	return &auditedHandler{EventHandler: h}
+}
+
+type auditedHandler struct {
+	githubapp.EventHandler
+}
+
+func (h *auditedHandler) Handle(ctx context.Context, eventType, deliveryID string, payload []byte) error {
+	var event struct {
+		Sender struct {
+			Login string `json:"login"`
+		} `json:"sender"`
+	}
+	_ = json.Unmarshal(payload, &event)
+
+	return h.EventHandler.Handle(audit.WithEvent(ctx, event.Sender.Login, deliveryID), eventType, deliveryID, payload)
+}
@@ -16,13 +16,22 @@ package handler
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/audit"
 	"github.com/palantir/bulldozer/bulldozer"
+	"github.com/palantir/bulldozer/notify"
 	"github.com/palantir/bulldozer/pull"
+	"github.com/palantir/bulldozer/ratelimit"
+	"github.com/palantir/bulldozer/tracing"
 	"github.com/palantir/go-githubapp/githubapp"
 	"github.com/pkg/errors"
+	"github.com/rcrowley/go-metrics"
 	"github.com/rs/zerolog"
+	"github.com/shurcooL/githubv4"
 )
 
 type Base struct {
@@ -31,25 +40,224 @@ type Base struct {
 	ConfigFetcher            *ConfigFetcher
 	PushRestrictionUserToken string
 	DisableUpdateFeature     bool
+
+	// DryRun comments on pull requests instead of merging or updating them,
+	// regardless of the repository configuration
+	DryRun bool
+
+	// MergeTrain serializes merges for repositories that enable merge trains.
+	// It must be shared by all handlers.
+	MergeTrain *bulldozer.MergeTrain
+
+	// TeamMembershipCache stores team members for signals that check team
+	// membership. It must be shared by all handlers.
+	TeamMembershipCache *pull.TeamMembershipCache
+
+	// CodeOwnersCache stores the CODEOWNERS files of branches for signals
+	// that check code owner approvals. It must be shared by all handlers.
+	CodeOwnersCache *pull.CodeOwnersCache
+
+	// CheckConclusions sets the results of check run conclusions. If nil,
+	// the defaults are used.
+	CheckConclusions pull.CheckConclusions
+
+	// Scheduler evaluates pull requests again when a blackout window or a
+	// merge delay ends or to retry failed merges. If nil, these pull
+	// requests wait for the next webhook.
+	Scheduler *Scheduler
+
+	// DelayTracker records when pull requests became ready to merge for
+	// repositories that delay merges. It must be shared by all handlers.
+	DelayTracker *bulldozer.DelayTracker
+
+	// RetryTracker counts failed merges for repositories that retry merges.
+	// It must be shared by all handlers.
+	RetryTracker *bulldozer.RetryTracker
+
+	// RerunTracker counts check runs that were requested again for
+	// repositories that rerun failed checks. It must be shared by all
+	// handlers.
+	RerunTracker *bulldozer.RerunTracker
+
+	// Notifier sends notifications to the sinks defined by the server. If
+	// nil, no notifications are sent.
+	Notifier *notify.Notifier
+
+	// Registry records metrics about merges, updates, and evaluations. If
+	// nil, no metrics are recorded.
+	Registry metrics.Registry
+
+	// AuditLogger records the outcome of every evaluation. If nil, no audit
+	// records are written.
+	AuditLogger *audit.Logger
+
+	// Pauses are organizations and repositories where pull requests are not
+	// merged or updated. If nil, nothing is paused.
+	Pauses *Pauses
+
+	// LastActions remembers the outcome of the most recent evaluation of
+	// each pull request for the status dashboard. If nil, outcomes are not
+	// remembered.
+	LastActions *LastActions
+
+	// Limiter limits concurrent evaluations for each installation and
+	// repository. If nil, there are no limits.
+	Limiter *Limiter
+
+	// SelfModification controls which ref of pull requests configuration is
+	// read from and whether pull requests that change configuration files
+	// may be merged. Its paths must include the configuration paths.
+	SelfModification SelfModification
+
+	// Dependents remembers the pull requests that depend on other issues and
+	// pull requests, to evaluate them again when their dependencies close.
+	// It must be shared by all handlers. If nil, dependents wait for the
+	// next webhook.
+	Dependents *Dependents
+
+	// Policies constrain the configuration of repositories in each
+	// organization. The "*" key applies to organizations without their own
+	// policy. Pull requests in repositories with configuration that breaks
+	// the policy are not evaluated.
+	Policies map[string]bulldozer.Policy
+
+	// AppID is the ID of the GitHub App, used to ignore check suites that
+	// contain the check run bulldozer publishes and to tell bulldozer's check
+	// runs apart from others with similar names. If zero, these check suites
+	// are evaluated like any other and check runs are told apart by name.
+	AppID int64
+
+	// DebounceInterval delays evaluations caused by events, so that events
+	// for the same pull request that arrive within the interval are
+	// evaluated once. If zero, events are evaluated immediately.
+	DebounceInterval time.Duration
+
+	// ConfigurationPaths are the paths of the configuration file in
+	// repositories, in order. Pull requests that change one of the files get
+	// a check run that validates the first changed file. If empty, changes
+	// are not validated.
+	ConfigurationPaths []string
+
+	// Signer signs the commits that bulldozer creates. If nil, commits are
+	// not signed.
+	Signer *bulldozer.CommitSigner
+
+	// PullRequestCache stores the open pull requests for commits found while
+	// handling status events. It must be shared by all handlers. If nil,
+	// lookups are not cached.
+	PullRequestCache *pull.LookupCache
+
+	// TicketTracker looks up the status of tickets for the tickets signal.
+	// If nil, the signal matches every pull request that refers to a ticket.
+	TicketTracker pull.TicketTracker
+
+	// PolicyBot is the policy-bot server that explanations read unapproved
+	// approval rules from. If its URL is empty, rules are not read.
+	PolicyBot bulldozer.PolicyBotServer
+
+	// KillSwitch disables bulldozer for repositories that opt out with a
+	// topic or a file. Disabled repositories are treated as if they have no
+	// configuration.
+	KillSwitch KillSwitch
+}
+
+// NewPullContext creates a context for evaluating the pull request.
+func (b *Base) NewPullContext(client *github.Client, pr *github.PullRequest) pull.Context {
+	var opts []pull.ContextOption
+	if b.TeamMembershipCache != nil {
+		opts = append(opts, pull.WithTeamMembershipCache(b.TeamMembershipCache))
+	}
+	if b.CodeOwnersCache != nil {
+		opts = append(opts, pull.WithCodeOwnersCache(b.CodeOwnersCache))
+	}
+	if b.CheckConclusions != nil {
+		opts = append(opts, pull.WithCheckConclusions(b.CheckConclusions))
+	}
+	if b.TicketTracker != nil {
+		opts = append(opts, pull.WithTicketTracker(b.TicketTracker))
+	}
+	if b.AppID != 0 {
+		opts = append(opts, pull.WithAppID(b.AppID))
+	}
+	return pull.NewGithubContext(client, pr, opts...)
+}
+
+// OpenPullRequestsForSHA returns the open pull requests where the head of the
+// source branch matches the SHA, using the cache if there is one.
+func (b *Base) OpenPullRequestsForSHA(ctx context.Context, client *github.Client, owner, repo, sha string) ([]*github.PullRequest, error) {
+	if b.PullRequestCache != nil {
+		return b.PullRequestCache.GetAllPossibleOpenPullRequestsForSHA(ctx, client, owner, repo, sha)
+	}
+	return pull.GetAllPossibleOpenPullRequestsForSHA(ctx, client, owner, repo, sha)
+}
+
+// invalidatePullRequests removes the cached pull requests for the SHAs, which
+// may have changed. It does nothing if there is no cache.
+func (b *Base) invalidatePullRequests(owner, repo string, shas ...string) {
+	if b.PullRequestCache == nil {
+		return
+	}
+	for _, sha := range shas {
+		if sha != "" {
+			b.PullRequestCache.Invalidate(owner, repo, sha)
+		}
+	}
 }
 
 func (b *Base) FetchConfigForPR(ctx context.Context, client *github.Client, pr *github.PullRequest) (*bulldozer.Config, error) {
 	owner := pr.GetBase().GetRepo().GetOwner().GetLogin()
 	repo := pr.GetBase().GetRepo().GetName()
 	ref := pr.GetBase().GetRef()
-	return b.FetchConfig(ctx, client, owner, repo, ref)
+
+	// pull request payloads include the topics of the repository, so they do
+	// not need to be looked up
+	topics := pr.GetBase().GetRepo().Topics
+	if topics == nil {
+		topics = []string{}
+	}
+	return b.fetchConfig(ctx, client, owner, repo, ref, b.SelfModification.configRef(pr), topics)
 }
 
 func (b *Base) FetchConfig(ctx context.Context, client *github.Client, owner, repo, ref string) (*bulldozer.Config, error) {
+	return b.fetchConfig(ctx, client, owner, repo, ref, ref, nil)
+}
+
+// fetchConfig loads the configuration for the branch ref from configRef,
+// which is the same as ref unless configuration is read from the head of
+// pull requests.
+func (b *Base) fetchConfig(ctx context.Context, client *github.Client, owner, repo, ref, configRef string, topics []string) (*bulldozer.Config, error) {
 	logger := zerolog.Ctx(ctx)
 
-	fc := b.ConfigFetcher.Config(ctx, client, owner, repo, ref)
+	reason, err := b.KillSwitch.Reason(ctx, client, owner, repo, ref, topics)
+	if err != nil {
+		return nil, err
+	}
+	if reason != "" {
+		logger.Info().Msgf("Bulldozer is disabled for the repository because %s", reason)
+		return nil, nil
+	}
+
+	fetchCtx, span := tracing.Start(ctx, "fetch config", tracing.SpanKindInternal,
+		tracing.String("github.repository", owner+"/"+repo),
+		tracing.String("github.ref", configRef),
+	)
+	fc := b.ConfigFetcher.Config(fetchCtx, client, owner, repo, configRef)
+	span.RecordError(fc.LoadError)
+	span.RecordError(fc.ParseError)
+	span.End()
+
 	switch {
 	case fc.LoadError != nil:
 		return nil, errors.Wrapf(fc.LoadError, "failed to load configuration: %s: %s", fc.Source, fc.Path)
 
 	case fc.ParseError != nil:
 		logger.Warn().Msgf("Invalid configuration in %s: %s", fc.Source, fc.Path)
+		b.Notifier.Notify(ctx, nil, notify.Event{
+			Type:    notify.ConfigError,
+			Owner:   owner,
+			Repo:    repo,
+			Message: fmt.Sprintf("invalid configuration in %s: %s: %s", fc.Source, fc.Path, fc.ParseError),
+		})
 		return nil, nil
 
 	case fc.Config == nil:
@@ -57,10 +265,36 @@ func (b *Base) FetchConfig(ctx context.Context, client *github.Client, owner, re
 		return nil, nil
 	}
 
-	return fc.Config, nil
+	config := *fc.Config.ForBranch(strings.TrimPrefix(ref, "refs/heads/"))
+	if violations := b.policyViolations(owner, &config); len(violations) > 0 {
+		logger.Warn().Msgf("Configuration in %s: %s breaks the organization policy: %s", fc.Source, fc.Path, strings.Join(violations, "; "))
+		b.Notifier.Notify(ctx, nil, notify.Event{
+			Type:    notify.ConfigError,
+			Owner:   owner,
+			Repo:    repo,
+			Message: fmt.Sprintf("configuration in %s: %s breaks the organization policy: %s", fc.Source, fc.Path, strings.Join(violations, "; ")),
+		})
+		return nil, nil
+	}
+	config.Merge.Signer = b.Signer
+	config.Update.Signer = b.Signer
+	return &config, nil
+}
+
+func (b *Base) ProcessPullRequest(ctx context.Context, pullCtx pull.Context, client *github.Client, v4client *githubv4.Client, config *bulldozer.Config, pr *github.PullRequest) error {
+	if config == nil || !config.CheckRun || b.DryRun || config.DryRun {
+		return b.processPullRequest(ctx, pullCtx, client, v4client, config, pr)
+	}
+
+	var e evaluation
+	err := b.processPullRequest(withEvaluation(ctx, &e), pullCtx, client, v4client, config, pr)
+	if e.decision != "" {
+		b.publishCheckRun(ctx, pullCtx, client, config, e)
+	}
+	return err
 }
 
-func (b *Base) ProcessPullRequest(ctx context.Context, pullCtx pull.Context, client *github.Client, config *bulldozer.Config, pr *github.PullRequest) error {
+func (b *Base) processPullRequest(ctx context.Context, pullCtx pull.Context, client *github.Client, v4client *githubv4.Client, config *bulldozer.Config, pr *github.PullRequest) error {
 	logger := zerolog.Ctx(ctx)
 
 	if config == nil {
@@ -68,27 +302,671 @@ func (b *Base) ProcessPullRequest(ctx context.Context, pullCtx pull.Context, cli
 		return nil
 	}
 
+	ref := PullRequestRef{Owner: pullCtx.Owner(), Repo: pullCtx.Repo(), Number: pullCtx.Number()}
+	b.Dependents.Set(ref, bulldozer.ParseDependencies(pullCtx.Body(), pullCtx.Owner(), pullCtx.Repo()))
+
+	if reason := b.pauseReason(pullCtx); reason != "" {
+		logger.Info().Msgf("Not merging pull request because %s", reason)
+		b.recordMerge(ctx, pullCtx, outcomePaused, reason)
+		return nil
+	}
+
+	file, err := b.SelfModification.deniedChange(ctx, pullCtx)
+	if err != nil {
+		return err
+	}
+	if file != "" {
+		reason := fmt.Sprintf("not mergeable because pull request changes the configuration file %q", file)
+		logger.Info().Msgf("Not merging pull request because it changes the configuration file %q", file)
+		b.recordMerge(ctx, pullCtx, outcomeNotReady, reason)
+		return nil
+	}
+
+	if b.DryRun || config.DryRun {
+		b.recordMerge(ctx, pullCtx, outcomeDryRun, "")
+		return bulldozer.DryRunMergePR(ctx, pullCtx, client, config.Merge)
+	}
+
+	merger, err := b.newMerger(client, v4client)
+	if err != nil {
+		return err
+	}
+
+	if rollup := config.Merge.Rollup; rollup != nil && bulldozer.IsRollupPR(pullCtx, *rollup) {
+		rollupConfig := bulldozer.RollupMergeConfig(config.Merge)
+		shouldMerge, reason, err := b.shouldMerge(ctx, pullCtx, rollupConfig)
+		if err != nil {
+			return err
+		}
+		if !shouldMerge {
+			b.recordMerge(ctx, pullCtx, outcomeNotReady, reason)
+			return nil
+		}
+		if allowed, _, err := b.checkExternalPolicy(ctx, pullCtx, config, reason); err != nil || !allowed {
+			return err
+		}
+		result := b.mergePR(ctx, pullCtx, merger, rollupConfig, reason)
+		b.finishMerge(ctx, pullCtx, client, merger, rollupConfig, config.Notifications, result)
+		return nil
+	}
+
+	shouldMerge, reason, err := b.shouldMerge(ctx, pullCtx, config.Merge)
+	if err != nil {
+		return err
+	}
+	if !shouldMerge {
+		end, held, err := bulldozer.BlackoutEnd(config.Merge.BlackoutWindows, time.Now())
+		if err != nil {
+			return errors.Wrap(err, "unable to determine blackout windows")
+		}
+		if held {
+			// the pull request is still ready to merge, so do not reset the delay
+			logger.Debug().Msgf("Scheduling evaluation after the blackout window ends at %s", end.Format(time.RFC3339))
+			b.schedule(ctx, pullCtx, end)
+			b.recordMerge(ctx, pullCtx, outcomeWaiting, reason)
+			return nil
+		}
+		if b.DelayTracker != nil {
+			b.DelayTracker.Reset(pullCtx)
+		}
+		rerun, err := b.rerunChecks(ctx, pullCtx, client, config.Merge)
+		if err != nil {
+			logger.Error().Err(err).Msg("Failed to request failed check runs again")
+		}
+		if len(rerun) > 0 {
+			b.recordMerge(ctx, pullCtx, outcomeWaiting, fmt.Sprintf("%s and waiting for failed checks to run again: [%s]", reason, strings.Join(rerun, ",")))
+			return nil
+		}
+		if err := bulldozer.ReportDeniedFiles(ctx, pullCtx, client, config.Merge); err != nil {
+			logger.Error().Err(err).Msg("Failed to report denied files")
+		}
+		if at := bulldozer.MinAgeReachedAt(pullCtx, config.Merge); !at.IsZero() {
+			// no event may arrive when the pull request becomes old enough
+			logger.Debug().Msgf("Scheduling evaluation after the pull request reaches the minimum age at %s", at.Format(time.RFC3339))
+			b.schedule(ctx, pullCtx, at)
+		}
+		b.recordMerge(ctx, pullCtx, outcomeNotReady, reason)
+		return nil
+	}
+
+	if rollup := config.Merge.Rollup; rollup != nil {
+		rolledUp, err := b.updateRollup(ctx, pullCtx, client, config.Merge, *rollup)
+		if err != nil {
+			return err
+		}
+		if rolledUp {
+			b.recordMerge(ctx, pullCtx, outcomeRolledUp, fmt.Sprintf("%s and added to a rollup pull request", reason))
+			return nil
+		}
+	}
+
+	problems, err := bulldozer.LintCommitMessage(ctx, pullCtx, config.Merge)
+	if err != nil {
+		return errors.Wrap(err, "unable to check commit message")
+	}
+	if len(problems) > 0 {
+		if err := bulldozer.ReportCommitLint(ctx, pullCtx, client, config.Merge.CommitLint, problems); err != nil {
+			logger.Error().Err(err).Msg("Failed to report commit message problems")
+		}
+		if b.DelayTracker != nil {
+			b.DelayTracker.Reset(pullCtx)
+		}
+		b.recordMerge(ctx, pullCtx, outcomeNotReady, fmt.Sprintf("not mergeable because the squash commit message breaks rules: %s", strings.Join(problems, "; ")))
+		return nil
+	}
+
+	if config.Merge.Delay > 0 && b.DelayTracker != nil {
+		delay := time.Duration(config.Merge.Delay)
+		if readyAt := b.DelayTracker.Eligible(pullCtx, delay); time.Now().Before(readyAt) {
+			logger.Debug().Msgf("Waiting until %s to merge, after the pull request is ready to merge for %s", readyAt.Format(time.RFC3339), delay)
+			b.schedule(ctx, pullCtx, readyAt)
+			b.recordMerge(ctx, pullCtx, outcomeWaiting, fmt.Sprintf("%s and waiting until %s to merge", reason, readyAt.Format(time.RFC3339)))
+			return nil
+		}
+	}
+
+	readyAt, err := bulldozer.PreviewSquashCommit(ctx, pullCtx, client, config.Merge)
+	if err != nil {
+		return errors.Wrap(err, "unable to preview squash commit")
+	}
+	if time.Now().Before(readyAt) {
+		logger.Debug().Msgf("Waiting until %s to merge, after the objection window for the squash commit preview", readyAt.Format(time.RFC3339))
+		b.schedule(ctx, pullCtx, readyAt)
+		b.recordMerge(ctx, pullCtx, outcomeWaiting, fmt.Sprintf("%s and waiting until %s for objections to the squash commit", reason, readyAt.Format(time.RFC3339)))
+		return nil
+	}
+
+	if allowed, _, err := b.checkExternalPolicy(ctx, pullCtx, config, reason); err != nil || !allowed {
+		return err
+	}
+
+	trainCtx, release, err := b.joinMergeTrain(ctx, pullCtx, client, config.Merge)
+	if err != nil {
+		return err
+	}
+	if release != nil {
+		defer release()
+		pullCtx = trainCtx
+
+		allowed, trainReason, err := b.recheckAfterMergeTrain(ctx, pullCtx, config, config.Merge)
+		if err != nil || !allowed {
+			return err
+		}
+		reason = trainReason
+	}
+
+	if config.Merge.Drafts == bulldozer.DraftsReady && pullCtx.IsDraft(ctx) {
+		if err := bulldozer.MarkReadyForReview(ctx, pullCtx, v4client); err != nil {
+			return err
+		}
+	}
+
+	result := b.mergePR(ctx, pullCtx, merger, config.Merge, reason)
+	if b.DelayTracker != nil {
+		b.DelayTracker.Reset(pullCtx)
+	}
+	b.scheduleRetry(ctx, pullCtx, config.Merge, result.Retry)
+	b.finishMerge(ctx, pullCtx, client, merger, config.Merge, config.Notifications, result)
+	return nil
+}
+
+// joinMergeTrain waits until the pull request may merge into its base branch
+// if the merge train is enabled. It returns the latest state of the pull
+// request, which callers must evaluate again, and a function that lets the
+// next pull request merge. If the merge train is not enabled, it returns a nil
+// function.
+func (b *Base) joinMergeTrain(ctx context.Context, pullCtx pull.Context, client *github.Client, mergeConfig bulldozer.MergeConfig) (pull.Context, func(), error) {
+	// priorities only matter if there is a queue of pull requests waiting to
+	// merge, so configuring priorities also enables the merge train
+	if (!mergeConfig.MergeTrain && len(mergeConfig.Priority) == 0) || b.MergeTrain == nil {
+		return pullCtx, nil, nil
+	}
+
+	owner, repo := pullCtx.Owner(), pullCtx.Repo()
+	base, _ := pullCtx.Branches()
+
+	priority, err := bulldozer.DeterminePriority(ctx, pullCtx, mergeConfig)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to determine merge priority")
+	}
+
+	zerolog.Ctx(ctx).Debug().Msgf("Waiting for other merges into %s with priority %d", base, priority)
+	release, err := b.MergeTrain.Acquire(ctx, owner, repo, base, priority)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// another pull request may have merged while waiting, so evaluate the
+	// pull request again using its latest state
+	pr, _, err := client.PullRequests.Get(ctx, owner, repo, pullCtx.Number())
+	if err != nil {
+		release()
+		return nil, nil, errors.Wrapf(err, "failed to get pull request %s", pullCtx.Locator())
+	}
+	return b.NewPullContext(client, pr), release, nil
+}
+
+// recheckAfterMergeTrain evaluates the pull request again after it waited for
+// other merges in the merge train, since it may have been paused, changed, or
+// denied by the external policy in the meantime. If it may no longer merge,
+// it records the outcome and returns false and the reason. Otherwise, it
+// returns the reason it is mergeable.
+func (b *Base) recheckAfterMergeTrain(ctx context.Context, pullCtx pull.Context, config *bulldozer.Config, mergeConfig bulldozer.MergeConfig) (bool, string, error) {
+	logger := zerolog.Ctx(ctx)
+
+	if reason := b.pauseReason(pullCtx); reason != "" {
+		logger.Info().Msgf("Not merging pull request because %s", reason)
+		b.recordMerge(ctx, pullCtx, outcomePaused, reason)
+		return false, reason, nil
+	}
+
+	file, err := b.SelfModification.deniedChange(ctx, pullCtx)
+	if err != nil {
+		return false, "", err
+	}
+	if file != "" {
+		reason := fmt.Sprintf("not mergeable because pull request changes the configuration file %q", file)
+		logger.Info().Msgf("Not merging pull request because it changes the configuration file %q", file)
+		b.recordMerge(ctx, pullCtx, outcomeNotReady, reason)
+		return false, reason, nil
+	}
+
+	shouldMerge, reason, err := b.shouldMerge(ctx, pullCtx, mergeConfig)
+	if err != nil {
+		return false, "", err
+	}
+	if !shouldMerge {
+		logger.Debug().Msg("Pull request is no longer ready to merge after waiting for other merges")
+		b.recordMerge(ctx, pullCtx, outcomeNotReady, reason)
+		return false, reason, nil
+	}
+
+	allowed, denied, err := b.checkExternalPolicy(ctx, pullCtx, config, reason)
+	if err != nil || !allowed {
+		return false, denied, err
+	}
+	return true, reason, nil
+}
+
+// pauseReason describes why bulldozer is paused for the pull request. It
+// returns an empty string if the pull request is not paused.
+func (b *Base) pauseReason(pullCtx pull.Context) string {
+	switch {
+	case b.Pauses.IsPaused(pullCtx.Owner(), pullCtx.Repo()):
+		return "bulldozer is paused for the repository"
+	case b.Pauses.IsPullRequestPaused(pullCtx.Owner(), pullCtx.Repo(), pullCtx.Number()):
+		return "bulldozer is paused for the pull request"
+	}
+	return ""
+}
+
+// shouldMerge returns true if the pull request should be merged and the
+// reason for the decision.
+func (b *Base) shouldMerge(ctx context.Context, pullCtx pull.Context, mergeConfig bulldozer.MergeConfig) (bool, string, error) {
+	shouldMerge, reason, err := bulldozer.ExplainMergePR(ctx, pullCtx, mergeConfig)
+	if err != nil {
+		return false, "", errors.Wrap(err, "unable to determine merge status")
+	}
+	zerolog.Ctx(ctx).Debug().Msgf("%s is deemed %s", pullCtx.Locator(), reason)
+	return shouldMerge, reason, nil
+}
+
+// mergePR merges the pull request, recording a span, metrics, and an audit
+// record for the merge. The reason is why the pull request is mergeable.
+func (b *Base) mergePR(ctx context.Context, pullCtx pull.Context, merger bulldozer.Merger, mergeConfig bulldozer.MergeConfig, reason string) bulldozer.MergeResult {
+	mergeCtx, span := tracing.Start(ctx, "merge", tracing.SpanKindInternal, tracing.Int("github.pull_request", pullCtx.Number()))
+	result := bulldozer.MergePR(mergeCtx, pullCtx, merger, mergeConfig)
+	span.SetAttributes(tracing.Bool("bulldozer.merged", result.Merged))
+	span.RecordError(result.Err)
+	span.End()
+
+	b.count(MetricsKeyMergesAttempted)
+	if result.Merged {
+		b.count(MetricsKeyMergesSucceeded)
+		b.recordMerge(ctx, pullCtx, outcomeMerged, reason)
+	} else {
+		b.count(fmt.Sprintf("%s[reason:%s]", MetricsKeyMergesFailed, result.Reason()))
+		b.recordMerge(ctx, pullCtx, outcomeFailed, fmt.Sprintf("%s, but the merge failed: %v", reason, result.Err))
+	}
+	return result
+}
+
+// finishMerge sends notifications about the result of a merge. If the pull
+// request merged, it also schedules deletion of the head branch, retargets
+// stacked pull requests, opens backports, and runs the after merge actions.
+// Pull requests added to a merge queue are not merged yet, so they are
+// skipped.
+func (b *Base) finishMerge(ctx context.Context, pullCtx pull.Context, client *github.Client, merger bulldozer.Merger, mergeConfig bulldozer.MergeConfig, routes []notify.Route, result bulldozer.MergeResult) {
+	logger := zerolog.Ctx(ctx)
+
+	if !result.Merged {
+		// merges that are retried are only temporary failures
+		if !result.Retry && result.Err != nil {
+			b.notify(ctx, pullCtx, routes, notify.MergeFailed, fmt.Sprintf("bulldozer could not merge the pull request: %s", result.Err))
+		}
+		return
+	}
+	if result.Queued {
+		return
+	}
+
+	b.notify(ctx, pullCtx, routes, notify.MergeSucceeded, "bulldozer merged the pull request")
+	b.scheduleDelete(ctx, pullCtx, mergeConfig)
+
+	if mergeConfig.Cascade {
+		retargeted, err := bulldozer.RetargetStacked(ctx, pullCtx, client)
+		if err != nil {
+			logger.Error().Err(err).Msg("Failed to retarget stacked pull requests")
+		}
+		if len(retargeted) > 0 {
+			// the head branch was not deleted while other pull requests
+			// targeted it, so try again now that they are retargeted
+			if err == nil && mergeConfig.DeleteAfterMerge && mergeConfig.DeleteGracePeriod <= 0 {
+				bulldozer.DeleteHead(ctx, pullCtx, merger)
+			}
+			if b.Scheduler != nil {
+				for _, stacked := range retargeted {
+					b.Scheduler.Schedule(ctx, PullRequestRef{
+						Owner:  pullCtx.Owner(),
+						Repo:   pullCtx.Repo(),
+						Number: stacked.GetNumber(),
+					}, time.Now())
+				}
+			}
+		}
+	}
+
+	if len(mergeConfig.Backport) > 0 {
+		// the pull request includes the merge commit once it is merged
+		pr, _, err := client.PullRequests.Get(ctx, pullCtx.Owner(), pullCtx.Repo(), pullCtx.Number())
+		if err != nil {
+			err = errors.Wrap(err, "failed to get merged pull request")
+		}
+		var method bulldozer.MergeMethod
+		if err == nil {
+			method, err = bulldozer.DetermineMergeMethod(ctx, pullCtx, mergeConfig)
+		}
+		if err == nil {
+			err = bulldozer.BackportPR(ctx, pullCtx, client, mergeConfig.Backport, pr.GetMergeCommitSHA(), method, mergeConfig.Signer)
+		}
+		if err != nil {
+			logger.Error().Err(err).Msg("Failed to backport pull request")
+		}
+	}
+
+	if len(mergeConfig.AfterMerge) > 0 {
+		if err := bulldozer.RunAfterMerge(ctx, pullCtx, client, mergeConfig.AfterMerge); err != nil {
+			logger.Error().Err(err).Msg("Failed to run after merge actions")
+		}
+	}
+}
+
+// notify sends an event about the pull request to the sinks routed to by the
+// repository configuration.
+func (b *Base) notify(ctx context.Context, pullCtx pull.Context, routes []notify.Route, t notify.EventType, message string) {
+	b.Notifier.Notify(ctx, routes, notify.Event{
+		Type:    t,
+		Owner:   pullCtx.Owner(),
+		Repo:    pullCtx.Repo(),
+		Number:  pullCtx.Number(),
+		Title:   pullCtx.Title(),
+		Message: message,
+	})
+}
+
+// newMerger creates the merger used for all merges and branch deletions.
+func (b *Base) newMerger(client *github.Client, v4client *githubv4.Client) (bulldozer.Merger, error) {
 	merger := bulldozer.NewGitHubMerger(client)
 	if b.PushRestrictionUserToken != "" {
 		tokenClient, err := b.NewTokenClient(b.PushRestrictionUserToken)
 		if err != nil {
-			return errors.Wrap(err, "failed to create token client")
+			return nil, errors.Wrap(err, "failed to create token client")
 		}
 		merger = bulldozer.NewPushRestrictionMerger(merger, bulldozer.NewGitHubMerger(tokenClient))
 	}
+	return bulldozer.NewMergeQueueMerger(merger, v4client), nil
+}
+
+// updateRollup adds the pull request to a rollup if it matches the rollup
+// trigger and other pull requests targeting the same branch are also ready to
+// merge in a rollup. It returns false if the pull request should be merged
+// by itself.
+func (b *Base) updateRollup(ctx context.Context, pullCtx pull.Context, client *github.Client, mergeConfig bulldozer.MergeConfig, rollup bulldozer.RollupConfig) (bool, error) {
+	logger := zerolog.Ctx(ctx)
+
+	matchesRollup := func(pullCtx pull.Context) (bool, error) {
+		if !rollup.Trigger.Enabled() {
+			return true, nil
+		}
+		triggered, _, err := bulldozer.IsPRTriggered(ctx, pullCtx, rollup.Trigger)
+		return triggered, err
+	}
 
-	shouldMerge, err := bulldozer.ShouldMergePR(ctx, pullCtx, config.Merge)
+	matches, err := matchesRollup(pullCtx)
 	if err != nil {
-		return errors.Wrap(err, "unable to determine merge status")
+		return false, errors.Wrap(err, "unable to determine if pull request is triggered for rollup")
 	}
-	if shouldMerge {
-		bulldozer.MergePR(ctx, pullCtx, merger, config.Merge)
+	if !matches {
+		return false, nil
 	}
 
+	owner, repo := pullCtx.Owner(), pullCtx.Repo()
+	base, _ := pullCtx.Branches()
+
+	prs, err := pull.ListOpenNonDraftPullRequestsForRef(ctx, client, owner, repo, "refs/heads/"+base)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to list pull requests for rollup")
+	}
+
+	var eligible []*github.PullRequest
+	for _, pr := range prs {
+		otherCtx := b.NewPullContext(client, pr)
+		if bulldozer.IsRollupPR(otherCtx, rollup) {
+			continue
+		}
+
+		matches, err := matchesRollup(otherCtx)
+		if err != nil {
+			return false, errors.Wrap(err, "unable to determine if pull request is triggered for rollup")
+		}
+		if !matches {
+			continue
+		}
+
+		shouldMerge, err := bulldozer.ShouldMergePR(ctx, otherCtx, mergeConfig)
+		if err != nil {
+			return false, errors.Wrap(err, "unable to determine merge status")
+		}
+		if shouldMerge {
+			eligible = append(eligible, pr)
+		}
+	}
+
+	if len(eligible) < 2 {
+		logger.Debug().Msg("Merging pull request by itself because no other pull requests are ready to merge in a rollup")
+		return false, nil
+	}
+
+	_, err = bulldozer.UpdateRollup(ctx, client, owner, repo, base, eligible, rollup)
+	return true, err
+}
+
+// schedule evaluates the pull request again at the given time.
+func (b *Base) schedule(ctx context.Context, pullCtx pull.Context, at time.Time) {
+	if b.Scheduler == nil {
+		return
+	}
+	b.Scheduler.Schedule(ctx, PullRequestRef{
+		Owner:  pullCtx.Owner(),
+		Repo:   pullCtx.Repo(),
+		Number: pullCtx.Number(),
+	}, at)
+}
+
+// debounce schedules an evaluation of the pull request after the debounce
+// interval instead of evaluating it while handling the event, coalescing it
+// with evaluations scheduled by other events. It returns false if events are
+// not debounced and the caller should evaluate the pull request.
+func (b *Base) debounce(ctx context.Context, owner, repo string, number int) bool {
+	if b.DebounceInterval <= 0 || b.Scheduler == nil {
+		return false
+	}
+
+	logger := zerolog.Ctx(ctx)
+	ref := PullRequestRef{Owner: owner, Repo: repo, Number: number}
+	if b.Scheduler.Debounce(ctx, ref, time.Now().Add(b.DebounceInterval)) {
+		logger.Debug().Msgf("Scheduled evaluation in %s", b.DebounceInterval)
+	} else {
+		logger.Debug().Msg("Coalesced event with a scheduled evaluation")
+		b.count(MetricsKeyEventsCoalesced)
+	}
+	return true
+}
+
+// scheduleRetry evaluates the pull request again after a backoff if the merge
+// failed with a temporary error.
+func (b *Base) scheduleRetry(ctx context.Context, pullCtx pull.Context, mergeConfig bulldozer.MergeConfig, retry bool) {
+	if b.RetryTracker == nil {
+		return
+	}
+	if !retry {
+		b.RetryTracker.Reset(pullCtx)
+		return
+	}
+	if mergeConfig.Retry.MaxAttempts <= 0 {
+		return
+	}
+
+	logger := zerolog.Ctx(ctx)
+	backoff, ok := b.RetryTracker.Next(pullCtx, mergeConfig.Retry)
+	if !ok {
+		logger.Info().Msgf("Not retrying merge after %d attempts", mergeConfig.Retry.MaxAttempts)
+		return
+	}
+
+	logger.Info().Msgf("Retrying merge in %s (attempt %d of %d)", backoff, b.RetryTracker.Attempts(pullCtx), mergeConfig.Retry.MaxAttempts)
+	b.schedule(ctx, pullCtx, time.Now().Add(backoff))
+}
+
+// rerunChecks requests failed check runs again if they are the only reason
+// the pull request is not mergeable and they were not requested again too
+// many times for its head commit. It returns the names of the check runs.
+func (b *Base) rerunChecks(ctx context.Context, pullCtx pull.Context, client *github.Client, mergeConfig bulldozer.MergeConfig) ([]string, error) {
+	if b.RerunTracker == nil || mergeConfig.Rerun.MaxAttempts <= 0 {
+		return nil, nil
+	}
+
+	checkRuns, err := bulldozer.RerunnableChecks(ctx, pullCtx, mergeConfig)
+	if err != nil || len(checkRuns) == 0 {
+		return nil, err
+	}
+
+	names := make([]string, len(checkRuns))
+	for i, run := range checkRuns {
+		names[i] = run.Name
+	}
+
+	logger := zerolog.Ctx(ctx)
+	if !b.RerunTracker.Next(pullCtx, names, mergeConfig.Rerun.MaxAttempts) {
+		logger.Info().Msgf("Not requesting failed check runs again after %d attempts", mergeConfig.Rerun.MaxAttempts)
+		return nil, nil
+	}
+
+	logger.Info().Msgf("Requesting failed check runs again: [%s]", strings.Join(names, ","))
+	if err := bulldozer.RerunChecks(ctx, pullCtx, client, checkRuns); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// scheduleDelete evaluates the pull request again after the grace period for
+// deleting its head branch ends.
+func (b *Base) scheduleDelete(ctx context.Context, pullCtx pull.Context, mergeConfig bulldozer.MergeConfig) {
+	if !mergeConfig.DeleteAfterMerge || mergeConfig.DeleteGracePeriod <= 0 {
+		return
+	}
+
+	grace := time.Duration(mergeConfig.DeleteGracePeriod)
+	zerolog.Ctx(ctx).Debug().Msgf("Scheduling deletion of the head branch after the grace period of %s", grace)
+	b.schedule(ctx, pullCtx, time.Now().Add(grace))
+}
+
+// deleteHead deletes the head branch of a merged pull request once the
+// grace period for deleting it ends.
+func (b *Base) deleteHead(ctx context.Context, pullCtx pull.Context, client *github.Client, v4client *githubv4.Client, pr *github.PullRequest) error {
+	logger := zerolog.Ctx(ctx)
+
+	config, err := b.FetchConfigForPR(ctx, client, pr)
+	if err != nil {
+		return err
+	}
+	if config == nil || !config.Merge.DeleteAfterMerge || config.Merge.DeleteGracePeriod <= 0 {
+		logger.Debug().Msg("Doing nothing since pull request is merged")
+		return nil
+	}
+
+	if deleteAt := pr.GetMergedAt().Add(time.Duration(config.Merge.DeleteGracePeriod)); time.Now().Before(deleteAt) {
+		b.schedule(ctx, pullCtx, deleteAt)
+		return nil
+	}
+
+	merger, err := b.newMerger(client, v4client)
+	if err != nil {
+		return err
+	}
+	bulldozer.DeleteHead(ctx, pullCtx, merger)
 	return nil
 }
 
-func (b *Base) UpdatePullRequest(ctx context.Context, pullCtx pull.Context, client *github.Client, config *bulldozer.Config, pr *github.PullRequest, baseRef string) (bool, error) {
+// installationClient returns a client for the installation that contains the
+// repository.
+func (b *Base) installationClient(ctx context.Context, owner, repo string) (*github.Client, error) {
+	appClient, err := b.NewAppClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to instantiate github app client")
+	}
+
+	installation, err := githubapp.NewInstallationsService(appClient).GetByRepository(ctx, owner, repo)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get installation for %s/%s", owner, repo)
+	}
+
+	client, err := b.NewInstallationClient(installation.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to instantiate github client")
+	}
+	return client, nil
+}
+
+// EvaluatePullRequest updates and merges an open pull request, like the
+// handlers do when they receive an event for the pull request. It finds the
+// installation for the repository, so it can be called without an event.
+func (b *Base) EvaluatePullRequest(ctx context.Context, ref PullRequestRef) error {
+	appClient, err := b.NewAppClient()
+	if err != nil {
+		return errors.Wrap(err, "failed to instantiate github app client")
+	}
+
+	installation, err := githubapp.NewInstallationsService(appClient).GetByRepository(ctx, ref.Owner, ref.Repo)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get installation for %s/%s", ref.Owner, ref.Repo)
+	}
+
+	repo := &github.Repository{
+		Owner: &github.User{Login: github.String(ref.Owner)},
+		Name:  github.String(ref.Repo),
+	}
+	ctx, logger := githubapp.PreparePRContext(ctx, installation.ID, repo, ref.Number)
+
+	release, err := b.Limiter.Acquire(ctx, installation.ID, ref.Owner+"/"+ref.Repo)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	client, err := b.NewInstallationClient(installation.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to instantiate github client")
+	}
+
+	v4client, err := b.NewInstallationV4Client(installation.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to instantiate github v4 client")
+	}
+
+	pr, _, err := client.PullRequests.Get(ctx, ref.Owner, ref.Repo, ref.Number)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get pull request %s/%s#%d", ref.Owner, ref.Repo, ref.Number)
+	}
+	pullCtx := b.NewPullContext(client, pr)
+	if pr.GetMerged() {
+		return b.deleteHead(ctx, pullCtx, client, v4client, pr)
+	}
+	if pr.GetState() != "open" {
+		logger.Debug().Msg("Doing nothing since pull request is closed")
+		return nil
+	}
+
+	config, err := b.FetchConfigForPR(ctx, client, pr)
+	if err != nil {
+		return err
+	}
+
+	if !b.DisableUpdateFeature {
+		base, _ := pullCtx.Branches()
+		didUpdatePR, err := b.UpdatePullRequest(ctx, pullCtx, client, v4client, config, pr, base)
+		if err != nil {
+			logger.Error().Err(errors.WithStack(err)).Msg("Error updating pull request")
+		}
+		if didUpdatePR {
+			return nil
+		}
+	}
+
+	return b.ProcessPullRequest(ctx, pullCtx, client, v4client, config, pr)
+}
+
+func (b *Base) UpdatePullRequest(ctx context.Context, pullCtx pull.Context, client *github.Client, v4client *githubv4.Client, config *bulldozer.Config, pr *github.PullRequest, baseRef string) (bool, error) {
 	logger := zerolog.Ctx(ctx)
 
 	if config == nil {
@@ -96,15 +974,48 @@ func (b *Base) UpdatePullRequest(ctx context.Context, pullCtx pull.Context, clie
 		return false, nil
 	}
 
-	shouldUpdate, err := bulldozer.ShouldUpdatePR(ctx, pullCtx, config.Update)
+	if reason := b.pauseReason(pullCtx); reason != "" {
+		logger.Info().Msgf("Not updating pull request because %s", reason)
+		b.recordUpdate(ctx, pullCtx, outcomePaused, reason)
+		return false, nil
+	}
+
+	if b.DryRun || config.DryRun {
+		b.recordUpdate(ctx, pullCtx, outcomeDryRun, "")
+		return false, bulldozer.DryRunUpdatePR(ctx, pullCtx, client, config.Update, baseRef)
+	}
+
+	// updates are deferred when the rate limit is low, so that the
+	// remaining requests are used for merges
+	ctx = ratelimit.WithPriority(ctx, ratelimit.PriorityLow)
+
+	shouldUpdate, reason, err := bulldozer.ExplainUpdatePR(ctx, pullCtx, config.Update)
+	if errors.Is(err, ratelimit.ErrDeferred) {
+		logger.Info().Msg("Not updating pull request because the installation rate limit is low")
+		return false, nil
+	}
 	if err != nil {
 		return false, errors.Wrap(err, "unable to determine update status")
 	}
+	logger.Debug().Msgf("%s is deemed %s", pullCtx.Locator(), reason)
 
 	didUpdatePR := false
+	if !shouldUpdate {
+		b.recordUpdate(ctx, pullCtx, outcomeNotReady, reason)
+	}
 
 	if shouldUpdate {
-		didUpdatePR = bulldozer.UpdatePR(ctx, pullCtx, client, config.Update, baseRef)
+		updateCtx, span := tracing.Start(ctx, "update", tracing.SpanKindInternal, tracing.Int("github.pull_request", pullCtx.Number()))
+		result := bulldozer.UpdatePR(updateCtx, pullCtx, client, v4client, config.Update, baseRef)
+		span.SetAttributes(tracing.Bool("bulldozer.updated", result.Updated), tracing.Bool("bulldozer.conflict", result.Conflict))
+		span.End()
+
+		b.countUpdate(result)
+		b.recordUpdate(ctx, pullCtx, updateDecision(result), reason)
+		if result.Conflict {
+			b.notify(ctx, pullCtx, config.Notifications, notify.ConflictDetected, fmt.Sprintf("bulldozer could not update the pull request because it conflicts with %s", strings.TrimPrefix(baseRef, "refs/heads/")))
+		}
+		didUpdatePR = result.Updated
 	}
 
 	return didUpdatePR, nil
@@ -0,0 +1,157 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/bulldozer"
+	"github.com/palantir/bulldozer/pull"
+	"github.com/palantir/bulldozer/pull/pulltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateRollup(t *testing.T) {
+	var created *github.NewPullRequest
+	var merged []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/testorg/testrepo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			created = &github.NewPullRequest{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(created))
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"number": 10}`)
+		case r.URL.Query().Get("head") != "":
+			// no rollup pull request exists yet
+			fmt.Fprint(w, `[]`)
+		default:
+			fmt.Fprint(w, `[
+				{"number": 1, "state": "open", "head": {"ref": "feature-1", "sha": "a"}, "base": {"ref": "develop", "repo": {"name": "testrepo", "owner": {"login": "testorg"}}}},
+				{"number": 2, "state": "open", "head": {"ref": "feature-2", "sha": "b"}, "base": {"ref": "develop", "repo": {"name": "testrepo", "owner": {"login": "testorg"}}}},
+				{"number": 3, "state": "open", "head": {"ref": "feature-3", "sha": "c"}, "base": {"ref": "main", "repo": {"name": "testrepo", "owner": {"login": "testorg"}}}}
+			]`)
+		}
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/commits/", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/status"):
+			fmt.Fprint(w, `{"state": "pending", "statuses": []}`)
+		case strings.HasSuffix(r.URL.Path, "/check-runs"):
+			fmt.Fprint(w, `{"total_count": 0, "check_runs": []}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/git/ref/heads/develop", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ref": "refs/heads/develop", "object": {"sha": "base"}}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/git/refs/heads/bulldozer/rollup/develop", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/merges", func(w http.ResponseWriter, r *http.Request) {
+		var req github.RepositoryMergeRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		merged = append(merged, req.GetHead())
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{}`)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	b := &Base{}
+	pullCtx := &pulltest.MockPullContext{
+		OwnerValue:  "testorg",
+		RepoValue:   "testrepo",
+		NumberValue: 1,
+		BranchBase:  "develop",
+		BranchName:  "feature-1",
+	}
+	mergeConfig := bulldozer.MergeConfig{AllowMergeWithNoChecks: true}
+
+	rolledUp, err := b.updateRollup(context.Background(), pullCtx, client, mergeConfig, bulldozer.RollupConfig{})
+	require.NoError(t, err)
+	assert.True(t, rolledUp)
+
+	// the pull request into main is not part of the rollup for develop
+	assert.Equal(t, []string{"a", "b"}, merged)
+	require.NotNil(t, created)
+	assert.Equal(t, "bulldozer/rollup/develop", created.GetHead())
+	assert.Equal(t, "Rollup of 2 pull requests", created.GetTitle())
+}
+
+func TestNewMergerGitDataFallback(t *testing.T) {
+	var updated map[string]interface{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/testorg/testrepo/pulls/1/merge", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		fmt.Fprint(w, `{"message": "Merge commits are not allowed on this repository."}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/pulls/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"number": 1, "title": "Fix the bug", "merge_commit_sha": "test", "base": {"ref": "develop"}, "head": {"sha": "head", "label": "testorg:feature"}}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/git/ref/heads/develop", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ref": "refs/heads/develop", "object": {"sha": "base"}}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/git/commits/test", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"sha": "test", "tree": {"sha": "tree"}, "parents": [{"sha": "base"}, {"sha": "head"}]}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/git/commits", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"sha": "merged"}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/git/refs/heads/develop", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&updated))
+		fmt.Fprint(w, `{}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	b := &Base{}
+	merger, err := b.newMerger(client, nil)
+	require.NoError(t, err)
+
+	pullCtx := &pulltest.MockPullContext{
+		OwnerValue:      "testorg",
+		RepoValue:       "testrepo",
+		NumberValue:     1,
+		HeadSHAValue:    "head",
+		MergeStateValue: &pull.MergeState{Mergeable: github.Bool(true)},
+	}
+	result := bulldozer.MergePR(context.Background(), pullCtx, merger, bulldozer.MergeConfig{Method: bulldozer.MergeCommit, GitDataFallback: true})
+	require.NoError(t, result.Err)
+	assert.True(t, result.Merged, "fallback did not merge")
+	assert.Equal(t, "merged", updated["sha"])
+}
@@ -19,7 +19,7 @@ import (
 	"encoding/json"
 
 	"github.com/google/go-github/v50/github"
-	"github.com/palantir/bulldozer/pull"
+	"github.com/palantir/bulldozer/bulldozer"
 	"github.com/palantir/go-githubapp/githubapp"
 	"github.com/pkg/errors"
 )
@@ -54,6 +54,16 @@ func (h *CheckRun) Handle(ctx context.Context, eventType, deliveryID string, pay
 		return errors.Wrap(err, "failed to instantiate github client")
 	}
 
+	v4client, err := h.ClientCreator.NewInstallationV4Client(installationID)
+	if err != nil {
+		return errors.Wrap(err, "failed to instantiate github v4 client")
+	}
+
+	if run := event.GetCheckRun(); bulldozer.IsBulldozerCheckRun(run.GetName(), run.GetApp().GetID(), h.AppID) {
+		logger.Debug().Msg("Doing nothing since check_run is a bulldozer check run")
+		return nil
+	}
+
 	prs := event.GetCheckRun().PullRequests
 	if len(prs) == 0 {
 		logger.Debug().Msg("Doing nothing since status change event affects no open pull requests")
@@ -64,6 +74,10 @@ func (h *CheckRun) Handle(ctx context.Context, eventType, deliveryID string, pay
 		logger := logger.With().Int(githubapp.LogKeyPRNum, pr.GetNumber()).Logger()
 		ctx := logger.WithContext(ctx)
 
+		if h.debounce(ctx, repo.GetOwner().GetLogin(), repo.GetName(), pr.GetNumber()) {
+			continue
+		}
+
 		// The PR included in the CheckRun response is very slim on information.
 		// It does not contain the owner information or label information we
 		// need to process the pull request.
@@ -71,7 +85,7 @@ func (h *CheckRun) Handle(ctx context.Context, eventType, deliveryID string, pay
 		if err != nil {
 			return errors.Wrapf(err, "failed to fetch PR number %q for CheckRun", pr.GetNumber())
 		}
-		pullCtx := pull.NewGithubContext(client, fullPR)
+		pullCtx := h.NewPullContext(client, fullPR)
 
 		config, err := h.FetchConfigForPR(ctx, client, fullPR)
 		if err != nil {
@@ -82,7 +96,7 @@ func (h *CheckRun) Handle(ctx context.Context, eventType, deliveryID string, pay
 			logger.Debug().Msgf("Skipping updates to pull request due to server configuration override")
 		} else {
 			base, _ := pullCtx.Branches()
-			didUpdatePR, err := h.UpdatePullRequest(logger.WithContext(ctx), pullCtx, client, config, pr, base)
+			didUpdatePR, err := h.UpdatePullRequest(logger.WithContext(ctx), pullCtx, client, v4client, config, pr, base)
 			if err != nil {
 				logger.Error().Err(errors.WithStack(err)).Msg("Error updating pull request")
 			}
@@ -90,7 +104,7 @@ func (h *CheckRun) Handle(ctx context.Context, eventType, deliveryID string, pay
 				continue
 			}
 		}
-		if err := h.ProcessPullRequest(ctx, pullCtx, client, config, fullPR); err != nil {
+		if err := h.ProcessPullRequest(ctx, pullCtx, client, v4client, config, fullPR); err != nil {
 			logger.Error().Err(errors.WithStack(err)).Msg("Error processing pull request")
 		}
 	}
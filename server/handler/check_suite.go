@@ -0,0 +1,140 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/pull"
+	"github.com/palantir/go-githubapp/githubapp"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// CheckSuite evaluates pull requests when a check suite completes. Apps like
+// GitHub Actions report results as check suites without sending status
+// events.
+type CheckSuite struct {
+	Base
+}
+
+func (h *CheckSuite) Handles() []string {
+	return []string{"check_suite"}
+}
+
+func (h *CheckSuite) Handle(ctx context.Context, eventType, deliveryID string, payload []byte) error {
+	var event github.CheckSuiteEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return errors.Wrap(err, "failed to parse check_suite event payload")
+	}
+
+	repo := event.GetRepo()
+	installationID := githubapp.GetInstallationIDFromEvent(&event)
+	ctx, logger := githubapp.PrepareRepoContext(ctx, installationID, repo)
+
+	if event.GetAction() != "completed" {
+		logger.Debug().Msgf("Doing nothing since check_suite action was %q instead of 'completed'", event.GetAction())
+		return nil
+	}
+
+	suite := event.GetCheckSuite()
+	if h.AppID != 0 && suite.GetApp().GetID() == h.AppID {
+		logger.Debug().Msg("Doing nothing since check_suite contains the bulldozer check run")
+		return nil
+	}
+
+	return h.evaluateCommit(ctx, installationID, repo, suite.GetHeadSHA(), suite.PullRequests)
+}
+
+// evaluateCommit updates and merges the open pull requests for a commit after
+// checks or deployments of the commit change. Check suite and workflow run
+// events do not list pull requests from forks and deployment events do not
+// list pull requests at all, so if prs is empty, they are found by the head
+// SHA of the commit.
+func (b *Base) evaluateCommit(ctx context.Context, installationID int64, repo *github.Repository, sha string, prs []*github.PullRequest) error {
+	logger := zerolog.Ctx(ctx)
+	owner := repo.GetOwner().GetLogin()
+	repoName := repo.GetName()
+
+	client, err := b.ClientCreator.NewInstallationClient(installationID)
+	if err != nil {
+		return errors.Wrap(err, "failed to instantiate github client")
+	}
+
+	v4client, err := b.ClientCreator.NewInstallationV4Client(installationID)
+	if err != nil {
+		return errors.Wrap(err, "failed to instantiate github v4 client")
+	}
+
+	if len(prs) == 0 && sha != "" {
+		prs, err = pull.ListOpenPullRequestsForSHA(ctx, client, owner, repoName, sha)
+		if err != nil {
+			return errors.Wrap(err, "failed to determine open pull requests for the commit")
+		}
+	}
+	if len(prs) == 0 {
+		logger.Debug().Msg("Doing nothing since the commit is not the head of any open pull requests")
+		return nil
+	}
+
+	for _, pr := range prs {
+		logger := logger.With().Int(githubapp.LogKeyPRNum, pr.GetNumber()).Logger()
+		ctx := logger.WithContext(ctx)
+
+		if b.debounce(ctx, owner, repoName, pr.GetNumber()) {
+			continue
+		}
+
+		// pull requests in check events only include the number, head, and
+		// base, so get the full pull request
+		fullPR, _, err := client.PullRequests.Get(ctx, owner, repoName, pr.GetNumber())
+		if err != nil {
+			return errors.Wrapf(err, "failed to get pull request %s/%s#%d", owner, repoName, pr.GetNumber())
+		}
+		if fullPR.GetState() != "open" {
+			logger.Debug().Msg("Doing nothing since pull request is closed")
+			continue
+		}
+		pullCtx := b.NewPullContext(client, fullPR)
+
+		config, err := b.FetchConfigForPR(ctx, client, fullPR)
+		if err != nil {
+			return err
+		}
+
+		if b.DisableUpdateFeature {
+			logger.Debug().Msgf("Skipping updates to pull request due to server configuration override")
+		} else {
+			base, _ := pullCtx.Branches()
+			didUpdatePR, err := b.UpdatePullRequest(ctx, pullCtx, client, v4client, config, fullPR, base)
+			if err != nil {
+				logger.Error().Err(errors.WithStack(err)).Msg("Error updating pull request")
+			}
+			if didUpdatePR {
+				continue
+			}
+		}
+		if err := b.ProcessPullRequest(ctx, pullCtx, client, v4client, config, fullPR); err != nil {
+			logger.Error().Err(errors.WithStack(err)).Msg("Error processing pull request")
+		}
+	}
+
+	return nil
+}
+
+// type assertion
+var _ githubapp.EventHandler = &CheckSuite{}
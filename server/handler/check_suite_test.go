@@ -0,0 +1,149 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/go-githubapp/githubapp"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testClientCreator returns the same client for all installations.
+type testClientCreator struct {
+	githubapp.ClientCreator
+	client *github.Client
+}
+
+func (c *testClientCreator) NewInstallationClient(installationID int64) (*github.Client, error) {
+	return c.client, nil
+}
+
+func (c *testClientCreator) NewInstallationV4Client(installationID int64) (*githubv4.Client, error) {
+	return githubv4.NewClient(c.client.Client()), nil
+}
+
+func TestCompletedChecks(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/testorg/testrepo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"number":7,"head":{"sha":"f00"}},
+			{"number":8,"head":{"sha":"ba5"}}
+		]`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	newBase := func() Base {
+		return Base{
+			ClientCreator: &testClientCreator{client: client},
+			Scheduler: NewScheduler(NewMemoryQueue(), func(ctx context.Context, ref PullRequestRef) error {
+				return nil
+			}),
+			AppID:            42,
+			DebounceInterval: time.Hour,
+		}
+	}
+
+	scheduled := func(t *testing.T, b Base) []int {
+		items, err := b.Scheduler.List(context.Background())
+		require.NoError(t, err)
+
+		var numbers []int
+		for _, item := range items {
+			numbers = append(numbers, item.Ref.Number)
+		}
+		return numbers
+	}
+
+	repository := `"repository":{"name":"testrepo","owner":{"login":"testorg"}},"installation":{"id":1}`
+
+	tests := map[string]struct {
+		handler  func(b Base) githubapp.EventHandler
+		event    string
+		payload  string
+		expected []int
+	}{
+		"checkSuiteRequested": {
+			handler:  func(b Base) githubapp.EventHandler { return &CheckSuite{Base: b} },
+			event:    "check_suite",
+			payload:  `{"action":"requested","check_suite":{"head_sha":"f00","app":{"id":1}},` + repository + `}`,
+			expected: nil,
+		},
+		"checkSuiteBulldozer": {
+			handler:  func(b Base) githubapp.EventHandler { return &CheckSuite{Base: b} },
+			event:    "check_suite",
+			payload:  `{"action":"completed","check_suite":{"head_sha":"f00","app":{"id":42}},` + repository + `}`,
+			expected: nil,
+		},
+		"checkSuitePullRequests": {
+			handler:  func(b Base) githubapp.EventHandler { return &CheckSuite{Base: b} },
+			event:    "check_suite",
+			payload:  `{"action":"completed","check_suite":{"head_sha":"f00","app":{"id":1},"pull_requests":[{"number":5}]},` + repository + `}`,
+			expected: []int{5},
+		},
+		"checkSuiteFork": {
+			handler:  func(b Base) githubapp.EventHandler { return &CheckSuite{Base: b} },
+			event:    "check_suite",
+			payload:  `{"action":"completed","check_suite":{"head_sha":"f00","app":{"id":1},"pull_requests":[]},` + repository + `}`,
+			expected: []int{7},
+		},
+		"workflowRunInProgress": {
+			handler:  func(b Base) githubapp.EventHandler { return &WorkflowRun{Base: b} },
+			event:    "workflow_run",
+			payload:  `{"action":"in_progress","workflow_run":{"head_sha":"ba5"},` + repository + `}`,
+			expected: nil,
+		},
+		"workflowRunCompleted": {
+			handler:  func(b Base) githubapp.EventHandler { return &WorkflowRun{Base: b} },
+			event:    "workflow_run",
+			payload:  `{"action":"completed","workflow_run":{"head_sha":"ba5","conclusion":"success"},` + repository + `}`,
+			expected: []int{8},
+		},
+		"deploymentStatus": {
+			handler:  func(b Base) githubapp.EventHandler { return &Deployment{Base: b} },
+			event:    "deployment_status",
+			payload:  `{"action":"created","deployment_status":{"state":"success"},"deployment":{"sha":"f00","environment":"staging"},` + repository + `}`,
+			expected: []int{7},
+		},
+		"deploymentUnknownCommit": {
+			handler:  func(b Base) githubapp.EventHandler { return &Deployment{Base: b} },
+			event:    "deployment",
+			payload:  `{"action":"created","deployment":{"sha":"c0ffee","environment":"staging"},` + repository + `}`,
+			expected: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			b := newBase()
+			err := test.handler(b).Handle(context.Background(), test.event, "delivery", []byte(test.payload))
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, scheduled(t, b))
+		})
+	}
+}
@@ -0,0 +1,309 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/bulldozer"
+	"github.com/palantir/bulldozer/notify"
+	"github.com/palantir/bulldozer/pull"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/shurcooL/githubv4"
+)
+
+const commandPrefix = "/bulldozer"
+
+const commandHelp = `bulldozer supports these commands:
+
+| Command | Description |
+| --- | --- |
+| ` + "`/bulldozer status`" + ` | Explains why the pull request is or is not ready to merge |
+| ` + "`/bulldozer merge now`" + ` | Merges the pull request without waiting for a trigger signal or merge delay |
+| ` + "`/bulldozer update`" + ` | Updates the pull request with the latest changes from its base branch |
+| ` + "`/bulldozer pause [duration]`" + ` | Stops merging and updating the pull request, for example for ` + "`2h`" + `, or until it is resumed |
+| ` + "`/bulldozer resume`" + ` | Removes a pause created by ` + "`/bulldozer pause`" + ` |
+`
+
+// command is a slash command in a pull request comment, like
+// "/bulldozer pause 2h".
+type command struct {
+	name string
+	args []string
+}
+
+func (c command) String() string {
+	return strings.TrimSpace(commandPrefix + " " + strings.Join(append([]string{c.name}, c.args...), " "))
+}
+
+// parseCommand returns the first command in the comment. Commands must start
+// a line of the comment.
+func parseCommand(body string) (command, bool) {
+	for _, line := range strings.Split(body, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || !strings.EqualFold(fields[0], commandPrefix) {
+			continue
+		}
+
+		var c command
+		if len(fields) > 1 {
+			c.name = strings.ToLower(fields[1])
+			c.args = fields[2:]
+		}
+		return c, true
+	}
+	return command{}, false
+}
+
+// runCommand runs a command from a comment by user on the pull request and
+// replies with the result. Only users with write access to the repository
+// can run commands.
+func (b *Base) runCommand(ctx context.Context, client *github.Client, v4client *githubv4.Client, pr *github.PullRequest, user string, cmd command) error {
+	logger := zerolog.Ctx(ctx)
+	pullCtx := b.NewPullContext(client, pr)
+
+	logger.Info().Msgf("Received command %q from %s", cmd, user)
+
+	permission, _, err := client.Repositories.GetPermissionLevel(ctx, pullCtx.Owner(), pullCtx.Repo(), user)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get permission level of %s", user)
+	}
+	if p := permission.GetPermission(); p != "admin" && p != "write" {
+		logger.Info().Msgf("Ignoring command from %s with %s permission", user, p)
+		return b.reply(ctx, client, pullCtx, fmt.Sprintf("@%s only users with write access to the repository can use bulldozer commands.", user))
+	}
+
+	config, err := b.FetchConfigForPR(ctx, client, pr)
+	if err != nil {
+		return err
+	}
+	if config == nil {
+		return b.reply(ctx, client, pullCtx, "bulldozer is not configured for this repository.")
+	}
+
+	var message string
+	switch cmd.name {
+	case "status":
+		message, err = b.commandStatus(ctx, pullCtx, config)
+	case "merge":
+		message, err = b.commandMerge(ctx, pullCtx, client, v4client, config, user)
+	case "update":
+		message, err = b.commandUpdate(ctx, pullCtx, client, v4client, config, user)
+	case "pause":
+		message, err = b.commandPause(ctx, pullCtx, cmd.args)
+	case "resume":
+		message, err = b.commandResume(ctx, pullCtx)
+	default:
+		message = commandHelp
+	}
+	if err != nil {
+		if replyErr := b.reply(ctx, client, pullCtx, fmt.Sprintf("bulldozer could not run `%s`: %s", cmd, err)); replyErr != nil {
+			logger.Error().Err(replyErr).Msg("Failed to reply to command")
+		}
+		return err
+	}
+	return b.reply(ctx, client, pullCtx, message)
+}
+
+func (b *Base) commandStatus(ctx context.Context, pullCtx pull.Context, config *bulldozer.Config) (string, error) {
+	e, err := b.explainEligibility(ctx, pullCtx, config)
+	if err != nil {
+		return "", err
+	}
+
+	var message strings.Builder
+	if reason := b.pauseReason(pullCtx); reason != "" {
+		fmt.Fprintf(&message, "The pull request is not merged or updated because %s.\n\n", reason)
+	}
+	fmt.Fprintf(&message, "The pull request is %s.\n\n%s", e.Reason, e.Markdown())
+	return message.String(), nil
+}
+
+// commandMerge merges the pull request as if it matched a trigger signal and
+// has no merge delay. Ignore signals, required statuses, blackout windows,
+// commit message rules, limits on changing configuration files, and the
+// external policy of the organization still apply. Running the command
+// removes any pause of the pull request, but not of the repository.
+func (b *Base) commandMerge(ctx context.Context, pullCtx pull.Context, client *github.Client, v4client *githubv4.Client, config *bulldozer.Config, user string) (string, error) {
+	if b.Pauses != nil {
+		if err := b.Pauses.ResumePullRequest(ctx, pullCtx.Owner(), pullCtx.Repo(), pullCtx.Number()); err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Msg("Failed to resume pull request")
+		}
+	}
+	if reason := b.pauseReason(pullCtx); reason != "" {
+		return fmt.Sprintf("The pull request was not merged because %s.", reason), nil
+	}
+
+	file, err := b.SelfModification.deniedChange(ctx, pullCtx)
+	if err != nil {
+		return "", err
+	}
+	if file != "" {
+		return fmt.Sprintf("The pull request was not merged because it changes the configuration file %q.", file), nil
+	}
+
+	if b.DryRun || config.DryRun {
+		return "The pull request was not merged because dry run is enabled.", nil
+	}
+
+	mergeConfig := config.Merge
+	mergeConfig.Trigger = bulldozer.Signals{}
+	mergeConfig.Delay = 0
+
+	shouldMerge, reason, err := b.shouldMerge(ctx, pullCtx, mergeConfig)
+	if err != nil {
+		return "", err
+	}
+	if !shouldMerge {
+		return fmt.Sprintf("The pull request was not merged because it is %s.", reason), nil
+	}
+
+	problems, err := bulldozer.LintCommitMessage(ctx, pullCtx, mergeConfig)
+	if err != nil {
+		return "", err
+	}
+	if len(problems) > 0 {
+		return fmt.Sprintf("The pull request was not merged because its squash commit message breaks rules: %s.", strings.Join(problems, "; ")), nil
+	}
+
+	allowed, denied, err := b.checkExternalPolicy(ctx, pullCtx, config, reason)
+	if err != nil {
+		return "", err
+	}
+	if !allowed {
+		return fmt.Sprintf("The pull request was not merged because it is %s.", denied), nil
+	}
+
+	trainCtx, release, err := b.joinMergeTrain(ctx, pullCtx, client, mergeConfig)
+	if err != nil {
+		return "", err
+	}
+	if release != nil {
+		defer release()
+		pullCtx = trainCtx
+
+		allowed, trainReason, err := b.recheckAfterMergeTrain(ctx, pullCtx, config, mergeConfig)
+		if err != nil {
+			return "", err
+		}
+		if !allowed {
+			return fmt.Sprintf("The pull request was not merged after waiting for other merges: %s.", trainReason), nil
+		}
+		reason = trainReason
+	}
+
+	merger, err := b.newMerger(client, v4client)
+	if err != nil {
+		return "", err
+	}
+
+	result := b.mergePR(ctx, pullCtx, merger, mergeConfig, fmt.Sprintf("%s because %s requested a merge", reason, user))
+	b.finishMerge(ctx, pullCtx, client, merger, mergeConfig, config.Notifications, result)
+
+	switch {
+	case result.Queued:
+		return "Added the pull request to the merge queue.", nil
+	case result.Merged:
+		return "Merged the pull request.", nil
+	}
+	return fmt.Sprintf("The merge failed: %v", result.Err), nil
+}
+
+// commandUpdate updates the pull request, even if the update signals and
+// limits in the configuration would not update it.
+func (b *Base) commandUpdate(ctx context.Context, pullCtx pull.Context, client *github.Client, v4client *githubv4.Client, config *bulldozer.Config, user string) (string, error) {
+	if b.DisableUpdateFeature {
+		return "The pull request was not updated because updates are disabled for this server.", nil
+	}
+	if reason := b.pauseReason(pullCtx); reason != "" {
+		return fmt.Sprintf("The pull request was not updated because %s.", reason), nil
+	}
+	if b.DryRun || config.DryRun {
+		return "The pull request was not updated because dry run is enabled.", nil
+	}
+	if config.Update.Disabled {
+		return "The pull request was not updated because the configuration is merge only.", nil
+	}
+
+	updateConfig := config.Update
+	updateConfig.MinBehindBy = 0
+	updateConfig.MinInterval = 0
+
+	base, _ := pullCtx.Branches()
+	result := bulldozer.UpdatePR(ctx, pullCtx, client, v4client, updateConfig, base)
+	b.countUpdate(result)
+	b.recordUpdate(ctx, pullCtx, updateDecision(result), fmt.Sprintf("%s requested an update", user))
+
+	switch {
+	case result.Updated:
+		return fmt.Sprintf("Updated the pull request with the latest changes from `%s`.", base), nil
+	case result.Conflict:
+		b.notify(ctx, pullCtx, config.Notifications, notify.ConflictDetected, fmt.Sprintf("bulldozer could not update the pull request because it conflicts with %s", base))
+		return fmt.Sprintf("The pull request was not updated because it conflicts with `%s`.", base), nil
+	}
+	return "The pull request was not updated. It may already be up to date or be from a fork.", nil
+}
+
+func (b *Base) commandPause(ctx context.Context, pullCtx pull.Context, args []string) (string, error) {
+	if b.Pauses == nil {
+		return "Pausing is not enabled for this server.", nil
+	}
+
+	var until time.Time
+	if len(args) > 0 {
+		d, err := time.ParseDuration(args[0])
+		if err != nil || d <= 0 {
+			return fmt.Sprintf("%q is not a valid duration. Use a duration like `30m` or `2h`.", args[0]), nil
+		}
+		until = time.Now().Add(d)
+	}
+
+	if err := b.Pauses.PausePullRequest(ctx, pullCtx.Owner(), pullCtx.Repo(), pullCtx.Number(), until); err != nil {
+		return "", err
+	}
+	if until.IsZero() {
+		return "Paused bulldozer for the pull request until it is resumed with `/bulldozer resume`.", nil
+	}
+
+	// evaluate the pull request again when the pause ends, in case no other
+	// events arrive
+	b.schedule(ctx, pullCtx, until)
+	return fmt.Sprintf("Paused bulldozer for the pull request until %s.", until.UTC().Format(time.RFC3339)), nil
+}
+
+func (b *Base) commandResume(ctx context.Context, pullCtx pull.Context) (string, error) {
+	if b.Pauses == nil {
+		return "Pausing is not enabled for this server.", nil
+	}
+
+	if err := b.Pauses.ResumePullRequest(ctx, pullCtx.Owner(), pullCtx.Repo(), pullCtx.Number()); err != nil {
+		return "", err
+	}
+	b.schedule(ctx, pullCtx, time.Now())
+	return "Resumed bulldozer for the pull request.", nil
+}
+
+// reply comments on the pull request.
+func (b *Base) reply(ctx context.Context, client *github.Client, pullCtx pull.Context, message string) error {
+	_, _, err := client.Issues.CreateComment(ctx, pullCtx.Owner(), pullCtx.Repo(), pullCtx.Number(), &github.IssueComment{
+		Body: github.String(message),
+	})
+	return errors.Wrap(err, "failed to reply to command")
+}
@@ -0,0 +1,243 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/bulldozer"
+	"github.com/palantir/go-githubapp/appconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCommand(t *testing.T) {
+	tests := map[string]struct {
+		body     string
+		command  command
+		expected bool
+	}{
+		"pause": {
+			body:     "/bulldozer pause 2h",
+			command:  command{name: "pause", args: []string{"2h"}},
+			expected: true,
+		},
+		"laterLine": {
+			body:     "The build is flaky.\r\n/Bulldozer MERGE now\r\n",
+			command:  command{name: "merge", args: []string{"now"}},
+			expected: true,
+		},
+		"noName": {
+			body:     "/bulldozer",
+			command:  command{},
+			expected: true,
+		},
+		"notLineStart": {
+			body:     "please run /bulldozer merge now",
+			expected: false,
+		},
+		"otherPrefix": {
+			body:     "/bulldozers merge",
+			expected: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c, ok := parseCommand(test.body)
+			assert.Equal(t, test.expected, ok)
+			if ok {
+				assert.Equal(t, test.command.name, c.name)
+				assert.Equal(t, len(test.command.args), len(c.args))
+			}
+		})
+	}
+}
+
+func TestRunCommand(t *testing.T) {
+	var replies []string
+	permission := "write"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/testorg/testrepo/collaborators/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"permission":%q}`, permission)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/issues/1/comments", func(w http.ResponseWriter, r *http.Request) {
+		var comment github.IssueComment
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&comment))
+		replies = append(replies, comment.GetBody())
+		fmt.Fprint(w, `{}`)
+	})
+	var onGetPullRequest func()
+	mux.HandleFunc("/repos/testorg/testrepo/pulls/1", func(w http.ResponseWriter, r *http.Request) {
+		if onGetPullRequest != nil {
+			onGetPullRequest()
+		}
+		fmt.Fprint(w, `{"number":1,"state":"open","base":{"ref":"develop","repo":{"name":"testrepo","owner":{"login":"testorg"}}}}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/pulls/1/files", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"filename":".bulldozer.yml"}]`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/commits/", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/status"):
+			fmt.Fprint(w, `{"state":"pending","statuses":[]}`)
+		case strings.HasSuffix(r.URL.Path, "/check-runs"):
+			fmt.Fprint(w, `{"total_count":0,"check_runs":[]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		// configuration files do not exist, so the default is used
+		http.NotFound(w, r)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	pr := &github.PullRequest{
+		Number: github.Int(1),
+		Base: &github.PullRequestBranch{
+			Ref: github.String("develop"),
+			Repo: &github.Repository{
+				Name:  github.String("testrepo"),
+				Owner: &github.User{Login: github.String("testorg")},
+			},
+		},
+	}
+
+	b := &Base{
+		ConfigFetcher: NewConfigFetcher(appconfig.NewLoader([]string{".bulldozer.yml"}), &DefaultConfig{Config: &bulldozer.Config{}}),
+		Pauses:        NewPauses(),
+	}
+
+	run := func(t *testing.T, body string) string {
+		replies = nil
+		cmd, ok := parseCommand(body)
+		require.True(t, ok)
+		require.NoError(t, b.runCommand(context.Background(), client, nil, pr, "testuser", cmd))
+		require.Len(t, replies, 1)
+		return replies[0]
+	}
+
+	t.Run("pause", func(t *testing.T) {
+		reply := run(t, "/bulldozer pause 2h")
+		assert.Contains(t, reply, "Paused bulldozer for the pull request until")
+		assert.True(t, b.Pauses.IsPullRequestPaused("testorg", "testrepo", 1))
+		assert.False(t, b.Pauses.IsPullRequestPaused("testorg", "testrepo", 2))
+
+		pauses := b.Pauses.List()
+		require.Len(t, pauses, 1)
+		assert.Equal(t, "testorg/testrepo#1", pauses[0].Target)
+		require.NotNil(t, pauses[0].Until)
+		assert.WithinDuration(t, time.Now().Add(2*time.Hour), *pauses[0].Until, time.Minute)
+	})
+
+	t.Run("invalidDuration", func(t *testing.T) {
+		assert.Contains(t, run(t, "/bulldozer pause soon"), `"soon" is not a valid duration`)
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		assert.Equal(t, "Resumed bulldozer for the pull request.", run(t, "/bulldozer resume"))
+		assert.False(t, b.Pauses.IsPullRequestPaused("testorg", "testrepo", 1))
+	})
+
+	t.Run("help", func(t *testing.T) {
+		assert.Equal(t, commandHelp, run(t, "/bulldozer"))
+		assert.Equal(t, commandHelp, run(t, "/bulldozer explode"))
+	})
+
+	t.Run("mergeChangesConfiguration", func(t *testing.T) {
+		deny := false
+		b.SelfModification = SelfModification{AllowMerge: &deny, Paths: []string{".bulldozer.yml"}}
+		defer func() { b.SelfModification = SelfModification{} }()
+
+		assert.Equal(t, `The pull request was not merged because it changes the configuration file ".bulldozer.yml".`, run(t, "/bulldozer merge now"))
+	})
+
+	t.Run("mergeDeniedByExternalPolicy", func(t *testing.T) {
+		policy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"decision": "deny", "reason": "the release is frozen"}`)
+		}))
+		defer policy.Close()
+
+		fetcher := b.ConfigFetcher
+		b.ConfigFetcher = NewConfigFetcher(appconfig.NewLoader([]string{".bulldozer.yml"}), &DefaultConfig{Config: &bulldozer.Config{
+			Merge: bulldozer.MergeConfig{AllowMergeWithNoChecks: true},
+		}})
+		b.Policies = map[string]bulldozer.Policy{"testorg": {External: &bulldozer.ExternalPolicy{URL: policy.URL}}}
+		defer func() {
+			b.ConfigFetcher = fetcher
+			b.Policies = nil
+		}()
+
+		assert.Equal(t, "The pull request was not merged because it is not mergeable because the external policy denied the merge: the release is frozen.", run(t, "/bulldozer merge now"))
+	})
+
+	t.Run("mergePausedWhileWaitingForTrain", func(t *testing.T) {
+		fetcher := b.ConfigFetcher
+		b.ConfigFetcher = NewConfigFetcher(appconfig.NewLoader([]string{".bulldozer.yml"}), &DefaultConfig{Config: &bulldozer.Config{
+			Merge: bulldozer.MergeConfig{AllowMergeWithNoChecks: true, MergeTrain: true},
+		}})
+		b.MergeTrain = bulldozer.NewMergeTrain()
+
+		// the repository is paused while the pull request waits for the train
+		onGetPullRequest = func() {
+			require.NoError(t, b.Pauses.Pause(context.Background(), "testorg", "testrepo", time.Time{}))
+		}
+		defer func() {
+			b.ConfigFetcher = fetcher
+			b.MergeTrain = nil
+			onGetPullRequest = nil
+			require.NoError(t, b.Pauses.Resume(context.Background(), "testorg", "testrepo"))
+		}()
+
+		assert.Equal(t, "The pull request was not merged after waiting for other merges: bulldozer is paused for the repository.", run(t, "/bulldozer merge now"))
+	})
+
+	t.Run("permission", func(t *testing.T) {
+		permission = "read"
+		defer func() { permission = "write" }()
+
+		assert.Contains(t, run(t, "/bulldozer pause"), "only users with write access")
+		assert.False(t, b.Pauses.IsPullRequestPaused("testorg", "testrepo", 1))
+	})
+}
+
+func TestPausesExpire(t *testing.T) {
+	ctx := context.Background()
+	p := NewPauses()
+	require.NoError(t, p.PausePullRequest(ctx, "testorg", "testrepo", 1, time.Now().Add(-time.Second)))
+	require.NoError(t, p.PausePullRequest(ctx, "testorg", "testrepo", 2, time.Time{}))
+
+	assert.False(t, p.IsPullRequestPaused("testorg", "testrepo", 1), "expired pause should not apply")
+	assert.True(t, p.IsPullRequestPaused("TestOrg", "TestRepo", 2))
+	assert.Len(t, p.List(), 1)
+
+	require.NoError(t, p.Pause(ctx, "testorg", "", time.Time{}))
+	assert.True(t, p.IsPullRequestPaused("testorg", "testrepo", 1), "owner pause should apply to pull requests")
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/palantir/go-githubapp/githubapp"
+)
+
+// Limiter limits how many events and evaluations run at the same time for
+// each installation and each repository. Limiting repositories prevents
+// concurrent merges and updates from racing with each other, while limiting
+// installations avoids GitHub's secondary rate limits. It is safe for
+// concurrent use.
+type Limiter struct {
+	perInstallation int
+	perRepository   int
+
+	mu         sync.Mutex
+	semaphores map[string]*semaphore
+}
+
+// semaphore is a counting semaphore that is removed from its map when no
+// goroutine holds or waits for it.
+type semaphore struct {
+	slots chan struct{}
+	refs  int
+}
+
+// NewLimiter creates a limiter. A limit that is not positive means there is
+// no limit.
+func NewLimiter(perInstallation, perRepository int) *Limiter {
+	return &Limiter{
+		perInstallation: perInstallation,
+		perRepository:   perRepository,
+		semaphores:      make(map[string]*semaphore),
+	}
+}
+
+// Acquire waits until work for the repository can start and returns a
+// function that must be called when the work is done. The repository is
+// formatted as "owner/name" and may be empty if the work does not affect a
+// repository. A nil Limiter does not limit anything.
+func (l *Limiter) Acquire(ctx context.Context, installationID int64, repo string) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	// always acquire the repository before the installation, so that work
+	// waiting for a repository never holds an installation slot
+	var releases []func()
+	release := func() {
+		for i := len(releases) - 1; i >= 0; i-- {
+			releases[i]()
+		}
+	}
+
+	if repo != "" && l.perRepository > 0 {
+		r, err := l.acquire(ctx, "repository:"+strings.ToLower(repo), l.perRepository)
+		if err != nil {
+			return nil, err
+		}
+		releases = append(releases, r)
+	}
+	if l.perInstallation > 0 {
+		r, err := l.acquire(ctx, fmt.Sprintf("installation:%d", installationID), l.perInstallation)
+		if err != nil {
+			release()
+			return nil, err
+		}
+		releases = append(releases, r)
+	}
+	return release, nil
+}
+
+func (l *Limiter) acquire(ctx context.Context, key string, limit int) (func(), error) {
+	l.mu.Lock()
+	sem, ok := l.semaphores[key]
+	if !ok {
+		sem = &semaphore{slots: make(chan struct{}, limit)}
+		l.semaphores[key] = sem
+	}
+	sem.refs++
+	l.mu.Unlock()
+
+	unref := func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if sem.refs--; sem.refs == 0 {
+			delete(l.semaphores, key)
+		}
+	}
+
+	select {
+	case sem.slots <- struct{}{}:
+		return func() {
+			<-sem.slots
+			unref()
+		}, nil
+	case <-ctx.Done():
+		unref()
+		return nil, ctx.Err()
+	}
+}
+
+// Limited wraps an event handler so that events wait for the limiter before
+// they are handled.
+func Limited(l *Limiter, h githubapp.EventHandler) githubapp.EventHandler {
+	return &limitedHandler{EventHandler: h, limiter: l}
+}
+
+type limitedHandler struct {
+	githubapp.EventHandler
+	limiter *Limiter
+}
+
+func (h *limitedHandler) Handle(ctx context.Context, eventType, deliveryID string, payload []byte) error {
+	var event struct {
+		Installation struct {
+			ID int64 `json:"id"`
+		} `json:"installation"`
+		Repository struct {
+			FullName string `json:"full_name"`
+		} `json:"repository"`
+	}
+	_ = json.Unmarshal(payload, &event)
+
+	release, err := h.limiter.Acquire(ctx, event.Installation.ID, event.Repository.FullName)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return h.EventHandler.Handle(ctx, eventType, deliveryID, payload)
+}
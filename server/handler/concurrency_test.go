@@ -0,0 +1,90 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter(t *testing.T) {
+	run := func(l *Limiter, work []struct {
+		installation int64
+		repo         string
+	}) int32 {
+		var running, max int32
+		var wg sync.WaitGroup
+		for _, w := range work {
+			wg.Add(1)
+			go func(installation int64, repo string) {
+				defer wg.Done()
+				release, err := l.Acquire(context.Background(), installation, repo)
+				require.NoError(t, err)
+				defer release()
+
+				n := atomic.AddInt32(&running, 1)
+				for {
+					m := atomic.LoadInt32(&max)
+					if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt32(&running, -1)
+			}(w.installation, w.repo)
+		}
+		wg.Wait()
+		return max
+	}
+
+	type work = []struct {
+		installation int64
+		repo         string
+	}
+
+	t.Run("perRepository", func(t *testing.T) {
+		l := NewLimiter(0, 1)
+		assert.Equal(t, int32(1), run(l, work{{1, "testorg/testrepo"}, {1, "TestOrg/TestRepo"}, {2, "testorg/testrepo"}}))
+		assert.Empty(t, l.semaphores, "unused semaphores should be removed")
+	})
+
+	t.Run("perInstallation", func(t *testing.T) {
+		l := NewLimiter(2, 0)
+		assert.Equal(t, int32(2), run(l, work{{1, "testorg/a"}, {1, "testorg/b"}, {1, "testorg/c"}, {1, "testorg/d"}}))
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		var l *Limiter
+		assert.Equal(t, int32(3), run(l, work{{1, "testorg/testrepo"}, {1, "testorg/testrepo"}, {1, "testorg/testrepo"}}))
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		l := NewLimiter(0, 1)
+		release, err := l.Acquire(context.Background(), 1, "testorg/testrepo")
+		require.NoError(t, err)
+		defer release()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = l.Acquire(ctx, 1, "testorg/testrepo")
+		assert.Equal(t, context.Canceled, err)
+	})
+}
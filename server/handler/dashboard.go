@@ -0,0 +1,323 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"container/list"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/palantir/bulldozer/audit"
+	"github.com/palantir/bulldozer/bulldozer"
+	"github.com/palantir/go-baseapp/baseapp"
+	"github.com/rs/zerolog"
+	"goji.io/pat"
+)
+
+// DefaultLastActionsSize is the number of pull requests LastActions remembers
+// if no size is given.
+const DefaultLastActionsSize = 10000
+
+// LastActions remembers the most recent evaluation of recently evaluated pull
+// requests. Once it is full, it forgets the pull request that was evaluated
+// least recently. It is safe for concurrent use.
+type LastActions struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	records map[PullRequestRef]*list.Element
+}
+
+// NewLastActions creates a LastActions that remembers up to size pull
+// requests. If size is not positive, it uses DefaultLastActionsSize.
+func NewLastActions(size int) *LastActions {
+	if size <= 0 {
+		size = DefaultLastActionsSize
+	}
+	return &LastActions{
+		size:    size,
+		order:   list.New(),
+		records: make(map[PullRequestRef]*list.Element),
+	}
+}
+
+// Add remembers the record as the last action for its pull request. A nil
+// LastActions does nothing.
+func (l *LastActions) Add(r audit.Record) {
+	if l == nil {
+		return
+	}
+	if r.Time.IsZero() {
+		r.Time = time.Now().UTC()
+	}
+	ref := PullRequestRef{Owner: r.Owner, Repo: r.Repo, Number: r.Number}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if e, ok := l.records[ref]; ok {
+		e.Value = r
+		l.order.MoveToFront(e)
+		return
+	}
+	l.records[ref] = l.order.PushFront(r)
+
+	if l.order.Len() > l.size {
+		oldest := l.order.Back()
+		old := oldest.Value.(audit.Record)
+		delete(l.records, PullRequestRef{Owner: old.Owner, Repo: old.Repo, Number: old.Number})
+		l.order.Remove(oldest)
+	}
+}
+
+// Get returns the last action for the pull request, if there is one.
+func (l *LastActions) Get(ref PullRequestRef) (audit.Record, bool) {
+	if l == nil {
+		return audit.Record{}, false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if e, ok := l.records[ref]; ok {
+		return e.Value.(audit.Record), true
+	}
+	return audit.Record{}, false
+}
+
+// PullRequestStatus describes why bulldozer has or has not merged a pull
+// request.
+type PullRequestStatus struct {
+	Owner   string `json:"owner"`
+	Repo    string `json:"repo"`
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	URL     string `json:"url"`
+	HeadSHA string `json:"head_sha"`
+	State   string `json:"state"`
+
+	// Paused is true if bulldozer is paused for the repository by the admin
+	// API or for the pull request by a comment command.
+	Paused bool `json:"paused"`
+
+	// ConfigError explains why the pull request has no configuration, if
+	// the configuration failed to load or bulldozer does not apply to it.
+	ConfigError string `json:"config_error,omitempty"`
+
+	// Eligibility is nil if the pull request is closed or the repository
+	// has no valid configuration.
+	Eligibility *bulldozer.Eligibility `json:"eligibility,omitempty"`
+
+	// LastAction is the outcome of the last evaluation since the server
+	// started, if any.
+	LastAction *audit.Record `json:"last_action,omitempty"`
+}
+
+// RepositoryStatus describes whether bulldozer is paused for a repository.
+type RepositoryStatus struct {
+	Owner  string `json:"owner"`
+	Repo   string `json:"repo"`
+	Paused bool   `json:"paused"`
+
+	// Pause is the pause of the repository, or of its owner if only the owner
+	// is paused.
+	Pause *Pause `json:"pause,omitempty"`
+}
+
+// Dashboard shows the evaluated state of pull requests, so users can find
+// out why a pull request is not merged. It evaluates pull requests without
+// merging or updating them.
+type Dashboard struct {
+	Base
+
+	// Token is the bearer token requests must include. If empty, all requests
+	// are rejected.
+	Token string
+}
+
+// API returns a handler that writes the status of a pull request as JSON. It
+// must be mounted with a pattern that binds the owner, repo, and number
+// parameters.
+func (d *Dashboard) API() http.Handler {
+	return requireToken(d.Token, func(w http.ResponseWriter, r *http.Request) {
+		status, code, err := d.status(r)
+		if err != nil {
+			writeError(w, code, err.Error())
+			return
+		}
+		baseapp.WriteJSON(w, http.StatusOK, status)
+	})
+}
+
+// RepositoryAPI returns a handler that writes the pause state of a repository
+// as JSON. It must be mounted with a pattern that binds the owner and repo
+// parameters.
+func (d *Dashboard) RepositoryAPI() http.Handler {
+	return requireToken(d.Token, func(w http.ResponseWriter, r *http.Request) {
+		status := RepositoryStatus{Owner: pat.Param(r, "owner"), Repo: pat.Param(r, "repo")}
+		if pause, ok := d.Pauses.Get(status.Owner, status.Repo); ok {
+			status.Paused = true
+			status.Pause = &pause
+		}
+		baseapp.WriteJSON(w, http.StatusOK, status)
+	})
+}
+
+// UI returns a handler that shows the status of a pull request as a web page.
+// It must be mounted with a pattern that binds the owner, repo, and number
+// parameters.
+func (d *Dashboard) UI() http.Handler {
+	return requireToken(d.Token, func(w http.ResponseWriter, r *http.Request) {
+		status, code, err := d.status(r)
+		if err != nil {
+			http.Error(w, err.Error(), code)
+			return
+		}
+		if err := writeStatusPage(w, status); err != nil {
+			zerolog.Ctx(r.Context()).Error().Err(err).Msg("Failed to render status page")
+		}
+	})
+}
+
+func (d *Dashboard) status(r *http.Request) (*PullRequestStatus, int, error) {
+	ctx := r.Context()
+	owner, repo := pat.Param(r, "owner"), pat.Param(r, "repo")
+
+	number, err := strconv.Atoi(pat.Param(r, "number"))
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid pull request number")
+	}
+
+	client, err := d.installationClient(ctx, owner, repo)
+	if err != nil {
+		return nil, http.StatusNotFound, err
+	}
+
+	pr, _, err := client.PullRequests.Get(ctx, owner, repo, number)
+	if err != nil {
+		return nil, http.StatusBadGateway, fmt.Errorf("failed to get pull request %s/%s#%d: %s", owner, repo, number, err)
+	}
+
+	status := &PullRequestStatus{
+		Owner:   owner,
+		Repo:    repo,
+		Number:  number,
+		Title:   pr.GetTitle(),
+		URL:     pr.GetHTMLURL(),
+		HeadSHA: pr.GetHead().GetSHA(),
+		State:   pr.GetState(),
+		Paused:  d.Pauses.IsPullRequestPaused(owner, repo, number),
+	}
+	if pr.GetMerged() {
+		status.State = "merged"
+	}
+	if last, ok := d.LastActions.Get(PullRequestRef{Owner: owner, Repo: repo, Number: number}); ok {
+		status.LastAction = &last
+	}
+	if pr.GetState() != "open" {
+		return status, http.StatusOK, nil
+	}
+
+	config, err := d.FetchConfigForPR(ctx, client, pr)
+	switch {
+	case err != nil:
+		status.ConfigError = err.Error()
+		return status, http.StatusOK, nil
+	case config == nil:
+		status.ConfigError = "bulldozer does not apply to the pull request: the configuration is missing, invalid, or breaks the organization policy, or bulldozer is disabled for the repository"
+		return status, http.StatusOK, nil
+	}
+
+	eligibility, err := d.explainEligibility(ctx, d.NewPullContext(client, pr), config)
+	if err != nil {
+		return nil, http.StatusBadGateway, err
+	}
+	status.Eligibility = eligibility
+	return status, http.StatusOK, nil
+}
+
+var statusPage = template.Must(template.New("status").Funcs(template.FuncMap{
+	"contains": func(values []string, value string) bool {
+		for _, v := range values {
+			if v == value {
+				return true
+			}
+		}
+		return false
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>bulldozer: {{.Owner}}/{{.Repo}}#{{.Number}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em auto; max-width: 60em; color: #24292f; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+th, td { border: 1px solid #d0d7de; padding: 0.3em 0.6em; text-align: left; }
+.yes { color: #1a7f37; }
+.no { color: #cf222e; }
+.note { background: #fff8c5; padding: 0.5em; }
+</style>
+</head>
+<body>
+<h1><a href="{{.URL}}">{{.Owner}}/{{.Repo}}#{{.Number}}</a>: {{.Title}}</h1>
+<p>State: {{.State}} at <code>{{.HeadSHA}}</code></p>
+{{if .Paused}}<p class="note">bulldozer is paused for this pull request or repository.</p>{{end}}
+{{if .ConfigError}}<p class="note">Configuration: {{.ConfigError}}</p>{{end}}
+{{with .Eligibility}}
+<h2>Merge</h2>
+<p class="{{if .Mergeable}}yes{{else}}no{{end}}">The pull request is {{.Reason}}.</p>
+<p>The pull request is {{.UpdateReason}}.</p>
+{{if .Draft}}<p>The pull request is a draft.</p>{{end}}
+<h2>Signals</h2>
+{{if or .Trigger .Ignore}}
+<table>
+<tr><th>Behavior</th><th>Signal</th><th>Matched</th><th>Description</th></tr>
+{{range .Trigger}}<tr><td>trigger</td><td>{{.Name}}</td><td class="{{if .Matched}}yes{{else}}no{{end}}">{{.Matched}}</td><td>{{.Description}}</td></tr>
+{{end}}{{range .Ignore}}<tr><td>ignore</td><td>{{.Name}}</td><td class="{{if .Matched}}no{{else}}yes{{end}}">{{.Matched}}</td><td>{{.Description}}</td></tr>
+{{end}}</table>
+{{else}}<p>No trigger or ignore signals are configured.</p>{{end}}
+<h2>Required statuses</h2>
+{{if .RequiredStatuses}}
+<table>
+<tr><th>Status</th><th>Passed</th></tr>
+{{$unsatisfied := .UnsatisfiedStatuses}}{{range .RequiredStatuses}}<tr><td>{{.}}</td>{{if contains $unsatisfied .}}<td class="no">false</td>{{else}}<td class="yes">true</td>{{end}}</tr>
+{{end}}</table>
+{{else}}<p>No statuses are required.</p>{{end}}
+{{if .PolicyBot}}
+<h2>Unapproved policy-bot rules</h2>
+<table>
+<tr><th>Rule</th><th>Status</th><th>Description</th></tr>
+{{range .PolicyBot}}<tr><td>{{.Name}}</td><td class="no">{{.Status}}</td><td>{{.Description}}</td></tr>
+{{end}}</table>
+{{end}}
+{{end}}
+<h2>Last action</h2>
+{{with .LastAction}}
+<p>{{.Time.Format "2006-01-02 15:04:05 MST"}}: {{.Action}} evaluation at <code>{{.SHA}}</code> was {{.Decision}}.</p>
+{{if .Reason}}<p>Reason: {{.Reason}}</p>{{end}}
+{{else}}<p>bulldozer has not evaluated this pull request since it started.</p>{{end}}
+</body>
+</html>
+`))
+
+func writeStatusPage(w http.ResponseWriter, status *PullRequestStatus) error {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	return statusPage.Execute(w, status)
+}
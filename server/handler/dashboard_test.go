@@ -0,0 +1,115 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/palantir/bulldozer/audit"
+	"github.com/palantir/bulldozer/bulldozer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goji.io"
+	"goji.io/pat"
+)
+
+func TestLastActions(t *testing.T) {
+	actions := NewLastActions(2)
+
+	actions.Add(audit.Record{Owner: "testorg", Repo: "testrepo", Number: 1, Decision: "not_ready"})
+	actions.Add(audit.Record{Owner: "testorg", Repo: "testrepo", Number: 2, Decision: "not_ready"})
+	actions.Add(audit.Record{Owner: "testorg", Repo: "testrepo", Number: 1, Decision: "merged"})
+
+	r, ok := actions.Get(PullRequestRef{Owner: "testorg", Repo: "testrepo", Number: 1})
+	require.True(t, ok)
+	assert.Equal(t, "merged", r.Decision)
+	assert.False(t, r.Time.IsZero())
+
+	// adding a third pull request forgets the least recently evaluated one
+	actions.Add(audit.Record{Owner: "testorg", Repo: "testrepo", Number: 3, Decision: "waiting"})
+
+	_, ok = actions.Get(PullRequestRef{Owner: "testorg", Repo: "testrepo", Number: 2})
+	assert.False(t, ok)
+	_, ok = actions.Get(PullRequestRef{Owner: "testorg", Repo: "testrepo", Number: 1})
+	assert.True(t, ok)
+
+	var nilActions *LastActions
+	nilActions.Add(r)
+	_, ok = nilActions.Get(PullRequestRef{Owner: "testorg", Repo: "testrepo", Number: 1})
+	assert.False(t, ok)
+}
+
+func TestDashboardAuthentication(t *testing.T) {
+	do := func(d *Dashboard, token string) int {
+		mux := goji.NewMux()
+		mux.Handle(pat.Get("/api/status/:owner/:repo"), d.RepositoryAPI())
+
+		r := httptest.NewRequest(http.MethodGet, "/api/status/testorg/testrepo", nil)
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	d := &Dashboard{Base: Base{Pauses: NewPauses()}, Token: "secret"}
+	assert.Equal(t, http.StatusUnauthorized, do(d, ""))
+	assert.Equal(t, http.StatusUnauthorized, do(d, "wrong"))
+	assert.Equal(t, http.StatusOK, do(d, "secret"))
+
+	// without a token, the dashboard rejects all requests
+	d.Token = ""
+	assert.Equal(t, http.StatusUnauthorized, do(d, ""))
+}
+
+func TestWriteStatusPage(t *testing.T) {
+	w := httptest.NewRecorder()
+	err := writeStatusPage(w, &PullRequestStatus{
+		Owner:   "testorg",
+		Repo:    "testrepo",
+		Number:  1,
+		Title:   "<Add feature>",
+		HeadSHA: "a6b1b2c",
+		State:   "open",
+		Eligibility: &bulldozer.Eligibility{
+			Reason:              "not mergeable because of unfulfilled status checks: [lint]",
+			UpdateReason:        "not updateable because updates are not configured",
+			Trigger:             []bulldozer.SignalResult{{Name: "labels", Matched: true, Description: `pull request has a triggered label: "merge when ready"`}},
+			RequiredStatuses:    []string{"build", "lint"},
+			SuccessStatuses:     []string{"build"},
+			UnsatisfiedStatuses: []string{"lint"},
+		},
+		LastAction: &audit.Record{
+			Time:     time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC),
+			Action:   audit.ActionMerge,
+			SHA:      "a6b1b2c",
+			Decision: "not_ready",
+		},
+	})
+	require.NoError(t, err)
+
+	body := w.Body.String()
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, body, "&lt;Add feature&gt;")
+	assert.Contains(t, body, "The pull request is not mergeable because of unfulfilled status checks: [lint].")
+	assert.Contains(t, body, "<td>trigger</td><td>labels</td>")
+	assert.Contains(t, body, `<tr><td>lint</td><td class="no">false</td></tr>`)
+	assert.Contains(t, body, `<tr><td>build</td><td class="yes">true</td></tr>`)
+	assert.Contains(t, body, "2026-06-01 12:00:00 UTC: merge evaluation at <code>a6b1b2c</code> was not_ready.")
+}
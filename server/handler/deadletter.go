@@ -0,0 +1,303 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/palantir/go-githubapp/githubapp"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// DefaultDeadLetterLimit is the number of dead letters kept if no limit is
+// given. Once the limit is reached, the oldest dead letter is dropped.
+const DefaultDeadLetterLimit = 1000
+
+// ErrDeadLetterNotFound is returned when replaying or deleting a dead letter
+// that does not exist.
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+// DeadLetter is a webhook delivery that failed to process.
+type DeadLetter struct {
+	// ID is the GUID of the webhook delivery.
+	ID        string          `json:"id"`
+	EventType string          `json:"event_type"`
+	Payload   json.RawMessage `json:"payload"`
+
+	Error    string    `json:"error"`
+	Time     time.Time `json:"time"`
+	Attempts int       `json:"attempts"`
+}
+
+// DeadLetterStore stores dead letters by ID. Implementations must be safe for
+// concurrent use.
+type DeadLetterStore interface {
+	// Put adds a dead letter, replacing any dead letter with the same ID.
+	Put(ctx context.Context, letter DeadLetter) error
+
+	// Get returns the dead letter with the ID or ErrDeadLetterNotFound.
+	Get(ctx context.Context, id string) (DeadLetter, error)
+
+	// Delete removes the dead letter with the ID, if it exists.
+	Delete(ctx context.Context, id string) error
+
+	// List returns all dead letters in order of time.
+	List(ctx context.Context) ([]DeadLetter, error)
+}
+
+// MemoryDeadLetterStore is a DeadLetterStore that does not survive restarts.
+type MemoryDeadLetterStore struct {
+	limit int
+
+	mu      sync.Mutex
+	letters map[string]DeadLetter
+}
+
+// NewMemoryDeadLetterStore creates a store that keeps up to limit dead
+// letters. If limit is not positive, it uses DefaultDeadLetterLimit.
+func NewMemoryDeadLetterStore(limit int) *MemoryDeadLetterStore {
+	if limit <= 0 {
+		limit = DefaultDeadLetterLimit
+	}
+	return &MemoryDeadLetterStore{
+		limit:   limit,
+		letters: make(map[string]DeadLetter),
+	}
+}
+
+func (s *MemoryDeadLetterStore) Put(ctx context.Context, letter DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.letters[letter.ID] = letter
+	for len(s.letters) > s.limit {
+		var oldest *DeadLetter
+		for _, l := range s.letters {
+			if oldest == nil || l.Time.Before(oldest.Time) {
+				l := l
+				oldest = &l
+			}
+		}
+		delete(s.letters, oldest.ID)
+	}
+	return nil
+}
+
+func (s *MemoryDeadLetterStore) Get(ctx context.Context, id string) (DeadLetter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	letter, ok := s.letters[id]
+	if !ok {
+		return DeadLetter{}, ErrDeadLetterNotFound
+	}
+	return letter, nil
+}
+
+func (s *MemoryDeadLetterStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.letters, id)
+	return nil
+}
+
+func (s *MemoryDeadLetterStore) List(ctx context.Context) ([]DeadLetter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	letters := make([]DeadLetter, 0, len(s.letters))
+	for _, l := range s.letters {
+		letters = append(letters, l)
+	}
+	sort.Slice(letters, func(i, j int) bool {
+		return letters[i].Time.Before(letters[j].Time)
+	})
+	return letters, nil
+}
+
+// FileDeadLetterStore is a DeadLetterStore that stores dead letters in a JSON
+// file, so that they survive restarts. The file must not be shared by
+// multiple servers.
+type FileDeadLetterStore struct {
+	path string
+
+	mu     sync.Mutex
+	memory *MemoryDeadLetterStore
+}
+
+// NewFileDeadLetterStore creates a store at the given path that keeps up to
+// limit dead letters, loading any existing dead letters from the file.
+func NewFileDeadLetterStore(path string, limit int) (*FileDeadLetterStore, error) {
+	s := &FileDeadLetterStore{
+		path:   path,
+		memory: NewMemoryDeadLetterStore(limit),
+	}
+
+	b, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		return s, nil
+	case err != nil:
+		return nil, errors.Wrapf(err, "failed to read dead letter file %s", path)
+	}
+
+	var letters []DeadLetter
+	if err := json.Unmarshal(b, &letters); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse dead letter file %s", path)
+	}
+	for _, l := range letters {
+		_ = s.memory.Put(context.Background(), l)
+	}
+	return s, nil
+}
+
+func (s *FileDeadLetterStore) Put(ctx context.Context, letter DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_ = s.memory.Put(ctx, letter)
+	return s.save(ctx)
+}
+
+func (s *FileDeadLetterStore) Get(ctx context.Context, id string) (DeadLetter, error) {
+	return s.memory.Get(ctx, id)
+}
+
+func (s *FileDeadLetterStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_ = s.memory.Delete(ctx, id)
+	return s.save(ctx)
+}
+
+func (s *FileDeadLetterStore) List(ctx context.Context) ([]DeadLetter, error) {
+	return s.memory.List(ctx)
+}
+
+// save replaces the file with the current dead letters.
+func (s *FileDeadLetterStore) save(ctx context.Context) error {
+	letters, _ := s.memory.List(ctx)
+	b, err := json.Marshal(letters)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize dead letters")
+	}
+	return writeFileAtomic(s.path, b)
+}
+
+// DeadLetters stores webhook deliveries that fail to process and replays
+// them on request.
+type DeadLetters struct {
+	store DeadLetterStore
+
+	mu       sync.Mutex
+	handlers map[string]githubapp.EventHandler
+}
+
+func NewDeadLetters(store DeadLetterStore) *DeadLetters {
+	return &DeadLetters{
+		store:    store,
+		handlers: make(map[string]githubapp.EventHandler),
+	}
+}
+
+// Wrap wraps an event handler to store deliveries that it fails to process.
+// The wrapped handler is used to replay deliveries for its event types.
+func (d *DeadLetters) Wrap(h githubapp.EventHandler) githubapp.EventHandler {
+	wrapped := &deadLetterHandler{EventHandler: h, deadLetters: d}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, eventType := range h.Handles() {
+		d.handlers[eventType] = wrapped
+	}
+	return wrapped
+}
+
+// List returns all dead letters in order of time.
+func (d *DeadLetters) List(ctx context.Context) ([]DeadLetter, error) {
+	return d.store.List(ctx)
+}
+
+// Delete removes a dead letter without replaying it.
+func (d *DeadLetters) Delete(ctx context.Context, id string) error {
+	if _, err := d.store.Get(ctx, id); err != nil {
+		return err
+	}
+	return d.store.Delete(ctx, id)
+}
+
+// Replay processes a dead letter again. If it succeeds, the dead letter is
+// removed. Otherwise, it remains with the new error.
+func (d *DeadLetters) Replay(ctx context.Context, id string) error {
+	letter, err := d.store.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	h, ok := d.handlers[letter.EventType]
+	d.mu.Unlock()
+	if !ok {
+		return errors.Errorf("no handler for %s events", letter.EventType)
+	}
+
+	if err := h.Handle(ctx, letter.EventType, letter.ID, letter.Payload); err != nil {
+		return err
+	}
+	return d.store.Delete(ctx, id)
+}
+
+// add stores a failed delivery, counting previous attempts to process it.
+func (d *DeadLetters) add(ctx context.Context, eventType, deliveryID string, payload []byte, handleErr error) {
+	letter := DeadLetter{
+		ID:        deliveryID,
+		EventType: eventType,
+		Payload:   json.RawMessage(payload),
+		Error:     handleErr.Error(),
+		Time:      time.Now().UTC(),
+		Attempts:  1,
+	}
+	if previous, err := d.store.Get(ctx, deliveryID); err == nil {
+		letter.Attempts = previous.Attempts + 1
+	}
+
+	if err := d.store.Put(ctx, letter); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msgf("Failed to store dead letter for delivery %s", deliveryID)
+	}
+}
+
+type deadLetterHandler struct {
+	githubapp.EventHandler
+	deadLetters *DeadLetters
+}
+
+func (h *deadLetterHandler) Handle(ctx context.Context, eventType, deliveryID string, payload []byte) error {
+	err := h.EventHandler.Handle(ctx, eventType, deliveryID, payload)
+	if err != nil && deliveryID != "" {
+		h.deadLetters.add(ctx, eventType, deliveryID, payload, err)
+	}
+	return err
+}
+
+// type assertions
+var _ DeadLetterStore = &MemoryDeadLetterStore{}
+var _ DeadLetterStore = &FileDeadLetterStore{}
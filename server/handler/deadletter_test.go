@@ -0,0 +1,112 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingHandler struct {
+	err      error
+	payloads []string
+}
+
+func (h *failingHandler) Handles() []string {
+	return []string{"status"}
+}
+
+func (h *failingHandler) Handle(ctx context.Context, eventType, deliveryID string, payload []byte) error {
+	h.payloads = append(h.payloads, string(payload))
+	return h.err
+}
+
+func TestDeadLetters(t *testing.T) {
+	ctx := context.Background()
+
+	h := &failingHandler{err: errors.New("github is down")}
+	deadLetters := NewDeadLetters(NewMemoryDeadLetterStore(0))
+	wrapped := deadLetters.Wrap(h)
+
+	err := wrapped.Handle(ctx, "status", "delivery-1", []byte(`{"sha":"abc"}`))
+	require.EqualError(t, err, "github is down")
+
+	letters, err := deadLetters.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, letters, 1)
+	assert.Equal(t, "delivery-1", letters[0].ID)
+	assert.Equal(t, "status", letters[0].EventType)
+	assert.JSONEq(t, `{"sha":"abc"}`, string(letters[0].Payload))
+	assert.Equal(t, "github is down", letters[0].Error)
+	assert.Equal(t, 1, letters[0].Attempts)
+
+	// a failed replay keeps the dead letter and counts the attempt
+	require.Error(t, deadLetters.Replay(ctx, "delivery-1"))
+	letters, _ = deadLetters.List(ctx)
+	require.Len(t, letters, 1)
+	assert.Equal(t, 2, letters[0].Attempts)
+
+	// a successful replay removes the dead letter
+	h.err = nil
+	require.NoError(t, deadLetters.Replay(ctx, "delivery-1"))
+	letters, _ = deadLetters.List(ctx)
+	assert.Empty(t, letters)
+	assert.Equal(t, []string{`{"sha":"abc"}`, `{"sha":"abc"}`, `{"sha":"abc"}`}, h.payloads)
+
+	assert.Equal(t, ErrDeadLetterNotFound, deadLetters.Replay(ctx, "delivery-1"))
+	assert.Equal(t, ErrDeadLetterNotFound, deadLetters.Delete(ctx, "delivery-1"))
+}
+
+func TestMemoryDeadLetterStoreLimit(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryDeadLetterStore(2)
+
+	now := time.Now()
+	require.NoError(t, store.Put(ctx, DeadLetter{ID: "b", Time: now.Add(-time.Minute)}))
+	require.NoError(t, store.Put(ctx, DeadLetter{ID: "a", Time: now.Add(-time.Hour)}))
+	require.NoError(t, store.Put(ctx, DeadLetter{ID: "c", Time: now}))
+
+	letters, err := store.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, letters, 2)
+	assert.Equal(t, "b", letters[0].ID)
+	assert.Equal(t, "c", letters[1].ID)
+}
+
+func TestFileDeadLetterStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "deadletters.json")
+
+	store, err := NewFileDeadLetterStore(path, 0)
+	require.NoError(t, err)
+	require.NoError(t, store.Put(ctx, DeadLetter{ID: "a", EventType: "push", Payload: []byte(`{}`), Time: time.Now().UTC()}))
+	require.NoError(t, store.Put(ctx, DeadLetter{ID: "b", EventType: "status", Payload: []byte(`{}`), Time: time.Now().UTC()}))
+	require.NoError(t, store.Delete(ctx, "a"))
+
+	restored, err := NewFileDeadLetterStore(path, 0)
+	require.NoError(t, err)
+
+	letters, err := restored.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, letters, 1)
+	assert.Equal(t, "b", letters[0].ID)
+	assert.Equal(t, "status", letters[0].EventType)
+}
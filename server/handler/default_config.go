@@ -0,0 +1,115 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"github.com/palantir/bulldozer/bulldozer"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+type DefaultConfigMode string
+
+const (
+	// DefaultConfigFallback uses the default configuration only for
+	// repositories without a configuration file.
+	DefaultConfigFallback DefaultConfigMode = "fallback"
+
+	// DefaultConfigOverride merges repository configuration files into the
+	// default configuration, like extends, so they can change any value.
+	DefaultConfigOverride DefaultConfigMode = "override"
+
+	// DefaultConfigExtend merges repository configuration files into the
+	// default configuration, but they can only add to lists and set values
+	// that the default configuration does not set.
+	DefaultConfigExtend DefaultConfigMode = "extend"
+)
+
+// DefaultConfig is repository configuration defined by the server.
+type DefaultConfig struct {
+	Config *bulldozer.Config
+	Mode   DefaultConfigMode
+
+	// content is the configuration as YAML, which repository configuration
+	// files are merged into
+	content []byte
+}
+
+func (c *DefaultConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var config bulldozer.Config
+	if err := unmarshal(&config); err != nil {
+		return err
+	}
+
+	var m yaml.MapSlice
+	if err := unmarshal(&m); err != nil {
+		return err
+	}
+	if !hasKey(m, "version") {
+		m = append(yaml.MapSlice{{Key: "version", Value: 1}}, m...)
+	}
+	content, err := yaml.Marshal(m)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal default configuration")
+	}
+
+	c.Config, c.content = &config, content
+	return nil
+}
+
+func (m DefaultConfigMode) Validate() error {
+	switch m {
+	case "", DefaultConfigFallback, DefaultConfigOverride, DefaultConfigExtend:
+		return nil
+	}
+	return errors.Errorf("invalid mode %q: must be %q, %q, or %q", m, DefaultConfigFallback, DefaultConfigOverride, DefaultConfigExtend)
+}
+
+// apply combines the repository configuration with the default
+// configuration, unless the default is only a fallback. Version 0
+// configuration files are never combined.
+func (c *DefaultConfig) apply(content []byte) ([]byte, error) {
+	if c == nil || c.Mode == "" || c.Mode == DefaultConfigFallback {
+		return content, nil
+	}
+
+	var version struct {
+		Version int `yaml:"version"`
+	}
+	if err := yaml.Unmarshal(content, &version); err != nil || version.Version != 1 {
+		return content, nil
+	}
+
+	var merged []byte
+	var err error
+	if c.Mode == DefaultConfigExtend {
+		merged, err = bulldozer.ExtendConfig(c.content, content)
+	} else {
+		merged, err = bulldozer.MergeConfigs(c.content, content)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to merge configuration with the server default")
+	}
+	return merged, nil
+}
+
+func hasKey(m yaml.MapSlice, key string) bool {
+	for _, item := range m {
+		if item.Key == key {
+			return true
+		}
+	}
+	return false
+}
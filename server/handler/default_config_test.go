@@ -0,0 +1,117 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/bulldozer"
+	"github.com/palantir/go-githubapp/appconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestConfigFetcherDefaultConfig(t *testing.T) {
+	files := map[string]string{
+		"testorg/testrepo/contents/.bulldozer.yml@main": `
+version: 1
+merge:
+  trigger:
+    labels: ["merge when ready"]
+`,
+		"testorg/otherrepo/contents/.bulldozer.yml@main": `
+version: 1
+merge:
+  method: merge
+`,
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.URL.Path, "/repos/") + "@" + r.URL.Query().Get("ref")
+		content, ok := files[key]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"type":     "file",
+			"encoding": "base64",
+			"content":  base64.StdEncoding.EncodeToString([]byte(content)),
+		})
+	}))
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	var options Options
+	require.NoError(t, yaml.UnmarshalStrict([]byte(`
+default_repository_config:
+  merge:
+    trigger:
+      authors: ["renovate[bot]"]
+    method: squash
+`), &options))
+
+	fetch := func(mode DefaultConfigMode, repo string) FetchedConfig {
+		options.DefaultRepositoryConfigMode = mode
+		fetcher := NewConfigFetcher(appconfig.NewLoader([]string{".bulldozer.yml"}), options.DefaultConfig())
+		return fetcher.Config(context.Background(), client, "testorg", repo, "main")
+	}
+
+	t.Run("fallback", func(t *testing.T) {
+		fc := fetch(DefaultConfigFallback, "missingrepo")
+		require.NoError(t, fc.LoadError)
+		require.NotNil(t, fc.Config)
+		assert.Equal(t, bulldozer.AuthorsSignal{"renovate[bot]"}, fc.Config.Merge.Trigger.Authors)
+
+		fc = fetch(DefaultConfigFallback, "testrepo")
+		require.NoError(t, fc.ParseError)
+		assert.Empty(t, fc.Config.Merge.Trigger.Authors)
+		assert.Equal(t, bulldozer.LabelsSignal{"merge when ready"}, fc.Config.Merge.Trigger.Labels)
+	})
+
+	t.Run("override", func(t *testing.T) {
+		fc := fetch(DefaultConfigOverride, "otherrepo")
+		require.NoError(t, fc.ParseError)
+		assert.Equal(t, bulldozer.AuthorsSignal{"renovate[bot]"}, fc.Config.Merge.Trigger.Authors)
+		assert.Equal(t, bulldozer.MergeCommit, fc.Config.Merge.Method)
+	})
+
+	t.Run("extend", func(t *testing.T) {
+		fc := fetch(DefaultConfigExtend, "testrepo")
+		require.NoError(t, fc.ParseError)
+		assert.Equal(t, bulldozer.AuthorsSignal{"renovate[bot]"}, fc.Config.Merge.Trigger.Authors)
+		assert.Equal(t, bulldozer.LabelsSignal{"merge when ready"}, fc.Config.Merge.Trigger.Labels)
+		assert.Equal(t, bulldozer.SquashAndMerge, fc.Config.Merge.Method)
+
+		fc = fetch(DefaultConfigExtend, "otherrepo")
+		assert.EqualError(t, fc.ParseError, `failed to merge configuration with the server default: cannot change "merge.method", which is set by the base configuration`)
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		assert.NoError(t, DefaultConfigExtend.Validate())
+		assert.EqualError(t, DefaultConfigMode("merge").Validate(), `invalid mode "merge": must be "fallback", "override", or "extend"`)
+	})
+}
@@ -0,0 +1,159 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/bulldozer"
+	"github.com/palantir/go-githubapp/githubapp"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// Dependents remembers the pull requests that declare dependencies on each
+// issue or pull request, so that they are evaluated again when a dependency
+// closes. It only knows about pull requests that were evaluated since the
+// server started. It is safe for concurrent use.
+type Dependents struct {
+	mu           sync.Mutex
+	dependents   map[string]map[PullRequestRef]bool
+	dependencies map[PullRequestRef][]string
+}
+
+func NewDependents() *Dependents {
+	return &Dependents{
+		dependents:   make(map[string]map[PullRequestRef]bool),
+		dependencies: make(map[PullRequestRef][]string),
+	}
+}
+
+func dependencyKey(owner, repo string, number int) string {
+	return strings.ToLower(fmt.Sprintf("%s/%s#%d", owner, repo, number))
+}
+
+// Set replaces the dependencies of the pull request.
+func (d *Dependents) Set(ref PullRequestRef, deps []bulldozer.Dependency) {
+	if d == nil {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.remove(ref)
+	if len(deps) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(deps))
+	for _, dep := range deps {
+		key := dependencyKey(dep.Owner, dep.Repo, dep.Number)
+		if d.dependents[key] == nil {
+			d.dependents[key] = make(map[PullRequestRef]bool)
+		}
+		d.dependents[key][ref] = true
+		keys = append(keys, key)
+	}
+	d.dependencies[ref] = keys
+}
+
+// Remove forgets the dependencies of the pull request.
+func (d *Dependents) Remove(ref PullRequestRef) {
+	if d == nil {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.remove(ref)
+}
+
+func (d *Dependents) remove(ref PullRequestRef) {
+	for _, key := range d.dependencies[ref] {
+		delete(d.dependents[key], ref)
+		if len(d.dependents[key]) == 0 {
+			delete(d.dependents, key)
+		}
+	}
+	delete(d.dependencies, ref)
+}
+
+// Get returns the pull requests that depend on the issue or pull request.
+func (d *Dependents) Get(owner, repo string, number int) []PullRequestRef {
+	if d == nil {
+		return nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var refs []PullRequestRef
+	for ref := range d.dependents[dependencyKey(owner, repo, number)] {
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// evaluateDependents schedules evaluations of the pull requests that depend
+// on the issue or pull request.
+func (b *Base) evaluateDependents(ctx context.Context, owner, repo string, number int) {
+	if b.Scheduler == nil {
+		return
+	}
+	for _, ref := range b.Dependents.Get(owner, repo, number) {
+		zerolog.Ctx(ctx).Debug().Msgf("Scheduling evaluation of %s/%s#%d, which depends on %s/%s#%d", ref.Owner, ref.Repo, ref.Number, owner, repo, number)
+		b.Scheduler.Schedule(ctx, ref, time.Now())
+	}
+}
+
+// Issues evaluates pull requests that depend on issues when the issues are
+// closed or reopened.
+type Issues struct {
+	Base
+}
+
+func (h *Issues) Handles() []string {
+	return []string{"issues"}
+}
+
+func (h *Issues) Handle(ctx context.Context, eventType, deliveryID string, payload []byte) error {
+	var event github.IssuesEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return errors.Wrap(err, "failed to parse issues event payload")
+	}
+
+	repo := event.GetRepo()
+	number := event.GetIssue().GetNumber()
+	installationID := githubapp.GetInstallationIDFromEvent(&event)
+	ctx, logger := githubapp.PreparePRContext(ctx, installationID, repo, number)
+
+	logger.Debug().Msgf("Received issues %s event", event.GetAction())
+
+	switch event.GetAction() {
+	case "closed", "reopened":
+		h.evaluateDependents(ctx, repo.GetOwner().GetLogin(), repo.GetName(), number)
+	}
+	return nil
+}
+
+// type assertion
+var _ githubapp.EventHandler = &Issues{}
@@ -0,0 +1,53 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"testing"
+
+	"github.com/palantir/bulldozer/bulldozer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDependents(t *testing.T) {
+	d := NewDependents()
+	pr1 := PullRequestRef{Owner: "testorg", Repo: "testrepo", Number: 1}
+	pr2 := PullRequestRef{Owner: "testorg", Repo: "testrepo", Number: 2}
+
+	d.Set(pr1, []bulldozer.Dependency{
+		{Owner: "testorg", Repo: "testrepo", Number: 10},
+		{Owner: "TestOrg", Repo: "OtherRepo", Number: 20},
+	})
+	d.Set(pr2, []bulldozer.Dependency{
+		{Owner: "testorg", Repo: "testrepo", Number: 10},
+	})
+
+	assert.ElementsMatch(t, []PullRequestRef{pr1, pr2}, d.Get("testorg", "testrepo", 10))
+	assert.Equal(t, []PullRequestRef{pr1}, d.Get("testorg", "otherrepo", 20))
+
+	d.Set(pr1, []bulldozer.Dependency{
+		{Owner: "testorg", Repo: "testrepo", Number: 30},
+	})
+	assert.Equal(t, []PullRequestRef{pr2}, d.Get("testorg", "testrepo", 10))
+	assert.Empty(t, d.Get("testorg", "otherrepo", 20))
+	assert.Equal(t, []PullRequestRef{pr1}, d.Get("testorg", "testrepo", 30))
+
+	d.Remove(pr2)
+	assert.Empty(t, d.Get("testorg", "testrepo", 10))
+
+	var nilDependents *Dependents
+	nilDependents.Set(pr1, nil)
+	assert.Empty(t, nilDependents.Get("testorg", "testrepo", 30))
+}
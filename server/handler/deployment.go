@@ -0,0 +1,58 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/go-githubapp/githubapp"
+	"github.com/pkg/errors"
+)
+
+// Deployment evaluates pull requests when a deployment of their head commit
+// is created or changes state, for repositories that use deployment signals.
+type Deployment struct {
+	Base
+}
+
+func (h *Deployment) Handles() []string {
+	return []string{"deployment", "deployment_status"}
+}
+
+func (h *Deployment) Handle(ctx context.Context, eventType, deliveryID string, payload []byte) error {
+	// deployment events are a subset of deployment_status events
+	var event github.DeploymentStatusEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return errors.Wrapf(err, "failed to parse %s event payload", eventType)
+	}
+
+	repo := event.GetRepo()
+	installationID := githubapp.GetInstallationIDFromEvent(&event)
+	ctx, logger := githubapp.PrepareRepoContext(ctx, installationID, repo)
+
+	deployment := event.GetDeployment()
+	state := "pending"
+	if eventType == "deployment_status" {
+		state = event.GetDeploymentStatus().GetState()
+	}
+	logger.Debug().Msgf("Received %s event for environment %q with state %q", eventType, deployment.GetEnvironment(), state)
+
+	return h.evaluateCommit(ctx, installationID, repo, deployment.GetSHA(), nil)
+}
+
+// type assertion
+var _ githubapp.EventHandler = &Deployment{}
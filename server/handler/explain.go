@@ -0,0 +1,113 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/bulldozer"
+	"github.com/palantir/bulldozer/pull"
+	"github.com/rs/zerolog"
+)
+
+// evaluation captures the last merge decision recorded while processing a
+// pull request.
+type evaluation struct {
+	decision string
+	reason   string
+}
+
+type evaluationKey struct{}
+
+// withEvaluation returns a context that captures merge decisions in e.
+func withEvaluation(ctx context.Context, e *evaluation) context.Context {
+	return context.WithValue(ctx, evaluationKey{}, e)
+}
+
+// publishCheckRun publishes the decision and the state of each signal and
+// status as the bulldozer check run. Errors are logged, because the check run
+// is only informational.
+func (b *Base) publishCheckRun(ctx context.Context, pullCtx pull.Context, client *github.Client, config *bulldozer.Config, e evaluation) {
+	logger := zerolog.Ctx(ctx)
+
+	eligibility, err := b.explainEligibility(ctx, pullCtx, config)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to evaluate pull request for check run")
+		return
+	}
+
+	title, summary := checkRunOutput(e)
+	if err := bulldozer.PublishCheckRun(ctx, pullCtx, client, eligibility, title, summary); err != nil {
+		logger.Error().Err(err).Msg("Failed to publish check run")
+	}
+}
+
+var policyBotClient = &http.Client{Timeout: 10 * time.Second}
+
+// explainEligibility evaluates the pull request like
+// bulldozer.ExplainEligibility and adds the policy-bot rules that are not
+// approved if the server can read them and the policy-bot status has not
+// passed. Errors reading the rules are logged, because the rules are only
+// informational.
+func (b *Base) explainEligibility(ctx context.Context, pullCtx pull.Context, config *bulldozer.Config) (*bulldozer.Eligibility, error) {
+	eligibility, err := bulldozer.ExplainEligibility(ctx, pullCtx, *config)
+	if err != nil || !b.PolicyBot.Enabled() {
+		return eligibility, err
+	}
+
+	statuses, err := bulldozer.PolicyBotStatuses(ctx, pullCtx, config.Merge.PolicyBot)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range statuses {
+		if s.State != "success" {
+			rules, err := b.PolicyBot.UnmetRules(ctx, policyBotClient, pullCtx.Owner(), pullCtx.Repo(), pullCtx.Number())
+			if err != nil {
+				zerolog.Ctx(ctx).Warn().Err(err).Msg("Failed to read policy-bot rules")
+				break
+			}
+			eligibility.PolicyBot = rules
+			break
+		}
+	}
+	return eligibility, nil
+}
+
+// checkRunOutput returns the title and summary of the check run for the
+// decision.
+func checkRunOutput(e evaluation) (string, string) {
+	var title string
+	switch e.decision {
+	case outcomeNotReady:
+		title = "Not ready to merge"
+	case outcomeWaiting:
+		title = "Waiting to merge"
+	case outcomeRolledUp:
+		title = "Added to a rollup"
+	case outcomeMerged:
+		title = "Merged"
+	case outcomeFailed:
+		title = "Merge failed"
+	case outcomePaused:
+		return "Paused", fmt.Sprintf("The pull request is not merged or updated because %s.", e.reason)
+	default:
+		title = e.decision
+	}
+	return title, fmt.Sprintf("The pull request is %s.", e.reason)
+}
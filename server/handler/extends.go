@@ -0,0 +1,182 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/bulldozer"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// MaxExtendsDepth is the maximum number of configuration files that can
+	// be chained together with extends.
+	MaxExtendsDepth = 5
+
+	// DefaultExtendsCacheTTL is how long configuration files that are
+	// extended by other files are cached.
+	DefaultExtendsCacheTTL = 5 * time.Minute
+)
+
+// extendsError is an error loading an extended or included configuration
+// file, as opposed to an error in the configuration itself.
+type extendsError struct {
+	error
+}
+
+type extendsKey struct {
+	owner string
+	ref   string
+}
+
+type extendsEntry struct {
+	content []byte
+	expires time.Time
+}
+
+// extendsCache stores extended configuration files for a fixed amount of
+// time, so that many repositories that extend the same file do not each
+// fetch it. Entries are keyed by the owner of the repository that requested
+// the file, so they are never shared between installations. It is safe for
+// concurrent use.
+type extendsCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[extendsKey]extendsEntry
+}
+
+func newExtendsCache(ttl time.Duration) *extendsCache {
+	return &extendsCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[extendsKey]extendsEntry),
+	}
+}
+
+func (c *extendsCache) get(owner string, ref bulldozer.ExtendsRef) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := newExtendsKey(owner, ref)
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.content, true
+}
+
+func (c *extendsCache) add(owner string, ref bulldozer.ExtendsRef, content []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+
+	c.entries[newExtendsKey(owner, ref)] = extendsEntry{
+		content: content,
+		expires: now.Add(c.ttl),
+	}
+}
+
+func newExtendsKey(owner string, ref bulldozer.ExtendsRef) extendsKey {
+	return extendsKey{
+		owner: strings.ToLower(owner),
+		ref:   strings.ToLower(ref.String()),
+	}
+}
+
+// resolveExtends merges the configuration into the files it extends, if any.
+// The chain contains the files that have already been read, to detect
+// cycles.
+func (cf *ConfigFetcher) resolveExtends(ctx context.Context, client *github.Client, owner string, content []byte, chain []string) ([]byte, error) {
+	extends, err := bulldozer.ReadExtends(content)
+	if err != nil || extends == "" {
+		return content, err
+	}
+
+	ref, err := bulldozer.ParseExtendsRef(extends)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range chain {
+		if strings.EqualFold(c, ref.String()) {
+			return nil, errors.Errorf("configuration extends itself: %s -> %s", strings.Join(chain, " -> "), ref)
+		}
+	}
+	if len(chain) > MaxExtendsDepth {
+		return nil, errors.Errorf("configuration extends more than %d files: %s", MaxExtendsDepth, strings.Join(chain, " -> "))
+	}
+
+	base, err := cf.fetchExtends(ctx, client, owner, ref)
+	if err != nil {
+		return nil, err
+	}
+	base, err = cf.resolveExtends(ctx, client, owner, base, append(chain, ref.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := bulldozer.MergeConfigs(base, content)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to merge configuration with %s", ref)
+	}
+	return merged, nil
+}
+
+func (cf *ConfigFetcher) fetchExtends(ctx context.Context, client *github.Client, owner string, ref bulldozer.ExtendsRef) ([]byte, error) {
+	if content, ok := cf.extends.get(owner, ref); ok {
+		return content, nil
+	}
+
+	zerolog.Ctx(ctx).Debug().Msgf("Fetching extended configuration %s", ref)
+
+	file, _, res, err := client.Repositories.GetContents(ctx, ref.Owner, ref.Repo, ref.Path, &github.RepositoryContentGetOptions{
+		Ref: ref.Ref,
+	})
+	if err != nil {
+		if res != nil && res.StatusCode == http.StatusNotFound {
+			return nil, errors.Errorf("extended configuration %s does not exist", ref)
+		}
+		return nil, extendsError{errors.Wrapf(err, "failed to fetch extended configuration %s", ref)}
+	}
+	if file == nil {
+		return nil, errors.Errorf("extended configuration %s is not a file", ref)
+	}
+
+	content, err := file.GetContent()
+	if err != nil {
+		return nil, extendsError{errors.Wrapf(err, "failed to decode extended configuration %s", ref)}
+	}
+
+	cf.extends.add(owner, ref, []byte(content))
+	return []byte(content), nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/bulldozer"
+	"github.com/palantir/go-githubapp/appconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigFetcherExtends(t *testing.T) {
+	files := map[string]string{}
+	requests := map[string]int{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.URL.Path, "/repos/") + "@" + r.URL.Query().Get("ref")
+		requests[key]++
+
+		content, ok := files[key]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"type":     "file",
+			"encoding": "base64",
+			"content":  base64.StdEncoding.EncodeToString([]byte(content)),
+		})
+	}))
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	fetcher := NewConfigFetcher(appconfig.NewLoader([]string{".bulldozer.yml"}), nil)
+
+	files["testorg/policy/contents/bulldozer/base.yml@v1"] = `
+version: 1
+merge:
+  trigger:
+    labels: ["merge when ready"]
+  ignore:
+    labels: ["do not merge"]
+  method: squash
+  delete_after_merge: true
+`
+
+	t.Run("merged", func(t *testing.T) {
+		files["testorg/testrepo/contents/.bulldozer.yml@main"] = `
+version: 1
+extends: testorg/policy@v1:bulldozer/base.yml
+merge:
+  method: merge
+  ignore:
+    labels: ["wip"]
+`
+
+		fc := fetcher.Config(context.Background(), client, "testorg", "testrepo", "main")
+		require.NoError(t, fc.LoadError)
+		require.NoError(t, fc.ParseError)
+
+		assert.Equal(t, bulldozer.MergeCommit, fc.Config.Merge.Method)
+		assert.Equal(t, bulldozer.LabelsSignal{"merge when ready"}, fc.Config.Merge.Trigger.Labels)
+		assert.Equal(t, bulldozer.LabelsSignal{"wip"}, fc.Config.Merge.Ignore.Labels)
+		assert.True(t, fc.Config.Merge.DeleteAfterMerge)
+		assert.Empty(t, fc.Config.Extends)
+	})
+
+	t.Run("cached", func(t *testing.T) {
+		files["testorg/otherrepo/contents/.bulldozer.yml@main"] = `
+version: 1
+extends: testorg/policy@v1:bulldozer/base.yml
+`
+
+		fc := fetcher.Config(context.Background(), client, "testorg", "otherrepo", "main")
+		require.NoError(t, fc.ParseError)
+		assert.Equal(t, bulldozer.SquashAndMerge, fc.Config.Merge.Method)
+		assert.Equal(t, 1, requests["testorg/policy/contents/bulldozer/base.yml@v1"])
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		files["testorg/testrepo/contents/.bulldozer.yml@main"] = `
+version: 1
+extends: testorg/policy@main:a.yml
+`
+		files["testorg/policy/contents/a.yml@main"] = `
+version: 1
+extends: testorg/testrepo@main:.bulldozer.yml
+`
+
+		fc := fetcher.Config(context.Background(), client, "testorg", "testrepo", "main")
+		require.NoError(t, fc.LoadError)
+		assert.EqualError(t, fc.ParseError, "configuration extends itself: testorg/testrepo@main:.bulldozer.yml -> testorg/policy@main:a.yml -> testorg/testrepo@main:.bulldozer.yml")
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		files["testorg/testrepo/contents/.bulldozer.yml@main"] = `
+version: 1
+extends: testorg/policy:missing.yml
+`
+
+		fc := fetcher.Config(context.Background(), client, "testorg", "testrepo", "main")
+		require.NoError(t, fc.LoadError)
+		assert.EqualError(t, fc.ParseError, "extended configuration testorg/policy:missing.yml does not exist")
+	})
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/palantir/bulldozer/bulldozer"
+	"github.com/palantir/bulldozer/pull"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// checkExternalPolicy asks the external policy for the organization whether
+// the pull request, which is otherwise ready to merge, may merge. If it may
+// not merge now, it records the decision, schedules another evaluation if
+// the decision may change, and returns false and the recorded reason.
+func (b *Base) checkExternalPolicy(ctx context.Context, pullCtx pull.Context, config *bulldozer.Config, reason string) (bool, string, error) {
+	policy := b.policy(pullCtx.Owner())
+	if policy == nil || policy.External == nil {
+		return true, "", nil
+	}
+	external := policy.External
+	logger := zerolog.Ctx(ctx)
+
+	eligibility, err := b.explainEligibility(ctx, pullCtx, config)
+	if err != nil {
+		return false, "", errors.Wrap(err, "unable to evaluate pull request for external policy")
+	}
+	req, err := bulldozer.NewExternalPolicyRequest(ctx, pullCtx, reason, eligibility)
+	if err != nil {
+		return false, "", err
+	}
+
+	res, err := external.Decide(ctx, nil, req)
+	if err != nil {
+		if external.FailOpen() {
+			logger.Warn().Err(err).Msg("Failed to check external policy, merging because it fails open")
+			return true, "", nil
+		}
+		logger.Error().Err(err).Msg("Failed to check external policy, not merging because it fails closed")
+		b.schedule(ctx, pullCtx, time.Now().Add(bulldozer.DefaultExternalPolicyRetry))
+		waiting := fmt.Sprintf("%s and waiting for the external policy, which could not be checked", reason)
+		b.recordMerge(ctx, pullCtx, outcomeWaiting, waiting)
+		return false, waiting, nil
+	}
+
+	switch res.Decision {
+	case bulldozer.ExternalDeny:
+		logger.Info().Msgf("Not merging pull request because the external policy denied the merge: %s", res.Reason)
+		denied := withExternalReason("not mergeable because the external policy denied the merge", res.Reason)
+		b.recordMerge(ctx, pullCtx, outcomeNotReady, denied)
+		return false, denied, nil
+	case bulldozer.ExternalDefer:
+		at := time.Now().Add(res.Retry())
+		logger.Debug().Msgf("External policy deferred the merge, scheduling evaluation at %s", at.Format(time.RFC3339))
+		b.schedule(ctx, pullCtx, at)
+		deferred := withExternalReason(fmt.Sprintf("%s and the external policy deferred the merge until %s", reason, at.Format(time.RFC3339)), res.Reason)
+		b.recordMerge(ctx, pullCtx, outcomeWaiting, deferred)
+		return false, deferred, nil
+	}
+	return true, "", nil
+}
+
+func withExternalReason(reason, external string) string {
+	if external == "" {
+		return reason
+	}
+	return fmt.Sprintf("%s: %s", reason, external)
+}
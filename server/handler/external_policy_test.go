@@ -0,0 +1,128 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/palantir/bulldozer/bulldozer"
+	"github.com/palantir/bulldozer/pull/pulltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckExternalPolicy(t *testing.T) {
+	var received bulldozer.ExternalPolicyRequest
+	var response string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		if response == "" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	defer srv.Close()
+
+	external := &bulldozer.ExternalPolicy{URL: srv.URL, Headers: map[string]string{"Authorization": "Bearer token"}}
+	b := Base{
+		Policies: map[string]bulldozer.Policy{
+			"testorg": {External: external},
+		},
+	}
+	pullCtx := &pulltest.MockPullContext{
+		OwnerValue:  "testorg",
+		RepoValue:   "testrepo",
+		NumberValue: 1,
+		TitleValue:  "Add the client",
+		LabelValue:  []string{"merge when ready"},
+	}
+	config := &bulldozer.Config{}
+
+	tests := map[string]struct {
+		Response    string
+		FailOpen    bool
+		Allowed     bool
+		Decision    string
+		ReasonRegex string
+	}{
+		"allow": {
+			Response: `{"decision": "allow"}`,
+			Allowed:  true,
+		},
+		"deny": {
+			Response:    `{"decision": "deny", "reason": "the release is frozen"}`,
+			Decision:    outcomeNotReady,
+			ReasonRegex: `^not mergeable because the external policy denied the merge: the release is frozen$`,
+		},
+		"defer": {
+			Response:    `{"decision": "defer", "retry_after": 60}`,
+			Decision:    outcomeWaiting,
+			ReasonRegex: `^mergeable because ready and the external policy deferred the merge until .+$`,
+		},
+		"failClosed": {
+			Decision:    outcomeWaiting,
+			ReasonRegex: `^mergeable because ready and waiting for the external policy, which could not be checked$`,
+		},
+		"failOpen": {
+			FailOpen: true,
+			Allowed:  true,
+		},
+		"invalidDecision": {
+			Response:    `{"decision": "maybe"}`,
+			Decision:    outcomeWaiting,
+			ReasonRegex: `could not be checked$`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			response = test.Response
+			external.FailureMode = bulldozer.ExternalFailClosed
+			if test.FailOpen {
+				external.FailureMode = bulldozer.ExternalFailOpen
+			}
+
+			var e evaluation
+			ctx := withEvaluation(context.Background(), &e)
+
+			allowed, reason, err := b.checkExternalPolicy(ctx, pullCtx, config, "mergeable because ready")
+			require.NoError(t, err)
+			assert.Equal(t, test.Allowed, allowed)
+			assert.Equal(t, test.Decision, e.decision)
+			assert.Equal(t, e.reason, reason)
+			if test.ReasonRegex != "" {
+				assert.Regexp(t, test.ReasonRegex, e.reason)
+			}
+
+			assert.Equal(t, "testrepo", received.Repo)
+			assert.Equal(t, []string{"merge when ready"}, received.Labels)
+			assert.Equal(t, "mergeable because ready", received.Reason)
+			assert.NotNil(t, received.Eligibility)
+		})
+	}
+
+	t.Run("noPolicy", func(t *testing.T) {
+		other := &pulltest.MockPullContext{OwnerValue: "otherorg", RepoValue: "testrepo"}
+		allowed, _, err := b.checkExternalPolicy(context.Background(), other, config, "mergeable because ready")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	})
+}
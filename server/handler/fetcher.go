@@ -16,6 +16,7 @@ package handler
 
 import (
 	"context"
+	"sync"
 
 	"github.com/google/go-github/v50/github"
 	"github.com/palantir/bulldozer/bulldozer"
@@ -33,17 +34,35 @@ type FetchedConfig struct {
 }
 
 type ConfigFetcher struct {
-	loader        *appconfig.Loader
-	defaultConfig *bulldozer.Config
+	loader  *appconfig.Loader
+	extends *extendsCache
+
+	mu            sync.Mutex
+	defaultConfig *DefaultConfig
 }
 
-func NewConfigFetcher(loader *appconfig.Loader, defaultConfig *bulldozer.Config) *ConfigFetcher {
+func NewConfigFetcher(loader *appconfig.Loader, defaultConfig *DefaultConfig) *ConfigFetcher {
 	return &ConfigFetcher{
 		loader:        loader,
 		defaultConfig: defaultConfig,
+		extends:       newExtendsCache(DefaultExtendsCacheTTL),
 	}
 }
 
+// SetDefaultConfig replaces the configuration used for repositories that do
+// not define one. If nil, these repositories are not evaluated.
+func (cf *ConfigFetcher) SetDefaultConfig(config *DefaultConfig) {
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+	cf.defaultConfig = config
+}
+
+func (cf *ConfigFetcher) getDefaultConfig() *DefaultConfig {
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+	return cf.defaultConfig
+}
+
 func (cf *ConfigFetcher) Config(ctx context.Context, client *github.Client, owner, repo, ref string) FetchedConfig {
 	logger := zerolog.Ctx(ctx)
 
@@ -58,16 +77,33 @@ func (cf *ConfigFetcher) Config(ctx context.Context, client *github.Client, owne
 		fc.LoadError = err
 		return fc
 	case c.IsUndefined():
-		if cf.defaultConfig != nil {
+		if defaultConfig := cf.getDefaultConfig(); defaultConfig != nil {
 			logger.Debug().Msgf("No repository configuration found, using server default")
-			fc.Config = cf.defaultConfig
+			fc.Config = defaultConfig.Config
+		}
+		return fc
+	}
+
+	content, err := cf.resolveExtends(ctx, client, owner, c.Content, []string{c.Source + ":" + c.Path})
+	if err == nil {
+		content, err = cf.getDefaultConfig().apply(content)
+	}
+	if err == nil {
+		content, err = cf.resolveIncludes(ctx, client, c.Source, content)
+	}
+	if err != nil {
+		if _, ok := err.(extendsError); ok {
+			fc.LoadError = err
+		} else {
+			fc.ParseError = err
 		}
 		return fc
 	}
 
-	if config, err := bulldozer.ParseConfig(c.Content); err != nil {
+	if config, err := bulldozer.ParseConfig(content); err != nil {
 		fc.ParseError = err
 	} else {
+		logger.Debug().Msgf("Loaded configuration from %s: %s", c.Source, c.Path)
 		fc.Config = config
 	}
 	return fc
@@ -0,0 +1,83 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/bulldozer"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// MaxIncludes is the maximum number of configuration fragments that a
+// configuration file can include.
+const MaxIncludes = 20
+
+// resolveIncludes adds the configuration fragments listed by the
+// configuration, if any. Fragments are read from the same repository and ref
+// as the configuration file, which is identified by source.
+func (cf *ConfigFetcher) resolveIncludes(ctx context.Context, client *github.Client, source string, content []byte) ([]byte, error) {
+	includes, err := bulldozer.ReadIncludes(content)
+	if err != nil || len(includes) == 0 {
+		return content, err
+	}
+	if len(includes) > MaxIncludes {
+		return nil, errors.Errorf("configuration includes more than %d files", MaxIncludes)
+	}
+
+	for _, p := range includes {
+		ref, err := bulldozer.ParseExtendsRef(source + ":" + path.Clean(strings.TrimPrefix(p, "/")))
+		if err != nil {
+			return nil, err
+		}
+
+		fragment, err := cf.fetchInclude(ctx, client, ref)
+		if err != nil {
+			return nil, err
+		}
+		if content, err = bulldozer.AppendConfig(content, fragment); err != nil {
+			return nil, errors.Wrapf(err, "failed to include %s", ref.Path)
+		}
+	}
+	return content, nil
+}
+
+func (cf *ConfigFetcher) fetchInclude(ctx context.Context, client *github.Client, ref bulldozer.ExtendsRef) ([]byte, error) {
+	zerolog.Ctx(ctx).Debug().Msgf("Fetching included configuration %s", ref)
+
+	file, _, res, err := client.Repositories.GetContents(ctx, ref.Owner, ref.Repo, ref.Path, &github.RepositoryContentGetOptions{
+		Ref: ref.Ref,
+	})
+	if err != nil {
+		if res != nil && res.StatusCode == http.StatusNotFound {
+			return nil, errors.Errorf("included configuration %s does not exist", ref.Path)
+		}
+		return nil, extendsError{errors.Wrapf(err, "failed to fetch included configuration %s", ref)}
+	}
+	if file == nil {
+		return nil, errors.Errorf("included configuration %s is not a file", ref.Path)
+	}
+
+	content, err := file.GetContent()
+	if err != nil {
+		return nil, extendsError{errors.Wrapf(err, "failed to decode included configuration %s", ref)}
+	}
+	return []byte(content), nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/bulldozer"
+	"github.com/palantir/go-githubapp/appconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigFetcherIncludes(t *testing.T) {
+	files := map[string]string{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.URL.Path, "/repos/") + "@" + r.URL.Query().Get("ref")
+		content, ok := files[key]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"type":     "file",
+			"encoding": "base64",
+			"content":  base64.StdEncoding.EncodeToString([]byte(content)),
+		})
+	}))
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	fetcher := NewConfigFetcher(appconfig.NewLoader([]string{".github/bulldozer.yml", ".bulldozer.yml"}), nil)
+
+	files["testorg/testrepo/contents/services/payments/bulldozer.yml@main"] = `
+merge:
+  trigger:
+    only_paths: ["services/payments/**"]
+  required_statuses: ["payments/integration"]
+`
+
+	t.Run("included", func(t *testing.T) {
+		files["testorg/testrepo/contents/.github/bulldozer.yml@main"] = `
+version: 1
+include: ["services/payments/bulldozer.yml"]
+merge:
+  trigger:
+    labels: ["merge when ready"]
+  required_statuses: ["ci"]
+`
+
+		fc := fetcher.Config(context.Background(), client, "testorg", "testrepo", "main")
+		require.NoError(t, fc.LoadError)
+		require.NoError(t, fc.ParseError)
+
+		assert.Equal(t, ".github/bulldozer.yml", fc.Path)
+		assert.Equal(t, bulldozer.LabelsSignal{"merge when ready"}, fc.Config.Merge.Trigger.Labels)
+		assert.Equal(t, bulldozer.OnlyPathsSignal{"services/payments/**"}, fc.Config.Merge.Trigger.OnlyPaths)
+		assert.Equal(t, []string{"ci", "payments/integration"}, fc.Config.Merge.RequiredStatuses)
+		assert.Empty(t, fc.Config.Include)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		files["testorg/testrepo/contents/.github/bulldozer.yml@main"] = `
+version: 1
+include: ["services/missing/bulldozer.yml"]
+`
+
+		fc := fetcher.Config(context.Background(), client, "testorg", "testrepo", "main")
+		require.NoError(t, fc.LoadError)
+		assert.EqualError(t, fc.ParseError, "included configuration services/missing/bulldozer.yml does not exist")
+	})
+}
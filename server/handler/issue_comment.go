@@ -19,7 +19,6 @@ import (
 	"encoding/json"
 
 	"github.com/google/go-github/v50/github"
-	"github.com/palantir/bulldozer/pull"
 	"github.com/palantir/go-githubapp/githubapp"
 	"github.com/pkg/errors"
 )
@@ -47,22 +46,40 @@ func (h *IssueComment) Handle(ctx context.Context, eventType, deliveryID string,
 
 	logger.Debug().Msgf("Received issue_comment %s event", event.GetAction())
 
+	// only run commands from new comments, so that editing a comment does
+	// not run its command again, and ignore bots, including bulldozer
+	cmd, isCommand := parseCommand(event.GetComment().GetBody())
+	isCommand = isCommand && event.GetAction() == "created" && event.GetComment().GetUser().GetType() != "Bot"
+
+	if !isCommand && h.debounce(ctx, owner, repoName, number) {
+		return nil
+	}
+
 	client, err := h.ClientCreator.NewInstallationClient(installationID)
 	if err != nil {
 		return errors.Wrap(err, "failed to instantiate github client")
 	}
 
+	v4client, err := h.ClientCreator.NewInstallationV4Client(installationID)
+	if err != nil {
+		return errors.Wrap(err, "failed to instantiate github v4 client")
+	}
+
 	pr, _, err := client.PullRequests.Get(ctx, repo.GetOwner().GetLogin(), repo.GetName(), number)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get pull request %s/%s#%d", owner, repoName, number)
 	}
-	pullCtx := pull.NewGithubContext(client, pr)
+
+	if isCommand {
+		return h.runCommand(ctx, client, v4client, pr, event.GetComment().GetUser().GetLogin(), cmd)
+	}
+	pullCtx := h.NewPullContext(client, pr)
 
 	config, err := h.FetchConfigForPR(ctx, client, pr)
 	if err != nil {
 		return err
 	}
-	if err := h.ProcessPullRequest(ctx, pullCtx, client, config, pr); err != nil {
+	if err := h.ProcessPullRequest(ctx, pullCtx, client, v4client, config, pr); err != nil {
 		logger.Error().Err(errors.WithStack(err)).Msg("Error processing pull request")
 	}
 
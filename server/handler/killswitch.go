@@ -0,0 +1,81 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/pkg/errors"
+)
+
+// KillSwitch lets repository administrators disable bulldozer for a
+// repository without changing the server configuration, by adding a topic to
+// the repository or a file to the target branch.
+type KillSwitch struct {
+	// Topic disables bulldozer for repositories that have it. If empty,
+	// topics are ignored.
+	Topic string
+
+	// File disables bulldozer for pull requests that target a branch that
+	// contains it. If empty, files are ignored.
+	File string
+}
+
+// HasTopic returns true if the topics include the kill switch topic.
+func (k KillSwitch) HasTopic(topics []string) bool {
+	if k.Topic == "" {
+		return false
+	}
+	for _, t := range topics {
+		if strings.EqualFold(t, k.Topic) {
+			return true
+		}
+	}
+	return false
+}
+
+// Reason describes why bulldozer is disabled for the branch of the
+// repository. It returns an empty string if bulldozer is not disabled. If
+// topics is nil, the topics of the repository are looked up.
+func (k KillSwitch) Reason(ctx context.Context, client *github.Client, owner, repo, ref string, topics []string) (string, error) {
+	if k.Topic != "" && topics == nil {
+		repository, _, err := client.Repositories.Get(ctx, owner, repo)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to get repository %s/%s", owner, repo)
+		}
+		topics = repository.Topics
+	}
+	if k.HasTopic(topics) {
+		return fmt.Sprintf("the repository has the topic %q", k.Topic), nil
+	}
+
+	if k.File == "" {
+		return "", nil
+	}
+	_, _, res, err := client.Repositories.GetContents(ctx, owner, repo, k.File, &github.RepositoryContentGetOptions{
+		Ref: ref,
+	})
+	switch {
+	case res != nil && res.StatusCode == http.StatusNotFound:
+		return "", nil
+	case err != nil:
+		return "", errors.Wrapf(err, "failed to check for %s", k.File)
+	}
+	return fmt.Sprintf("the branch contains %s", k.File), nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKillSwitch(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/testorg/testrepo", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"name": "testrepo", "topics": ["go", "Bulldozer-Disabled"]}`)
+	})
+	mux.HandleFunc("/repos/testorg/otherrepo", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"name": "otherrepo", "topics": ["go"]}`)
+	})
+	mux.HandleFunc("/repos/testorg/otherrepo/contents/.github/bulldozer-disabled", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("ref") != "release" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"message": "Not Found"}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"type": "file", "path": ".github/bulldozer-disabled", "content": ""}`)
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	ctx := context.Background()
+	k := KillSwitch{Topic: "bulldozer-disabled", File: ".github/bulldozer-disabled"}
+
+	reason, err := k.Reason(ctx, client, "testorg", "testrepo", "develop", nil)
+	require.NoError(t, err)
+	assert.Equal(t, `the repository has the topic "bulldozer-disabled"`, reason)
+
+	reason, err = k.Reason(ctx, client, "testorg", "testrepo", "develop", []string{})
+	require.NoError(t, err)
+	assert.Empty(t, reason, "topics were looked up when the caller provided them")
+
+	reason, err = k.Reason(ctx, client, "testorg", "otherrepo", "develop", nil)
+	require.NoError(t, err)
+	assert.Empty(t, reason)
+
+	reason, err = k.Reason(ctx, client, "testorg", "otherrepo", "release", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "the branch contains .github/bulldozer-disabled", reason)
+
+	reason, err = KillSwitch{}.Reason(ctx, client, "testorg", "testrepo", "release", nil)
+	require.NoError(t, err)
+	assert.Empty(t, reason, "empty kill switch disabled the repository")
+}
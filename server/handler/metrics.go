@@ -0,0 +1,283 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/palantir/bulldozer/bulldozer"
+	"github.com/palantir/go-githubapp/githubapp"
+	"github.com/rcrowley/go-metrics"
+)
+
+const (
+	MetricsKeyMergesAttempted = "merges.attempted"
+	MetricsKeyMergesSucceeded = "merges.succeeded"
+	MetricsKeyMergesFailed    = "merges.failed"
+	MetricsKeyUpdates         = "updates"
+	MetricsKeyEvaluations     = "evaluations"
+	MetricsKeyWebhookLatency  = "webhook.latency"
+
+	MetricsKeyReconcileScheduled = "reconcile.scheduled"
+	MetricsKeyEventsCoalesced    = "events.coalesced"
+
+	MetricsKeyPullRequestCacheHits   = "pull_requests.cache.hits"
+	MetricsKeyPullRequestCacheMisses = "pull_requests.cache.misses"
+)
+
+// Outcomes of evaluating a pull request for merging, used as the outcome tag
+// of the evaluations metric.
+const (
+	outcomeNotReady = "not_ready"
+	outcomeWaiting  = "waiting"
+	outcomeRolledUp = "rolled_up"
+	outcomeDryRun   = "dry_run"
+	outcomePaused   = "paused"
+	outcomeMerged   = "merged"
+	outcomeFailed   = "failed"
+)
+
+// count increments the counter with the name in the registry. It does
+// nothing if the handler has no registry.
+func (b *Base) count(name string) {
+	if b.Registry == nil {
+		return
+	}
+	metrics.GetOrRegisterCounter(name, b.Registry).Inc(1)
+}
+
+// CacheMetrics counts the hits and misses of a pull request cache in a
+// registry, tagged with the kind of lookup.
+type CacheMetrics struct {
+	Registry metrics.Registry
+}
+
+func (m CacheMetrics) ObserveCacheLookup(method string, hit bool) {
+	key := MetricsKeyPullRequestCacheMisses
+	if hit {
+		key = MetricsKeyPullRequestCacheHits
+	}
+	metrics.GetOrRegisterCounter(fmt.Sprintf("%s[method:%s]", key, method), m.Registry).Inc(1)
+}
+
+func (b *Base) countEvaluation(outcome string) {
+	b.count(fmt.Sprintf("%s[outcome:%s]", MetricsKeyEvaluations, outcome))
+}
+
+func (b *Base) countUpdate(result bulldozer.UpdateResult) {
+	b.count(fmt.Sprintf("%s[result:%s]", MetricsKeyUpdates, updateDecision(result)))
+}
+
+func updateDecision(result bulldozer.UpdateResult) string {
+	switch {
+	case result.Updated:
+		return "updated"
+	case result.Conflict:
+		return "conflict"
+	}
+	return "failed"
+}
+
+// Timed wraps an event handler to record how long it takes to handle each
+// type of event.
+func Timed(registry metrics.Registry, h githubapp.EventHandler) githubapp.EventHandler {
+	return &timedHandler{EventHandler: h, registry: registry}
+}
+
+type timedHandler struct {
+	githubapp.EventHandler
+	registry metrics.Registry
+}
+
+func (h *timedHandler) Handle(ctx context.Context, eventType, deliveryID string, payload []byte) error {
+	start := time.Now()
+	defer func() {
+		name := fmt.Sprintf("%s[event:%s]", MetricsKeyWebhookLatency, eventType)
+		metrics.GetOrRegisterTimer(name, h.registry).UpdateSince(start)
+	}()
+	return h.EventHandler.Handle(ctx, eventType, deliveryID, payload)
+}
+
+// Metrics returns a handler that writes the metrics in the registry in the
+// Prometheus text format. Metric names may include tags in the form
+// "name[tag1:value1,tag2:value2]", which are converted to labels. Histograms
+// and timers are written as summaries, with timers measured in seconds.
+func Metrics(registry metrics.Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+
+		out := bufio.NewWriter(w)
+		defer out.Flush()
+		for _, f := range collectFamilies(registry) {
+			fmt.Fprintf(out, "# TYPE %s %s\n", f.name, f.kind)
+			for _, s := range f.samples {
+				fmt.Fprintln(out, s)
+			}
+		}
+	})
+}
+
+type family struct {
+	name    string
+	kind    string
+	samples []string
+}
+
+func collectFamilies(registry metrics.Registry) []*family {
+	families := make(map[string]*family)
+	add := func(name, kind, sample string) {
+		f, ok := families[name]
+		if !ok {
+			f = &family{name: name, kind: kind}
+			families[name] = f
+		}
+		f.samples = append(f.samples, sample)
+	}
+
+	registry.Each(func(fullName string, metric interface{}) {
+		name, labels := prometheusName(fullName)
+
+		switch m := metric.(type) {
+		case metrics.Counter:
+			add(name+"_total", "counter", sample(name+"_total", labels, float64(m.Count())))
+
+		case metrics.Gauge:
+			add(name, "gauge", sample(name, labels, float64(m.Value())))
+
+		case metrics.GaugeFloat64:
+			add(name, "gauge", sample(name, labels, m.Value()))
+
+		case metrics.Meter:
+			add(name+"_total", "counter", sample(name+"_total", labels, float64(m.Count())))
+
+		case metrics.Histogram:
+			s := m.Snapshot()
+			for _, sm := range summary(name, labels, s.Count(), float64(s.Sum()), s.Percentiles(quantiles), 1) {
+				add(name, "summary", sm)
+			}
+
+		case metrics.Timer:
+			s := m.Snapshot()
+			name += "_seconds"
+			for _, sm := range summary(name, labels, s.Count(), float64(s.Sum()), s.Percentiles(quantiles), float64(time.Second)) {
+				add(name, "summary", sm)
+			}
+		}
+	})
+
+	result := make([]*family, 0, len(families))
+	for _, f := range families {
+		sort.Strings(f.samples)
+		result = append(result, f)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].name < result[j].name })
+	return result
+}
+
+var quantiles = []float64{0.5, 0.95, 0.99}
+
+func summary(name string, labels []string, count int64, sum float64, values []float64, scale float64) []string {
+	samples := make([]string, 0, len(values)+2)
+	for i, q := range quantiles {
+		ql := append(append([]string{}, labels...), fmt.Sprintf(`quantile="%v"`, q))
+		samples = append(samples, sample(name, ql, values[i]/scale))
+	}
+	samples = append(samples, sample(name+"_sum", labels, sum/scale))
+	samples = append(samples, sample(name+"_count", labels, float64(count)))
+	return samples
+}
+
+func sample(name string, labels []string, value float64) string {
+	if len(labels) == 0 {
+		return fmt.Sprintf("%s %v", name, value)
+	}
+	return fmt.Sprintf("%s{%s} %v", name, strings.Join(labels, ","), value)
+}
+
+// prometheusName converts a metric name with optional tags into a valid
+// Prometheus metric name and a sorted list of labels.
+func prometheusName(name string) (string, []string) {
+	var labels []string
+	if start := strings.IndexRune(name, '['); start >= 0 && strings.HasSuffix(name, "]") {
+		for _, tag := range strings.Split(name[start+1:len(name)-1], ",") {
+			if tag == "" {
+				continue
+			}
+			key, value, ok := strings.Cut(tag, ":")
+			if !ok {
+				value = "true"
+			}
+			labels = append(labels, fmt.Sprintf(`%s="%s"`, sanitizeName(key), labelEscaper.Replace(value)))
+		}
+		sort.Strings(labels)
+		name = name[:start]
+	}
+	return sanitizeName(name), labels
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func sanitizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// TaggedRegistry returns a registry that adds a tag to the names of the
+// metrics it registers and gets, so that the same metrics can be recorded
+// separately for different sources in one registry. Each and GetAll include
+// all metrics in the underlying registry.
+func TaggedRegistry(registry metrics.Registry, tag, value string) metrics.Registry {
+	return &taggedRegistry{Registry: registry, tag: tag + ":" + value}
+}
+
+type taggedRegistry struct {
+	metrics.Registry
+	tag string
+}
+
+func (r *taggedRegistry) name(name string) string {
+	if strings.HasSuffix(name, "]") {
+		return name[:len(name)-1] + "," + r.tag + "]"
+	}
+	return name + "[" + r.tag + "]"
+}
+
+func (r *taggedRegistry) Get(name string) interface{} {
+	return r.Registry.Get(r.name(name))
+}
+
+func (r *taggedRegistry) GetOrRegister(name string, metric interface{}) interface{} {
+	return r.Registry.GetOrRegister(r.name(name), metric)
+}
+
+func (r *taggedRegistry) Register(name string, metric interface{}) error {
+	return r.Registry.Register(r.name(name), metric)
+}
+
+func (r *taggedRegistry) Unregister(name string) {
+	r.Registry.Unregister(r.name(name))
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	registry := metrics.NewPrefixedRegistry("bulldozer.")
+	b := &Base{Registry: registry}
+
+	b.count(MetricsKeyMergesAttempted)
+	b.count(MetricsKeyMergesAttempted)
+	b.count(MetricsKeyMergesFailed + "[reason:not_mergeable]")
+	b.count(MetricsKeyMergesFailed + "[reason:closed]")
+	metrics.GetOrRegisterGauge("github.rate.remaining[installation:42]", registry).Update(4990)
+	metrics.GetOrRegisterTimer(MetricsKeyWebhookLatency+"[event:status]", registry).Update(2 * time.Second)
+
+	r := httptest.NewRecorder()
+	Metrics(registry).ServeHTTP(r, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, `# TYPE bulldozer_github_rate_remaining gauge
+bulldozer_github_rate_remaining{installation="42"} 4990
+# TYPE bulldozer_merges_attempted_total counter
+bulldozer_merges_attempted_total 2
+# TYPE bulldozer_merges_failed_total counter
+bulldozer_merges_failed_total{reason="closed"} 1
+bulldozer_merges_failed_total{reason="not_mergeable"} 1
+# TYPE bulldozer_webhook_latency_seconds summary
+bulldozer_webhook_latency_seconds_count{event="status"} 1
+bulldozer_webhook_latency_seconds_sum{event="status"} 2
+bulldozer_webhook_latency_seconds{event="status",quantile="0.5"} 2
+bulldozer_webhook_latency_seconds{event="status",quantile="0.95"} 2
+bulldozer_webhook_latency_seconds{event="status",quantile="0.99"} 2
+`, r.Body.String())
+}
+
+func TestCacheMetrics(t *testing.T) {
+	registry := metrics.NewRegistry()
+	m := CacheMetrics{Registry: registry}
+
+	m.ObserveCacheLookup("all_sha", true)
+	m.ObserveCacheLookup("all_sha", true)
+	m.ObserveCacheLookup("all_sha", false)
+
+	assert.Equal(t, int64(2), metrics.GetOrRegisterCounter(MetricsKeyPullRequestCacheHits+"[method:all_sha]", registry).Count())
+	assert.Equal(t, int64(1), metrics.GetOrRegisterCounter(MetricsKeyPullRequestCacheMisses+"[method:all_sha]", registry).Count())
+}
+
+func TestPrometheusName(t *testing.T) {
+	tests := map[string]struct {
+		Input  string
+		Name   string
+		Labels []string
+	}{
+		"plain": {
+			Input: "bulldozer.server.requests.2xx",
+			Name:  "bulldozer_server_requests_2xx",
+		},
+		"tags": {
+			Input:  "bulldozer.evaluations[outcome:merged,dry]",
+			Name:   "bulldozer_evaluations",
+			Labels: []string{`dry="true"`, `outcome="merged"`},
+		},
+		"escaped": {
+			Input:  `bulldozer.errors[message:a "quoted" value]`,
+			Name:   "bulldozer_errors",
+			Labels: []string{`message="a \"quoted\" value"`},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			name, labels := prometheusName(test.Input)
+			assert.Equal(t, test.Name, name)
+			assert.Equal(t, test.Labels, labels)
+		})
+	}
+}
+
+func TestTaggedRegistry(t *testing.T) {
+	registry := metrics.NewPrefixedRegistry("bulldozer.")
+	tagged := TaggedRegistry(registry, "app", "enterprise")
+
+	(&Base{Registry: registry}).count(MetricsKeyMergesAttempted)
+	(&Base{Registry: tagged}).count(MetricsKeyMergesAttempted)
+	(&Base{Registry: tagged}).count(MetricsKeyMergesFailed + "[reason:closed]")
+
+	assert.NotNil(t, tagged.Get(MetricsKeyMergesAttempted))
+	assert.NotSame(t, registry.Get(MetricsKeyMergesAttempted), tagged.Get(MetricsKeyMergesAttempted))
+
+	r := httptest.NewRecorder()
+	Metrics(registry).ServeHTTP(r, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, `# TYPE bulldozer_merges_attempted_total counter
+bulldozer_merges_attempted_total 1
+bulldozer_merges_attempted_total{app="enterprise"} 1
+# TYPE bulldozer_merges_failed_total counter
+bulldozer_merges_failed_total{app="enterprise",reason="closed"} 1
+`, r.Body.String())
+}
@@ -17,8 +17,12 @@ package handler
 import (
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/palantir/bulldozer/bulldozer"
+	"github.com/palantir/bulldozer/notify"
+	"github.com/palantir/bulldozer/pull"
+	"github.com/palantir/bulldozer/tickets"
 )
 
 const (
@@ -26,20 +30,125 @@ const (
 	DefaultConfigurationPath       = ".bulldozer.yml"
 	DefaultSharedConfigurationPath = "bulldozer.yml"
 	DefaultAppName                 = "bulldozer"
+	DefaultDisableTopic            = "bulldozer-disabled"
 )
 
 type Options struct {
 	AppName                  string `yaml:"app_name"`
 	PushRestrictionUserToken string `yaml:"push_restriction_user_token"`
 
-	ConfigurationPath       string            `yaml:"configuration_path"`
-	SharedRepository        string            `yaml:"shared_repository"`
-	SharedConfigurationPath string            `yaml:"shared_configuration_path"`
-	DefaultRepositoryConfig *bulldozer.Config `yaml:"default_repository_config"`
+	ConfigurationPath       string         `yaml:"configuration_path"`
+	SharedRepository        string         `yaml:"shared_repository"`
+	SharedConfigurationPath string         `yaml:"shared_configuration_path"`
+	DefaultRepositoryConfig *DefaultConfig `yaml:"default_repository_config"`
+
+	// DefaultRepositoryConfigMode sets how repository configuration files
+	// combine with DefaultRepositoryConfig. With "fallback", the default, the
+	// default configuration is only used for repositories without a file.
+	// With "override", repository files are merged into it and may change
+	// any value. With "extend", repository files are merged into it but may
+	// only add to lists and set values that it does not set.
+	DefaultRepositoryConfigMode DefaultConfigMode `yaml:"default_repository_config_mode"`
+
+	// ConfigurationPaths are paths to look for configuration files in, in
+	// order. The first file that exists is used. If set, it replaces
+	// ConfigurationPath.
+	ConfigurationPaths []string `yaml:"configuration_paths"`
 
 	ConfigurationV0Paths []string `yaml:"configuration_v0_paths"`
 
 	DisableUpdateFeature bool `yaml:"disable_update_feature"`
+
+	DryRun bool `yaml:"dry_run"`
+
+	// QueuePath is a file that stores pending evaluations of pull requests,
+	// like merges held by blackout windows or delays, and pauses, so that
+	// they survive restarts. If empty, both are only kept in memory.
+	QueuePath string `yaml:"queue_path"`
+
+	// DeadLetterPath is a file that stores webhook deliveries that failed to
+	// process, so that they can be replayed with the admin API after
+	// restarts. If empty, failed deliveries are only kept in memory.
+	DeadLetterPath string `yaml:"dead_letter_path"`
+
+	// ReconcileInterval is the average time between sweeps that evaluate
+	// open pull requests in all installations, to catch pull requests that
+	// are stuck because of missed webhooks. If zero, there are no sweeps.
+	ReconcileInterval time.Duration `yaml:"reconcile_interval"`
+
+	// DebounceInterval is how long bulldozer waits after an event before it
+	// evaluates the pull request, so that bursts of events for the same pull
+	// request, like statuses and check runs for the same commit, cause one
+	// evaluation. If zero, each event is evaluated immediately.
+	DebounceInterval time.Duration `yaml:"debounce_interval"`
+
+	// RateLimitReserve is the number of requests in the rate limit of each
+	// installation that are reserved for merges. Updates and background
+	// sweeps are deferred when fewer requests remain. If zero, the default
+	// is 500.
+	RateLimitReserve int `yaml:"rate_limit_reserve"`
+
+	// PullRequestCacheTTL is how long the open pull requests for a commit are
+	// cached when handling status events, so that the many statuses reported
+	// for the same commit do not each list pull requests. Cached lookups are
+	// invalidated by pull request events. If zero, lookups are not cached.
+	PullRequestCacheTTL time.Duration `yaml:"pull_request_cache_ttl"`
+
+	// CheckConclusions sets whether check runs that complete with a
+	// conclusion, like "neutral", "skipped", or "stale", are treated as
+	// "success", "failure", or "pending". By default, "neutral" and "skipped"
+	// are successful and all other conclusions except "success" are failures.
+	CheckConclusions pull.CheckConclusions `yaml:"check_conclusions"`
+
+	// SelfModification sets whether repository configuration is read from
+	// the base or the head of pull requests and whether pull requests that
+	// change configuration files may be merged.
+	SelfModification SelfModification `yaml:"self_modification"`
+
+	// Policies constrain the configuration of repositories in each
+	// organization, keyed by organization. The "*" key applies to
+	// organizations without their own policy.
+	Policies map[string]bulldozer.Policy `yaml:"policies"`
+
+	// Notifications defines named sinks that repositories can route events
+	// to. Sinks include credentials, like webhook URLs, so they are only
+	// defined by the server.
+	Notifications map[string]notify.SinkConfig `yaml:"notifications"`
+
+	// IssueTracker defines the issue tracker, like Jira, that the tickets
+	// signal reads ticket statuses from. It includes credentials, so it is
+	// only defined by the server.
+	IssueTracker tickets.Config `yaml:"issue_tracker"`
+
+	// PolicyBot is the policy-bot server that explanations of pull requests
+	// waiting for policy-bot approval read unapproved rules from.
+	PolicyBot bulldozer.PolicyBotServer `yaml:"policy_bot"`
+
+	// AdminToken enables the admin API. Requests to the API must include the
+	// token as a bearer token. If empty, the admin API is disabled.
+	AdminToken string `yaml:"admin_token"`
+
+	// EnableDashboard serves pages and a JSON API that show why pull requests
+	// are or are not merged. Requests must include AdminToken as a bearer
+	// token, so the dashboard is unusable if AdminToken is empty.
+	EnableDashboard bool `yaml:"enable_dashboard"`
+
+	// SigningKey is an ASCII armored GPG private key that signs the commits
+	// bulldozer creates, for branches that require signed commits. If the key
+	// is encrypted, SigningKeyPassphrase decrypts it. If empty, commits are
+	// not signed.
+	SigningKey           string `yaml:"signing_key"`
+	SigningKeyPassphrase string `yaml:"signing_key_passphrase"`
+
+	// DisableTopic is a repository topic that disables bulldozer for the
+	// repository. If empty, the default is "bulldozer-disabled"; set it to
+	// "-" to ignore topics.
+	DisableTopic string `yaml:"disable_topic"`
+
+	// DisableFile is a path that disables bulldozer for pull requests that
+	// target a branch containing a file at the path. Checking for the file
+	// costs a request for every evaluation. If empty, files are ignored.
+	DisableFile string `yaml:"disable_file"`
 }
 
 func (o *Options) fillDefaults() {
@@ -55,6 +164,38 @@ func (o *Options) fillDefaults() {
 	if o.SharedConfigurationPath == "" {
 		o.SharedConfigurationPath = DefaultSharedConfigurationPath
 	}
+	if o.DisableTopic == "" {
+		o.DisableTopic = DefaultDisableTopic
+	}
+}
+
+// DefaultConfig returns the default repository configuration with its mode,
+// or nil if there is no default configuration.
+func (o *Options) DefaultConfig() *DefaultConfig {
+	if o.DefaultRepositoryConfig == nil {
+		return nil
+	}
+	c := *o.DefaultRepositoryConfig
+	c.Mode = o.DefaultRepositoryConfigMode
+	return &c
+}
+
+// ConfigPaths returns the paths to look for configuration files in, in order,
+// not including the paths for version 0 configuration files.
+func (o *Options) ConfigPaths() []string {
+	if len(o.ConfigurationPaths) > 0 {
+		return o.ConfigurationPaths
+	}
+	return []string{o.ConfigurationPath}
+}
+
+// KillSwitch returns the kill switch defined by the options.
+func (o *Options) KillSwitch() KillSwitch {
+	k := KillSwitch{Topic: o.DisableTopic, File: o.DisableFile}
+	if k.Topic == "-" {
+		k.Topic = ""
+	}
+	return k
 }
 
 func (o *Options) SetValuesFromEnv(prefix string) {
@@ -64,6 +205,21 @@ func (o *Options) SetValuesFromEnv(prefix string) {
 	setStringFromEnv("SHARED_CONFIGURATION_PATH", prefix, &o.SharedConfigurationPath)
 	setBooleanFromEnv("DISABLE_UPDATE_FEATURE", prefix, &o.DisableUpdateFeature)
 	setStringFromEnv("PUSH_RESTRICTION_USER_TOKEN", prefix, &o.PushRestrictionUserToken)
+	setBooleanFromEnv("DRY_RUN", prefix, &o.DryRun)
+	setStringFromEnv("QUEUE_PATH", prefix, &o.QueuePath)
+	setStringFromEnv("DEAD_LETTER_PATH", prefix, &o.DeadLetterPath)
+	setDurationFromEnv("RECONCILE_INTERVAL", prefix, &o.ReconcileInterval)
+	setDurationFromEnv("DEBOUNCE_INTERVAL", prefix, &o.DebounceInterval)
+	setIntFromEnv("RATE_LIMIT_RESERVE", prefix, &o.RateLimitReserve)
+	setDurationFromEnv("PULL_REQUEST_CACHE_TTL", prefix, &o.PullRequestCacheTTL)
+	setStringFromEnv("ADMIN_TOKEN", prefix, &o.AdminToken)
+	setBooleanFromEnv("ENABLE_DASHBOARD", prefix, &o.EnableDashboard)
+	setStringFromEnv("SIGNING_KEY", prefix, &o.SigningKey)
+	setStringFromEnv("SIGNING_KEY_PASSPHRASE", prefix, &o.SigningKeyPassphrase)
+	setStringFromEnv("ISSUE_TRACKER_TOKEN", prefix, &o.IssueTracker.Token)
+	setStringFromEnv("POLICY_BOT_TOKEN", prefix, &o.PolicyBot.Token)
+	setStringFromEnv("DISABLE_TOPIC", prefix, &o.DisableTopic)
+	setStringFromEnv("DISABLE_FILE", prefix, &o.DisableFile)
 	o.fillDefaults()
 }
 
@@ -82,3 +238,19 @@ func setBooleanFromEnv(key, prefix string, value *bool) bool {
 	}
 	return false
 }
+
+func setIntFromEnv(key, prefix string, value *int) bool {
+	if v, ok := os.LookupEnv(prefix + key); ok {
+		*value, _ = strconv.Atoi(v)
+		return true
+	}
+	return false
+}
+
+func setDurationFromEnv(key, prefix string, value *time.Duration) bool {
+	if v, ok := os.LookupEnv(prefix + key); ok {
+		*value, _ = time.ParseDuration(v)
+		return true
+	}
+	return false
+}
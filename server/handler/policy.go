@@ -0,0 +1,74 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/bulldozer"
+)
+
+// DefaultPolicyKey is the key of the policy that applies to organizations
+// without their own policy.
+const DefaultPolicyKey = "*"
+
+// policy returns the policy for the organization or nil if there is no
+// policy. Organization names are case-insensitive.
+func (b *Base) policy(owner string) *bulldozer.Policy {
+	var fallback *bulldozer.Policy
+	for key, policy := range b.Policies {
+		policy := policy
+		switch {
+		case strings.EqualFold(key, owner):
+			return &policy
+		case key == DefaultPolicyKey:
+			fallback = &policy
+		}
+	}
+	return fallback
+}
+
+// policyViolations returns the ways the configuration breaks the policy for
+// the organization.
+func (b *Base) policyViolations(owner string, config *bulldozer.Config) []string {
+	if policy := b.policy(owner); policy != nil {
+		return policy.Violations(config)
+	}
+	return nil
+}
+
+// policyIssues loads the configuration at the ref and returns the ways it
+// breaks the policy for the organization as validation issues, with the path
+// of the configuration file.
+func (b *Base) policyIssues(ctx context.Context, client *github.Client, owner, repo, ref string) ([]bulldozer.ValidationIssue, string) {
+	if b.policy(owner) == nil {
+		return nil, ""
+	}
+
+	// the default configuration of the server is not checked, because the
+	// repository cannot fix it
+	fc := b.ConfigFetcher.Config(ctx, client, owner, repo, ref)
+	if fc.Config == nil || fc.Path == "" {
+		return nil, ""
+	}
+
+	var issues []bulldozer.ValidationIssue
+	for _, v := range b.policyViolations(owner, fc.Config) {
+		issues = append(issues, bulldozer.ValidationIssue{Message: "breaks the organization policy: " + v})
+	}
+	return issues, fc.Path
+}
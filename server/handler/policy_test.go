@@ -0,0 +1,114 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/bulldozer"
+	"github.com/palantir/go-githubapp/appconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateConfigChangePolicy(t *testing.T) {
+	var files string
+	var checkRun *github.CreateCheckRunOptions
+	configs := map[string]string{
+		"develop": "version: 1\nmerge:\n  method: merge\n",
+		"f00":     "version: 1\nmerge:\n  method: squash\n  ignore:\n    labels: [\"do not merge\"]\n",
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/testorg/testrepo/pulls/1/files", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, files)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/contents/.bulldozer.yml", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"type":     "file",
+			"encoding": "base64",
+			"content":  base64.StdEncoding.EncodeToString([]byte(configs[r.URL.Query().Get("ref")])),
+		})
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		checkRun = &github.CreateCheckRunOptions{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(checkRun))
+		fmt.Fprint(w, `{}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	repo := &github.Repository{Name: github.String("testrepo"), Owner: &github.User{Login: github.String("testorg")}}
+	pr := &github.PullRequest{
+		Number: github.Int(1),
+		Base:   &github.PullRequestBranch{Ref: github.String("develop"), Repo: repo},
+		Head:   &github.PullRequestBranch{SHA: github.String("f00"), Repo: repo},
+	}
+	b := Base{
+		ConfigFetcher:      NewConfigFetcher(appconfig.NewLoader([]string{".bulldozer.yml"}), nil),
+		ConfigurationPaths: []string{".bulldozer.yml"},
+		Policies: map[string]bulldozer.Policy{
+			"TestOrg": {
+				MergeMethods:         []bulldozer.MergeMethod{bulldozer.SquashAndMerge, bulldozer.RebaseAndMerge},
+				RequiredIgnoreLabels: []string{"do not merge"},
+			},
+			"*": {},
+		},
+	}
+
+	t.Run("unchanged", func(t *testing.T) {
+		files = `[{"filename":"README.md"}]`
+		checkRun = nil
+
+		require.NoError(t, b.ValidateConfigChange(context.Background(), client, pr))
+		require.NotNil(t, checkRun)
+		assert.Equal(t, "f00", checkRun.HeadSHA)
+		assert.Equal(t, "failure", checkRun.GetConclusion())
+		require.Len(t, checkRun.Output.Annotations, 2)
+		assert.Equal(t, `breaks the organization policy: merge method "merge" is not allowed, use one of "squash", "rebase"`, checkRun.Output.Annotations[0].GetMessage())
+		assert.Equal(t, `breaks the organization policy: merge.ignore.labels must include "do not merge"`, checkRun.Output.Annotations[1].GetMessage())
+	})
+
+	t.Run("fixed", func(t *testing.T) {
+		files = `[{"filename":".bulldozer.yml"}]`
+		checkRun = nil
+
+		require.NoError(t, b.ValidateConfigChange(context.Background(), client, pr))
+		require.NotNil(t, checkRun)
+		assert.Equal(t, "success", checkRun.GetConclusion())
+	})
+
+	t.Run("enforced", func(t *testing.T) {
+		config, err := b.FetchConfigForPR(context.Background(), client, pr)
+		require.NoError(t, err)
+		assert.Nil(t, config)
+
+		b.Policies = map[string]bulldozer.Policy{"otherorg": b.Policies["TestOrg"]}
+		config, err = b.FetchConfigForPR(context.Background(), client, pr)
+		require.NoError(t, err)
+		assert.NotNil(t, config)
+	})
+}
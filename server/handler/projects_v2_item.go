@@ -0,0 +1,120 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/go-githubapp/githubapp"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/shurcooL/githubv4"
+)
+
+// projectsV2ItemEvent is the payload of a projects_v2_item event, which the
+// vendored client does not define.
+type projectsV2ItemEvent struct {
+	Action string `json:"action"`
+	Item   struct {
+		ContentNodeID string `json:"content_node_id"`
+		ContentType   string `json:"content_type"`
+	} `json:"projects_v2_item"`
+	Installation *github.Installation `json:"installation"`
+}
+
+// ProjectsV2Item evaluates pull requests when their project items change, for
+// repositories that use project field signals. Project events are sent for
+// organizations, so the pull request is found from the node ID of the item.
+type ProjectsV2Item struct {
+	Base
+}
+
+func (h *ProjectsV2Item) Handles() []string {
+	return []string{"projects_v2_item"}
+}
+
+func (h *ProjectsV2Item) Handle(ctx context.Context, eventType, deliveryID string, payload []byte) error {
+	var event projectsV2ItemEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return errors.Wrap(err, "failed to parse projects_v2_item event payload")
+	}
+
+	installationID := event.Installation.GetID()
+	logger := zerolog.Ctx(ctx).With().Int64(githubapp.LogKeyInstallationID, installationID).Logger()
+	ctx = logger.WithContext(ctx)
+
+	logger.Debug().Msgf("Received projects_v2_item %s event", event.Action)
+
+	if event.Item.ContentType != "PullRequest" {
+		logger.Debug().Msgf("Doing nothing since project item is a %s", event.Item.ContentType)
+		return nil
+	}
+	if event.Action == "deleted" || h.Scheduler == nil {
+		return nil
+	}
+
+	v4client, err := h.ClientCreator.NewInstallationV4Client(installationID)
+	if err != nil {
+		return errors.Wrap(err, "failed to instantiate github v4 client")
+	}
+
+	ref, err := pullRequestRefForNode(ctx, v4client, event.Item.ContentNodeID)
+	if err != nil {
+		return err
+	}
+	if ref.Number == 0 {
+		logger.Debug().Msgf("Doing nothing since pull request %s was not found", event.Item.ContentNodeID)
+		return nil
+	}
+
+	if !h.debounce(ctx, ref.Owner, ref.Repo, ref.Number) {
+		h.Scheduler.Schedule(ctx, ref, time.Now())
+	}
+	return nil
+}
+
+// pullRequestRefForNode returns the pull request with the node ID.
+func pullRequestRefForNode(ctx context.Context, v4client *githubv4.Client, id string) (PullRequestRef, error) {
+	var q struct {
+		Node struct {
+			PullRequest struct {
+				Number     int
+				Repository struct {
+					Name  string
+					Owner struct {
+						Login string
+					}
+				}
+			} `graphql:"... on PullRequest"`
+		} `graphql:"node(id: $id)"`
+	}
+
+	if err := v4client.Query(ctx, &q, map[string]interface{}{"id": githubv4.ID(id)}); err != nil {
+		return PullRequestRef{}, errors.Wrapf(err, "failed to get pull request %s", id)
+	}
+
+	pr := q.Node.PullRequest
+	return PullRequestRef{
+		Owner:  pr.Repository.Owner.Login,
+		Repo:   pr.Repository.Name,
+		Number: pr.Number,
+	}, nil
+}
+
+// type assertion
+var _ githubapp.EventHandler = &ProjectsV2Item{}
@@ -19,7 +19,6 @@ import (
 	"encoding/json"
 
 	"github.com/google/go-github/v50/github"
-	"github.com/palantir/bulldozer/pull"
 	"github.com/palantir/go-githubapp/githubapp"
 	"github.com/pkg/errors"
 )
@@ -47,8 +46,13 @@ func (h *PullRequest) Handle(ctx context.Context, eventType, deliveryID string,
 
 	logger.Debug().Msgf("Received pull_request %s event", event.GetAction())
 
+	// the event may change the pull requests for the old and new head
+	h.invalidatePullRequests(owner, repoName, event.GetPullRequest().GetHead().GetSHA(), event.GetBefore())
+
 	if event.GetAction() == "closed" {
-		logger.Debug().Msg("Doing nothing since pull request is closed")
+		logger.Debug().Msg("Evaluating dependents since pull request is closed")
+		h.Dependents.Remove(PullRequestRef{Owner: owner, Repo: repoName, Number: number})
+		h.evaluateDependents(ctx, owner, repoName, number)
 		return nil
 	}
 
@@ -57,11 +61,27 @@ func (h *PullRequest) Handle(ctx context.Context, eventType, deliveryID string,
 		return errors.Wrap(err, "failed to instantiate github client")
 	}
 
+	switch event.GetAction() {
+	case "opened", "reopened", "synchronize":
+		if err := h.ValidateConfigChange(ctx, client, event.GetPullRequest()); err != nil {
+			logger.Error().Err(errors.WithStack(err)).Msg("Error validating configuration")
+		}
+	}
+
+	if h.debounce(ctx, owner, repoName, number) {
+		return nil
+	}
+
+	v4client, err := h.ClientCreator.NewInstallationV4Client(installationID)
+	if err != nil {
+		return errors.Wrap(err, "failed to instantiate github v4 client")
+	}
+
 	pr, _, err := client.PullRequests.Get(ctx, owner, repoName, number)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get pull request %s/%s#%d", owner, repoName, number)
 	}
-	pullCtx := pull.NewGithubContext(client, pr)
+	pullCtx := h.NewPullContext(client, pr)
 
 	config, err := h.FetchConfigForPR(ctx, client, pr)
 	if err != nil {
@@ -73,7 +93,7 @@ func (h *PullRequest) Handle(ctx context.Context, eventType, deliveryID string,
 			logger.Debug().Msgf("Skipping updates to pull request due to server configuration override")
 		} else {
 			base, _ := pullCtx.Branches()
-			didUpdatePR, err := h.UpdatePullRequest(logger.WithContext(ctx), pullCtx, client, config, pr, base)
+			didUpdatePR, err := h.UpdatePullRequest(logger.WithContext(ctx), pullCtx, client, v4client, config, pr, base)
 			if err != nil {
 				logger.Error().Err(errors.WithStack(err)).Msg("Error updating pull request")
 			}
@@ -84,7 +104,7 @@ func (h *PullRequest) Handle(ctx context.Context, eventType, deliveryID string,
 		}
 	}
 
-	if err := h.ProcessPullRequest(ctx, pullCtx, client, config, pr); err != nil {
+	if err := h.ProcessPullRequest(ctx, pullCtx, client, v4client, config, pr); err != nil {
 		logger.Error().Err(errors.WithStack(err)).Msg("Error processing pull request")
 	}
 
@@ -17,11 +17,14 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"strings"
+	"time"
 
 	"github.com/google/go-github/v50/github"
 	"github.com/palantir/bulldozer/pull"
 	"github.com/palantir/go-githubapp/githubapp"
 	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
 )
 
 type Push struct {
@@ -55,15 +58,24 @@ func (h *Push) Handle(ctx context.Context, eventType, deliveryID string, payload
 	ctx, logger := githubapp.PrepareRepoContext(ctx, installationID, ghRepo)
 	logger.Debug().Msgf("Received push event with base ref %s", baseRef)
 
+	client, err := h.ClientCreator.NewInstallationClient(installationID)
+	if err != nil {
+		return errors.Wrap(err, "failed to instantiate github client")
+	}
+
+	if repo.GetFork() && !event.GetDeleted() && strings.HasPrefix(baseRef, "refs/heads/") {
+		h.scheduleUpstreamPullRequests(ctx, client, owner, repoName, baseRef)
+	}
+
 	// Skip any further processing of pull request updates if enabled at the server level
 	if h.DisableUpdateFeature {
 		logger.Debug().Msgf("Skipping updates to base ref %s due to server configuration override", baseRef)
 		return nil
 	}
 
-	client, err := h.ClientCreator.NewInstallationClient(installationID)
+	v4client, err := h.ClientCreator.NewInstallationV4Client(installationID)
 	if err != nil {
-		return errors.Wrap(err, "failed to instantiate github client")
+		return errors.Wrap(err, "failed to instantiate github v4 client")
 	}
 
 	prs, err := pull.ListOpenPullRequestsForRef(ctx, client, owner, repoName, baseRef)
@@ -85,8 +97,8 @@ func (h *Push) Handle(ctx context.Context, eventType, deliveryID string, payload
 		logger := logger.With().Int(githubapp.LogKeyPRNum, pr.GetNumber()).Logger()
 		logger.Debug().Msgf("Considering pull request for update")
 
-		pullCtx := pull.NewGithubContext(client, pr)
-		if _, err := h.UpdatePullRequest(logger.WithContext(ctx), pullCtx, client, config, pr, baseRef); err != nil {
+		pullCtx := h.NewPullContext(client, pr)
+		if _, err := h.UpdatePullRequest(logger.WithContext(ctx), pullCtx, client, v4client, config, pr, baseRef); err != nil {
 			logger.Error().Err(errors.WithStack(err)).Msg("Error updating pull request")
 		}
 	}
@@ -94,5 +106,33 @@ func (h *Push) Handle(ctx context.Context, eventType, deliveryID string, payload
 	return nil
 }
 
+// scheduleUpstreamPullRequests evaluates the open pull requests in the
+// repositories the fork was created from that use the pushed branch as their
+// head. Bulldozer may not receive events for these pull requests if it is only
+// installed for the fork, but it can evaluate them if it is also installed for
+// the upstream repository.
+func (h *Push) scheduleUpstreamPullRequests(ctx context.Context, client *github.Client, owner, repoName, ref string) {
+	logger := zerolog.Ctx(ctx)
+	if h.Scheduler == nil {
+		return
+	}
+
+	prs, err := pull.ListOpenUpstreamPullRequestsForFork(ctx, client, owner, repoName, ref)
+	if err != nil {
+		logger.Debug().Err(err).Msg("Failed to list some upstream pull requests for the pushed branch")
+	}
+
+	for _, pr := range prs {
+		upstream := pr.GetBase().GetRepo()
+		ref := PullRequestRef{
+			Owner:  upstream.GetOwner().GetLogin(),
+			Repo:   upstream.GetName(),
+			Number: pr.GetNumber(),
+		}
+		logger.Debug().Msgf("Scheduling evaluation of upstream pull request %s/%s#%d", ref.Owner, ref.Repo, ref.Number)
+		h.Scheduler.Schedule(ctx, ref, time.Now())
+	}
+}
+
 // type assertion
 var _ githubapp.EventHandler = &Push{}
@@ -0,0 +1,209 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// QueueItem is a pending evaluation of a pull request.
+type QueueItem struct {
+	Ref PullRequestRef `json:"ref"`
+	At  time.Time      `json:"at"`
+}
+
+// Queue stores pending evaluations of pull requests. Each pull request has at
+// most one pending evaluation. Implementations must be safe for concurrent
+// use.
+type Queue interface {
+	// Put adds an item, replacing any item for the same pull request.
+	Put(ctx context.Context, item QueueItem) error
+
+	// Delete removes the item for the pull request, if it exists.
+	Delete(ctx context.Context, ref PullRequestRef) error
+
+	// List returns all items in order of time.
+	List(ctx context.Context) ([]QueueItem, error)
+}
+
+// MemoryQueue is a Queue that does not survive restarts.
+type MemoryQueue struct {
+	mu    sync.Mutex
+	items map[PullRequestRef]QueueItem
+}
+
+func NewMemoryQueue() *MemoryQueue {
+	return &MemoryQueue{
+		items: make(map[PullRequestRef]QueueItem),
+	}
+}
+
+func (q *MemoryQueue) Put(ctx context.Context, item QueueItem) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.items[item.Ref] = item
+	return nil
+}
+
+func (q *MemoryQueue) Delete(ctx context.Context, ref PullRequestRef) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.items, ref)
+	return nil
+}
+
+func (q *MemoryQueue) List(ctx context.Context) ([]QueueItem, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	items := make([]QueueItem, 0, len(q.items))
+	for _, item := range q.items {
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].At.Before(items[j].At)
+	})
+	return items, nil
+}
+
+// FileQueue is a Queue that stores items in a JSON file, so that they survive
+// restarts. It is also a PauseStore that keeps pauses in the same file. The
+// file must not be shared by multiple servers.
+type FileQueue struct {
+	path string
+
+	mu     sync.Mutex
+	memory *MemoryQueue
+	pauses []Pause
+}
+
+// fileQueueContent is the content of the file of a FileQueue. Files written
+// before pauses were stored contain only the array of items.
+type fileQueueContent struct {
+	Items  []QueueItem `json:"items"`
+	Pauses []Pause     `json:"pauses,omitempty"`
+}
+
+// NewFileQueue creates a queue stored at the given path, loading any existing
+// items from the file.
+func NewFileQueue(path string) (*FileQueue, error) {
+	q := &FileQueue{
+		path:   path,
+		memory: NewMemoryQueue(),
+	}
+
+	b, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		return q, nil
+	case err != nil:
+		return nil, errors.Wrapf(err, "failed to read queue file %s", path)
+	}
+
+	var content fileQueueContent
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte("[")) {
+		err = json.Unmarshal(b, &content.Items)
+	} else {
+		err = json.Unmarshal(b, &content)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse queue file %s", path)
+	}
+	for _, item := range content.Items {
+		q.memory.items[item.Ref] = item
+	}
+	q.pauses = content.Pauses
+	return q, nil
+}
+
+func (q *FileQueue) Put(ctx context.Context, item QueueItem) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	_ = q.memory.Put(ctx, item)
+	return q.save(ctx)
+}
+
+func (q *FileQueue) Delete(ctx context.Context, ref PullRequestRef) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	_ = q.memory.Delete(ctx, ref)
+	return q.save(ctx)
+}
+
+func (q *FileQueue) List(ctx context.Context) ([]QueueItem, error) {
+	return q.memory.List(ctx)
+}
+
+func (q *FileQueue) LoadPauses(ctx context.Context) ([]Pause, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return append([]Pause(nil), q.pauses...), nil
+}
+
+func (q *FileQueue) SavePauses(ctx context.Context, pauses []Pause) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.pauses = pauses
+	return q.save(ctx)
+}
+
+// save replaces the file with the current items and pauses.
+func (q *FileQueue) save(ctx context.Context) error {
+	items, _ := q.memory.List(ctx)
+	b, err := json.Marshal(fileQueueContent{Items: items, Pauses: q.pauses})
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize queue")
+	}
+	return writeFileAtomic(q.path, b)
+}
+
+// writeFileAtomic replaces the file with the content. Writing to a temporary
+// file and renaming it means a crash never leaves a partially written file.
+func writeFileAtomic(path string, b []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return errors.Wrapf(err, "failed to create temporary file for %s", path)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		return errors.Wrapf(err, "failed to write temporary file for %s", path)
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrapf(err, "failed to write temporary file for %s", path)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return errors.Wrapf(err, "failed to replace %s", path)
+	}
+	return nil
+}
+
+// type assertions
+var _ Queue = &MemoryQueue{}
+var _ Queue = &FileQueue{}
+var _ PauseStore = &FileQueue{}
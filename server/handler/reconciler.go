@@ -0,0 +1,214 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/bulldozer"
+	"github.com/palantir/bulldozer/ratelimit"
+	"github.com/palantir/go-githubapp/githubapp"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// DefaultMinRateLimitRemaining is the number of requests that must remain in
+// the rate limit of an installation for the reconciler to continue sweeping
+// it, so that sweeps never use the requests needed to handle webhooks.
+const DefaultMinRateLimitRemaining = 1000
+
+// Reconciler periodically lists the open pull requests in every installation
+// and schedules evaluations of the ones that may be ready to merge. This
+// catches pull requests that are stuck because a webhook was not delivered
+// or failed to process.
+type Reconciler struct {
+	Base
+
+	// Interval is the average time between sweeps. Each sweep starts at a
+	// random time between half and one and a half times the interval after
+	// the previous sweep, and evaluations found by a sweep are spread over
+	// half the interval.
+	Interval time.Duration
+
+	// MinRateLimitRemaining stops sweeping an installation once it has fewer
+	// remaining requests. If zero, DefaultMinRateLimitRemaining is used.
+	MinRateLimitRemaining int
+}
+
+// Run sweeps until the context is canceled.
+func (r *Reconciler) Run(ctx context.Context) {
+	logger := zerolog.Ctx(ctx)
+	for {
+		wait := r.Interval/2 + time.Duration(rand.Int63n(int64(r.Interval)+1))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		start := time.Now()
+		scheduled, err := r.Sweep(ctx)
+		if err != nil {
+			logger.Error().Err(err).Msg("Failed to sweep open pull requests")
+		}
+		logger.Info().Msgf("Reconciliation sweep scheduled %d evaluations in %s", scheduled, time.Since(start).Round(time.Millisecond))
+	}
+}
+
+// Sweep schedules evaluations of open pull requests in all installations
+// and returns the number of scheduled evaluations. Pull requests are skipped
+// if an evaluation is already scheduled, the repository is paused or has no
+// configuration, or they are drafts that are not merged by the
+// configuration.
+func (r *Reconciler) Sweep(ctx context.Context) (int, error) {
+	// listing is deferred when the rate limit is low, so that the remaining
+	// requests are used for webhooks
+	ctx = ratelimit.WithPriority(ctx, ratelimit.PriorityLow)
+
+	appClient, err := r.NewAppClient()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to instantiate github app client")
+	}
+
+	installations, err := githubapp.NewInstallationsService(appClient).ListAll(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to list installations")
+	}
+
+	items, err := r.Scheduler.List(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to list scheduled evaluations")
+	}
+	pending := make(map[PullRequestRef]bool)
+	for _, item := range items {
+		pending[item.Ref] = true
+	}
+
+	var scheduled int
+	for _, installation := range installations {
+		client, err := r.NewInstallationClient(installation.ID)
+		if err != nil {
+			return scheduled, errors.Wrap(err, "failed to instantiate github client")
+		}
+
+		n, err := r.sweepInstallation(ctx, client, pending)
+		scheduled += n
+		if err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Msgf("Failed to sweep installation %d", installation.ID)
+		}
+	}
+	return scheduled, nil
+}
+
+func (r *Reconciler) sweepInstallation(ctx context.Context, client *github.Client, pending map[PullRequestRef]bool) (int, error) {
+	var scheduled int
+
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		repos, res, err := client.Apps.ListRepos(ctx, opts)
+		if err != nil {
+			return scheduled, errors.Wrap(err, "failed to list repositories")
+		}
+		if r.rateLimited(ctx, res) {
+			return scheduled, nil
+		}
+
+		for _, repo := range repos.Repositories {
+			if repo.GetArchived() || r.KillSwitch.HasTopic(repo.Topics) || r.Pauses.IsPaused(repo.GetOwner().GetLogin(), repo.GetName()) {
+				continue
+			}
+			n, limited, err := r.sweepRepository(ctx, client, repo, pending)
+			scheduled += n
+			if err != nil {
+				return scheduled, err
+			}
+			if limited {
+				return scheduled, nil
+			}
+		}
+
+		if res.NextPage == 0 {
+			return scheduled, nil
+		}
+		opts.Page = res.NextPage
+	}
+}
+
+func (r *Reconciler) sweepRepository(ctx context.Context, client *github.Client, repo *github.Repository, pending map[PullRequestRef]bool) (int, bool, error) {
+	owner, name := repo.GetOwner().GetLogin(), repo.GetName()
+	configs := make(map[string]*bulldozer.Config)
+
+	var scheduled int
+	opts := &github.PullRequestListOptions{State: "open", ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		prs, res, err := client.PullRequests.List(ctx, owner, name, opts)
+		if err != nil {
+			return scheduled, false, errors.Wrapf(err, "failed to list pull requests for %s/%s", owner, name)
+		}
+
+		for _, pr := range prs {
+			ref := PullRequestRef{Owner: owner, Repo: name, Number: pr.GetNumber()}
+			if pending[ref] {
+				continue
+			}
+
+			base, configRef := pr.GetBase().GetRef(), r.SelfModification.configRef(pr)
+			config, ok := configs[configRef]
+			if !ok {
+				config = r.ConfigFetcher.Config(ctx, client, owner, name, configRef).Config.ForBranch(base)
+				configs[configRef] = config
+			}
+			if config == nil || (pr.GetDraft() && config.Merge.Drafts != bulldozer.DraftsReady) {
+				continue
+			}
+
+			r.Scheduler.Schedule(ctx, ref, time.Now().Add(r.jitter()))
+			r.count(MetricsKeyReconcileScheduled)
+			pending[ref] = true
+			scheduled++
+		}
+
+		if r.rateLimited(ctx, res) {
+			return scheduled, true, nil
+		}
+		if res.NextPage == 0 {
+			return scheduled, false, nil
+		}
+		opts.Page = res.NextPage
+	}
+}
+
+// rateLimited returns true if the installation has too few remaining requests
+// to continue sweeping.
+func (r *Reconciler) rateLimited(ctx context.Context, res *github.Response) bool {
+	min := r.MinRateLimitRemaining
+	if min == 0 {
+		min = DefaultMinRateLimitRemaining
+	}
+	if res.Rate.Limit > 0 && res.Rate.Remaining < min {
+		zerolog.Ctx(ctx).Warn().Msgf("Stopping sweep of installation with %d remaining requests until %s", res.Rate.Remaining, res.Rate.Reset.Format(time.RFC3339))
+		return true
+	}
+	return false
+}
+
+// jitter returns a random delay for an evaluation, so that evaluations from a
+// sweep do not all happen at once.
+func (r *Reconciler) jitter() time.Duration {
+	return time.Duration(rand.Int63n(int64(r.Interval/2) + 1))
+}
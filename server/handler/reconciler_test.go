@@ -0,0 +1,99 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/bulldozer"
+	"github.com/palantir/go-githubapp/appconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcilerSweepInstallation(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/installation/repositories", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"total_count":3,"repositories":[
+			{"name":"testrepo","owner":{"login":"testorg"}},
+			{"name":"archived","owner":{"login":"testorg"},"archived":true},
+			{"name":"paused","owner":{"login":"testorg"}}
+		]}`)
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "open", r.URL.Query().Get("state"))
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "4000")
+		fmt.Fprint(w, `[
+			{"number":1,"base":{"ref":"develop"}},
+			{"number":2,"base":{"ref":"develop"},"draft":true},
+			{"number":3,"base":{"ref":"develop"}}
+		]`)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		// configuration files do not exist, so the default is used
+		http.NotFound(w, r)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	scheduled := make(chan PullRequestRef, 10)
+	scheduler := NewScheduler(NewMemoryQueue(), func(ctx context.Context, ref PullRequestRef) error {
+		scheduled <- ref
+		return nil
+	})
+	scheduler.Schedule(context.Background(), PullRequestRef{Owner: "testorg", Repo: "testrepo", Number: 3}, time.Now().Add(time.Hour))
+
+	pauses := NewPauses()
+	require.NoError(t, pauses.Pause(context.Background(), "testorg", "paused", time.Time{}))
+
+	r := &Reconciler{
+		Base: Base{
+			ConfigFetcher: NewConfigFetcher(appconfig.NewLoader([]string{".bulldozer.yml"}), &DefaultConfig{Config: &bulldozer.Config{}}),
+			Scheduler:     scheduler,
+			Pauses:        pauses,
+		},
+		Interval: time.Millisecond,
+	}
+
+	pending := map[PullRequestRef]bool{{Owner: "testorg", Repo: "testrepo", Number: 3}: true}
+	n, err := r.sweepInstallation(context.Background(), client, pending)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n, "only the ready, unscheduled pull request should be scheduled")
+
+	select {
+	case ref := <-scheduled:
+		assert.Equal(t, PullRequestRef{Owner: "testorg", Repo: "testrepo", Number: 1}, ref)
+	case <-time.After(5 * time.Second):
+		t.Fatal("pull request was not evaluated")
+	}
+
+	t.Run("rateLimited", func(t *testing.T) {
+		r.MinRateLimitRemaining = 4500
+		n, err := r.sweepInstallation(context.Background(), client, map[PullRequestRef]bool{})
+		require.NoError(t, err)
+		assert.Equal(t, 2, n, "pull requests listed before reaching the limit should be scheduled")
+	})
+}
@@ -0,0 +1,150 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// PullRequestRef identifies a pull request.
+type PullRequestRef struct {
+	Owner  string `json:"owner"`
+	Repo   string `json:"repo"`
+	Number int    `json:"number"`
+}
+
+// Scheduler evaluates pull requests at a later time, for conditions that
+// change without GitHub sending a webhook. Pending evaluations are stored in
+// a Queue, so they survive restarts if the Queue does. It is safe for
+// concurrent use.
+type Scheduler struct {
+	queue    Queue
+	evaluate func(ctx context.Context, ref PullRequestRef) error
+
+	mu     sync.Mutex
+	timers map[PullRequestRef]*scheduled
+}
+
+// scheduled is a pending evaluation and the time it runs.
+type scheduled struct {
+	timer *time.Timer
+	at    time.Time
+}
+
+func NewScheduler(queue Queue, evaluate func(ctx context.Context, ref PullRequestRef) error) *Scheduler {
+	return &Scheduler{
+		queue:    queue,
+		evaluate: evaluate,
+		timers:   make(map[PullRequestRef]*scheduled),
+	}
+}
+
+// Schedule evaluates the pull request at the given time, replacing any
+// evaluation already scheduled for the pull request. The evaluation uses the
+// logger from the context, but is not canceled when the context is done.
+func (s *Scheduler) Schedule(ctx context.Context, ref PullRequestRef, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.queue.Put(ctx, QueueItem{Ref: ref, At: at}); err != nil {
+		zerolog.Ctx(ctx).Error().Err(errors.WithStack(err)).Msg("Failed to store scheduled evaluation")
+	}
+	s.start(*zerolog.Ctx(ctx), ref, at)
+}
+
+// Debounce evaluates the pull request at the given time, unless an evaluation
+// is already scheduled at or before that time. It returns false if the
+// existing evaluation is kept. Calling Debounce for each event in a burst
+// evaluates the pull request once, no later than the time passed for the
+// first event.
+func (s *Scheduler) Debounce(ctx context.Context, ref PullRequestRef, at time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.timers[ref]; ok && !existing.at.After(at) {
+		return false
+	}
+
+	if err := s.queue.Put(ctx, QueueItem{Ref: ref, At: at}); err != nil {
+		zerolog.Ctx(ctx).Error().Err(errors.WithStack(err)).Msg("Failed to store scheduled evaluation")
+	}
+	s.start(*zerolog.Ctx(ctx), ref, at)
+	return true
+}
+
+// Restore schedules all evaluations in the queue. Evaluations that were due
+// while the server was stopped run immediately.
+func (s *Scheduler) Restore(ctx context.Context) error {
+	items, err := s.queue.List(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to list scheduled evaluations")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	logger := *zerolog.Ctx(ctx)
+	for _, item := range items {
+		s.start(logger, item.Ref, item.At)
+	}
+	logger.Debug().Msgf("Restored %d scheduled evaluations", len(items))
+	return nil
+}
+
+// start creates a timer for the evaluation. The caller must hold s.mu.
+func (s *Scheduler) start(logger zerolog.Logger, ref PullRequestRef, at time.Time) {
+	if existing, ok := s.timers[ref]; ok {
+		existing.timer.Stop()
+	}
+
+	entry := &scheduled{at: at}
+	entry.timer = time.AfterFunc(time.Until(at), func() {
+		ctx := logger.WithContext(context.Background())
+
+		s.mu.Lock()
+		if s.timers[ref] != entry {
+			// the evaluation was replaced after the timer fired
+			s.mu.Unlock()
+			return
+		}
+		delete(s.timers, ref)
+		if err := s.queue.Delete(ctx, ref); err != nil {
+			logger.Error().Err(errors.WithStack(err)).Msg("Failed to remove scheduled evaluation")
+		}
+		s.mu.Unlock()
+
+		if err := s.evaluate(ctx, ref); err != nil {
+			logger.Error().Err(errors.WithStack(err)).Msg("Error evaluating scheduled pull request")
+		}
+	})
+	s.timers[ref] = entry
+}
+
+// Pending returns the number of scheduled evaluations.
+func (s *Scheduler) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.timers)
+}
+
+// List returns the scheduled evaluations in order of time.
+func (s *Scheduler) List(ctx context.Context) ([]QueueItem, error) {
+	return s.queue.List(ctx)
+}
@@ -0,0 +1,196 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduler(t *testing.T) {
+	evaluated := make(chan PullRequestRef, 10)
+	scheduler := NewScheduler(NewMemoryQueue(), func(ctx context.Context, ref PullRequestRef) error {
+		evaluated <- ref
+		return nil
+	})
+
+	ctx := context.Background()
+	pr1 := PullRequestRef{Owner: "testorg", Repo: "testrepo", Number: 1}
+	pr2 := PullRequestRef{Owner: "testorg", Repo: "testrepo", Number: 2}
+
+	scheduler.Schedule(ctx, pr1, time.Now().Add(time.Hour))
+	scheduler.Schedule(ctx, pr1, time.Now().Add(10*time.Millisecond))
+	scheduler.Schedule(ctx, pr2, time.Now().Add(20*time.Millisecond))
+	assert.Equal(t, 2, scheduler.Pending())
+
+	for _, expected := range []PullRequestRef{pr1, pr2} {
+		select {
+		case ref := <-evaluated:
+			assert.Equal(t, expected, ref)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("pull request %d was not evaluated", expected.Number)
+		}
+	}
+
+	select {
+	case ref := <-evaluated:
+		t.Fatalf("replaced evaluation of pull request %d was not canceled", ref.Number)
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.Equal(t, 0, scheduler.Pending())
+}
+
+func TestSchedulerDebounce(t *testing.T) {
+	evaluated := make(chan PullRequestRef, 10)
+	scheduler := NewScheduler(NewMemoryQueue(), func(ctx context.Context, ref PullRequestRef) error {
+		evaluated <- ref
+		return nil
+	})
+
+	ctx := context.Background()
+	pr1 := PullRequestRef{Owner: "testorg", Repo: "testrepo", Number: 1}
+
+	first := time.Now().Add(20 * time.Millisecond)
+	assert.True(t, scheduler.Debounce(ctx, pr1, first))
+	assert.False(t, scheduler.Debounce(ctx, pr1, first.Add(10*time.Millisecond)), "later event should be coalesced")
+	assert.False(t, scheduler.Debounce(ctx, pr1, first), "event at the same time should be coalesced")
+	assert.Equal(t, 1, scheduler.Pending())
+
+	select {
+	case ref := <-evaluated:
+		assert.Equal(t, pr1, ref)
+	case <-time.After(5 * time.Second):
+		t.Fatal("pull request was not evaluated")
+	}
+
+	select {
+	case <-evaluated:
+		t.Fatal("coalesced events evaluated the pull request more than once")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	scheduler.Schedule(ctx, pr1, time.Now().Add(time.Hour))
+	assert.True(t, scheduler.Debounce(ctx, pr1, time.Now().Add(10*time.Millisecond)), "earlier event should replace a later evaluation")
+
+	select {
+	case ref := <-evaluated:
+		assert.Equal(t, pr1, ref)
+	case <-time.After(5 * time.Second):
+		t.Fatal("pull request was not evaluated")
+	}
+}
+
+func TestSchedulerRestore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "queue.json")
+	pr1 := PullRequestRef{Owner: "testorg", Repo: "testrepo", Number: 1}
+
+	queue, err := NewFileQueue(path)
+	require.NoError(t, err)
+
+	scheduler := NewScheduler(queue, func(ctx context.Context, ref PullRequestRef) error {
+		return nil
+	})
+	scheduler.Schedule(ctx, pr1, time.Now().Add(time.Hour))
+
+	// simulate a restart by loading the queue from the file
+	restored, err := NewFileQueue(path)
+	require.NoError(t, err)
+
+	items, err := restored.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, pr1, items[0].Ref)
+
+	require.NoError(t, restored.Put(ctx, QueueItem{Ref: pr1, At: time.Now().Add(-time.Minute)}))
+
+	evaluated := make(chan PullRequestRef, 1)
+	restoredScheduler := NewScheduler(restored, func(ctx context.Context, ref PullRequestRef) error {
+		evaluated <- ref
+		return nil
+	})
+	require.NoError(t, restoredScheduler.Restore(ctx))
+
+	select {
+	case ref := <-evaluated:
+		assert.Equal(t, pr1, ref)
+	case <-time.After(5 * time.Second):
+		t.Fatal("overdue evaluation did not run after restore")
+	}
+
+	assert.Eventually(t, func() bool {
+		q, err := NewFileQueue(path)
+		if err != nil {
+			return false
+		}
+		items, err := q.List(ctx)
+		return err == nil && len(items) == 0
+	}, 5*time.Second, 10*time.Millisecond, "evaluation was not removed from the queue")
+}
+
+func TestFileQueuePauses(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "queue.json")
+
+	queue, err := NewFileQueue(path)
+	require.NoError(t, err)
+	require.NoError(t, queue.Put(ctx, QueueItem{Ref: PullRequestRef{Owner: "testorg", Repo: "testrepo", Number: 1}, At: time.Now()}))
+
+	pauses, err := LoadPauses(ctx, queue)
+	require.NoError(t, err)
+	require.NoError(t, pauses.Pause(ctx, "testorg", "testrepo", time.Time{}))
+	require.NoError(t, pauses.Pause(ctx, "otherorg", "", time.Now().Add(time.Hour)))
+	require.NoError(t, pauses.PausePullRequest(ctx, "testorg", "otherrepo", 2, time.Now().Add(-time.Second)))
+
+	// simulate a restart by loading the queue from the file
+	restored, err := NewFileQueue(path)
+	require.NoError(t, err)
+	items, err := restored.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, items, 1)
+
+	restoredPauses, err := LoadPauses(ctx, restored)
+	require.NoError(t, err)
+	assert.True(t, restoredPauses.IsPaused("testorg", "testrepo"))
+	assert.True(t, restoredPauses.IsPaused("otherorg", "otherrepo"))
+	assert.False(t, restoredPauses.IsPullRequestPaused("testorg", "otherrepo", 2), "expired pause was restored")
+
+	require.NoError(t, restoredPauses.Resume(ctx, "testorg", "testrepo"))
+	restored, err = NewFileQueue(path)
+	require.NoError(t, err)
+	saved, err := restored.LoadPauses(ctx)
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	assert.Equal(t, "otherorg", saved[0].Target)
+}
+
+func TestFileQueueLegacyFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[{"ref": {"owner": "testorg", "repo": "testrepo", "number": 1}, "at": "2026-06-01T12:00:00Z"}]`), 0o600))
+
+	queue, err := NewFileQueue(path)
+	require.NoError(t, err)
+
+	items, err := queue.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, 1, items[0].Ref.Number)
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"path"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/pull"
+	"github.com/pkg/errors"
+)
+
+type ConfigRef string
+
+const (
+	ConfigRefBase ConfigRef = "base"
+	ConfigRefHead ConfigRef = "head"
+)
+
+// SelfModification controls pull requests that change the files that
+// configure bulldozer, so that a pull request cannot weaken the policy that
+// decides whether it is merged.
+type SelfModification struct {
+	// ConfigRef is the side of pull requests that repository configuration
+	// is read from. If empty, configuration is read from the base branch.
+	ConfigRef ConfigRef `yaml:"config_ref"`
+
+	// AllowMerge sets whether pull requests that change configuration files
+	// may be merged. If nil, they may be merged if configuration is read
+	// from the base branch, where the changes have no effect until the pull
+	// request merges, and not if it is read from the head.
+	AllowMerge *bool `yaml:"allow_merge"`
+
+	// Paths are additional configuration files, which may contain the
+	// wildcards supported by path.Match. The configuration paths of the
+	// server are always included.
+	Paths []string `yaml:"paths"`
+}
+
+func (s SelfModification) Validate() error {
+	switch s.ConfigRef {
+	case "", ConfigRefBase, ConfigRefHead:
+	default:
+		return errors.Errorf("invalid config_ref %q: must be %q or %q", s.ConfigRef, ConfigRefBase, ConfigRefHead)
+	}
+	for _, p := range s.Paths {
+		if _, err := path.Match(p, ""); err != nil {
+			return errors.Wrapf(err, "invalid path %q", p)
+		}
+	}
+	return nil
+}
+
+// mergeAllowed returns true if pull requests that change configuration files
+// may be merged.
+func (s SelfModification) mergeAllowed() bool {
+	if s.AllowMerge != nil {
+		return *s.AllowMerge
+	}
+	return s.ConfigRef != ConfigRefHead
+}
+
+// configRef returns the ref that configuration for the pull request is read
+// from.
+func (s SelfModification) configRef(pr *github.PullRequest) string {
+	if s.ConfigRef == ConfigRefHead {
+		return pr.GetHead().GetSHA()
+	}
+	return pr.GetBase().GetRef()
+}
+
+// deniedChange returns a configuration file changed by the pull request if
+// pull requests that change configuration files may not be merged. It
+// returns an empty string if the pull request may be merged.
+func (s SelfModification) deniedChange(ctx context.Context, pullCtx pull.Context) (string, error) {
+	if s.mergeAllowed() || len(s.Paths) == 0 {
+		return "", nil
+	}
+
+	files, err := pullCtx.ChangedFiles(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to list changed files")
+	}
+	for _, file := range files {
+		for _, p := range s.Paths {
+			if matched, _ := path.Match(p, file); matched {
+				return file, nil
+			}
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/pull/pulltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelfModification(t *testing.T) {
+	allow, deny := true, false
+	paths := []string{".bulldozer.yml", ".github/bulldozer/*.yml"}
+
+	tests := map[string]struct {
+		SelfModification SelfModification
+		Files            []string
+		Denied           string
+	}{
+		"baseAllowsByDefault": {
+			SelfModification: SelfModification{Paths: paths},
+			Files:            []string{".bulldozer.yml"},
+		},
+		"headDeniesByDefault": {
+			SelfModification: SelfModification{ConfigRef: ConfigRefHead, Paths: paths},
+			Files:            []string{"README.md", ".bulldozer.yml"},
+			Denied:           ".bulldozer.yml",
+		},
+		"headAllowed": {
+			SelfModification: SelfModification{ConfigRef: ConfigRefHead, AllowMerge: &allow, Paths: paths},
+			Files:            []string{".bulldozer.yml"},
+		},
+		"baseDenied": {
+			SelfModification: SelfModification{ConfigRef: ConfigRefBase, AllowMerge: &deny, Paths: paths},
+			Files:            []string{".github/bulldozer/main.yml"},
+			Denied:           ".github/bulldozer/main.yml",
+		},
+		"otherFiles": {
+			SelfModification: SelfModification{AllowMerge: &deny, Paths: paths},
+			Files:            []string{"docs/.bulldozer.yml", ".github/bulldozer/v1/main.yml"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pullCtx := &pulltest.MockPullContext{ChangedFilesValue: test.Files}
+
+			denied, err := test.SelfModification.deniedChange(context.Background(), pullCtx)
+			require.NoError(t, err)
+			assert.Equal(t, test.Denied, denied)
+		})
+	}
+
+	t.Run("configRef", func(t *testing.T) {
+		pr := &github.PullRequest{
+			Base: &github.PullRequestBranch{Ref: github.String("develop")},
+			Head: &github.PullRequestBranch{SHA: github.String("a6b1b2c")},
+		}
+		assert.Equal(t, "develop", SelfModification{}.configRef(pr))
+		assert.Equal(t, "a6b1b2c", SelfModification{ConfigRef: ConfigRefHead}.configRef(pr))
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		assert.NoError(t, SelfModification{ConfigRef: ConfigRefHead, Paths: paths}.Validate())
+		assert.EqualError(t, SelfModification{ConfigRef: "main"}.Validate(), `invalid config_ref "main": must be "base" or "head"`)
+		assert.Error(t, SelfModification{Paths: []string{"[.yml"}}.Validate())
+	})
+}
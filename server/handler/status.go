@@ -19,7 +19,6 @@ import (
 	"encoding/json"
 
 	"github.com/google/go-github/v50/github"
-	"github.com/palantir/bulldozer/pull"
 	"github.com/palantir/go-githubapp/githubapp"
 	"github.com/pkg/errors"
 )
@@ -59,7 +58,12 @@ func (h *Status) Handle(ctx context.Context, eventType, deliveryID string, paylo
 		return errors.Wrap(err, "failed to instantiate github client")
 	}
 
-	prs, err := pull.ListOpenPullRequestsForSHA(ctx, client, owner, repoName, event.GetSHA())
+	v4client, err := h.ClientCreator.NewInstallationV4Client(installationID)
+	if err != nil {
+		return errors.Wrap(err, "failed to instantiate github v4 client")
+	}
+
+	prs, err := h.OpenPullRequestsForSHA(ctx, client, owner, repoName, event.GetSHA())
 	if err != nil {
 		return errors.Wrap(err, "failed to determine open pull requests matching the status context change")
 	}
@@ -70,8 +74,11 @@ func (h *Status) Handle(ctx context.Context, eventType, deliveryID string, paylo
 	}
 
 	for _, pr := range prs {
-		pullCtx := pull.NewGithubContext(client, pr)
+		pullCtx := h.NewPullContext(client, pr)
 		logger := logger.With().Int(githubapp.LogKeyPRNum, pr.GetNumber()).Logger()
+		if h.debounce(logger.WithContext(ctx), owner, repoName, pr.GetNumber()) {
+			continue
+		}
 		config, err := h.FetchConfigForPR(ctx, client, pr)
 		if err != nil {
 			return err
@@ -81,7 +88,7 @@ func (h *Status) Handle(ctx context.Context, eventType, deliveryID string, paylo
 			logger.Debug().Msgf("Skipping updates to pull request due to server configuration override")
 		} else {
 			base, _ := pullCtx.Branches()
-			didUpdatePR, err := h.UpdatePullRequest(logger.WithContext(ctx), pullCtx, client, config, pr, base)
+			didUpdatePR, err := h.UpdatePullRequest(logger.WithContext(ctx), pullCtx, client, v4client, config, pr, base)
 			if err != nil {
 				logger.Error().Err(errors.WithStack(err)).Msg("Error updating pull request")
 			}
@@ -89,7 +96,7 @@ func (h *Status) Handle(ctx context.Context, eventType, deliveryID string, paylo
 				continue
 			}
 		}
-		if err := h.ProcessPullRequest(logger.WithContext(ctx), pullCtx, client, config, pr); err != nil {
+		if err := h.ProcessPullRequest(logger.WithContext(ctx), pullCtx, client, v4client, config, pr); err != nil {
 			logger.Error().Err(errors.WithStack(err)).Msg("Error processing pull request")
 		}
 	}
@@ -0,0 +1,55 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/palantir/bulldozer/tracing"
+	"github.com/palantir/go-githubapp/githubapp"
+)
+
+// Traced wraps an event handler to create a span for each webhook delivery.
+// Spans created while handling the event, including spans for GitHub API
+// requests, are children of this span.
+func Traced(tracer *tracing.Tracer, h githubapp.EventHandler) githubapp.EventHandler {
+	return &tracedHandler{EventHandler: h, tracer: tracer}
+}
+
+type tracedHandler struct {
+	githubapp.EventHandler
+	tracer *tracing.Tracer
+}
+
+func (h *tracedHandler) Handle(ctx context.Context, eventType, deliveryID string, payload []byte) error {
+	var event struct {
+		Repository struct {
+			FullName string `json:"full_name"`
+		} `json:"repository"`
+	}
+	_ = json.Unmarshal(payload, &event)
+
+	ctx, span := tracing.Start(tracing.WithTracer(ctx, h.tracer), "webhook "+eventType, tracing.SpanKindServer,
+		tracing.String("github.event", eventType),
+		tracing.String("github.delivery", deliveryID),
+		tracing.String("github.repository", event.Repository.FullName),
+	)
+	defer span.End()
+
+	err := h.EventHandler.Handle(ctx, eventType, deliveryID, payload)
+	span.RecordError(err)
+	return err
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/bulldozer"
+	"github.com/palantir/go-baseapp/baseapp"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// maxValidateSize is the largest configuration file the validate endpoint
+// accepts.
+const maxValidateSize = 1 << 20
+
+// Validate returns a handler that validates the configuration file in the
+// request body and responds with the result.
+func Validate() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxValidateSize))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "failed to read configuration: "+err.Error())
+			return
+		}
+		baseapp.WriteJSON(w, http.StatusOK, bulldozer.ValidateConfig(body))
+	})
+}
+
+// ValidateConfigChange publishes a check run with the result of validating
+// the configuration file if the pull request changes it. It does nothing if
+// the pull request deletes the file. If the pull request does not change the
+// file, it publishes a failing check run if the configuration breaks the
+// policy for the organization.
+func (b *Base) ValidateConfigChange(ctx context.Context, client *github.Client, pr *github.PullRequest) error {
+	owner := pr.GetBase().GetRepo().GetOwner().GetLogin()
+	repo := pr.GetBase().GetRepo().GetName()
+	head := pr.GetHead()
+
+	if len(b.ConfigurationPaths) == 0 {
+		return nil
+	}
+
+	files, err := b.NewPullContext(client, pr).ChangedFiles(ctx)
+	if err != nil {
+		return err
+	}
+
+	changed := make(map[string]bool, len(files))
+	for _, f := range files {
+		changed[f] = true
+	}
+
+	var configPath string
+	for _, p := range b.ConfigurationPaths {
+		if changed[p] {
+			configPath = p
+			break
+		}
+	}
+	if configPath == "" {
+		issues, path := b.policyIssues(ctx, client, owner, repo, b.SelfModification.configRef(pr))
+		if len(issues) == 0 {
+			return nil
+		}
+		result := &bulldozer.ValidationResult{Errors: issues, Warnings: []bulldozer.ValidationIssue{}}
+		return bulldozer.PublishValidationCheckRun(ctx, client, owner, repo, head.GetSHA(), path, result)
+	}
+
+	file, _, res, err := client.Repositories.GetContents(ctx, head.GetRepo().GetOwner().GetLogin(), head.GetRepo().GetName(), configPath, &github.RepositoryContentGetOptions{
+		Ref: head.GetSHA(),
+	})
+	if err != nil {
+		if res != nil && res.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return errors.Wrap(err, "failed to fetch configuration")
+	}
+	if file == nil {
+		return nil
+	}
+
+	content, err := file.GetContent()
+	if err != nil {
+		return errors.Wrap(err, "failed to decode configuration")
+	}
+
+	result := bulldozer.ValidateConfig([]byte(content))
+	if result.Valid {
+		// policies apply to the complete configuration, including extended
+		// and included files
+		issues, _ := b.policyIssues(ctx, client, owner, repo, head.GetSHA())
+		result.Errors = append(result.Errors, issues...)
+		result.Valid = len(result.Errors) == 0
+	}
+	zerolog.Ctx(ctx).Debug().Msgf("Validated changed configuration: valid=%t errors=%d warnings=%d", result.Valid, len(result.Errors), len(result.Warnings))
+
+	return bulldozer.PublishValidationCheckRun(ctx, client, owner, repo, head.GetSHA(), configPath, result)
+}
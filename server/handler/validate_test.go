@@ -0,0 +1,105 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/bulldozer/bulldozer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/api/validate", strings.NewReader("version: 1\nmerge:\n  mthod: squash\n"))
+	w := httptest.NewRecorder()
+	Validate().ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var result bulldozer.ValidationResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.False(t, result.Valid)
+	assert.Equal(t, []bulldozer.ValidationIssue{{Line: 3, Message: `unknown key "mthod"`}}, result.Errors)
+}
+
+func TestValidateConfigChange(t *testing.T) {
+	var files string
+	var checkRun *github.CreateCheckRunOptions
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/testorg/testrepo/pulls/1/files", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, files)
+	})
+	mux.HandleFunc("/repos/testuser/testrepo/contents/.bulldozer.yml", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "f00", r.URL.Query().Get("ref"))
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"type":     "file",
+			"encoding": "base64",
+			"content":  base64.StdEncoding.EncodeToString([]byte("version: 1\nupdate:\n  method: squash\n")),
+		})
+	})
+	mux.HandleFunc("/repos/testorg/testrepo/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		checkRun = &github.CreateCheckRunOptions{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(checkRun))
+		fmt.Fprint(w, `{}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := github.NewClient(srv.Client())
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	pr := &github.PullRequest{
+		Number: github.Int(1),
+		Base: &github.PullRequestBranch{
+			Repo: &github.Repository{Name: github.String("testrepo"), Owner: &github.User{Login: github.String("testorg")}},
+		},
+		Head: &github.PullRequestBranch{
+			SHA:  github.String("f00"),
+			Repo: &github.Repository{Name: github.String("testrepo"), Owner: &github.User{Login: github.String("testuser")}},
+		},
+	}
+	b := Base{ConfigurationPaths: []string{".github/bulldozer.yml", ".bulldozer.yml"}}
+
+	t.Run("unchanged", func(t *testing.T) {
+		files = `[{"filename":"README.md"}]`
+		checkRun = nil
+
+		require.NoError(t, b.ValidateConfigChange(context.Background(), client, pr))
+		assert.Nil(t, checkRun)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		files = `[{"filename":"README.md"},{"filename":".bulldozer.yml"}]`
+		checkRun = nil
+
+		require.NoError(t, b.ValidateConfigChange(context.Background(), client, pr))
+		require.NotNil(t, checkRun)
+		assert.Equal(t, bulldozer.ValidationCheckRunName, checkRun.Name)
+		assert.Equal(t, "f00", checkRun.HeadSHA)
+		assert.Equal(t, "failure", checkRun.GetConclusion())
+		require.Len(t, checkRun.Output.Annotations, 1)
+		assert.Equal(t, `invalid update method "squash"`, checkRun.Output.Annotations[0].GetMessage())
+	})
+}
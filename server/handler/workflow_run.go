@@ -0,0 +1,58 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/palantir/go-githubapp/githubapp"
+	"github.com/pkg/errors"
+)
+
+// WorkflowRun evaluates pull requests when a GitHub Actions workflow run
+// completes.
+type WorkflowRun struct {
+	Base
+}
+
+func (h *WorkflowRun) Handles() []string {
+	return []string{"workflow_run"}
+}
+
+func (h *WorkflowRun) Handle(ctx context.Context, eventType, deliveryID string, payload []byte) error {
+	var event github.WorkflowRunEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return errors.Wrap(err, "failed to parse workflow_run event payload")
+	}
+
+	repo := event.GetRepo()
+	installationID := githubapp.GetInstallationIDFromEvent(&event)
+	ctx, logger := githubapp.PrepareRepoContext(ctx, installationID, repo)
+
+	if event.GetAction() != "completed" {
+		logger.Debug().Msgf("Doing nothing since workflow_run action was %q instead of 'completed'", event.GetAction())
+		return nil
+	}
+
+	run := event.GetWorkflowRun()
+	logger.Debug().Msgf("Received workflow_run completed event for %q with conclusion %q", run.GetName(), run.GetConclusion())
+
+	return h.evaluateCommit(ctx, installationID, repo, run.GetHeadSHA(), run.PullRequests)
+}
+
+// type assertion
+var _ githubapp.EventHandler = &WorkflowRun{}
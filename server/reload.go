@@ -0,0 +1,131 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// webhookHandler dispatches webhooks using a secret that can change while the
+// server is running. Changing the secret creates a new dispatcher, but all
+// dispatchers share the same scheduler, so events that are queued or being
+// processed are not affected.
+type webhookHandler struct {
+	newDispatcher func(secret string) http.Handler
+
+	mu         sync.Mutex
+	secret     string
+	dispatcher http.Handler
+}
+
+func newWebhookHandler(secret string, newDispatcher func(secret string) http.Handler) *webhookHandler {
+	return &webhookHandler{
+		newDispatcher: newDispatcher,
+		secret:        secret,
+		dispatcher:    newDispatcher(secret),
+	}
+}
+
+func (h *webhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	dispatcher := h.dispatcher
+	h.mu.Unlock()
+
+	dispatcher.ServeHTTP(w, r)
+}
+
+func (h *webhookHandler) setSecret(secret string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if secret != h.secret {
+		h.secret = secret
+		h.dispatcher = h.newDispatcher(secret)
+	}
+}
+
+// parseLogLevel parses a log level. An empty level logs all messages.
+func parseLogLevel(level string) (zerolog.Level, error) {
+	if level == "" {
+		return zerolog.TraceLevel, nil
+	}
+	l, err := zerolog.ParseLevel(level)
+	if err != nil {
+		return l, errors.Errorf("invalid log level %q", level)
+	}
+	return l, nil
+}
+
+// Reload applies the log level, webhook secrets, notification sinks, and
+// default repository configuration from c. Other settings, including the
+// list of apps, only change when the server restarts. If any of these
+// settings are invalid, Reload returns an error without changing anything.
+// Events that are being processed are not interrupted.
+func (s *Server) Reload(c *Config) error {
+	level, err := parseLogLevel(c.Logging.Level)
+	if err != nil {
+		return err
+	}
+	if err := c.Options.DefaultRepositoryConfigMode.Validate(); err != nil {
+		return errors.Wrap(err, "invalid default_repository_config_mode")
+	}
+	if err := s.notifier.Reload(c.Options.Notifications, &http.Client{Timeout: 10 * time.Second}); err != nil {
+		return errors.Wrap(err, "failed to reload notifications")
+	}
+
+	secrets := map[string]string{"": c.Github.App.WebhookSecret}
+	for _, appConfig := range c.Apps {
+		secrets[appConfig.Name] = appConfig.Github.App.WebhookSecret
+	}
+
+	zerolog.SetGlobalLevel(level)
+	for _, a := range s.apps {
+		if secret, ok := secrets[a.name]; ok {
+			a.webhook.setSecret(secret)
+		}
+		a.base.ConfigFetcher.SetDefaultConfig(c.Options.DefaultConfig())
+	}
+	return nil
+}
+
+// ReloadOnHangup reloads the configuration returned by load each time the
+// process receives SIGHUP. It never returns, so callers should run it in a
+// separate goroutine.
+func (s *Server) ReloadOnHangup(load func() (*Config, error)) {
+	logger := s.base.Logger()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	for range signals {
+		c, err := load()
+		if err == nil {
+			err = s.Reload(c)
+		}
+		if err != nil {
+			logger.Error().Err(err).Msg("Failed to reload server configuration")
+			continue
+		}
+		logger.Info().Msg("Reloaded server configuration")
+	}
+}
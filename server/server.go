@@ -15,34 +15,84 @@
 package server
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/c2h5oh/datasize"
 	"github.com/die-net/lrucache"
 	"github.com/gregjones/httpcache"
+	"github.com/palantir/bulldozer/audit"
+	"github.com/palantir/bulldozer/bulldozer"
+	"github.com/palantir/bulldozer/cache"
+	"github.com/palantir/bulldozer/notify"
+	"github.com/palantir/bulldozer/pull"
+	"github.com/palantir/bulldozer/ratelimit"
 	"github.com/palantir/bulldozer/server/handler"
+	"github.com/palantir/bulldozer/tickets"
+	"github.com/palantir/bulldozer/tracing"
 	"github.com/palantir/bulldozer/version"
 	"github.com/palantir/go-baseapp/baseapp"
 	"github.com/palantir/go-baseapp/baseapp/datadog"
 	"github.com/palantir/go-githubapp/appconfig"
 	"github.com/palantir/go-githubapp/githubapp"
 	"github.com/pkg/errors"
+	"github.com/rcrowley/go-metrics"
 	"github.com/rs/zerolog"
 	"goji.io/pat"
 )
 
+// pullRequestCacheSize is the maximum number of commits for which the open
+// pull requests are cached.
+const pullRequestCacheSize = 1000
+
 type Server struct {
 	config *Config
 	base   *baseapp.Server
+	tracer *tracing.Tracer
+
+	notifier *notify.Notifier
+
+	// apps contains the primary app, defined by the github section of the
+	// configuration, followed by each additional app
+	apps []*app
+}
+
+// app contains the handlers for one GitHub App.
+type app struct {
+	name        string
+	base        handler.Base
+	webhook     *webhookHandler
+	deadLetters *handler.DeadLetters
+	reconciler  *handler.Reconciler
+}
+
+// shared contains the parts of the server that are used by all apps.
+type shared struct {
+	registry  metrics.Registry
+	tracer    *tracing.Tracer
+	newCache  func() httpcache.Cache
+	notifier  *notify.Notifier
+	audit     *audit.Logger
+	signer    *bulldozer.CommitSigner
+	tickets   pull.TicketTracker
+	scheduler githubapp.Scheduler
 }
 
 // New instantiates a new Server.
 // Callers must then invoke Start to run the Server.
 func New(c *Config) (*Server, error) {
+	// The level is set globally instead of on the logger so that it can be
+	// changed when the configuration is reloaded
 	logger := baseapp.NewLogger(baseapp.LoggingConfig{
-		Level:  c.Logging.Level,
 		Pretty: c.Logging.Text,
 	})
+	if level, err := parseLogLevel(c.Logging.Level); err != nil {
+		logger.Warn().Msgf("Invalid log level %q, using the default level instead", c.Logging.Level)
+	} else {
+		zerolog.SetGlobalLevel(level)
+	}
 
 	serverParams := baseapp.DefaultParams(logger, c.Options.AppName+".")
 	base, err := baseapp.NewServer(c.Server, serverParams...)
@@ -55,27 +105,209 @@ func New(c *Config) (*Server, error) {
 		maxSize = int64(c.Cache.MaxSize)
 	}
 
+	var tracer *tracing.Tracer
+	if c.Tracing.Endpoint != "" {
+		tracer = tracing.NewTracer(tracing.NewOTLPExporter(c.Tracing, &http.Client{Timeout: 10 * time.Second}))
+	}
+
+	var redisCache *cache.Redis
+	if c.Cache.Redis.Address != "" {
+		redisCache, err = cache.NewRedis(c.Cache.Redis, logger)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to initialize redis cache")
+		}
+	}
+	newCache := func() httpcache.Cache {
+		var responses httpcache.Cache = lrucache.New(maxSize, 0)
+		if redisCache != nil {
+			responses = cache.Tiered(responses, redisCache)
+		}
+		return cache.Metered(responses, base.Registry())
+	}
+
+	if err := c.Options.CheckConclusions.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid check_conclusions")
+	}
+	if err := c.Options.DefaultRepositoryConfigMode.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid default_repository_config_mode")
+	}
+	for owner, policy := range c.Options.Policies {
+		if err := policy.Validate(); err != nil {
+			return nil, errors.Wrapf(err, "invalid policy for %s", owner)
+		}
+	}
+	if err := c.Options.SelfModification.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid self_modification")
+	}
+
+	notifier, err := notify.NewNotifier(c.Options.Notifications, &http.Client{Timeout: 10 * time.Second})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize notifications")
+	}
+
+	var auditLogger *audit.Logger
+	if c.Audit.Type != "" {
+		sink, err := audit.NewSink(c.Audit, &http.Client{Timeout: 10 * time.Second})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to initialize audit log")
+		}
+		auditLogger = audit.NewLogger(sink)
+	}
+
+	var signer *bulldozer.CommitSigner
+	if c.Options.SigningKey != "" {
+		signer, err = bulldozer.NewCommitSigner(c.Options.SigningKey, c.Options.SigningKeyPassphrase)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to initialize commit signing")
+		}
+	}
+
+	var ticketTracker pull.TicketTracker
+	if c.Options.IssueTracker.Enabled() {
+		ticketTracker, err = tickets.NewTracker(c.Options.IssueTracker, &http.Client{Timeout: 10 * time.Second})
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid issue_tracker")
+		}
+	}
+
+	queueSize := c.Workers.QueueSize
+	if queueSize < 1 {
+		queueSize = 100
+	}
+
+	workers := c.Workers.Workers
+	if workers < 1 {
+		workers = 10
+	}
+
+	sh := shared{
+		registry: base.Registry(),
+		tracer:   tracer,
+		newCache: newCache,
+		notifier: notifier,
+		audit:    auditLogger,
+		signer:   signer,
+		tickets:  ticketTracker,
+		scheduler: githubapp.QueueAsyncScheduler(
+			queueSize, workers,
+			githubapp.WithSchedulingMetrics(base.Registry()),
+			githubapp.WithAsyncErrorCallback(githubapp.MetricsAsyncErrorCallback(base.Registry())),
+		),
+	}
+
+	primary, err := newApp(c, "", c.Github, sh)
+	if err != nil {
+		return nil, err
+	}
+	apps := []*app{primary}
+	for _, appConfig := range c.Apps {
+		a, err := newApp(c, appConfig.Name, appConfig.Github, sh)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to initialize app %q", appConfig.Name)
+		}
+		apps = append(apps, a)
+	}
+
+	mux := base.Mux()
+
+	// webhook routes
+	mux.Handle(pat.Post(githubapp.DefaultWebhookRoute), primary.webhook)
+	for _, a := range apps[1:] {
+		mux.Handle(pat.Post(githubapp.DefaultWebhookRoute+"/"+a.name), a.webhook)
+	}
+
+	// any additional API routes
+	mux.Handle(pat.Get("/api/health"), handler.Health())
+	mux.Handle(pat.Post("/api/validate"), handler.Validate())
+	mux.Handle(pat.Get("/metrics"), handler.Metrics(base.Registry()))
+
+	if c.Options.AdminToken != "" {
+		admin := &handler.Admin{Base: primary.base, Token: c.Options.AdminToken, DeadLetters: primary.deadLetters}
+		mux.Handle(pat.New("/api/admin/*"), admin.Handler())
+	}
+
+	if c.Options.EnableDashboard {
+		dashboard := &handler.Dashboard{Base: primary.base, Token: c.Options.AdminToken}
+		mux.Handle(pat.Get("/api/status/:owner/:repo"), dashboard.RepositoryAPI())
+		mux.Handle(pat.Get("/api/status/:owner/:repo/:number"), dashboard.API())
+		mux.Handle(pat.Get("/status/:owner/:repo/:number"), dashboard.UI())
+	}
+
+	return &Server{
+		config:   c,
+		base:     base,
+		tracer:   tracer,
+		notifier: notifier,
+		apps:     apps,
+	}, nil
+}
+
+// newApp creates the handlers for a GitHub App. The primary app has an empty
+// name. Additional apps record metrics with an app tag and store queued
+// evaluations and dead letters in separate files.
+func newApp(c *Config, name string, githubConfig githubapp.Config, sh shared) (*app, error) {
+	registry := sh.registry
+	queuePath := c.Options.QueuePath
+	deadLetterPath := c.Options.DeadLetterPath
+	if name != "" {
+		registry = handler.TaggedRegistry(registry, "app", name)
+		if queuePath != "" {
+			queuePath += "." + name
+		}
+		if deadLetterPath != "" {
+			deadLetterPath += "." + name
+		}
+	}
+
+	clientMiddleware := []githubapp.ClientMiddleware{
+		githubapp.ClientLogging(zerolog.DebugLevel),
+		githubapp.ClientMetrics(registry),
+	}
+	if sh.tracer != nil {
+		clientMiddleware = append(clientMiddleware, tracing.ClientMiddleware())
+	}
+
 	userAgent := fmt.Sprintf("%s/%s", c.Options.AppName, version.GetVersion())
 	clientCreator, err := githubapp.NewDefaultCachingClientCreator(
-		c.Github,
+		githubConfig,
 		githubapp.WithClientUserAgent(userAgent),
-		githubapp.WithClientCaching(true, func() httpcache.Cache { return lrucache.New(maxSize, 0) }),
-		githubapp.WithClientMiddleware(
-			githubapp.ClientLogging(zerolog.DebugLevel),
-			githubapp.ClientMetrics(base.Registry()),
-		),
+		githubapp.WithClientCaching(true, sh.newCache),
+		githubapp.WithClientMiddleware(clientMiddleware...),
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to initialize Github client creator")
 	}
+	clientCreator = ratelimit.NewBudget(c.Options.RateLimitReserve, registry).ClientCreator(clientCreator)
 
-	configPaths := []string{c.Options.ConfigurationPath}
+	configPaths := append([]string{}, c.Options.ConfigPaths()...)
+	seenPaths := make(map[string]bool)
+	for _, p := range configPaths {
+		seenPaths[p] = true
+	}
 	for _, p := range c.Options.ConfigurationV0Paths {
-		if p != c.Options.ConfigurationPath {
+		if !seenPaths[p] {
 			configPaths = append(configPaths, p)
 		}
 	}
 
+	selfModification := c.Options.SelfModification
+	selfModification.Paths = append(append([]string{}, configPaths...), selfModification.Paths...)
+
+	var queue handler.Queue = handler.NewMemoryQueue()
+	pauses := handler.NewPauses()
+	if queuePath != "" {
+		fileQueue, err := handler.NewFileQueue(queuePath)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to initialize queue")
+		}
+		queue = fileQueue
+
+		pauses, err = handler.LoadPauses(context.Background(), fileQueue)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load pauses")
+		}
+	}
+
 	baseHandler := handler.Base{
 		ClientCreator: clientCreator,
 		ConfigFetcher: handler.NewConfigFetcher(
@@ -85,54 +317,100 @@ func New(c *Config) (*Server, error) {
 					c.Options.SharedConfigurationPath,
 				}),
 			),
-			c.Options.DefaultRepositoryConfig,
+			c.Options.DefaultConfig(),
 		),
 
 		PushRestrictionUserToken: c.Options.PushRestrictionUserToken,
 		DisableUpdateFeature:     c.Options.DisableUpdateFeature,
+		DryRun:                   c.Options.DryRun,
+		MergeTrain:               bulldozer.NewMergeTrain(),
+		TeamMembershipCache:      pull.NewTeamMembershipCache(5 * time.Minute),
+		CodeOwnersCache:          pull.NewCodeOwnersCache(5 * time.Minute),
+		CheckConclusions:         c.Options.CheckConclusions,
+		SelfModification:         selfModification,
+		Policies:                 c.Options.Policies,
+		DelayTracker:             bulldozer.NewDelayTracker(),
+		RetryTracker:             bulldozer.NewRetryTracker(),
+		RerunTracker:             bulldozer.NewRerunTracker(),
+		Dependents:               handler.NewDependents(),
+		Registry:                 registry,
+		Pauses:                   pauses,
+		Limiter:                  handler.NewLimiter(c.Workers.PerInstallation, c.Workers.PerRepository),
+		DebounceInterval:         c.Options.DebounceInterval,
+		AppID:                    githubConfig.App.IntegrationID,
+		ConfigurationPaths:       c.Options.ConfigPaths(),
+		Notifier:                 sh.notifier,
+		AuditLogger:              sh.audit,
+		Signer:                   sh.signer,
+		TicketTracker:            sh.tickets,
+		PolicyBot:                c.Options.PolicyBot,
+		KillSwitch:               c.Options.KillSwitch(),
 	}
-
-	queueSize := c.Workers.QueueSize
-	if queueSize < 1 {
-		queueSize = 100
+	if c.Options.PullRequestCacheTTL > 0 {
+		baseHandler.PullRequestCache = pull.NewLookupCache(c.Options.PullRequestCacheTTL, pullRequestCacheSize)
+		baseHandler.PullRequestCache.SetMetrics(handler.CacheMetrics{Registry: registry})
 	}
+	if c.Options.EnableDashboard {
+		baseHandler.LastActions = handler.NewLastActions(handler.DefaultLastActionsSize)
+	}
+	baseHandler.Scheduler = handler.NewScheduler(queue, baseHandler.EvaluatePullRequest)
 
-	workers := c.Workers.Workers
-	if workers < 1 {
-		workers = 10
+	eventHandlers := []githubapp.EventHandler{
+		&handler.CheckRun{Base: baseHandler},
+		&handler.CheckSuite{Base: baseHandler},
+		&handler.Deployment{Base: baseHandler},
+		&handler.IssueComment{Base: baseHandler},
+		&handler.Issues{Base: baseHandler},
+		&handler.PullRequest{Base: baseHandler},
+		&handler.PullRequestReview{Base: baseHandler},
+		&handler.ProjectsV2Item{Base: baseHandler},
+		&handler.Push{Base: baseHandler},
+		&handler.Status{Base: baseHandler},
+		&handler.WorkflowRun{Base: baseHandler},
 	}
 
-	webhookHandler := githubapp.NewEventDispatcher(
-		[]githubapp.EventHandler{
-			&handler.CheckRun{Base: baseHandler},
-			&handler.IssueComment{Base: baseHandler},
-			&handler.PullRequest{Base: baseHandler},
-			&handler.PullRequestReview{Base: baseHandler},
-			&handler.Push{Base: baseHandler},
-			&handler.Status{Base: baseHandler},
-		},
-		c.Github.App.WebhookSecret,
-		githubapp.WithErrorCallback(githubapp.MetricsErrorCallback(base.Registry())),
-		githubapp.WithScheduler(
-			githubapp.QueueAsyncScheduler(
-				queueSize, workers,
-				githubapp.WithSchedulingMetrics(base.Registry()),
-				githubapp.WithAsyncErrorCallback(githubapp.MetricsAsyncErrorCallback(base.Registry())),
-			),
-		),
-	)
+	var deadLetterStore handler.DeadLetterStore = handler.NewMemoryDeadLetterStore(handler.DefaultDeadLetterLimit)
+	if deadLetterPath != "" {
+		fileStore, err := handler.NewFileDeadLetterStore(deadLetterPath, handler.DefaultDeadLetterLimit)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to initialize dead letters")
+		}
+		deadLetterStore = fileStore
+	}
+	deadLetters := handler.NewDeadLetters(deadLetterStore)
 
-	mux := base.Mux()
+	for i, h := range eventHandlers {
+		if baseHandler.AuditLogger != nil {
+			h = handler.Audited(h)
+		}
+		if sh.tracer != nil {
+			h = handler.Traced(sh.tracer, h)
+		}
+		h = handler.Limited(baseHandler.Limiter, h)
+		h = deadLetters.Wrap(h)
+		eventHandlers[i] = handler.Timed(registry, h)
+	}
 
-	// webhook route
-	mux.Handle(pat.Post(githubapp.DefaultWebhookRoute), webhookHandler)
+	webhook := newWebhookHandler(githubConfig.App.WebhookSecret, func(secret string) http.Handler {
+		return githubapp.NewEventDispatcher(
+			eventHandlers,
+			secret,
+			githubapp.WithErrorCallback(githubapp.MetricsErrorCallback(registry)),
+			githubapp.WithScheduler(sh.scheduler),
+		)
+	})
 
-	// any additional API routes
-	mux.Handle(pat.Get("/api/health"), handler.Health())
+	var reconciler *handler.Reconciler
+	if c.Options.ReconcileInterval > 0 {
+		reconciler = &handler.Reconciler{Base: baseHandler, Interval: c.Options.ReconcileInterval}
+	}
 
-	return &Server{
-		config: c,
-		base:   base,
+	return &app{
+		name:        name,
+		base:        baseHandler,
+		webhook:     webhook,
+		deadLetters: deadLetters,
+		reconciler:  reconciler,
 	}, nil
 }
 
@@ -143,5 +421,22 @@ func (s *Server) Start() error {
 			return err
 		}
 	}
+	if s.tracer != nil {
+		go s.tracer.Run(s.base.Logger().WithContext(context.Background()), s.config.Tracing.Interval)
+	}
+	for _, a := range s.apps {
+		logger := s.base.Logger()
+		if a.name != "" {
+			logger = logger.With().Str("app", a.name).Logger()
+		}
+		ctx := logger.WithContext(context.Background())
+
+		if err := a.base.Scheduler.Restore(ctx); err != nil {
+			return err
+		}
+		if a.reconciler != nil {
+			go a.reconciler.Run(ctx)
+		}
+	}
 	return s.base.Start()
 }
@@ -0,0 +1,164 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tickets looks up the status of tickets in an issue tracker, like
+// Jira, so that pull requests can wait for their tickets to reach a status.
+package tickets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/palantir/bulldozer/pull"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultStatusField is the path to the status name in responses from
+	// the Jira REST API.
+	DefaultStatusField = "fields.status.name"
+
+	keyPlaceholder = "{key}"
+	jiraIssuePath  = "/rest/api/2/issue/" + keyPlaceholder + "?fields=status"
+)
+
+// Config defines the issue tracker. It includes credentials, so it is only
+// defined by the server.
+type Config struct {
+	// URL is either the base URL of a Jira server, like
+	// "https://example.atlassian.net", or the URL of any endpoint that
+	// returns a ticket as JSON, with "{key}" in place of the ticket key.
+	URL string `yaml:"url"`
+
+	// Username and Token authenticate requests. With a username, they are
+	// sent with basic authentication, like a Jira Cloud email and API token.
+	// Without one, the token is sent as a bearer token, like a Jira Data
+	// Center personal access token.
+	Username string `yaml:"username"`
+	Token    string `yaml:"token"`
+
+	// Headers are added to every request.
+	Headers map[string]string `yaml:"headers"`
+
+	// StatusField is the dot-separated path to the status name in the
+	// response. If empty, the default is "fields.status.name".
+	StatusField string `yaml:"status_field"`
+}
+
+// Enabled returns true if the configuration defines an issue tracker.
+func (c Config) Enabled() bool {
+	return c.URL != ""
+}
+
+// HTTPTracker looks up tickets with an HTTP API.
+type HTTPTracker struct {
+	client      *http.Client
+	url         string
+	username    string
+	token       string
+	headers     map[string]string
+	statusField []string
+}
+
+// NewTracker creates a tracker from the configuration. If client is nil, it
+// uses http.DefaultClient.
+func NewTracker(config Config, client *http.Client) (*HTTPTracker, error) {
+	if config.URL == "" {
+		return nil, errors.New("url is required")
+	}
+
+	u := config.URL
+	if !strings.Contains(u, keyPlaceholder) {
+		u = strings.TrimSuffix(u, "/") + jiraIssuePath
+	}
+	if _, err := url.Parse(strings.ReplaceAll(u, keyPlaceholder, "KEY-1")); err != nil {
+		return nil, errors.Wrap(err, "invalid url")
+	}
+
+	field := config.StatusField
+	if field == "" {
+		field = DefaultStatusField
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPTracker{
+		client:      client,
+		url:         u,
+		username:    config.Username,
+		token:       config.Token,
+		headers:     config.Headers,
+		statusField: strings.Split(field, "."),
+	}, nil
+}
+
+// Status returns the status of the ticket. It returns an error matching
+// pull.ErrNotFound if the tracker does not have the ticket.
+func (t *HTTPTracker) Status(ctx context.Context, key string) (string, error) {
+	u := strings.ReplaceAll(t.url, keyPlaceholder, url.PathEscape(key))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to create request for ticket %s", key)
+	}
+	req.Header.Set("Accept", "application/json")
+	for k, v := range t.headers {
+		req.Header.Set(k, v)
+	}
+	switch {
+	case t.username != "":
+		req.SetBasicAuth(t.username, t.token)
+	case t.token != "":
+		req.Header.Set("Authorization", "Bearer "+t.token)
+	}
+
+	res, err := t.client.Do(req)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get ticket %s", key)
+	}
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return "", errors.Wrapf(pull.ErrNotFound, "ticket %s", key)
+	case res.StatusCode < 200 || res.StatusCode >= 300:
+		return "", errors.Errorf("getting ticket %s failed with status %d", key, res.StatusCode)
+	}
+
+	var body interface{}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", errors.Wrapf(err, "failed to parse ticket %s", key)
+	}
+
+	value := body
+	for _, name := range t.statusField {
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			value = nil
+			break
+		}
+		value = fields[name]
+	}
+	status, ok := value.(string)
+	if !ok {
+		return "", errors.Errorf("ticket %s does not have a status at %q", key, strings.Join(t.statusField, "."))
+	}
+	return status, nil
+}
+
+// type assertion
+var _ pull.TicketTracker = &HTTPTracker{}
@@ -0,0 +1,89 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tickets
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/palantir/bulldozer/pull"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPTracker(t *testing.T) {
+	var path, query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, query = r.URL.Path, r.URL.RawQuery
+		if user, token, ok := r.BasicAuth(); !ok || user != "bot@example.com" || token != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/rest/api/2/issue/ABC-1":
+			_, _ = w.Write([]byte(`{"key": "ABC-1", "fields": {"status": {"name": "Ready to Merge"}}}`))
+		case "/rest/api/2/issue/ABC-2":
+			_, _ = w.Write([]byte(`{"key": "ABC-2", "fields": {}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	tracker, err := NewTracker(Config{URL: srv.URL + "/", Username: "bot@example.com", Token: "secret"}, srv.Client())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	status, err := tracker.Status(ctx, "ABC-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Ready to Merge", status)
+	assert.Equal(t, "/rest/api/2/issue/ABC-1", path)
+	assert.Equal(t, "fields=status", query)
+
+	_, err = tracker.Status(ctx, "ABC-2")
+	assert.EqualError(t, err, `ticket ABC-2 does not have a status at "fields.status.name"`)
+
+	_, err = tracker.Status(ctx, "ABC-3")
+	assert.True(t, errors.Is(err, pull.ErrNotFound), "expected not found error, got %v", err)
+
+	unauthorized, err := NewTracker(Config{URL: srv.URL}, srv.Client())
+	require.NoError(t, err)
+	_, err = unauthorized.Status(ctx, "ABC-1")
+	assert.EqualError(t, err, "getting ticket ABC-1 failed with status 401")
+}
+
+func TestHTTPTrackerTemplate(t *testing.T) {
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"ticket": {"state": "done"}}`))
+	}))
+	defer srv.Close()
+
+	tracker, err := NewTracker(Config{URL: srv.URL + "/tickets/{key}", Token: "secret", StatusField: "ticket.state"}, srv.Client())
+	require.NoError(t, err)
+
+	status, err := tracker.Status(context.Background(), "OPS-12")
+	require.NoError(t, err)
+	assert.Equal(t, "done", status)
+	assert.Equal(t, "Bearer secret", auth)
+
+	_, err = NewTracker(Config{}, nil)
+	assert.EqualError(t, err, "url is required")
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// ClientMiddleware creates a client span for each request made with a context
+// that contains a span. It is compatible with githubapp.ClientMiddleware.
+func ClientMiddleware() func(http.RoundTripper) http.RoundTripper {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			if SpanFromContext(r.Context()) == nil {
+				return next.RoundTrip(r)
+			}
+
+			_, span := Start(r.Context(), "HTTP "+r.Method, SpanKindClient,
+				String("http.method", r.Method),
+				String("http.url", r.URL.String()),
+			)
+			defer span.End()
+
+			res, err := next.RoundTrip(r)
+			if err != nil {
+				span.RecordError(err)
+				return res, err
+			}
+
+			span.SetAttributes(Int("http.status_code", res.StatusCode))
+			if res.StatusCode >= 500 {
+				span.RecordError(errors.Errorf("request failed with status %d", res.StatusCode))
+			}
+			return res, nil
+		})
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (fn roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return fn(r)
+}
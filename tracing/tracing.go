@@ -0,0 +1,259 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing records spans that describe how bulldozer handles webhooks
+// and exports them to an OpenTelemetry collector using the OTLP/HTTP protocol
+// with JSON encoding.
+//
+// Spans are created with Start, which uses the tracer and parent span stored
+// in the context. If the context has no tracer, Start returns a nil span and
+// all span methods do nothing, so code can be instrumented unconditionally.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	DefaultInterval  = 5 * time.Second
+	DefaultQueueSize = 2048
+)
+
+type Config struct {
+	// Endpoint is the URL of the OTLP/HTTP traces endpoint of a collector,
+	// like "http://localhost:4318/v1/traces". If empty, tracing is disabled.
+	Endpoint string `yaml:"endpoint"`
+
+	// Headers are added to export requests, for example to provide an
+	// authorization token.
+	Headers map[string]string `yaml:"headers"`
+
+	// ServiceName is the value of the service.name resource attribute.
+	ServiceName string `yaml:"service_name"`
+
+	// Interval is how often spans are exported.
+	Interval time.Duration `yaml:"interval"`
+}
+
+type SpanKind int
+
+const (
+	SpanKindInternal SpanKind = 1
+	SpanKindServer   SpanKind = 2
+	SpanKindClient   SpanKind = 3
+)
+
+// Attribute is a key and a string, integer, or boolean value.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func Int(key string, value int) Attribute {
+	return Attribute{Key: key, Value: int64(value)}
+}
+
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Exporter sends finished spans to a tracing backend.
+type Exporter interface {
+	Export(ctx context.Context, spans []*Span) error
+}
+
+// Tracer collects finished spans and exports them in batches.
+type Tracer struct {
+	exporter  Exporter
+	queueSize int
+
+	mu    sync.Mutex
+	spans []*Span
+}
+
+// NewTracer creates a tracer that exports spans with the exporter.
+func NewTracer(exporter Exporter) *Tracer {
+	return &Tracer{
+		exporter:  exporter,
+		queueSize: DefaultQueueSize,
+	}
+}
+
+// Run exports spans at the interval until the context is canceled.
+func (t *Tracer) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := t.Flush(ctx); err != nil {
+				zerolog.Ctx(ctx).Error().Err(err).Msg("Failed to export spans")
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Flush exports all finished spans.
+func (t *Tracer) Flush(ctx context.Context) error {
+	t.mu.Lock()
+	spans := t.spans
+	t.spans = nil
+	t.mu.Unlock()
+
+	if len(spans) == 0 {
+		return nil
+	}
+	return t.exporter.Export(ctx, spans)
+}
+
+func (t *Tracer) finish(s *Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// drop spans instead of using unbounded memory if the collector is down
+	if len(t.spans) < t.queueSize {
+		t.spans = append(t.spans, s)
+	}
+}
+
+type tracerKey struct{}
+type spanKey struct{}
+
+// WithTracer returns a context that creates spans with the tracer.
+func WithTracer(ctx context.Context, t *Tracer) context.Context {
+	return context.WithValue(ctx, tracerKey{}, t)
+}
+
+// SpanFromContext returns the current span in the context or nil.
+func SpanFromContext(ctx context.Context) *Span {
+	s, _ := ctx.Value(spanKey{}).(*Span)
+	return s
+}
+
+// Start creates a span that is a child of the current span in the context and
+// returns a context containing the new span. Callers must call End on the
+// span.
+func Start(ctx context.Context, name string, kind SpanKind, attrs ...Attribute) (context.Context, *Span) {
+	s := &Span{
+		name:  name,
+		kind:  kind,
+		start: time.Now(),
+		attrs: attrs,
+	}
+
+	if parent := SpanFromContext(ctx); parent != nil {
+		s.tracer = parent.tracer
+		s.traceID = parent.traceID
+		s.parentID = parent.spanID
+	} else {
+		t, _ := ctx.Value(tracerKey{}).(*Tracer)
+		if t == nil {
+			return ctx, nil
+		}
+		s.tracer = t
+		_, _ = rand.Read(s.traceID[:])
+	}
+	_, _ = rand.Read(s.spanID[:])
+
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+// Span is a timed operation.
+type Span struct {
+	tracer *Tracer
+
+	traceID  [16]byte
+	spanID   [8]byte
+	parentID [8]byte
+
+	name  string
+	kind  SpanKind
+	start time.Time
+
+	mu    sync.Mutex
+	end   time.Time
+	attrs []Attribute
+	err   error
+}
+
+// SetAttributes adds attributes to the span.
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attrs = append(s.attrs, attrs...)
+}
+
+// RecordError marks the span as failed if err is not nil.
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// End finishes the span and queues it for export. Calling End more than once
+// has no effect.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if !s.end.IsZero() {
+		s.mu.Unlock()
+		return
+	}
+	s.end = time.Now()
+	s.mu.Unlock()
+
+	s.tracer.finish(s)
+}
+
+// TraceID returns the hex-encoded trace ID of the span.
+func (s *Span) TraceID() string {
+	if s == nil {
+		return ""
+	}
+	return hex.EncodeToString(s.traceID[:])
+}
+
+func (s *Span) hasParent() bool {
+	return s.parentID != [8]byte{}
+}
+
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
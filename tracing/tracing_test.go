@@ -0,0 +1,115 @@
+// Copyright 2026 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartWithoutTracer(t *testing.T) {
+	ctx, span := Start(context.Background(), "webhook", SpanKindServer)
+	assert.Nil(t, span)
+	assert.Nil(t, SpanFromContext(ctx))
+
+	// methods on nil spans do nothing
+	span.SetAttributes(String("key", "value"))
+	span.RecordError(errors.New("failed"))
+	span.End()
+}
+
+func TestExport(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer api.Close()
+
+	var exported otlpRequest
+	var auth string
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		auth = r.Header.Get("Authorization")
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&exported))
+	}))
+	defer collector.Close()
+
+	tracer := NewTracer(NewOTLPExporter(Config{
+		Endpoint: collector.URL + "/v1/traces",
+		Headers:  map[string]string{"Authorization": "Bearer token"},
+	}, nil))
+
+	ctx := WithTracer(context.Background(), tracer)
+	ctx, root := Start(ctx, "webhook pull_request", SpanKindServer, String("github.delivery", "1234"))
+	require.NotNil(t, root)
+
+	childCtx, child := Start(ctx, "evaluate merge", SpanKindInternal, Int("github.pull_request", 7))
+	child.SetAttributes(Bool("bulldozer.should_merge", true))
+	child.End()
+
+	client := &http.Client{Transport: ClientMiddleware()(http.DefaultTransport)}
+	req, err := http.NewRequestWithContext(childCtx, http.MethodGet, api.URL+"/repos/testorg/testrepo", nil)
+	require.NoError(t, err)
+	res, err := client.Do(req)
+	require.NoError(t, err)
+	res.Body.Close()
+
+	root.RecordError(errors.New("failed to process"))
+	root.End()
+	root.End()
+
+	require.NoError(t, tracer.Flush(context.Background()))
+	assert.Equal(t, "Bearer token", auth)
+
+	require.Len(t, exported.ResourceSpans, 1)
+	assert.Equal(t, "service.name", exported.ResourceSpans[0].Resource.Attributes[0].Key)
+	assert.Equal(t, "bulldozer", exported.ResourceSpans[0].Resource.Attributes[0].Value["stringValue"])
+
+	spans := exported.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 3, "spans were exported more than once or not at all")
+
+	byName := make(map[string]otlpSpan)
+	for _, s := range spans {
+		assert.Equal(t, root.TraceID(), s.TraceID)
+		byName[s.Name] = s
+	}
+
+	webhook := byName["webhook pull_request"]
+	assert.Empty(t, webhook.ParentSpanID)
+	assert.Equal(t, SpanKindServer, webhook.Kind)
+	if assert.NotNil(t, webhook.Status) {
+		assert.Equal(t, "failed to process", webhook.Status.Message)
+	}
+
+	evaluate := byName["evaluate merge"]
+	assert.Equal(t, webhook.SpanID, evaluate.ParentSpanID)
+	assert.Equal(t, []otlpAttribute{
+		{Key: "github.pull_request", Value: map[string]interface{}{"intValue": "7"}},
+		{Key: "bulldozer.should_merge", Value: map[string]interface{}{"boolValue": true}},
+	}, evaluate.Attributes)
+
+	request := byName["HTTP GET"]
+	assert.Equal(t, evaluate.SpanID, request.ParentSpanID)
+	assert.Equal(t, SpanKindClient, request.Kind)
+	assert.NotNil(t, request.Status, "server error was not recorded")
+
+	require.NoError(t, tracer.Flush(context.Background()), "flushing without spans failed")
+}